@@ -0,0 +1,106 @@
+// Package accounts defines a backend-agnostic abstraction for signing
+// keys, mirroring go-ethereum's accounts package: a Wallet is anything
+// that can sign on behalf of one or more Accounts (a keystore file, an HD
+// seed, a hardware device, a remote signer), a Backend tracks a set of
+// wallets and reports when it changes, and a Manager fans any number of
+// backends into the single sorted wallet list callers actually want.
+package accounts
+
+import (
+	"math/big"
+
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Account identifies a single signing key: its address, plus the URL of
+// the wallet holding it. Keeping URL alongside Address (rather than just
+// the address) lets Manager.Find disambiguate the rare case of the same
+// key imported into more than one backend.
+type Account struct {
+	Address common.Address `json:"address"`
+	URL     URL            `json:"url"`
+}
+
+func (a Account) String() string {
+	return a.Address.Hex()
+}
+
+// WalletEventType enumerates the lifecycle events a Backend's feed emits.
+type WalletEventType int
+
+const (
+	// WalletArrived is sent when a backend discovers a new wallet, e.g. a
+	// key file dropped into the keystore directory.
+	WalletArrived WalletEventType = iota
+	// WalletDropped is sent when a wallet stops being available, e.g. its
+	// key file is deleted or a USB hardware wallet is unplugged.
+	WalletDropped
+)
+
+// WalletEvent is broadcast over a Backend's subscription feed whenever its
+// set of wallets changes.
+type WalletEvent struct {
+	Wallet Wallet
+	Kind   WalletEventType
+}
+
+// Wallet is anything capable of signing on behalf of one or more Accounts.
+// A keystore file, an HD wallet, a hardware device, and a remote signer all
+// implement it uniformly, so Manager and its callers never need to know
+// which kind of backend actually holds a given key.
+type Wallet interface {
+	// URL returns this wallet's canonical location, used by Manager to
+	// sort and dedupe wallets across backends.
+	URL() URL
+
+	// Status reports a human-readable state ("locked", "ok", ...) and an
+	// error if the wallet has failed in a way a caller should know about.
+	Status() (string, error)
+
+	// Open unlocks the wallet with passphrase so SignHash/SignTx can be
+	// called without supplying it again. Wallets that need no unlocking
+	// (an already-open hardware device, a remote signer) accept an empty
+	// passphrase.
+	Open(passphrase string) error
+
+	// Close locks the wallet, discarding any cached key material.
+	Close() error
+
+	// Accounts returns every account this wallet can sign for.
+	Accounts() []Account
+
+	// Contains reports whether account belongs to this wallet.
+	Contains(account Account) bool
+
+	// Derive requests a new account at path. pin mirrors go-ethereum's HD
+	// wallet semantics: whether the account should be remembered across a
+	// reopen rather than just handed out for this session. Wallets that
+	// can't derive new accounts (a single imported key) reject it.
+	Derive(path string, pin bool) (Account, error)
+
+	// SignHash signs an already-computed hash, e.g. for eth_sign-style
+	// message signing rather than a transaction.
+	SignHash(account Account, hash []byte) ([]byte, error)
+
+	// SignTx signs tx for chainID using account's key, which must already
+	// be unlocked via Open.
+	SignTx(account Account, tx *core.Transaction, chainID *big.Int) (*core.Transaction, error)
+
+	// SignTxWithPassphrase is SignTx for a wallet that hasn't been opened,
+	// decrypting the key with passphrase just for this one signature.
+	SignTxWithPassphrase(account Account, passphrase string, tx *core.Transaction, chainID *big.Int) (*core.Transaction, error)
+}
+
+// Backend tracks a set of wallets — one keystore directory, one hardware
+// device driver, one remote signer endpoint — and notifies subscribers
+// when that set changes.
+type Backend interface {
+	// Wallets returns the backend's currently known wallets, sorted by
+	// URL.
+	Wallets() []Wallet
+
+	// Subscribe registers sink to receive this backend's wallet
+	// arrival/drop events. The returned function unsubscribes it.
+	Subscribe(sink chan<- WalletEvent) (unsubscribe func())
+}
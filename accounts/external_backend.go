@@ -0,0 +1,254 @@
+package accounts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ErrSignerPending is returned by an ExternalBackend wallet's signing
+// methods when the remote signer reports the request is still waiting on
+// its own approval UI — the Clef/lotus-wallet flow where a human has to
+// click "approve" before the signature comes back. Callers should treat it
+// as transient and retry rather than surfacing it as a hard failure.
+var ErrSignerPending = fmt.Errorf("signer: request pending approval")
+
+// ExternalBackend is a Backend whose accounts live entirely behind a
+// remote signer process speaking the account_list/account_signTransaction/
+// account_signData JSON-RPC methods geth's Clef and Filecoin's lotus-wallet
+// expose, so the node holds no private key material of its own.
+type ExternalBackend struct {
+	client *externalRPCClient
+
+	mu   sync.Mutex
+	subs map[int]chan<- WalletEvent
+	next int
+}
+
+// NewExternalBackend creates a Backend that forwards every signing
+// operation to the JSON-RPC endpoint at url.
+func NewExternalBackend(url string) *ExternalBackend {
+	return &ExternalBackend{
+		client: &externalRPCClient{url: url, http: &http.Client{Timeout: 30 * time.Second}},
+		subs:   make(map[int]chan<- WalletEvent),
+	}
+}
+
+// Wallets asks the remote signer for its current account list, the same
+// way KeystoreBackend rescans its directory — a live call rather than a
+// cache, so an account added to the signer after startup still shows up.
+func (b *ExternalBackend) Wallets() []Wallet {
+	var addresses []common.Address
+	if err := b.client.call("account_list", nil, &addresses); err != nil {
+		return nil
+	}
+
+	wallets := make([]Wallet, 0, len(addresses))
+	for _, addr := range addresses {
+		wallets = append(wallets, &externalWallet{
+			client:  b.client,
+			account: Account{Address: addr, URL: URL{Scheme: "signer", Path: b.client.url}},
+		})
+	}
+	sortWallets(wallets)
+	return wallets
+}
+
+// Subscribe registers sink for this backend's wallet-arrival/-drop events.
+// ExternalBackend never emits any on its own — Manager.Find's
+// rescan-on-miss is what picks up an account the signer adds at runtime.
+func (b *ExternalBackend) Subscribe(sink chan<- WalletEvent) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.next
+	b.next++
+	b.subs[id] = sink
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subs, id)
+	}
+}
+
+// externalWallet is the single account/signature pair this backend hands
+// a remote signer's address. Open/Close are no-ops: the signer — not this
+// process — is the thing that holds (and unlocks) the key.
+type externalWallet struct {
+	client  *externalRPCClient
+	account Account
+}
+
+func (w *externalWallet) URL() URL { return w.account.URL }
+
+func (w *externalWallet) Status() (string, error) {
+	return "remote signer", nil
+}
+
+func (w *externalWallet) Open(passphrase string) error { return nil }
+func (w *externalWallet) Close() error                 { return nil }
+
+func (w *externalWallet) Accounts() []Account { return []Account{w.account} }
+
+func (w *externalWallet) Contains(account Account) bool {
+	return account.Address == w.account.Address
+}
+
+func (w *externalWallet) Derive(path string, pin bool) (Account, error) {
+	return Account{}, fmt.Errorf("external signer wallets cannot derive new accounts")
+}
+
+func (w *externalWallet) SignHash(account Account, hash []byte) ([]byte, error) {
+	var result hexBytes
+	err := w.client.call("account_signData", []interface{}{account.Address.Hex(), hexBytes(hash)}, &result)
+	if err != nil {
+		return nil, translateSignerError(err)
+	}
+	return result, nil
+}
+
+func (w *externalWallet) SignTx(account Account, tx *core.Transaction, chainID *big.Int) (*core.Transaction, error) {
+	return w.client.signTransaction(account, tx, chainID)
+}
+
+func (w *externalWallet) SignTxWithPassphrase(account Account, passphrase string, tx *core.Transaction, chainID *big.Int) (*core.Transaction, error) {
+	// The signer — not this process — guards the key, so there is no local
+	// passphrase to apply; forward the request exactly as SignTx would.
+	return w.SignTx(account, tx, chainID)
+}
+
+// translateSignerError recognizes the "still waiting on approval" error
+// Clef/lotus-wallet-style signers return and maps it to ErrSignerPending so
+// callers can distinguish "try again" from a hard failure.
+func translateSignerError(err error) error {
+	msg := strings.ToLower(err.Error())
+	if strings.Contains(msg, "pending") || strings.Contains(msg, "not yet approved") {
+		return fmt.Errorf("%w: %v", ErrSignerPending, err)
+	}
+	return err
+}
+
+// hexBytes (de)serializes to/from the "0x"-prefixed hex strings JSON-RPC
+// byte parameters use.
+type hexBytes []byte
+
+func (h hexBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal("0x" + fmt.Sprintf("%x", []byte(h)))
+}
+
+func (h *hexBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	s = strings.TrimPrefix(s, "0x")
+	decoded := make([]byte, len(s)/2)
+	if _, err := fmt.Sscanf(s, "%x", &decoded); err != nil && len(s) > 0 {
+		return fmt.Errorf("invalid hex string %q: %w", s, err)
+	}
+	*h = decoded
+	return nil
+}
+
+// externalRPCClient is a minimal JSON-RPC 2.0 client over HTTP, just
+// enough to call the account_* methods an ExternalBackend needs.
+type externalRPCClient struct {
+	url  string
+	http *http.Client
+
+	mu     sync.Mutex
+	nextID int
+}
+
+type jsonrpcRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+	ID      int         `json:"id"`
+}
+
+type jsonrpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (c *externalRPCClient) call(method string, params interface{}, result interface{}) error {
+	c.mu.Lock()
+	c.nextID++
+	id := c.nextID
+	c.mu.Unlock()
+
+	body, err := json.Marshal(jsonrpcRequest{JSONRPC: "2.0", Method: method, Params: params, ID: id})
+	if err != nil {
+		return fmt.Errorf("failed to encode signer request: %w", err)
+	}
+
+	resp, err := c.http.Post(c.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to reach signer at %s: %w", c.url, err)
+	}
+	defer resp.Body.Close()
+
+	var rpcResp jsonrpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return fmt.Errorf("invalid signer response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("signer error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(rpcResp.Result, result)
+}
+
+// signTransactionResult is the account_signTransaction response shape:
+// the RLP-encoded signed transaction plus its hash, matching what
+// Clef/lotus-wallet-style signers return so the caller never has to
+// reconstruct the signature fields itself.
+type signTransactionResult struct {
+	Raw  hexBytes `json:"raw"`
+	Hash string   `json:"hash"`
+}
+
+func (c *externalRPCClient) signTransaction(account Account, tx *core.Transaction, chainID *big.Int) (*core.Transaction, error) {
+	unsigned := *tx
+	unsigned.V, unsigned.R, unsigned.S = nil, nil, nil
+	unsigned.Hash, unsigned.From = common.Hash{}, common.Address{}
+
+	encoded, err := unsigned.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction: %w", err)
+	}
+
+	params := []interface{}{account.Address.Hex(), hexBytes(encoded), chainIDString(chainID)}
+
+	var result signTransactionResult
+	if err := c.call("account_signTransaction", params, &result); err != nil {
+		return nil, translateSignerError(err)
+	}
+
+	var signed core.Transaction
+	if err := rlp.DecodeBytes(result.Raw, &signed); err != nil {
+		return nil, fmt.Errorf("failed to decode signed transaction: %w", err)
+	}
+	return &signed, nil
+}
+
+func chainIDString(chainID *big.Int) string {
+	if chainID == nil {
+		return "0"
+	}
+	return chainID.String()
+}
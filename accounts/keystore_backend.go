@@ -0,0 +1,197 @@
+package accounts
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/DioraFund/diora-go-blockchain/internal/keystore"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// KeystoreBackend is a Backend whose wallets are the encrypted key files in
+// a keystore directory — one Wallet per file, mirroring go-ethereum's
+// accounts/keystore package.
+type KeystoreBackend struct {
+	ks *keystore.Keystore
+
+	mu   sync.Mutex
+	subs map[int]chan<- WalletEvent
+	next int
+}
+
+// NewKeystoreBackend creates a Backend over an already-constructed
+// Keystore, and starts forwarding its directory-watcher events (a key
+// file dropped in or deleted by an external tool) as WalletEvents.
+func NewKeystoreBackend(ks *keystore.Keystore) *KeystoreBackend {
+	b := &KeystoreBackend{
+		ks:   ks,
+		subs: make(map[int]chan<- WalletEvent),
+	}
+
+	cacheEvents := make(chan keystore.CacheEvent, 16)
+	ks.Subscribe(cacheEvents)
+	go b.watchCache(cacheEvents)
+
+	return b
+}
+
+// watchCache turns the keystore's own CacheEvent feed — driven by its
+// directory watcher, not by this backend's own writes — into
+// WalletArrived/WalletDropped events on b's subscribers.
+func (b *KeystoreBackend) watchCache(events <-chan keystore.CacheEvent) {
+	for event := range events {
+		w := &keystoreWallet{
+			ks:      b.ks,
+			path:    event.Path,
+			account: Account{Address: event.Address, URL: URL{Scheme: "keystore", Path: event.Path}},
+		}
+		kind := WalletDropped
+		if event.Arrived {
+			kind = WalletArrived
+		}
+		b.notify(WalletEvent{Wallet: w, Kind: kind})
+	}
+}
+
+// Wallets rescans the keystore directory and returns one wallet per key
+// file found there.
+func (b *KeystoreBackend) Wallets() []Wallet {
+	paths, err := b.ks.ListKeyFiles()
+	if err != nil {
+		return nil
+	}
+
+	wallets := make([]Wallet, 0, len(paths))
+	for _, path := range paths {
+		address, err := b.ks.ReadKeyFileAddress(path)
+		if err != nil {
+			continue
+		}
+		wallets = append(wallets, &keystoreWallet{
+			ks:      b.ks,
+			path:    path,
+			account: Account{Address: address, URL: URL{Scheme: "keystore", Path: path}},
+		})
+	}
+	sortWallets(wallets)
+	return wallets
+}
+
+// Subscribe registers sink for this backend's wallet-arrival/-drop events.
+// watchCache drives this feed, calling notify whenever the keystore's
+// directory watcher reports a key file appearing or disappearing at runtime.
+func (b *KeystoreBackend) Subscribe(sink chan<- WalletEvent) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.next
+	b.next++
+	b.subs[id] = sink
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subs, id)
+	}
+}
+
+// notify broadcasts a wallet-lifecycle event to every current subscriber.
+func (b *KeystoreBackend) notify(event WalletEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, sink := range b.subs {
+		select {
+		case sink <- event:
+		default:
+		}
+	}
+}
+
+// keystoreWallet wraps a single encrypted key file. Open decrypts it once
+// and caches the key until Close; SignHash/SignTx use that cached key,
+// while SignTxWithPassphrase decrypts transiently without requiring Open
+// at all — the same two signing paths go-ethereum's keystore wallet
+// supports.
+type keystoreWallet struct {
+	ks      *keystore.Keystore
+	path    string
+	account Account
+
+	mu  sync.Mutex
+	key *ecdsa.PrivateKey
+}
+
+func (w *keystoreWallet) URL() URL { return w.account.URL }
+
+func (w *keystoreWallet) Status() (string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.key == nil {
+		return "locked", nil
+	}
+	return "unlocked", nil
+}
+
+func (w *keystoreWallet) Open(passphrase string) error {
+	key, err := w.ks.DecryptKeyFile(w.path, passphrase)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	w.key = key
+	w.mu.Unlock()
+	return nil
+}
+
+func (w *keystoreWallet) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.key = nil
+	return nil
+}
+
+func (w *keystoreWallet) Accounts() []Account {
+	return []Account{w.account}
+}
+
+func (w *keystoreWallet) Contains(account Account) bool {
+	return account.Address == w.account.Address
+}
+
+func (w *keystoreWallet) Derive(path string, pin bool) (Account, error) {
+	return Account{}, fmt.Errorf("keystore wallets hold a single imported key and cannot derive new accounts")
+}
+
+func (w *keystoreWallet) unlockedKey() (*ecdsa.PrivateKey, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.key == nil {
+		return nil, fmt.Errorf("wallet %s is locked", w.account)
+	}
+	return w.key, nil
+}
+
+func (w *keystoreWallet) SignHash(account Account, hash []byte) ([]byte, error) {
+	key, err := w.unlockedKey()
+	if err != nil {
+		return nil, err
+	}
+	return gethcrypto.Sign(hash, key)
+}
+
+func (w *keystoreWallet) SignTx(account Account, tx *core.Transaction, chainID *big.Int) (*core.Transaction, error) {
+	key, err := w.unlockedKey()
+	if err != nil {
+		return nil, err
+	}
+	return signTx(tx, chainID, key)
+}
+
+func (w *keystoreWallet) SignTxWithPassphrase(account Account, passphrase string, tx *core.Transaction, chainID *big.Int) (*core.Transaction, error) {
+	key, err := w.ks.DecryptKeyFile(w.path, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return signTx(tx, chainID, key)
+}
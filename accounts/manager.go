@@ -0,0 +1,166 @@
+package accounts
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Manager fans the wallets of every registered Backend into a single
+// sorted list, and re-broadcasts their arrival/drop events to its own
+// subscribers. It's the one thing API handlers and the CLI talk to,
+// regardless of how many backends — keystore, HD, hardware, remote signer
+// — are actually behind it.
+type Manager struct {
+	backends []Backend
+
+	mu      sync.RWMutex
+	wallets []Wallet
+
+	subMu  sync.Mutex
+	subs   map[int]chan<- WalletEvent
+	nextID int
+
+	updates chan WalletEvent
+	quit    chan struct{}
+}
+
+// NewManager creates a Manager over backends, merges their current wallets,
+// and starts forwarding each backend's events into Manager's own feed.
+func NewManager(backends ...Backend) *Manager {
+	m := &Manager{
+		backends: backends,
+		subs:     make(map[int]chan<- WalletEvent),
+		updates:  make(chan WalletEvent, 16),
+		quit:     make(chan struct{}),
+	}
+
+	var wallets []Wallet
+	for _, backend := range backends {
+		wallets = append(wallets, backend.Wallets()...)
+		backend.Subscribe(m.updates)
+	}
+	sortWallets(wallets)
+	m.wallets = wallets
+
+	go m.loop()
+	return m
+}
+
+func sortWallets(wallets []Wallet) {
+	sort.Slice(wallets, func(i, j int) bool {
+		return wallets[i].URL().Cmp(wallets[j].URL()) < 0
+	})
+}
+
+// loop applies each backend event to the merged wallet list and
+// re-broadcasts it to Manager's own subscribers.
+func (m *Manager) loop() {
+	for {
+		select {
+		case <-m.quit:
+			return
+		case event := <-m.updates:
+			m.applyEvent(event)
+			m.broadcast(event)
+		}
+	}
+}
+
+func (m *Manager) applyEvent(event WalletEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch event.Kind {
+	case WalletArrived:
+		m.wallets = append(m.wallets, event.Wallet)
+		sortWallets(m.wallets)
+	case WalletDropped:
+		for i, w := range m.wallets {
+			if w.URL() == event.Wallet.URL() {
+				m.wallets = append(m.wallets[:i], m.wallets[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+func (m *Manager) broadcast(event WalletEvent) {
+	m.subMu.Lock()
+	defer m.subMu.Unlock()
+	for _, sink := range m.subs {
+		select {
+		case sink <- event:
+		default:
+		}
+	}
+}
+
+// Wallets returns every wallet known across all backends, sorted by URL.
+func (m *Manager) Wallets() []Wallet {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]Wallet, len(m.wallets))
+	copy(out, m.wallets)
+	return out
+}
+
+// Find returns the wallet holding account, or an error if none does. A miss
+// triggers one rescan of every backend before giving up, so an account
+// created after NewManager ran — before a backend's live-update watcher,
+// if it has one, would have reported it — is still found.
+func (m *Manager) Find(account Account) (Wallet, error) {
+	if w := m.findCached(account); w != nil {
+		return w, nil
+	}
+
+	m.rescan()
+
+	if w := m.findCached(account); w != nil {
+		return w, nil
+	}
+	return nil, fmt.Errorf("no wallet found for account %s", account)
+}
+
+func (m *Manager) findCached(account Account) Wallet {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, w := range m.wallets {
+		if w.Contains(account) {
+			return w
+		}
+	}
+	return nil
+}
+
+func (m *Manager) rescan() {
+	var wallets []Wallet
+	for _, backend := range m.backends {
+		wallets = append(wallets, backend.Wallets()...)
+	}
+	sortWallets(wallets)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.wallets = wallets
+}
+
+// Subscribe registers sink to receive every wallet arrival/drop event
+// across all backends. The returned function unsubscribes it.
+func (m *Manager) Subscribe(sink chan<- WalletEvent) (unsubscribe func()) {
+	m.subMu.Lock()
+	defer m.subMu.Unlock()
+	id := m.nextID
+	m.nextID++
+	m.subs[id] = sink
+	return func() {
+		m.subMu.Lock()
+		defer m.subMu.Unlock()
+		delete(m.subs, id)
+	}
+}
+
+// Close stops the Manager's event loop.
+func (m *Manager) Close() {
+	close(m.quit)
+}
@@ -0,0 +1,43 @@
+package accounts
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// signingHash is core.SigningHash under a local name, so the rest of this
+// file reads the same whether it's calling into core or computing
+// something accounts-specific.
+func signingHash(tx *core.Transaction, chainID *big.Int) common.Hash {
+	return core.SigningHash(tx, chainID)
+}
+
+// SigningHash is signingHash exported for callers — like usbwallet's
+// hardware-device drivers — that need the exact hash a signature must
+// cover without themselves holding a private key to sign it with.
+func SigningHash(tx *core.Transaction, chainID *big.Int) common.Hash {
+	return signingHash(tx, chainID)
+}
+
+// SignWithKey signs tx for chainID with key directly, without going
+// through a Wallet/Manager. It exists for standalone signer processes —
+// e.g. the reference diora-wallet server — that hold raw private keys
+// in memory rather than an encrypted keystore. Any transaction type
+// LatestSignerForChainID(chainID) supports may be passed in.
+func SignWithKey(tx *core.Transaction, chainID *big.Int, key *ecdsa.PrivateKey) (*core.Transaction, error) {
+	return signTx(tx, chainID, key)
+}
+
+// signTx signs tx with key and returns a copy with V, R, S, Hash, and From
+// filled in — the fields Blockchain.ValidateTransaction and
+// DecodeRawTransaction expect once a transaction is ready to submit. It
+// signs through LatestSignerForChainID, so a LegacyTxType transaction is
+// still folded the EIP-155 way (recoveryID + 35 + 2*chainID) while an
+// AccessListTxType/DynamicFeeTxType transaction gets the raw 0/1 parity its
+// own signing scheme expects, without this caller having to know which.
+func signTx(tx *core.Transaction, chainID *big.Int, key *ecdsa.PrivateKey) (*core.Transaction, error) {
+	return SignTx(tx, LatestSignerForChainID(chainID), key)
+}
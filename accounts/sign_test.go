@@ -0,0 +1,95 @@
+package accounts
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/ethereum/go-ethereum/common"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+func testTx(to common.Address) *core.Transaction {
+	return &core.Transaction{
+		Type:     core.LegacyTxType,
+		Nonce:    1,
+		GasPrice: big.NewInt(1),
+		GasLimit: 21000,
+		To:       &to,
+		Value:    big.NewInt(1000),
+	}
+}
+
+func TestSignWithKeyRoundTrip(t *testing.T) {
+	key, err := gethcrypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	chainID := big.NewInt(1337)
+
+	signed, err := SignWithKey(testTx(to), chainID, key)
+	if err != nil {
+		t.Fatalf("SignWithKey: %v", err)
+	}
+
+	want := gethcrypto.PubkeyToAddress(key.PublicKey)
+	if signed.From != want {
+		t.Fatalf("signed.From = %s, want %s", signed.From.Hex(), want.Hex())
+	}
+
+	signer := NewEIP155Signer(chainID)
+	got, err := signer.Sender(signed)
+	if err != nil {
+		t.Fatalf("Sender: %v", err)
+	}
+	if got != want {
+		t.Fatalf("Sender recovered %s, want %s", got.Hex(), want.Hex())
+	}
+}
+
+func TestEIP155SignerRejectsWrongChainID(t *testing.T) {
+	key, err := gethcrypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+
+	signed, err := SignWithKey(testTx(to), big.NewInt(1337), key)
+	if err != nil {
+		t.Fatalf("SignWithKey: %v", err)
+	}
+
+	signer := NewEIP155Signer(big.NewInt(1))
+	if _, err := signer.Sender(signed); err == nil {
+		t.Fatalf("Sender on mismatched chain ID: got nil error, want one")
+	}
+}
+
+func TestEIP155SignerRejectsMalleableSignature(t *testing.T) {
+	key, err := gethcrypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	chainID := big.NewInt(1337)
+
+	signed, err := SignWithKey(testTx(to), chainID, key)
+	if err != nil {
+		t.Fatalf("SignWithKey: %v", err)
+	}
+
+	// Flip (v, s) to the other half of secp256k1's order — a valid
+	// signature over the same hash that EIP-2 forbids as malleable.
+	signed.S = new(big.Int).Sub(gethcrypto.S256().Params().N, signed.S)
+	if signed.V.Bit(0) == 0 {
+		signed.V = new(big.Int).Add(signed.V, big.NewInt(1))
+	} else {
+		signed.V = new(big.Int).Sub(signed.V, big.NewInt(1))
+	}
+
+	signer := NewEIP155Signer(chainID)
+	if _, err := signer.Sender(signed); err == nil {
+		t.Fatalf("Sender on malleable signature: got nil error, want one")
+	}
+}
@@ -0,0 +1,270 @@
+package accounts
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/DioraFund/diora-go-blockchain/core"
+	dioracrypto "github.com/DioraFund/diora-go-blockchain/crypto"
+	"github.com/ethereum/go-ethereum/common"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer ties a transaction to the hash its signature actually covers,
+// knows how to recover the sender back out of that signature, and knows how
+// to fold a raw 65-byte [R || S || V] signature into the V, R, S a
+// transaction of its kind is stored with — so SignTx and Sender agree on
+// exactly one scheme for a given transaction type without either of them
+// hard-coding it.
+//
+// A concrete Signer only handles the transaction type(s) it was built for;
+// Sender and SignatureValues return an error for anything else.
+type Signer interface {
+	Hash(tx *core.Transaction) common.Hash
+	Sender(tx *core.Transaction) (common.Address, error)
+	SignatureValues(tx *core.Transaction, sig []byte) (r, s, v *big.Int, err error)
+}
+
+// HomesteadSigner signs a LegacyTxType transaction the pre-EIP-155 way
+// (v = recoveryID + 27): no chain id folded in anywhere, so the signature
+// is valid on every chain that still accepts unprotected transactions. It
+// exists for that compatibility, not as a recommendation — EIP155Signer
+// should be preferred wherever the chain enforces replay protection.
+type HomesteadSigner struct{}
+
+func (HomesteadSigner) Hash(tx *core.Transaction) common.Hash {
+	return signingHash(tx, nil)
+}
+
+func (s HomesteadSigner) Sender(tx *core.Transaction) (common.Address, error) {
+	if tx.Type != core.LegacyTxType {
+		return common.Address{}, fmt.Errorf("HomesteadSigner does not support transaction type %d", tx.Type)
+	}
+	return recoverLegacySender(tx, nil, s.Hash(tx))
+}
+
+func (HomesteadSigner) SignatureValues(tx *core.Transaction, sig []byte) (r, s, v *big.Int, err error) {
+	if tx.Type != core.LegacyTxType {
+		return nil, nil, nil, fmt.Errorf("HomesteadSigner does not support transaction type %d", tx.Type)
+	}
+	r, s = splitRS(sig)
+	return r, s, dioracrypto.EIP155V(sig[64], nil), nil
+}
+
+// EIP155Signer binds a LegacyTxType signature's v value to chainID
+// (v = recoveryID + 35 + 2*chainID), so a transaction signed for one chain
+// fails Sender on any other — the replay protection EIP-155 introduced.
+type EIP155Signer struct {
+	chainID *big.Int
+}
+
+// NewEIP155Signer returns the Signer signTx/ValidateTransaction use for
+// chainID. A nil or zero chainID signs the pre-EIP-155 way (v = recoveryID
+// + 27), for chains that haven't opted into replay protection.
+func NewEIP155Signer(chainID *big.Int) EIP155Signer {
+	if chainID == nil {
+		chainID = new(big.Int)
+	}
+	return EIP155Signer{chainID: chainID}
+}
+
+// Hash returns the hash signTx signs and Sender recovers against.
+func (s EIP155Signer) Hash(tx *core.Transaction) common.Hash {
+	return signingHash(tx, s.chainID)
+}
+
+// Sender recovers the address that produced tx's (V, R, S), rejecting a
+// signature whose v doesn't match this signer's chainID or whose s is
+// above secp256k1's half-order (the malleability EIP-2 forbids).
+func (s EIP155Signer) Sender(tx *core.Transaction) (common.Address, error) {
+	if tx.Type != core.LegacyTxType {
+		return common.Address{}, fmt.Errorf("EIP155Signer does not support transaction type %d", tx.Type)
+	}
+	return recoverLegacySender(tx, s.chainID, s.Hash(tx))
+}
+
+func (s EIP155Signer) SignatureValues(tx *core.Transaction, sig []byte) (r, sVal, v *big.Int, err error) {
+	if tx.Type != core.LegacyTxType {
+		return nil, nil, nil, fmt.Errorf("EIP155Signer does not support transaction type %d", tx.Type)
+	}
+	r, sVal = splitRS(sig)
+	return r, sVal, dioracrypto.EIP155V(sig[64], s.chainID), nil
+}
+
+// EIP2930Signer extends EIP155Signer with AccessListTxType support. An
+// access-list transaction folds chainID into the signed payload itself
+// rather than into v, so its v is always the raw 0/1 recovery parity;
+// legacy transactions still go through the embedded EIP155Signer unchanged.
+type EIP2930Signer struct {
+	EIP155Signer
+}
+
+// NewEIP2930Signer returns the Signer for chainID that additionally accepts
+// AccessListTxType transactions.
+func NewEIP2930Signer(chainID *big.Int) EIP2930Signer {
+	return EIP2930Signer{EIP155Signer: NewEIP155Signer(chainID)}
+}
+
+func (s EIP2930Signer) Hash(tx *core.Transaction) common.Hash {
+	if tx.Type == core.LegacyTxType {
+		return s.EIP155Signer.Hash(tx)
+	}
+	return typedHash(tx)
+}
+
+func (s EIP2930Signer) Sender(tx *core.Transaction) (common.Address, error) {
+	if tx.Type == core.LegacyTxType {
+		return s.EIP155Signer.Sender(tx)
+	}
+	if tx.Type != core.AccessListTxType {
+		return common.Address{}, fmt.Errorf("EIP2930Signer does not support transaction type %d", tx.Type)
+	}
+	return recoverTypedSender(tx, s.chainID, s.Hash(tx))
+}
+
+func (s EIP2930Signer) SignatureValues(tx *core.Transaction, sig []byte) (r, sVal, v *big.Int, err error) {
+	if tx.Type == core.LegacyTxType {
+		return s.EIP155Signer.SignatureValues(tx, sig)
+	}
+	if tx.Type != core.AccessListTxType {
+		return nil, nil, nil, fmt.Errorf("EIP2930Signer does not support transaction type %d", tx.Type)
+	}
+	r, sVal = splitRS(sig)
+	return r, sVal, big.NewInt(int64(sig[64])), nil
+}
+
+// LondonSigner extends EIP2930Signer with DynamicFeeTxType support, the
+// latest transaction kind this chain defines besides blobs.
+type LondonSigner struct {
+	EIP2930Signer
+}
+
+// NewLondonSigner returns the Signer for chainID that accepts every
+// transaction type this chain currently defines except BlobTxType.
+func NewLondonSigner(chainID *big.Int) LondonSigner {
+	return LondonSigner{EIP2930Signer: NewEIP2930Signer(chainID)}
+}
+
+func (s LondonSigner) Hash(tx *core.Transaction) common.Hash {
+	if tx.Type != core.DynamicFeeTxType {
+		return s.EIP2930Signer.Hash(tx)
+	}
+	return typedHash(tx)
+}
+
+func (s LondonSigner) Sender(tx *core.Transaction) (common.Address, error) {
+	if tx.Type != core.DynamicFeeTxType {
+		return s.EIP2930Signer.Sender(tx)
+	}
+	return recoverTypedSender(tx, s.chainID, s.Hash(tx))
+}
+
+func (s LondonSigner) SignatureValues(tx *core.Transaction, sig []byte) (r, sVal, v *big.Int, err error) {
+	if tx.Type != core.DynamicFeeTxType {
+		return s.EIP2930Signer.SignatureValues(tx, sig)
+	}
+	r, sVal = splitRS(sig)
+	return r, sVal, big.NewInt(int64(sig[64])), nil
+}
+
+// LatestSignerForChainID returns the most capable Signer this chain
+// currently implements for chainID — today, LondonSigner. A caller that
+// wants a specific older scheme (say, to reproduce a historical signature)
+// should construct HomesteadSigner/EIP155Signer/EIP2930Signer directly.
+func LatestSignerForChainID(chainID *big.Int) Signer {
+	return NewLondonSigner(chainID)
+}
+
+// SignTx signs tx under signer with key and returns a copy with V, R, S,
+// Hash, and From filled in via signer.SignatureValues, so any transaction
+// type a Signer supports can be signed through the same path instead of
+// each type needing its own signing helper.
+func SignTx(tx *core.Transaction, signer Signer, key *ecdsa.PrivateKey) (*core.Transaction, error) {
+	signed := *tx
+	hash := signer.Hash(&signed)
+
+	sig, err := gethcrypto.Sign(hash.Bytes(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	r, s, v, err := signer.SignatureValues(&signed, sig)
+	if err != nil {
+		return nil, err
+	}
+	signed.R, signed.S, signed.V = r, s, v
+	signed.From = gethcrypto.PubkeyToAddress(key.PublicKey)
+	signed.Hash = hash
+
+	return &signed, nil
+}
+
+// recoverLegacySender is HomesteadSigner/EIP155Signer's shared Sender: it
+// validates a LegacyTxType signature against chainID and recovers its
+// signer. A nil or zero chainID accepts the pre-EIP-155 unprotected form.
+func recoverLegacySender(tx *core.Transaction, chainID *big.Int, hash common.Hash) (common.Address, error) {
+	if tx.V == nil || tx.R == nil || tx.S == nil {
+		return common.Address{}, fmt.Errorf("transaction is unsigned")
+	}
+
+	recID, err := dioracrypto.EIP155RecoveryID(tx.V, chainID)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return recoverSenderFromSig(tx, hash, recID)
+}
+
+// recoverTypedSender is EIP2930Signer/LondonSigner's shared Sender for a
+// typed transaction, whose v is always the raw 0/1 recovery parity rather
+// than an EIP-155-folded value. Unlike a legacy transaction's chain id
+// (folded into v itself), a typed transaction's chain id has to be checked
+// against the signer's chainID explicitly — it lives in the payload that
+// hash already covers, not in anything recoverSenderFromSig inspects.
+func recoverTypedSender(tx *core.Transaction, chainID *big.Int, hash common.Hash) (common.Address, error) {
+	if tx.V == nil || tx.R == nil || tx.S == nil {
+		return common.Address{}, fmt.Errorf("transaction is unsigned")
+	}
+	if chainID != nil && chainID.Sign() != 0 && (tx.ChainID == nil || tx.ChainID.Cmp(chainID) != 0) {
+		return common.Address{}, fmt.Errorf("typed transaction chain id %v does not match %v", tx.ChainID, chainID)
+	}
+	if tx.V.BitLen() > 8 || (tx.V.Int64() != 0 && tx.V.Int64() != 1) {
+		return common.Address{}, fmt.Errorf("typed transaction signature v must be 0 or 1, got %s", tx.V)
+	}
+	return recoverSenderFromSig(tx, hash, byte(tx.V.Int64()))
+}
+
+func recoverSenderFromSig(tx *core.Transaction, hash common.Hash, recID byte) (common.Address, error) {
+	if !dioracrypto.ValidateSignatureValues(recID, tx.R, tx.S, true) {
+		return common.Address{}, fmt.Errorf("invalid signature values (malleable or out of range)")
+	}
+
+	sig := make([]byte, 65)
+	tx.R.FillBytes(sig[0:32])
+	tx.S.FillBytes(sig[32:64])
+	sig[64] = recID
+
+	pubkey, err := dioracrypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid signature: %w", err)
+	}
+	return gethcrypto.PubkeyToAddress(*pubkey), nil
+}
+
+// typedHash is EIP2930Signer/LondonSigner's shared Hash for a non-legacy
+// transaction type. core.TypedSigningHash already dispatches by tx.Type;
+// its error case (a typed transaction with no ChainID set) collapses to the
+// zero hash here instead of panicking. SignTx still signs that zero hash and
+// SignatureValues does not reject it; Sender rejects such a transaction via
+// its chain id check when the signer's chainID is non-zero.
+func typedHash(tx *core.Transaction) common.Hash {
+	hash, err := core.TypedSigningHash(tx)
+	if err != nil {
+		return common.Hash{}
+	}
+	return hash
+}
+
+func splitRS(sig []byte) (r, s *big.Int) {
+	return new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64])
+}
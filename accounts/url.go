@@ -0,0 +1,58 @@
+package accounts
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// URL is the canonical identification of a wallet or account backend, e.g.
+// "keystore:///path/to/UTC--...". Scheme and Path are kept as separate
+// fields rather than a single opaque string so Manager can sort and compare
+// wallets without re-parsing anything.
+type URL struct {
+	Scheme string
+	Path   string
+}
+
+// parseURL splits a "scheme:///path"-shaped string into its components.
+func parseURL(s string) (URL, error) {
+	parts := strings.SplitN(s, "://", 2)
+	if len(parts) != 2 {
+		return URL{}, fmt.Errorf("invalid account URL %q: missing scheme", s)
+	}
+	return URL{Scheme: parts[0], Path: parts[1]}, nil
+}
+
+func (u URL) String() string {
+	if u.Scheme == "" {
+		return u.Path
+	}
+	return fmt.Sprintf("%s://%s", u.Scheme, u.Path)
+}
+
+// Cmp orders two URLs by scheme first, then path — the order Manager keeps
+// its merged wallet list sorted in.
+func (u URL) Cmp(other URL) int {
+	if u.Scheme != other.Scheme {
+		return strings.Compare(u.Scheme, other.Scheme)
+	}
+	return strings.Compare(u.Path, other.Path)
+}
+
+func (u URL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.String())
+}
+
+func (u *URL) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := parseURL(s)
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
@@ -0,0 +1,366 @@
+package usbwallet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/karalabe/usb"
+)
+
+// Trezor's wire protocol wraps each protobuf message in a small framing
+// header on top of 64-byte HID reports. These are the subset of message
+// type IDs (trezor/messages-common.proto's MessageType enum) this driver
+// needs.
+const (
+	trezorMsgEthereumGetAddress = 56
+	trezorMsgEthereumAddress    = 57
+	trezorMsgEthereumSignTx     = 58
+	trezorMsgEthereumTxRequest  = 59
+	trezorMsgEthereumTxAck      = 60
+	trezorMsgEthereumSignMsg    = 64
+	trezorMsgEthereumMsgSig     = 65
+)
+
+// trezorChunkSize is the HID report payload size Trezor's transport wraps
+// messages in.
+const trezorChunkSize = 64
+
+// trezorDriver speaks Trezor's protobuf-over-HID wire protocol to the
+// device's Ethereum app. It encodes the handful of EthereumX messages it
+// needs directly (field tag + varint/length-delimited wire format) rather
+// than depending on the generated trezor-common protobuf package, since
+// only a few message shapes are needed here.
+type trezorDriver struct {
+	info usb.DeviceInfo
+
+	mu     sync.Mutex
+	device usb.Device
+}
+
+func newTrezorDriver(info usb.DeviceInfo) *trezorDriver {
+	return &trezorDriver{info: info}
+}
+
+func (d *trezorDriver) open() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.device != nil {
+		return nil
+	}
+	device, err := d.info.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open Trezor device: %w", err)
+	}
+	d.device = device
+	return nil
+}
+
+func (d *trezorDriver) close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.device == nil {
+		return nil
+	}
+	err := d.device.Close()
+	d.device = nil
+	return err
+}
+
+func (d *trezorDriver) status() (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.device == nil {
+		return "disconnected", nil
+	}
+	return "Trezor Ethereum app ready", nil
+}
+
+// derive sends an EthereumGetAddress message for path and parses the
+// EthereumAddress reply.
+func (d *trezorDriver) derive(path string, pin bool) (common.Address, error) {
+	indices, err := parseBIP32Indices(path)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	reply, msgType, err := d.call(trezorMsgEthereumGetAddress, encodeEthereumGetAddress(indices))
+	if err != nil {
+		return common.Address{}, err
+	}
+	if msgType != trezorMsgEthereumAddress {
+		return common.Address{}, fmt.Errorf("unexpected Trezor reply type %d", msgType)
+	}
+
+	address, ok := decodeLengthDelimitedField(reply, 2)
+	if !ok {
+		return common.Address{}, fmt.Errorf("malformed EthereumAddress reply")
+	}
+	return common.BytesToAddress(address), nil
+}
+
+// signTx sends an EthereumSignTx message and then answers the device's
+// EthereumTxRequest chunk requests with EthereumTxAck until it has seen
+// the whole RLP payload, finally parsing the v/r/s out of its last
+// request once the holder has approved on-screen.
+func (d *trezorDriver) signTx(path string, tx *core.Transaction, chainID *big.Int) (v, r, s *big.Int, err error) {
+	indices, err := parseBIP32Indices(path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	unsigned := *tx
+	unsigned.V, unsigned.R, unsigned.S = nil, nil, nil
+	unsigned.Hash, unsigned.From = common.Hash{}, common.Address{}
+	payload, err := rlp.EncodeToBytes(&unsigned)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to encode transaction: %w", err)
+	}
+
+	var chainIDVal uint64
+	if chainID != nil {
+		chainIDVal = chainID.Uint64()
+	}
+
+	reply, msgType, err := d.call(trezorMsgEthereumSignTx, encodeEthereumSignTx(indices, payload, chainIDVal))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	for msgType == trezorMsgEthereumTxRequest {
+		dataLen, _ := decodeVarintField(reply, 1)
+		sent := len(payload) - int(dataLen)
+		if sent < 0 {
+			sent = 0
+		}
+		if sent >= len(payload) {
+			break
+		}
+		end := sent + trezorChunkSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+		reply, msgType, err = d.call(trezorMsgEthereumTxAck, encodeEthereumTxAck(payload[sent:end]))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	vField, ok1 := decodeVarintField(reply, 2)
+	rField, ok2 := decodeLengthDelimitedField(reply, 3)
+	sField, ok3 := decodeLengthDelimitedField(reply, 4)
+	if !ok1 || !ok2 || !ok3 {
+		return nil, nil, nil, fmt.Errorf("malformed EthereumTxRequest signature fields")
+	}
+	return new(big.Int).SetUint64(vField), new(big.Int).SetBytes(rField), new(big.Int).SetBytes(sField), nil
+}
+
+// signHash sends an EthereumSignMessage for hash and returns the
+// signature from the EthereumMessageSignature reply.
+func (d *trezorDriver) signHash(path string, hash []byte) ([]byte, error) {
+	indices, err := parseBIP32Indices(path)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, msgType, err := d.call(trezorMsgEthereumSignMsg, encodeEthereumSignMsg(indices, hash))
+	if err != nil {
+		return nil, err
+	}
+	if msgType != trezorMsgEthereumMsgSig {
+		return nil, fmt.Errorf("unexpected Trezor reply type %d", msgType)
+	}
+
+	sig, ok := decodeLengthDelimitedField(reply, 2)
+	if !ok {
+		return nil, fmt.Errorf("malformed EthereumMessageSignature reply")
+	}
+	return sig, nil
+}
+
+// call writes one framed message and reads back the single reply message
+// that answers it.
+func (d *trezorDriver) call(msgType uint16, payload []byte) ([]byte, uint16, error) {
+	d.mu.Lock()
+	device := d.device
+	d.mu.Unlock()
+	if device == nil {
+		return nil, 0, fmt.Errorf("Trezor device not open")
+	}
+
+	frame := make([]byte, 8+len(payload))
+	binary.BigEndian.PutUint16(frame[0:2], msgType)
+	binary.BigEndian.PutUint32(frame[2:6], uint32(len(payload)))
+	copy(frame[8:], payload)
+
+	if _, err := device.Write(frame); err != nil {
+		return nil, 0, fmt.Errorf("failed to write Trezor message: %w", err)
+	}
+
+	header := make([]byte, 8)
+	n, err := device.Read(header)
+	if err != nil || n < 8 {
+		return nil, 0, fmt.Errorf("failed to read Trezor reply header: %w", err)
+	}
+	replyType := binary.BigEndian.Uint16(header[0:2])
+	replyLen := binary.BigEndian.Uint32(header[2:6])
+
+	body := make([]byte, replyLen)
+	if replyLen > 0 {
+		if _, err := device.Read(body); err != nil {
+			return nil, 0, fmt.Errorf("failed to read Trezor reply body: %w", err)
+		}
+	}
+	return body, replyType, nil
+}
+
+func parseBIP32Indices(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimPrefix(path, "m/"), "/")
+	indices := make([]uint32, 0, len(parts))
+	for _, part := range parts {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "H")
+		part = strings.TrimSuffix(strings.TrimSuffix(part, "'"), "H")
+
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path component %q: %w", part, err)
+		}
+		if hardened {
+			index += 0x80000000
+		}
+		indices = append(indices, uint32(index))
+	}
+	return indices, nil
+}
+
+// The encode*/decode* helpers below write and read the minimal subset of
+// protobuf's wire format this driver needs (varint and length-delimited
+// fields) rather than depending on the generated trezor-common package.
+
+func encodeEthereumGetAddress(indices []uint32) []byte {
+	var out []byte
+	for _, idx := range indices {
+		out = append(out, encodeVarintField(1, uint64(idx))...)
+	}
+	return out
+}
+
+func encodeEthereumSignTx(indices []uint32, payload []byte, chainID uint64) []byte {
+	var out []byte
+	for _, idx := range indices {
+		out = append(out, encodeVarintField(1, uint64(idx))...)
+	}
+	out = append(out, encodeLengthDelimitedField(6, payload)...)
+	if chainID != 0 {
+		out = append(out, encodeVarintField(9, chainID)...)
+	}
+	return out
+}
+
+func encodeEthereumTxAck(chunk []byte) []byte {
+	return encodeLengthDelimitedField(1, chunk)
+}
+
+func encodeEthereumSignMsg(indices []uint32, hash []byte) []byte {
+	var out []byte
+	for _, idx := range indices {
+		out = append(out, encodeVarintField(1, uint64(idx))...)
+	}
+	out = append(out, encodeLengthDelimitedField(2, hash)...)
+	return out
+}
+
+func encodeVarintField(fieldNum int, value uint64) []byte {
+	tag := byte(fieldNum<<3) | 0 // wire type 0: varint
+	out := []byte{tag}
+	for value >= 0x80 {
+		out = append(out, byte(value)|0x80)
+		value >>= 7
+	}
+	return append(out, byte(value))
+}
+
+func encodeLengthDelimitedField(fieldNum int, data []byte) []byte {
+	tag := byte(fieldNum<<3) | 2 // wire type 2: length-delimited
+	out := []byte{tag}
+	length := encodeVarintField(0, uint64(len(data)))[1:] // strip the dummy tag byte
+	out = append(out, length...)
+	return append(out, data...)
+}
+
+// decodeVarintField and decodeLengthDelimitedField linear-scan a
+// wire-format-encoded message for the first field with the given number,
+// sufficient for the small, fixed reply shapes this driver parses.
+
+func decodeVarintField(data []byte, fieldNum int) (uint64, bool) {
+	for i := 0; i < len(data); {
+		tag := data[i]
+		num := int(tag >> 3)
+		wireType := tag & 0x7
+		i++
+		switch wireType {
+		case 0:
+			value, n := readVarint(data[i:])
+			if num == fieldNum {
+				return value, true
+			}
+			i += n
+		case 2:
+			length, n := readVarint(data[i:])
+			i += n
+			if num == fieldNum {
+				return 0, false
+			}
+			i += int(length)
+		default:
+			return 0, false
+		}
+	}
+	return 0, false
+}
+
+func decodeLengthDelimitedField(data []byte, fieldNum int) ([]byte, bool) {
+	for i := 0; i < len(data); {
+		tag := data[i]
+		num := int(tag >> 3)
+		wireType := tag & 0x7
+		i++
+		switch wireType {
+		case 0:
+			_, n := readVarint(data[i:])
+			i += n
+		case 2:
+			length, n := readVarint(data[i:])
+			i += n
+			if num == fieldNum {
+				if i+int(length) > len(data) {
+					return nil, false
+				}
+				return data[i : i+int(length)], true
+			}
+			i += int(length)
+		default:
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+func readVarint(data []byte) (uint64, int) {
+	var value uint64
+	var shift uint
+	for i, b := range data {
+		value |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return value, i + 1
+		}
+		shift += 7
+	}
+	return value, len(data)
+}
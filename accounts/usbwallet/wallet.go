@@ -0,0 +1,148 @@
+package usbwallet
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/DioraFund/diora-go-blockchain/accounts"
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/karalabe/usb"
+)
+
+// driver is the per-vendor protocol a wallet speaks to its device: framing
+// and parsing for Ledger's APDU transport or Trezor's protobuf-over-HID
+// transport live behind this interface so wallet itself stays
+// device-agnostic.
+type driver interface {
+	// open establishes the device connection.
+	open() error
+	// close tears it down.
+	close() error
+	// status reports a human-readable device state.
+	status() (string, error)
+	// derive asks the device for the address at path (never the private
+	// key). Both drivers ignore pin; remembering a pinned account is done
+	// by wallet.Derive itself.
+	derive(path string, pin bool) (common.Address, error)
+	// signTx streams tx's signing payload to the device for on-screen
+	// confirmation and returns the v, r, s the holder approved.
+	signTx(path string, tx *core.Transaction, chainID *big.Int) (v, r, s *big.Int, err error)
+	// signHash is signTx's equivalent for a raw hash (eth_sign-style
+	// message signing).
+	signHash(path string, hash []byte) ([]byte, error)
+}
+
+// wallet is a single connected Ledger or Trezor device. Unlike a keystore
+// wallet it doesn't hold a key at all — every signature happens on the
+// device, so Open/Close just manage the USB handle, not key material.
+type wallet struct {
+	url    accounts.URL
+	info   usb.DeviceInfo
+	driver driver
+
+	mu       sync.Mutex
+	accounts []accounts.Account // paths the user has derived and pinned
+}
+
+func (w *wallet) URL() accounts.URL { return w.url }
+
+func (w *wallet) Status() (string, error) {
+	return w.driver.status()
+}
+
+func (w *wallet) Open(passphrase string) error {
+	// Hardware wallets have no passphrase of their own to unlock here —
+	// the PIN/passphrase, if any, is entered on the device itself.
+	return w.driver.open()
+}
+
+func (w *wallet) Close() error {
+	return w.driver.close()
+}
+
+func (w *wallet) Accounts() []accounts.Account {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	out := make([]accounts.Account, len(w.accounts))
+	copy(out, w.accounts)
+	return out
+}
+
+func (w *wallet) Contains(account accounts.Account) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, a := range w.accounts {
+		if a.Address == account.Address {
+			return true
+		}
+	}
+	return false
+}
+
+// Derive asks the device for the address at path without ever exposing
+// the seed that produced it. If pin is set, the account is remembered on
+// this wallet so Accounts()/Contains() see it across a reopen — the same
+// "pin" semantics go-ethereum's hardware wallets use.
+func (w *wallet) Derive(path string, pin bool) (accounts.Account, error) {
+	address, err := w.driver.derive(path, pin)
+	if err != nil {
+		return accounts.Account{}, fmt.Errorf("failed to derive %s: %w", path, err)
+	}
+
+	account := accounts.Account{Address: address, URL: accounts.URL{Scheme: w.url.Scheme, Path: w.url.Path + "/" + path}}
+	if pin {
+		w.mu.Lock()
+		w.accounts = append(w.accounts, account)
+		w.mu.Unlock()
+	}
+	return account, nil
+}
+
+// derivationPath finds the BIP-32 path account was last derived under.
+// Hardware wallets don't store it in Account itself (unlike the keystore's
+// Path field), so we look it up from what this wallet has pinned.
+func (w *wallet) derivationPath(account accounts.Account) (string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, a := range w.accounts {
+		if a.Address == account.Address {
+			// a.URL.Path is "<device path>/<derivation path>".
+			return a.URL.Path[len(w.url.Path)+1:], nil
+		}
+	}
+	return "", fmt.Errorf("account %s was not derived through this wallet", account)
+}
+
+func (w *wallet) SignHash(account accounts.Account, hash []byte) ([]byte, error) {
+	path, err := w.derivationPath(account)
+	if err != nil {
+		return nil, err
+	}
+	return w.driver.signHash(path, hash)
+}
+
+func (w *wallet) SignTx(account accounts.Account, tx *core.Transaction, chainID *big.Int) (*core.Transaction, error) {
+	path, err := w.derivationPath(account)
+	if err != nil {
+		return nil, err
+	}
+
+	v, r, s, err := w.driver.signTx(path, tx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("device declined to sign: %w", err)
+	}
+
+	signed := *tx
+	signed.V, signed.R, signed.S = v, r, s
+	signed.From = account.Address
+	signed.Hash = accounts.SigningHash(tx, chainID)
+	return &signed, nil
+}
+
+func (w *wallet) SignTxWithPassphrase(account accounts.Account, passphrase string, tx *core.Transaction, chainID *big.Int) (*core.Transaction, error) {
+	// There's no passphrase-based unlock for a hardware wallet — the
+	// device itself gates every signature — so this is just SignTx.
+	return w.SignTx(account, tx, chainID)
+}
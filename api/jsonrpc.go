@@ -0,0 +1,651 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+// JSON-RPC 2.0 error codes, per the spec plus the Ethereum "server error" range.
+const (
+	rpcErrParse          = -32700
+	rpcErrInvalidRequest = -32600
+	rpcErrMethodNotFound = -32601
+	rpcErrInvalidParams  = -32602
+	rpcErrInternal       = -32603
+	rpcErrServer         = -32000
+)
+
+// RPCRequest is a single JSON-RPC 2.0 request object.
+type RPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+}
+
+// RPCError is the {code, message} error envelope used by JSON-RPC 2.0.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// RPCResponse is a single JSON-RPC 2.0 response object.
+type RPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+// rpcHandlerFunc handles a single decoded RPC call and returns its result or an RPCError.
+type rpcHandlerFunc func(s *Server, params json.RawMessage) (interface{}, *RPCError)
+
+// rpcMethods is the eth_*/net_*/web3_*/... method registry, dispatched by
+// name. Each key's namespace (the part before the first underscore) is what
+// --http.api/--ws.api whitelist against — see namespaceOf/dispatchRPC.
+var rpcMethods = map[string]rpcHandlerFunc{
+	"web3_clientVersion":           rpcWeb3ClientVersion,
+	"web3_sha3":                    rpcWeb3Sha3,
+	"net_version":                  rpcNetVersion,
+	"eth_chainId":                  rpcChainID,
+	"eth_blockNumber":              rpcBlockNumber,
+	"eth_getBlockByNumber":         rpcGetBlockByNumber,
+	"eth_getBlockByHash":           rpcGetBlockByHash,
+	"eth_getBalance":               rpcGetBalance,
+	"eth_getTransactionCount":      rpcGetTransactionCount,
+	"eth_sendRawTransaction":       rpcSendRawTransaction,
+	"eth_call":                     rpcCall,
+	"eth_estimateGas":              rpcEstimateGas,
+	"eth_getLogs":                  rpcGetLogs,
+	"eth_gasPrice":                 rpcGasPrice,
+	"eth_getTransactionByHash":     rpcGetTransactionByHash,
+	"eth_getTransactionsByAddress": rpcGetTransactionsByAddress,
+	"governance_haltHeight":        rpcGovernanceHaltHeight,
+	"governance_submitProposal":    rpcGovernanceSubmitProposal,
+	"txpool_status":                rpcTxPoolStatus,
+	"txpool_content":               rpcTxPoolContent,
+	"admin_nodeInfo":               rpcAdminNodeInfo,
+	"admin_peers":                  rpcAdminPeers,
+	"admin_addPeer":                rpcAdminAddPeer,
+	"admin_addTrustedPeer":         rpcAdminAddTrustedPeer,
+	"admin_removePeer":             rpcAdminRemovePeer,
+	"admin_startRPC":               rpcAdminStartRPC,
+	"admin_stopRPC":                rpcAdminStopRPC,
+	"diora_protocolVersion":        rpcDioraProtocolVersion,
+}
+
+// defaultEnabledAPIs is the --http.api/--ws.api whitelist a node starts
+// with when the flag isn't given — the same "public, low-risk namespaces
+// only" default go-ethereum's geth ships, plus governance since the
+// existing `diora network status`/`diora governance` commands already
+// depend on it. admin/debug/txpool/diora (node internals, and a namespace
+// this release has nothing in yet beyond protocolVersion) are opt-in only.
+var defaultEnabledAPIs = []string{"eth", "net", "web3", "governance"}
+
+// namespaceOf returns method's namespace: the part before its first
+// underscore, e.g. "eth" for "eth_getBalance". Every rpcMethods key follows
+// this convention.
+func namespaceOf(method string) string {
+	for i := 0; i < len(method); i++ {
+		if method[i] == '_' {
+			return method[:i]
+		}
+	}
+	return method
+}
+
+func newRPCError(code int, msg string) *RPCError {
+	return &RPCError{Code: code, Message: msg}
+}
+
+func rpcResult(id json.RawMessage, result interface{}) RPCResponse {
+	return RPCResponse{JSONRPC: "2.0", ID: id, Result: result}
+}
+
+func rpcErrResponse(id json.RawMessage, err *RPCError) RPCResponse {
+	return RPCResponse{JSONRPC: "2.0", ID: id, Error: err}
+}
+
+// handleJSONRPC serves single and batch JSON-RPC 2.0 requests over HTTP.
+func (s *Server) handleJSONRPC(c *gin.Context) {
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusOK, rpcErrResponse(nil, newRPCError(rpcErrParse, "failed to read request body")))
+		return
+	}
+
+	trimmed := trimLeadingSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var reqs []RPCRequest
+		if err := json.Unmarshal(trimmed, &reqs); err != nil {
+			c.JSON(http.StatusOK, rpcErrResponse(nil, newRPCError(rpcErrParse, "invalid batch request")))
+			return
+		}
+		if len(reqs) == 0 {
+			c.JSON(http.StatusOK, rpcErrResponse(nil, newRPCError(rpcErrInvalidRequest, "empty batch")))
+			return
+		}
+		responses := make([]RPCResponse, len(reqs))
+		for i, req := range reqs {
+			responses[i] = s.dispatchRPC(req)
+		}
+		c.JSON(http.StatusOK, responses)
+		return
+	}
+
+	var req RPCRequest
+	if err := json.Unmarshal(trimmed, &req); err != nil {
+		c.JSON(http.StatusOK, rpcErrResponse(nil, newRPCError(rpcErrParse, "invalid request")))
+		return
+	}
+	c.JSON(http.StatusOK, s.dispatchRPC(req))
+}
+
+func (s *Server) dispatchRPC(req RPCRequest) RPCResponse {
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return rpcErrResponse(req.ID, newRPCError(rpcErrInvalidRequest, "invalid jsonrpc request"))
+	}
+
+	handler, ok := rpcMethods[req.Method]
+	if !ok {
+		return rpcErrResponse(req.ID, newRPCError(rpcErrMethodNotFound, fmt.Sprintf("method %q not found", req.Method)))
+	}
+	if !s.httpAPIs[namespaceOf(req.Method)] {
+		return rpcErrResponse(req.ID, newRPCError(rpcErrMethodNotFound, fmt.Sprintf("the %s namespace is not enabled (see --http.api)", namespaceOf(req.Method))))
+	}
+
+	result, rpcErr := handler(s, req.Params)
+	if rpcErr != nil {
+		return rpcErrResponse(req.ID, rpcErr)
+	}
+	return rpcResult(req.ID, result)
+}
+
+func trimLeadingSpace(b []byte) []byte {
+	i := 0
+	for i < len(b) && (b[i] == ' ' || b[i] == '\t' || b[i] == '\n' || b[i] == '\r') {
+		i++
+	}
+	return b[i:]
+}
+
+func rpcParamAt(params json.RawMessage, index int, out interface{}) bool {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(params, &raw); err != nil || index >= len(raw) {
+		return false
+	}
+	return json.Unmarshal(raw[index], out) == nil
+}
+
+func rpcWeb3ClientVersion(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	return "Diora/v1.0.0/go", nil
+}
+
+func rpcNetVersion(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	return s.blockchain.GetChainID().String(), nil
+}
+
+func rpcChainID(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	return hexBig(s.blockchain.GetChainID()), nil
+}
+
+func rpcBlockNumber(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	block := s.blockchain.GetCurrentBlock()
+	return hexBig(block.Header().Number), nil
+}
+
+func rpcGetBlockByNumber(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	var numberStr string
+	if !rpcParamAt(params, 0, &numberStr) {
+		return nil, newRPCError(rpcErrInvalidParams, "missing block number")
+	}
+
+	var block *core.Block
+	var err error
+	if numberStr == "latest" || numberStr == "pending" || numberStr == "" {
+		block = s.blockchain.GetCurrentBlock()
+	} else {
+		number, ok := hexOrDecToBig(numberStr)
+		if !ok {
+			return nil, newRPCError(rpcErrInvalidParams, "invalid block number")
+		}
+		block, err = s.blockchain.GetBlockByNumber(number)
+		if err != nil {
+			return nil, nil // null result for unknown block, per eth_getBlockByNumber semantics
+		}
+	}
+
+	return s.formatBlock(block), nil
+}
+
+func rpcGetBlockByHash(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	var hashStr string
+	if !rpcParamAt(params, 0, &hashStr) {
+		return nil, newRPCError(rpcErrInvalidParams, "missing block hash")
+	}
+
+	block, err := s.blockchain.GetBlockByHash(core.HexToHash(hashStr))
+	if err != nil {
+		return nil, nil
+	}
+	return s.formatBlock(block), nil
+}
+
+func rpcGetBalance(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	var addrStr string
+	if !rpcParamAt(params, 0, &addrStr) {
+		return nil, newRPCError(rpcErrInvalidParams, "missing address")
+	}
+	balance := s.blockchain.GetBalance(core.HexToAddress(addrStr))
+	return hexBig(balance), nil
+}
+
+func rpcGetTransactionCount(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	var addrStr string
+	if !rpcParamAt(params, 0, &addrStr) {
+		return nil, newRPCError(rpcErrInvalidParams, "missing address")
+	}
+	nonce := s.blockchain.GetNonce(core.HexToAddress(addrStr))
+	return fmt.Sprintf("0x%x", nonce), nil
+}
+
+func rpcSendRawTransaction(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	var rawTx string
+	if !rpcParamAt(params, 0, &rawTx) {
+		return nil, newRPCError(rpcErrInvalidParams, "missing raw transaction")
+	}
+
+	tx, err := core.DecodeRawTransaction(rawTx)
+	if err != nil {
+		return nil, newRPCError(rpcErrInvalidParams, fmt.Sprintf("invalid transaction: %v", err))
+	}
+
+	if err := s.blockchain.AddTransaction(tx); err != nil {
+		return nil, newRPCError(rpcErrServer, err.Error())
+	}
+
+	return tx.Hash.Hex(), nil
+}
+
+func rpcCall(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	var callArgs struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+		Data string `json:"data"`
+	}
+	if !rpcParamAt(params, 0, &callArgs) {
+		return nil, newRPCError(rpcErrInvalidParams, "missing call args")
+	}
+
+	result, err := s.blockchain.CallContract(core.HexToAddress(callArgs.From), core.HexToAddress(callArgs.To), hexToBytes(callArgs.Data))
+	if err != nil {
+		return nil, newRPCError(rpcErrServer, err.Error())
+	}
+	return "0x" + hexEncode(result), nil
+}
+
+func rpcEstimateGas(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	var callArgs struct {
+		From       string `json:"from"`
+		To         string `json:"to"`
+		Value      string `json:"value"`
+		Data       string `json:"data"`
+		GasPrice   string `json:"gasPrice"`
+		AccessList []struct {
+			Address     string   `json:"address"`
+			StorageKeys []string `json:"storageKeys"`
+		} `json:"accessList"`
+	}
+	rpcParamAt(params, 0, &callArgs)
+
+	var accessList core.AccessList
+	for _, tuple := range callArgs.AccessList {
+		keys := make([]common.Hash, len(tuple.StorageKeys))
+		for i, k := range tuple.StorageKeys {
+			keys[i] = common.HexToHash(k)
+		}
+		accessList = append(accessList, core.AccessTuple{
+			Address:     common.HexToAddress(tuple.Address),
+			StorageKeys: keys,
+		})
+	}
+
+	value, _ := hexOrDecToBig(callArgs.Value)
+	gasPrice, _ := hexOrDecToBig(callArgs.GasPrice)
+
+	gas, err := s.blockchain.EstimateGas(context.Background(), core.CallMsg{
+		From:       core.HexToAddress(callArgs.From),
+		To:         core.HexToAddress(callArgs.To),
+		Value:      value,
+		Data:       hexToBytes(callArgs.Data),
+		GasPrice:   gasPrice,
+		AccessList: accessList,
+	})
+	if err != nil {
+		return nil, newRPCError(rpcErrServer, err.Error())
+	}
+	return fmt.Sprintf("0x%x", gas), nil
+}
+
+func rpcGetLogs(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	var filter struct {
+		FromBlock string   `json:"fromBlock"`
+		ToBlock   string   `json:"toBlock"`
+		Address   string   `json:"address"`
+		Topics    []string `json:"topics"`
+	}
+	rpcParamAt(params, 0, &filter)
+
+	logs, err := s.blockchain.GetLogs(filter.FromBlock, filter.ToBlock, filter.Address, filter.Topics)
+	if err != nil {
+		return nil, newRPCError(rpcErrServer, err.Error())
+	}
+	return logs, nil
+}
+
+func rpcGasPrice(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	return hexBig(s.blockchain.MinGasPrice()), nil
+}
+
+// rpcWeb3Sha3 hashes the given hex-encoded bytes with Keccak256, the
+// standard web3_sha3 method MetaMask/ethers.js expect every Ethereum-style
+// JSON-RPC endpoint to serve.
+func rpcWeb3Sha3(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	var data string
+	if !rpcParamAt(params, 0, &data) {
+		return nil, newRPCError(rpcErrInvalidParams, "missing data")
+	}
+	return "0x" + hexEncode(core.Keccak256(hexToBytes(data))), nil
+}
+
+// rpcTxPoolStatus backs txpool_status: the pool's pending and queued
+// transaction counts, geth's own txpool_status shape.
+func rpcTxPoolStatus(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	pool := s.blockchain.TxPool()
+	pending, queued := 0, 0
+	for _, txs := range pool.Pending() {
+		pending += len(txs)
+	}
+	for _, txs := range pool.Queued() {
+		queued += len(txs)
+	}
+	return map[string]string{
+		"pending": fmt.Sprintf("0x%x", pending),
+		"queued":  fmt.Sprintf("0x%x", queued),
+	}, nil
+}
+
+// rpcTxPoolContent backs txpool_content: every pooled transaction's hash,
+// grouped the way geth's txpool_content is (by sender address, then by
+// nonce), so a caller can tell which of a sender's transactions are
+// executable versus stuck behind a gap.
+func rpcTxPoolContent(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	pool := s.blockchain.TxPool()
+
+	group := func(byAddr map[common.Address][]*core.Transaction) map[string]map[string]string {
+		out := make(map[string]map[string]string, len(byAddr))
+		for addr, txs := range byAddr {
+			byNonce := make(map[string]string, len(txs))
+			for _, tx := range txs {
+				byNonce[fmt.Sprintf("0x%x", tx.Nonce)] = tx.Hash.Hex()
+			}
+			out[addr.Hex()] = byNonce
+		}
+		return out
+	}
+
+	return map[string]interface{}{
+		"pending": group(pool.Pending()),
+		"queued":  group(pool.Queued()),
+	}, nil
+}
+
+// rpcAdminNodeInfo backs admin_nodeInfo: this node's own enode identity
+// (see package p2p) plus chain ID, network ID, and current head.
+func rpcAdminNodeInfo(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	self := s.peers.Self()
+	head := s.blockchain.GetCurrentBlock()
+	return map[string]interface{}{
+		"id":            self.ID,
+		"enode":         self.String(),
+		"listenAddr":    fmt.Sprintf("%s:%d", self.IP, self.Port),
+		"name":          "Diora/v1.0.0/go",
+		"protocols":     []string{"eth/1", "diora/1"},
+		"chainId":       hexBig(s.blockchain.GetChainID()),
+		"networkId":     s.blockchain.GetChainID().String(),
+		"headBlock":     hexBig(head.Header().Number),
+		"headBlockHash": head.Hash().Hex(),
+	}, nil
+}
+
+// rpcAdminPeers backs admin_peers: every peer an operator has registered
+// via admin_addPeer/admin_addTrustedPeer. Since this node has no p2p
+// transport yet (see package p2p's doc comment), these are static
+// registrations rather than live connections.
+func rpcAdminPeers(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	return s.peers.Peers(), nil
+}
+
+// rpcAdminAddPeer backs admin_addPeer(enode): register enode as a known
+// peer for a future transport to dial.
+func rpcAdminAddPeer(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	var enode string
+	if !rpcParamAt(params, 0, &enode) {
+		return nil, newRPCError(rpcErrInvalidParams, "missing enode")
+	}
+	if _, err := s.peers.Add(enode, false); err != nil {
+		return nil, newRPCError(rpcErrInvalidParams, err.Error())
+	}
+	return true, nil
+}
+
+// rpcAdminAddTrustedPeer backs admin_addTrustedPeer(enode): like
+// admin_addPeer, but the registration is marked trusted.
+func rpcAdminAddTrustedPeer(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	var enode string
+	if !rpcParamAt(params, 0, &enode) {
+		return nil, newRPCError(rpcErrInvalidParams, "missing enode")
+	}
+	if _, err := s.peers.Add(enode, true); err != nil {
+		return nil, newRPCError(rpcErrInvalidParams, err.Error())
+	}
+	return true, nil
+}
+
+// rpcAdminRemovePeer backs admin_removePeer(enode).
+func rpcAdminRemovePeer(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	var enode string
+	if !rpcParamAt(params, 0, &enode) {
+		return nil, newRPCError(rpcErrInvalidParams, "missing enode")
+	}
+	ok, err := s.peers.Remove(enode)
+	if err != nil {
+		return nil, newRPCError(rpcErrInvalidParams, err.Error())
+	}
+	return ok, nil
+}
+
+// rpcAdminStartRPC and rpcAdminStopRPC back admin_startRPC/admin_stopRPC.
+// This node's HTTP/WS servers are started once at process startup from
+// --http.api/--ws.api rather than toggled at runtime, so these report the
+// server as already running and are no-ops, matching geth's behavior when
+// asked to start an already-running endpoint.
+func rpcAdminStartRPC(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	return true, nil
+}
+
+func rpcAdminStopRPC(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	return true, nil
+}
+
+// rpcDioraProtocolVersion backs diora_protocolVersion, a placeholder for
+// this node's own custom namespace alongside the Ethereum-compatible ones —
+// nothing else lives under "diora" yet.
+func rpcDioraProtocolVersion(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	return "0x1", nil
+}
+
+// rpcGetTransactionByHash backs the tx CLI's status command: it checks the
+// pool first (pending/queued transactions have no receipt yet) and falls
+// back to the confirmed receipt and block. A null result means the node has
+// never seen this hash at all.
+func rpcGetTransactionByHash(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	var hashStr string
+	if !rpcParamAt(params, 0, &hashStr) {
+		return nil, newRPCError(rpcErrInvalidParams, "missing transaction hash")
+	}
+
+	status, err := s.blockchain.GetTransactionStatus(common.HexToHash(hashStr))
+	if err != nil {
+		return nil, newRPCError(rpcErrServer, err.Error())
+	}
+	if status.Status == "unknown" {
+		return nil, nil
+	}
+	return formatTxStatus(status), nil
+}
+
+// rpcGetTransactionsByAddress backs the tx CLI's history command: it
+// returns addr's pool entries (pending and queued) ahead of its confirmed
+// history, newest first, up to limit (default 10) total.
+func rpcGetTransactionsByAddress(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	var addrStr string
+	if !rpcParamAt(params, 0, &addrStr) {
+		return nil, newRPCError(rpcErrInvalidParams, "missing address")
+	}
+	limit := 10
+	rpcParamAt(params, 1, &limit)
+
+	txs := s.blockchain.GetAccountTransactions(common.HexToAddress(addrStr), limit)
+	results := make([]map[string]interface{}, 0, len(txs))
+	for _, tx := range txs {
+		status, err := s.blockchain.GetTransactionStatus(tx.Hash)
+		if err != nil {
+			return nil, newRPCError(rpcErrServer, err.Error())
+		}
+		results = append(results, formatTxStatus(status))
+	}
+	return results, nil
+}
+
+// formatTxStatus renders a core.TxStatus the way eth_getTransactionByHash
+// and eth_getTransactionsByAddress both want it: transaction fields when
+// the pool or a confirmed block could supply them, receipt fields layered
+// on top once there's one to report.
+func formatTxStatus(status *core.TxStatus) map[string]interface{} {
+	out := map[string]interface{}{"status": status.Status}
+
+	if tx := status.Transaction; tx != nil {
+		out["hash"] = tx.Hash.Hex()
+		out["from"] = tx.From.Hex()
+		if tx.To != nil {
+			out["to"] = tx.To.Hex()
+		}
+		out["nonce"] = fmt.Sprintf("0x%x", tx.Nonce)
+		out["value"] = hexBig(tx.Value)
+		out["gas"] = fmt.Sprintf("0x%x", tx.GasLimit)
+	}
+
+	if receipt := status.Receipt; receipt != nil {
+		out["blockHash"] = receipt.BlockHash.Hex()
+		out["blockNumber"] = hexBig(receipt.BlockNumber)
+		out["transactionIndex"] = fmt.Sprintf("0x%x", receipt.TransactionIndex)
+		out["gasUsed"] = fmt.Sprintf("0x%x", receipt.GasUsed)
+	}
+
+	return out
+}
+
+// rpcGovernanceHaltHeight backs `diora network status`'s pending halt height
+// field: null once no ProposalTypeSetHaltBlock proposal is pending, the
+// scheduled height otherwise.
+func rpcGovernanceHaltHeight(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	height, halted := s.blockchain.HaltHeight()
+	if !halted {
+		return nil, nil
+	}
+	return fmt.Sprintf("0x%x", height), nil
+}
+
+// rpcGovernanceSubmitProposal backs the `governance halt-block`/`governance
+// unhalt` CLI commands. Proposal voting and tallying aren't implemented yet
+// (core.Proposal has no storage or vote-counting path anywhere in the
+// chain), so a submitted set-halt-block/unhalt proposal is executed
+// immediately rather than waiting on a vote that has nowhere to happen; once
+// a real governance pipeline lands, this becomes the terminal step it calls
+// once a proposal passes, not the entry point.
+func rpcGovernanceSubmitProposal(s *Server, params json.RawMessage) (interface{}, *RPCError) {
+	var req struct {
+		Type     string `json:"type"`
+		Title    string `json:"title"`
+		Proposer string `json:"proposer"`
+		Payload  string `json:"payload"`
+	}
+	if !rpcParamAt(params, 0, &req) {
+		return nil, newRPCError(rpcErrInvalidParams, "missing proposal")
+	}
+
+	var proposalType core.ProposalType
+	switch req.Type {
+	case "set-halt-block":
+		proposalType = core.ProposalTypeSetHaltBlock
+	case "unhalt":
+		proposalType = core.ProposalTypeUnhalt
+	default:
+		return nil, newRPCError(rpcErrInvalidParams, fmt.Sprintf("unsupported proposal type %q", req.Type))
+	}
+
+	proposal := &core.Proposal{
+		Proposer: core.HexToAddress(req.Proposer),
+		Title:    req.Title,
+		Type:     proposalType,
+		Payload:  hexToBytes(req.Payload),
+		Status:   core.ProposalStatusPassed,
+	}
+	if err := s.blockchain.ExecuteProposal(proposal); err != nil {
+		return nil, newRPCError(rpcErrServer, err.Error())
+	}
+	return true, nil
+}
+
+func hexBig(n *big.Int) string {
+	if n == nil {
+		return "0x0"
+	}
+	return "0x" + n.Text(16)
+}
+
+func hexOrDecToBig(s string) (*big.Int, bool) {
+	if len(s) > 2 && s[0:2] == "0x" {
+		n, ok := new(big.Int).SetString(s[2:], 16)
+		return n, ok
+	}
+	n, ok := new(big.Int).SetString(s, 10)
+	return n, ok
+}
+
+func hexToBytes(s string) []byte {
+	if len(s) >= 2 && s[0:2] == "0x" {
+		s = s[2:]
+	}
+	b := make([]byte, len(s)/2)
+	fmt.Sscanf(s, "%x", &b)
+	return b
+}
+
+func hexEncode(b []byte) string {
+	const hextable = "0123456789abcdef"
+	out := make([]byte, len(b)*2)
+	for i, v := range b {
+		out[i*2] = hextable[v>>4]
+		out[i*2+1] = hextable[v&0x0f]
+	}
+	return string(out)
+}
@@ -1,17 +1,27 @@
 package main
 
 import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"math/big"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
+	"sync"
 	"syscall"
 	"time"
 
-	"github.com/diora-blockchain/diora/core"
+	"github.com/DioraFund/diora-go-blockchain/consensus"
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/DioraFund/diora-go-blockchain/p2p"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
@@ -20,9 +30,77 @@ import (
 type Server struct {
 	blockchain *core.Blockchain
 	router     *gin.Engine
+	httpServer *http.Server
 	upgrader   websocket.Upgrader
 	clients    map[*websocket.Conn]bool
+	clientsMu  sync.Mutex
 	broadcast  chan []byte
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// shutdownTimeout bounds how long Shutdown waits for in-flight requests
+	// to finish before giving up.
+	shutdownTimeout time.Duration
+
+	// httpAPIs/wsAPIs are the --http.api/--ws.api namespace whitelists:
+	// dispatchRPC rejects any method whose namespace (see namespaceOf)
+	// isn't in httpAPIs with "method not found" rather than running it.
+	// wsAPIs gates the /ws endpoint's own subscribe protocol the same way,
+	// via the "eth" namespace, since that protocol predates a generic
+	// JSON-RPC-over-websocket transport.
+	httpAPIs map[string]bool
+	wsAPIs   map[string]bool
+
+	// corsDomains/vhosts back --http.corsdomain/--http.vhosts: corsDomains
+	// is forwarded to the cors middleware, and vhosts restricts which Host
+	// header values setupRoutes' vhost middleware accepts.
+	corsDomains []string
+	vhosts      []string
+
+	// peers backs the admin_nodeInfo/admin_peers/admin_addPeer/
+	// admin_removePeer RPC methods.
+	peers *p2p.PeerManager
+}
+
+// ServerOptions configures the namespace/CORS/vhost restrictions NewServer
+// applies, mirroring geth's --http.api/--ws.api/--http.corsdomain/
+// --http.vhosts flags. A zero-value ServerOptions serves the
+// defaultEnabledAPIs namespaces over HTTP and websocket, and accepts every
+// origin and Host header as this server previously did.
+type ServerOptions struct {
+	// HTTPAPIs/WSAPIs list the enabled namespaces, e.g. []string{"eth",
+	// "net", "web3"}. Nil means defaultEnabledAPIs.
+	HTTPAPIs []string
+	WSAPIs   []string
+
+	// CORSDomains lists allowed CORS origins; nil or containing "*" means
+	// every origin is allowed (this server's previous default).
+	CORSDomains []string
+
+	// Vhosts lists allowed Host header values; nil or containing "*" means
+	// every Host is accepted (this server's previous default).
+	Vhosts []string
+
+	// DataDir is where this node's persistent nodekey lives (see
+	// p2p.LoadOrGenerateNodeKey). Defaults to "./data" when empty.
+	DataDir string
+
+	// P2PPort is the port this node's enode advertises as its listening
+	// address. It doesn't open a listener yet (see package p2p's doc
+	// comment) — it's purely what admin_nodeInfo reports.
+	P2PPort int
+}
+
+func namespaceSet(apis []string) map[string]bool {
+	if apis == nil {
+		apis = defaultEnabledAPIs
+	}
+	set := make(map[string]bool, len(apis))
+	for _, api := range apis {
+		set[api] = true
+	}
+	return set
 }
 
 type APIResponse struct {
@@ -43,10 +121,25 @@ type BlockResponse struct {
 	Difficulty      string            `json:"difficulty"`
 	TotalDifficulty string            `json:"totalDifficulty"`
 	Size            uint64            `json:"size"`
+	Deposits        []DepositResp     `json:"deposits"`
+	DepositsRoot    string            `json:"depositsRoot"`
+	BaseFee         string            `json:"baseFeePerGas,omitempty"`
+	BlobGasUsed     uint64            `json:"blobGasUsed"`
+	ExcessBlobGas   uint64            `json:"excessBlobGas"`
+}
+
+// DepositResp is the JSON shape of an EIP-6110-style validator deposit.
+type DepositResp struct {
+	PublicKey             string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawalCredentials"`
+	Amount                uint64 `json:"amount"`
+	Signature             string `json:"signature"`
+	Index                 uint64 `json:"index"`
 }
 
 type TransactionResp struct {
 	Hash        string `json:"hash"`
+	Type        uint8  `json:"type"`
 	From        string `json:"from"`
 	To          string `json:"to,omitempty"`
 	Value       string `json:"value"`
@@ -59,6 +152,14 @@ type TransactionResp struct {
 	BlockNumber uint64 `json:"blockNumber,omitempty"`
 	BlockHash   string `json:"blockHash,omitempty"`
 	Timestamp   uint64 `json:"timestamp,omitempty"`
+
+	// EIP-1559 fields, present only on type-2/type-3 transactions.
+	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas,omitempty"`
+	MaxFeePerGas         string `json:"maxFeePerGas,omitempty"`
+
+	// Blob-carrying (EIP-4844) fields, present only on type-3 transactions.
+	MaxFeePerBlobGas    string   `json:"maxFeePerBlobGas,omitempty"`
+	BlobVersionedHashes []string `json:"blobVersionedHashes,omitempty"`
 }
 
 type AccountResp struct {
@@ -82,7 +183,26 @@ type NetworkStats struct {
 	HashRate          string  `json:"hashRate"`
 }
 
-func NewServer(blockchain *core.Blockchain) *Server {
+// NewServer builds a Server, loading (or generating, on first run) this
+// node's persistent p2p identity from opts.DataDir so admin_nodeInfo can
+// report a stable enode URL across restarts.
+func NewServer(ctx context.Context, blockchain *core.Blockchain, opts ServerOptions) (*Server, error) {
+	dataDir := opts.DataDir
+	if dataDir == "" {
+		dataDir = "./data"
+	}
+	p2pPort := opts.P2PPort
+	if p2pPort == 0 {
+		p2pPort = 30303
+	}
+
+	nodeKey, err := p2p.LoadOrGenerateNodeKey(filepath.Join(dataDir, "nodekey"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load node key: %w", err)
+	}
+	self := p2p.NewEnode(&nodeKey.PublicKey, "127.0.0.1", p2pPort)
+
+	ctx, cancel := context.WithCancel(ctx)
 	return &Server{
 		blockchain: blockchain,
 		router:     gin.Default(),
@@ -91,18 +211,76 @@ func NewServer(blockchain *core.Blockchain) *Server {
 				return true // Allow all origins in development
 			},
 		},
-		clients:   make(map[*websocket.Conn]bool),
-		broadcast: make(chan []byte),
+		clients:         make(map[*websocket.Conn]bool),
+		broadcast:       make(chan []byte),
+		ctx:             ctx,
+		cancel:          cancel,
+		shutdownTimeout: 15 * time.Second,
+		httpAPIs:        namespaceSet(opts.HTTPAPIs),
+		wsAPIs:          namespaceSet(opts.WSAPIs),
+		corsDomains:     opts.CORSDomains,
+		vhosts:          opts.Vhosts,
+		peers:           p2p.NewPeerManager(self),
+	}, nil
+}
+
+func allowsAnyOrigin(domains []string) bool {
+	if len(domains) == 0 {
+		return true
+	}
+	for _, d := range domains {
+		if d == "*" {
+			return true
+		}
 	}
+	return false
+}
+
+// vhostMiddleware rejects any request whose Host header isn't in s.vhosts,
+// the same DNS-rebinding protection --http.vhosts gives geth's RPC server:
+// without it, a malicious webpage's JS can reach a local node's RPC port
+// simply by resolving an attacker-controlled hostname to 127.0.0.1.
+func (s *Server) vhostMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if allowsAnyOrigin(s.vhosts) {
+			c.Next()
+			return
+		}
+		host := c.Request.Host
+		if i := indexByte(host, ':'); i >= 0 {
+			host = host[:i]
+		}
+		for _, v := range s.vhosts {
+			if v == host {
+				c.Next()
+				return
+			}
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, APIResponse{Success: false, Error: fmt.Sprintf("host %q not in --http.vhosts", host)})
+	}
+}
+
+func indexByte(s string, b byte) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == b {
+			return i
+		}
+	}
+	return -1
 }
 
 func (s *Server) setupRoutes() {
 	// CORS middleware
 	config := cors.DefaultConfig()
-	config.AllowAllOrigins = true
+	if allowsAnyOrigin(s.corsDomains) {
+		config.AllowAllOrigins = true
+	} else {
+		config.AllowOrigins = s.corsDomains
+	}
 	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
 	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
 	s.router.Use(cors.New(config))
+	s.router.Use(s.vhostMiddleware())
 
 	// API routes
 	v1 := s.router.Group("/api/v1")
@@ -111,11 +289,13 @@ func (s *Server) setupRoutes() {
 		v1.GET("/network/stats", s.getNetworkStats)
 		v1.GET("/block/latest", s.getLatestBlock)
 		v1.GET("/block/:number", s.getBlockByNumber)
+		v1.GET("/block/:number/deposits", s.getBlockDeposits)
 		v1.GET("/block/hash/:hash", s.getBlockByHash)
 		v1.GET("/blocks", s.getBlocks)
 
 		// Transactions
 		v1.GET("/transaction/:hash", s.getTransaction)
+		v1.GET("/transaction/:hash/blobs", s.getTransactionBlobs)
 		v1.GET("/transactions", s.getTransactions)
 		v1.GET("/transactions/pending", s.getPendingTransactions)
 		v1.POST("/transaction", s.sendTransaction)
@@ -168,6 +348,11 @@ func (s *Server) setupRoutes() {
 
 	// Health check
 	s.router.GET("/health", s.healthCheck)
+
+	// Ethereum-compatible JSON-RPC 2.0, so MetaMask/ethers.js/web3.js and
+	// existing block explorers can talk to Diora without a custom client.
+	s.router.POST("/", s.handleJSONRPC)
+	s.router.POST("/rpc", s.handleJSONRPC)
 }
 
 func (s *Server) getNetworkStats(c *gin.Context) {
@@ -177,13 +362,13 @@ func (s *Server) getNetworkStats(c *gin.Context) {
 	stats := NetworkStats{
 		ChainID:           "1337", // Diora testnet
 		NetworkID:         1,
-		BlockNumber:       currentBlock.Header.Number.Uint64(),
+		BlockNumber:       currentBlock.Header().Number.Uint64(),
 		BlockTime:         6,            // 6 seconds
 		GasPrice:          "1000000000", // 1 Gwei
 		TotalTransactions: 0,            // TODO: Implement transaction count
 		ActiveValidators:  uint64(len(validators)),
 		TPS:               100.5, // TODO: Calculate real TPS
-		Difficulty:        currentBlock.Header.Difficulty.String(),
+		Difficulty:        currentBlock.Header().Difficulty.String(),
 		HashRate:          "1.2 TH/s", // TODO: Calculate real hash rate
 	}
 
@@ -266,11 +451,11 @@ func (s *Server) getBlocks(c *gin.Context) {
 		}
 
 		blocks = append(blocks, s.formatBlock(currentBlock))
-		if currentBlock.Header.Number.Uint64() == 0 {
+		if currentBlock.Header().Number.Uint64() == 0 {
 			break
 		}
 
-		parent, err := s.blockchain.GetBlockByHash(currentBlock.Header.ParentHash)
+		parent, err := s.blockchain.GetBlockByHash(currentBlock.Header().ParentHash)
 		if err != nil {
 			break
 		}
@@ -299,6 +484,40 @@ func (s *Server) getTransaction(c *gin.Context) {
 	})
 }
 
+// getTransactionBlobs returns the blob sidecar (blobs, commitments, proofs)
+// retained for a type-3 transaction, while it's still within the retention
+// window. Once it's swept, the transaction's versioned hashes are still
+// visible on its TransactionResp; only the sidecar itself is gone.
+func (s *Server) getTransactionBlobs(c *gin.Context) {
+	hash := common.HexToHash(c.Param("hash"))
+
+	sidecar, ok := s.blockchain.GetBlobSidecar(hash)
+	if !ok {
+		c.JSON(http.StatusNotFound, APIResponse{
+			Success: false,
+			Error:   "blob sidecar not found or past its retention window",
+		})
+		return
+	}
+
+	encodeAll := func(bs [][]byte) []string {
+		out := make([]string, len(bs))
+		for i, b := range bs {
+			out[i] = "0x" + hexEncode(b)
+		}
+		return out
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data: map[string]interface{}{
+			"blobs":       encodeAll(sidecar.Blobs),
+			"commitments": encodeAll(sidecar.Commitments),
+			"proofs":      encodeAll(sidecar.Proofs),
+		},
+	})
+}
+
 func (s *Server) getTransactions(c *gin.Context) {
 	page := c.DefaultQuery("page", "1")
 	limit := c.DefaultQuery("limit", "10")
@@ -332,6 +551,17 @@ func (s *Server) sendTransaction(c *gin.Context) {
 		Data      string `json:"data,omitempty"`
 		Nonce     uint64 `json:"nonce"`
 		Signature string `json:"signature"`
+
+		// Type-3 (EIP-4844) blob transaction fields. BlobVersionedHashes is
+		// what actually goes on chain; the sidecar is only needed here, for
+		// admission-time verification, and is stored separately afterwards.
+		MaxFeePerBlobGas    string   `json:"maxFeePerBlobGas,omitempty"`
+		BlobVersionedHashes []string `json:"blobVersionedHashes,omitempty"`
+		BlobSidecar         *struct {
+			Blobs       []string `json:"blobs"`
+			Commitments []string `json:"commitments"`
+			Proofs      []string `json:"proofs"`
+		} `json:"blobSidecar,omitempty"`
 	}
 
 	if err := c.ShouldBindJSON(&txData); err != nil {
@@ -342,6 +572,37 @@ func (s *Server) sendTransaction(c *gin.Context) {
 		return
 	}
 
+	if len(txData.BlobVersionedHashes) > 0 {
+		blobTx, sidecar, err := parseBlobTx(txData.MaxFeePerBlobGas, txData.BlobVersionedHashes, txData.BlobSidecar)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, APIResponse{Success: false, Error: err.Error()})
+			return
+		}
+		if err := core.VerifyBlobSidecar(sidecar, blobTx.BlobVersionedHashes); err != nil {
+			c.JSON(http.StatusBadRequest, APIResponse{Success: false, Error: err.Error()})
+			return
+		}
+		// core.Blockchain.AddTransactionWithSidecar exists and does the
+		// right thing (verify, then admit and store), but
+		// Transaction.MarshalBinary/UnmarshalBinary (core/txcodec.go) have
+		// no BlobTxType case, and typedSigningPayload's BlobTxType case
+		// (core/signing.go) signs the DynamicFeeTxType field list without
+		// the blob fee cap or versioned hashes, so a signature over it does
+		// not commit to the blobs at all. This endpoint therefore only
+		// validates the sidecar and does not admit it - it says so
+		// explicitly instead of returning a txHash that implies GET
+		// /transaction/:hash/blobs will find it.
+		c.JSON(http.StatusOK, APIResponse{
+			Success: true,
+			Data: map[string]interface{}{
+				"blobSidecarValidated": true,
+				"blobSidecarPersisted": false,
+				"message":              "blob sidecar validated but not admitted: BlobTxType has no wire encoding and its signing hash does not cover the blob fields, so sendTransaction cannot build a verifiable core.Transaction to hand AddTransactionWithSidecar",
+			},
+		})
+		return
+	}
+
 	// TODO: Implement transaction sending
 	c.JSON(http.StatusOK, APIResponse{
 		Success: true,
@@ -351,6 +612,78 @@ func (s *Server) sendTransaction(c *gin.Context) {
 	})
 }
 
+// parseBlobTx validates and decodes the blob fields of a sendTransaction
+// request into a core.BlobTx and its sidecar.
+func parseBlobTx(maxFeePerBlobGas string, versionedHashes []string, sidecar *struct {
+	Blobs       []string `json:"blobs"`
+	Commitments []string `json:"commitments"`
+	Proofs      []string `json:"proofs"`
+}) (*core.BlobTx, *core.BlobSidecar, error) {
+	if sidecar == nil {
+		return nil, nil, fmt.Errorf("blobVersionedHashes given without a blobSidecar")
+	}
+
+	fee, ok := new(big.Int).SetString(stripHexPrefix2(maxFeePerBlobGas), 0)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid maxFeePerBlobGas %q", maxFeePerBlobGas)
+	}
+
+	hashes := make([]common.Hash, len(versionedHashes))
+	for i, h := range versionedHashes {
+		hashes[i] = common.HexToHash(h)
+	}
+
+	decode := func(hexStrs []string) ([][]byte, error) {
+		out := make([][]byte, len(hexStrs))
+		for i, h := range hexStrs {
+			b, err := hex.DecodeString(stripHexPrefix2(h))
+			if err != nil {
+				return nil, fmt.Errorf("invalid hex value %q: %w", h, err)
+			}
+			out[i] = b
+		}
+		return out, nil
+	}
+
+	blobs, err := decode(sidecar.Blobs)
+	if err != nil {
+		return nil, nil, err
+	}
+	commitments, err := decode(sidecar.Commitments)
+	if err != nil {
+		return nil, nil, err
+	}
+	proofs, err := decode(sidecar.Proofs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &core.BlobTx{
+			MaxFeePerBlobGas:    fee,
+			BlobVersionedHashes: hashes,
+		}, &core.BlobSidecar{
+			Blobs:       blobs,
+			Commitments: commitments,
+			Proofs:      proofs,
+		}, nil
+}
+
+// baseFeeString renders a header's BaseFee for BlockResponse, tolerating
+// nil for a pre-London block loaded from an older database.
+func baseFeeString(baseFee *big.Int) string {
+	if baseFee == nil {
+		return ""
+	}
+	return baseFee.String()
+}
+
+func stripHexPrefix2(s string) string {
+	if len(s) >= 2 && s[0:2] == "0x" {
+		return s[2:]
+	}
+	return s
+}
+
 func (s *Server) getAccount(c *gin.Context) {
 	addressStr := c.Param("address")
 	address := core.HexToAddress(addressStr)
@@ -398,42 +731,18 @@ func (s *Server) getNonce(c *gin.Context) {
 	})
 }
 
+// getAccountTransactions returns an address's transfer history using the
+// block-range Seek cursor: ?fromBlock=&toBlock=&limit=&cursor=. Rather than
+// scanning the chain and filtering, it opens a leveldb iterator positioned
+// directly at the requested start via core.Blockchain.ForEachTransfer, and
+// returns an opaque nextCursor so large histories page in O(matches) instead
+// of O(chain).
 func (s *Server) getAccountTransactions(c *gin.Context) {
-	// TODO: Implement account transaction history
-	c.JSON(http.StatusOK, APIResponse{
-		Success: true,
-		Data:    []TransactionResp{},
-	})
+	s.getTransfersByPrefix(c, core.PrefixAccountTransfer, c.Param("address"))
 }
 
-// WebSocket handler for real-time updates
-func (s *Server) handleWebSocket(c *gin.Context) {
-	conn, err := s.upgrader.Upgrade(c.Writer, c.Request, nil)
-	if err != nil {
-		log.Println("WebSocket upgrade error:", err)
-		return
-	}
-	defer conn.Close()
-
-	s.clients[conn] = true
-
-	// Send initial data
-	s.sendInitialData(conn)
-
-	// Handle messages
-	for {
-		messageType, message, err := conn.ReadMessage()
-		if err != nil {
-			log.Println("WebSocket read error:", err)
-			delete(s.clients, conn)
-			break
-		}
-
-		if messageType == websocket.TextMessage {
-			log.Printf("Received message: %s", message)
-		}
-	}
-}
+// handleWebSocket and the subscribe/unsubscribe protocol it implements live
+// in subscriptions.go.
 
 func (s *Server) sendInitialData(conn *websocket.Conn) {
 	// Send latest block
@@ -451,7 +760,7 @@ func (s *Server) sendInitialData(conn *websocket.Conn) {
 	stats := NetworkStats{
 		ChainID:          "1337",
 		NetworkID:        1,
-		BlockNumber:      currentBlock.Header.Number.Uint64(),
+		BlockNumber:      currentBlock.Header().Number.Uint64(),
 		ActiveValidators: 100, // TODO: Get real count
 		TPS:              100.5,
 	}
@@ -475,38 +784,94 @@ func (s *Server) healthCheck(c *gin.Context) {
 }
 
 func (s *Server) formatBlock(block *core.Block) BlockResponse {
+	baseFee := block.Header().BaseFee
+
 	var txs []TransactionResp
-	for _, tx := range block.Transactions {
-		txs = append(txs, TransactionResp{
+	for _, tx := range block.Transactions() {
+		resp := TransactionResp{
 			Hash:        tx.Hash.Hex(),
+			Type:        uint8(tx.Type),
 			From:        tx.From.Hex(),
 			Value:       tx.Value.String(),
-			GasPrice:    tx.GasPrice.String(),
+			GasPrice:    core.EffectiveGasPrice(tx, baseFee).String(),
 			GasLimit:    tx.GasLimit,
 			GasUsed:     tx.GasLimit, // TODO: Get actual gas used
 			Nonce:       tx.Nonce,
 			Status:      1, // Success
-			BlockNumber: block.Header.Number.Uint64(),
-			BlockHash:   block.Hash.Hex(),
-			Timestamp:   block.Header.Timestamp,
+			BlockNumber: block.Header().Number.Uint64(),
+			BlockHash:   block.Hash().Hex(),
+			Timestamp:   block.Header().Timestamp,
+		}
+		if tx.Type != core.LegacyTxType {
+			resp.MaxPriorityFeePerGas = tx.GasTipCap.String()
+			resp.MaxFeePerGas = tx.GasFeeCap.String()
+		}
+		if tx.Blob != nil {
+			resp.MaxFeePerBlobGas = tx.Blob.MaxFeePerBlobGas.String()
+			for _, h := range tx.Blob.BlobVersionedHashes {
+				resp.BlobVersionedHashes = append(resp.BlobVersionedHashes, h.Hex())
+			}
+		}
+		txs = append(txs, resp)
+	}
+
+	var deposits []DepositResp
+	for _, d := range block.Deposits() {
+		deposits = append(deposits, DepositResp{
+			PublicKey:             "0x" + hexEncode(d.PublicKey),
+			WithdrawalCredentials: "0x" + hexEncode(d.WithdrawalCredentials),
+			Amount:                d.Amount,
+			Signature:             "0x" + hexEncode(d.Signature),
+			Index:                 d.Index,
 		})
 	}
 
 	return BlockResponse{
-		Number:          block.Header.Number.Uint64(),
-		Hash:            block.Hash.Hex(),
-		ParentHash:      block.Header.ParentHash.Hex(),
-		Timestamp:       block.Header.Timestamp,
+		Number:          block.Header().Number.Uint64(),
+		Hash:            block.Hash().Hex(),
+		ParentHash:      block.Header().ParentHash.Hex(),
+		Timestamp:       block.Header().Timestamp,
 		Transactions:    txs,
-		GasUsed:         block.Header.GasUsed,
-		GasLimit:        block.Header.GasLimit,
-		Miner:           block.Header.Validator.Hex(),
-		Difficulty:      block.Header.Difficulty.String(),
-		TotalDifficulty: block.Header.Difficulty.String(), // TODO: Calculate total difficulty
-		Size:            block.Size,
+		GasUsed:         block.Header().GasUsed,
+		GasLimit:        block.Header().GasLimit,
+		Miner:           block.Header().Validator.Hex(),
+		Difficulty:      block.Header().Difficulty.String(),
+		TotalDifficulty: block.Header().Difficulty.String(), // TODO: Calculate total difficulty
+		Size:            block.Size(),
+		Deposits:        deposits,
+		DepositsRoot:    block.Header().DepositsRoot.Hex(),
+		BaseFee:         baseFeeString(baseFee),
+		BlobGasUsed:     block.Header().BlobGasUsed,
+		ExcessBlobGas:   block.Header().ExcessBlobGas,
 	}
 }
 
+// getBlockDeposits returns the validator deposits committed in a given
+// block, backing the EIP-6110-style deposit pipeline.
+func (s *Server) getBlockDeposits(c *gin.Context) {
+	numberStr := c.Param("number")
+	var number uint64
+	if _, err := fmt.Sscanf(numberStr, "%d", &number); err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Success: false, Error: "invalid block number"})
+		return
+	}
+
+	block, err := s.blockchain.GetBlockByNumber(new(big.Int).SetUint64(number))
+	if err != nil {
+		c.JSON(http.StatusNotFound, APIResponse{Success: false, Error: "block not found"})
+		return
+	}
+	resp := s.formatBlock(block)
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data: map[string]interface{}{
+			"deposits":     resp.Deposits,
+			"depositsRoot": resp.DepositsRoot,
+		},
+	})
+}
+
 // Placeholder implementations for remaining endpoints
 func (s *Server) callContract(c *gin.Context) {
 	c.JSON(http.StatusNotImplemented, APIResponse{Success: false, Error: "Not implemented"})
@@ -530,7 +895,7 @@ func (s *Server) getTokenBalance(c *gin.Context) {
 	c.JSON(http.StatusNotImplemented, APIResponse{Success: false, Error: "Not implemented"})
 }
 func (s *Server) getTokenTransfers(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, APIResponse{Success: false, Error: "Not implemented"})
+	s.getTransfersByPrefix(c, core.PrefixTokenTransfer, c.Param("address"))
 }
 func (s *Server) getNFTs(c *gin.Context) {
 	c.JSON(http.StatusNotImplemented, APIResponse{Success: false, Error: "Not implemented"})
@@ -542,10 +907,78 @@ func (s *Server) getNFTOwner(c *gin.Context) {
 	c.JSON(http.StatusNotImplemented, APIResponse{Success: false, Error: "Not implemented"})
 }
 func (s *Server) getNFTTransfers(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, APIResponse{Success: false, Error: "Not implemented"})
+	s.getTransfersByPrefix(c, core.PrefixNFTTransfer, c.Param("contract"))
 }
+
+// getTransfersByPrefix serves the common cursor-paginated transfer listing
+// shared by account/token/NFT transfer endpoints.
+func (s *Server) getTransfersByPrefix(c *gin.Context, prefix, addressStr string) {
+	address := core.HexToAddress(addressStr)
+
+	limit := 25
+	if l := c.Query("limit"); l != "" {
+		if n, err := strconv.Atoi(l); err == nil && n > 0 && n <= 500 {
+			limit = n
+		}
+	}
+
+	var startBlock uint64
+	forward := true
+	if cursor := c.Query("cursor"); cursor != "" {
+		key, err := core.DecodeTransferCursor(cursor)
+		if err != nil || len(key) < len(prefix)+20+8 {
+			c.JSON(http.StatusBadRequest, APIResponse{Success: false, Error: "invalid cursor"})
+			return
+		}
+		startBlock = binary.BigEndian.Uint64(key[len(prefix)+20 : len(prefix)+28])
+	} else if fb := c.Query("fromBlock"); fb != "" {
+		if n, err := strconv.ParseUint(fb, 10, 64); err == nil {
+			startBlock = n
+		}
+	}
+
+	var toBlock uint64
+	hasToBlock := false
+	if tb := c.Query("toBlock"); tb != "" {
+		if n, err := strconv.ParseUint(tb, 10, 64); err == nil {
+			toBlock, hasToBlock = n, true
+		}
+	}
+
+	var transfers []*core.Transfer
+	var lastKey []byte
+	s.blockchain.ForEachTransfer(prefix, address, startBlock, forward, func(t *core.Transfer) bool {
+		if hasToBlock && t.BlockNumber > toBlock {
+			return false
+		}
+		transfers = append(transfers, t)
+		lastKey = binary.BigEndian.AppendUint64(append([]byte(prefix), address.Bytes()...), t.BlockNumber)
+		return len(transfers) < limit
+	})
+
+	var nextCursor string
+	if len(transfers) == limit {
+		nextCursor = core.EncodeTransferCursor(lastKey)
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data: map[string]interface{}{
+			"transfers":  transfers,
+			"nextCursor": nextCursor,
+		},
+	})
+}
+
+// getValidators lists the validator set as it stands after the deposit
+// stream has been applied; there is no separate off-chain staking table to
+// fall out of sync with it.
 func (s *Server) getValidators(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, APIResponse{Success: false, Error: "Not implemented"})
+	validators := s.blockchain.GetValidators()
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    validators,
+	})
 }
 func (s *Server) getValidator(c *gin.Context) {
 	c.JSON(http.StatusNotImplemented, APIResponse{Success: false, Error: "Not implemented"})
@@ -586,42 +1019,123 @@ func (s *Server) getAccountTransactions(c *gin.Context) {
 
 func (s *Server) start() {
 	s.setupRoutes()
+	s.httpServer = &http.Server{Addr: ":8080", Handler: s.router}
 
 	// Start broadcast goroutine
 	go s.handleBroadcasts()
 
-	// Graceful shutdown
+	// Cancel the server's context on SIGINT/SIGTERM, which fans out to
+	// Shutdown below and to any blockchain background goroutines that were
+	// handed the same context.
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
 	go func() {
-		sigint := make(chan os.Signal, 1)
-		signal.Notify(sigint, os.Interrupt, syscall.SIGTERM)
-		<-sigint
+		select {
+		case <-sigCh:
+			s.cancel()
+		case <-s.ctx.Done():
+		}
+	}()
 
+	go func() {
+		<-s.ctx.Done()
 		log.Println("Shutting down server...")
-		// TODO: Implement graceful shutdown
-		os.Exit(0)
+		if err := s.Shutdown(); err != nil {
+			log.Printf("Error during shutdown: %v", err)
+		}
 	}()
 
 	log.Println("Diora API Server starting on :8080")
-	if err := s.router.Run(":8080"); err != nil {
+	if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		log.Fatal("Failed to start server:", err)
 	}
 }
 
+// Shutdown drains in-flight HTTP requests, closes every tracked WebSocket
+// connection with a proper close frame, drains the broadcast channel, and
+// flushes the blockchain's state to its underlying KV store. Only one call
+// is supported: a second call closes the already-closed broadcast channel
+// and panics.
+func (s *Server) Shutdown() error {
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("HTTP server shutdown error: %v", err)
+	}
+
+	s.clientsMu.Lock()
+	for conn := range s.clients {
+		closeMsg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down")
+		conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second))
+		conn.Close()
+		delete(s.clients, conn)
+	}
+	s.clientsMu.Unlock()
+
+	s.drainBroadcast()
+
+	if err := s.blockchain.Close(); err != nil {
+		return fmt.Errorf("failed to close blockchain: %w", err)
+	}
+
+	return nil
+}
+
+// drainBroadcast discards any buffered broadcast messages so handleBroadcasts
+// can exit cleanly instead of blocking on a channel no one will write to
+// again.
+func (s *Server) drainBroadcast() {
+	close(s.broadcast)
+}
+
 func (s *Server) handleBroadcasts() {
-	for {
-		message := <-s.broadcast
+	for message := range s.broadcast {
+		s.clientsMu.Lock()
 		for client := range s.clients {
-			err := client.WriteMessage(websocket.TextMessage, message)
-			if err != nil {
+			if err := client.WriteMessage(websocket.TextMessage, message); err != nil {
 				log.Printf("WebSocket write error: %v", err)
 				client.Close()
 				delete(s.clients, client)
 			}
 		}
+		s.clientsMu.Unlock()
 	}
 }
 
+// splitAPIList parses a comma-separated --http.api/--http.corsdomain/
+// --http.vhosts value into its entries, treating an empty flag as "list not
+// given" (nil) rather than a single empty-string entry, so namespaceSet and
+// allowsAnyOrigin fall back to their defaults.
+func splitAPIList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var out []string
+	start := 0
+	for i := 0; i <= len(s); i++ {
+		if i == len(s) || s[i] == ',' {
+			if i > start {
+				out = append(out, s[start:i])
+			}
+			start = i + 1
+		}
+	}
+	return out
+}
+
 func main() {
+	httpAPIFlag := flag.String("http.api", "", "comma-separated list of RPC namespaces to serve over HTTP (default: eth,net,web3,governance)")
+	wsAPIFlag := flag.String("ws.api", "", "comma-separated list of RPC namespaces to serve over websocket (default: eth,net,web3,governance)")
+	corsDomainFlag := flag.String("http.corsdomain", "*", "comma-separated list of domains allowed to make cross-origin requests (* allows any)")
+	vhostsFlag := flag.String("http.vhosts", "*", "comma-separated list of virtual hostnames this server accepts requests for (* allows any)")
+	dataDirFlag := flag.String("datadir", "./data", "data directory for chain state and the node's p2p identity key")
+	p2pPortFlag := flag.Int("p2p.port", 30303, "port this node's enode advertises as its listening address")
+	flag.Parse()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	// Initialize blockchain
 	config := &core.Config{
 		ChainID:        big.NewInt(1337),
@@ -634,11 +1148,22 @@ func main() {
 		ValidatorCount: 100,
 	}
 
-	blockchain, err := core.NewBlockchain(config, "./data")
+	engine := consensus.NewPoS(config.StakeAmount, config.ValidatorCount)
+	blockchain, err := core.NewBlockchain(ctx, config, *dataDirFlag, engine)
 	if err != nil {
 		log.Fatal("Failed to initialize blockchain:", err)
 	}
 
-	server := NewServer(blockchain)
+	server, err := NewServer(ctx, blockchain, ServerOptions{
+		HTTPAPIs:    splitAPIList(*httpAPIFlag),
+		WSAPIs:      splitAPIList(*wsAPIFlag),
+		CORSDomains: splitAPIList(*corsDomainFlag),
+		Vhosts:      splitAPIList(*vhostsFlag),
+		DataDir:     *dataDirFlag,
+		P2PPort:     *p2pPortFlag,
+	})
+	if err != nil {
+		log.Fatal("Failed to initialize server:", err)
+	}
 	server.start()
 }
@@ -0,0 +1,67 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// BeaconEntry is one randomness round from a verifiable randomness beacon
+// (drand's "chained" scheme): Signature is a BLS signature over Round and
+// PreviousSignature, so each entry cryptographically commits to the entry
+// before it and the whole chain roots in the network's genesis. Randomness()
+// derives the actual unbiasable random value consumers want — the signature
+// itself, since nothing but the network's threshold of nodes could have
+// produced it for this round.
+type BeaconEntry struct {
+	Round             uint64
+	Signature         []byte
+	PreviousSignature []byte
+}
+
+// Randomness is the unbiasable random value this entry provides: the raw
+// BLS signature bytes. It's deterministic from Round and PreviousSignature
+// alone, so two honest beacons never disagree on it for the same round.
+func (e BeaconEntry) Randomness() []byte {
+	return e.Signature
+}
+
+var (
+	// ErrEntryNotAvailable is returned by Entry when round hasn't been
+	// produced yet (it's in the future) or has fallen out of whatever
+	// retention window the implementation keeps.
+	ErrEntryNotAvailable = errors.New("beacon: entry not available")
+
+	// ErrInvalidEntry is returned by VerifyEntry when cur doesn't chain from
+	// prev, or its signature doesn't verify against the beacon's public key.
+	ErrInvalidEntry = errors.New("beacon: invalid entry")
+)
+
+// BeaconAPI is a verifiable randomness beacon, implemented by a real drand
+// network (DrandBeacon) or a fixed set of entries for tests (MockBeacon).
+// Blockchain.ValidateBlock uses it to check a proposed block's
+// BeaconEntries, and the proposer-selection logic in consensus uses the
+// entries it validates to pick a leader no validator can grind on.
+type BeaconAPI interface {
+	// Entry returns the beacon's signed entry for round, fetching and
+	// caching it if necessary. It returns ErrEntryNotAvailable if round
+	// hasn't been reached yet.
+	Entry(ctx context.Context, round uint64) (BeaconEntry, error)
+
+	// VerifyEntry checks that cur is a valid successor to prev: its round
+	// immediately follows prev's, its PreviousSignature matches prev's
+	// Signature, and its Signature is a valid BLS signature over those two
+	// fields under the beacon's group public key.
+	VerifyEntry(prev, cur BeaconEntry) error
+}
+
+// RoundTimer is an optional capability a BeaconAPI implementation can offer:
+// deriving which round a wall-clock time falls in. Block validation uses it
+// to check a header's BeaconEntries correspond to the round its own
+// Timestamp falls in, not some other (e.g. stale) round — see
+// core.BlockValidator. DrandBeacon implements it; MockBeacon deliberately
+// doesn't, so tests that don't care about timing can skip that check by
+// using an API that doesn't support it.
+type RoundTimer interface {
+	RoundAt(t time.Time) uint64
+}
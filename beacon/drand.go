@@ -0,0 +1,153 @@
+package beacon
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DrandBeacon is a BeaconAPI backed by a real drand network, reached over
+// its public HTTP relay API (GET /public/{round} against any of BaseURLs —
+// several are accepted so a single relay going down doesn't stall the
+// chain). Entries are cached forever once fetched: a round's signature
+// never changes, so there's nothing to invalidate.
+type DrandBeacon struct {
+	// BaseURLs are tried in order for each request, e.g.
+	// "https://api.drand.sh/<chain-hash>". At least one is required.
+	BaseURLs []string
+
+	// GroupPublicKey is this network's BLS group public key, uncompressed
+	// G2 point bytes (192 bytes) — see verifySignature.
+	GroupPublicKey []byte
+
+	// GenesisTime and Period define the round schedule: round R is signed
+	// at GenesisTime + (R-1)*Period (round 1 at GenesisTime itself), letting RoundAt derive the round a given
+	// block timestamp must carry without a network round-trip.
+	GenesisTime time.Time
+	Period      time.Duration
+
+	// HTTPClient defaults to http.DefaultClient's settings via the zero
+	// value of http.Client if left nil; NewDrandBeacon sets a sane timeout.
+	HTTPClient *http.Client
+
+	mu    sync.Mutex
+	cache map[uint64]BeaconEntry
+}
+
+// NewDrandBeacon creates a DrandBeacon, wiring in a request timeout so a
+// stalled relay can't hang block validation indefinitely.
+func NewDrandBeacon(baseURLs []string, groupPublicKey []byte, genesisTime time.Time, period time.Duration) *DrandBeacon {
+	return &DrandBeacon{
+		BaseURLs:       baseURLs,
+		GroupPublicKey: groupPublicKey,
+		GenesisTime:    genesisTime,
+		Period:         period,
+		HTTPClient:     &http.Client{Timeout: 10 * time.Second},
+		cache:          make(map[uint64]BeaconEntry),
+	}
+}
+
+// RoundAt returns the round number whose signing time has passed by
+// timestamp, the "monotonically increasing round number derived from
+// Timestamp" a block header's BeaconEntries are checked against. It's how
+// DrandBeacon implements the optional RoundTimer interface.
+func (d *DrandBeacon) RoundAt(timestamp time.Time) uint64 {
+	if timestamp.Before(d.GenesisTime) || d.Period <= 0 {
+		return 0
+	}
+	return uint64(timestamp.Sub(d.GenesisTime)/d.Period) + 1
+}
+
+type drandHTTPEntry struct {
+	Round             uint64 `json:"round"`
+	Signature         string `json:"signature"`
+	PreviousSignature string `json:"previous_signature"`
+}
+
+// Entry fetches round from the cache, or from the first BaseURL to answer.
+func (d *DrandBeacon) Entry(ctx context.Context, round uint64) (BeaconEntry, error) {
+	d.mu.Lock()
+	if entry, cached := d.cache[round]; cached {
+		d.mu.Unlock()
+		return entry, nil
+	}
+	d.mu.Unlock()
+
+	var lastErr error
+	for _, base := range d.BaseURLs {
+		entry, err := d.fetch(ctx, base, round)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		d.mu.Lock()
+		d.cache[round] = entry
+		d.mu.Unlock()
+		return entry, nil
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("beacon: no drand relay configured")
+	}
+	return BeaconEntry{}, fmt.Errorf("%w: round %d: %v", ErrEntryNotAvailable, round, lastErr)
+}
+
+func (d *DrandBeacon) fetch(ctx context.Context, base string, round uint64) (BeaconEntry, error) {
+	url := fmt.Sprintf("%s/public/%d", base, round)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return BeaconEntry{}, err
+	}
+
+	client := d.HTTPClient
+	if client == nil {
+		client = &http.Client{}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return BeaconEntry{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return BeaconEntry{}, fmt.Errorf("drand relay %s returned status %d", base, resp.StatusCode)
+	}
+
+	var raw drandHTTPEntry
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return BeaconEntry{}, fmt.Errorf("decoding drand response: %w", err)
+	}
+
+	signature, err := hex.DecodeString(raw.Signature)
+	if err != nil {
+		return BeaconEntry{}, fmt.Errorf("decoding signature: %w", err)
+	}
+	previousSignature, err := hex.DecodeString(raw.PreviousSignature)
+	if err != nil {
+		return BeaconEntry{}, fmt.Errorf("decoding previous_signature: %w", err)
+	}
+
+	return BeaconEntry{
+		Round:             raw.Round,
+		Signature:         signature,
+		PreviousSignature: previousSignature,
+	}, nil
+}
+
+// VerifyEntry checks that cur chains from prev (round and
+// PreviousSignature linkage) and that cur.Signature is a valid BLS
+// signature over chainedMessage(cur.Round, prev.Signature) under d's group
+// public key.
+func (d *DrandBeacon) VerifyEntry(prev, cur BeaconEntry) error {
+	if cur.Round != prev.Round+1 {
+		return fmt.Errorf("%w: round %d does not follow round %d", ErrInvalidEntry, cur.Round, prev.Round)
+	}
+	if string(cur.PreviousSignature) != string(prev.Signature) {
+		return fmt.Errorf("%w: round %d's previous_signature does not match round %d's signature", ErrInvalidEntry, cur.Round, prev.Round)
+	}
+	return verifySignature(d.GroupPublicKey, chainedMessage(cur.Round, prev.Signature), cur.Signature)
+}
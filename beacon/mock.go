@@ -0,0 +1,55 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// MockBeacon is a BeaconAPI with a fixed, test-supplied set of entries and
+// no real cryptography: VerifyEntry only checks the round/PreviousSignature
+// linkage a real chained beacon would, skipping the BLS pairing check so
+// tests can use arbitrary placeholder signature bytes instead of real BLS
+// key material.
+type MockBeacon struct {
+	mu      sync.RWMutex
+	entries map[uint64]BeaconEntry
+}
+
+// NewMockBeacon creates a MockBeacon seeded with entries, keyed by round.
+func NewMockBeacon(entries ...BeaconEntry) *MockBeacon {
+	m := &MockBeacon{entries: make(map[uint64]BeaconEntry, len(entries))}
+	for _, e := range entries {
+		m.entries[e.Round] = e
+	}
+	return m
+}
+
+// AddEntry registers (or replaces) a single round's entry, for tests that
+// build up a chain of entries incrementally.
+func (m *MockBeacon) AddEntry(e BeaconEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[e.Round] = e
+}
+
+func (m *MockBeacon) Entry(ctx context.Context, round uint64) (BeaconEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entry, ok := m.entries[round]
+	if !ok {
+		return BeaconEntry{}, fmt.Errorf("%w: round %d", ErrEntryNotAvailable, round)
+	}
+	return entry, nil
+}
+
+func (m *MockBeacon) VerifyEntry(prev, cur BeaconEntry) error {
+	if cur.Round != prev.Round+1 {
+		return fmt.Errorf("%w: round %d does not follow round %d", ErrInvalidEntry, cur.Round, prev.Round)
+	}
+	if string(cur.PreviousSignature) != string(prev.Signature) {
+		return fmt.Errorf("%w: round %d's previous_signature does not match round %d's signature", ErrInvalidEntry, cur.Round, prev.Round)
+	}
+	return nil
+}
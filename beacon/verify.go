@@ -0,0 +1,64 @@
+package beacon
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+)
+
+// chainedMessage is the message a chained-scheme drand signature commits
+// to: round as a big-endian uint64 followed by the previous round's raw
+// signature. Hashing it to a curve point (rather than signing it directly)
+// is what lets verifySignature check it with a single pairing equation.
+// SHA-256 produces a 32-byte digest, but bls12381.G1.MapToCurve needs
+// exactly 48 bytes (one field element) of input, so the digest is left-
+// padded with zeros the same way a big-endian field element with a short
+// value is — well within the field modulus, so it always decodes cleanly.
+func chainedMessage(round uint64, previousSignature []byte) []byte {
+	buf := make([]byte, 8+len(previousSignature))
+	binary.BigEndian.PutUint64(buf[:8], round)
+	copy(buf[8:], previousSignature)
+	sum := sha256.Sum256(buf)
+
+	padded := make([]byte, 48)
+	copy(padded[48-len(sum):], sum[:])
+	return padded
+}
+
+// verifySignature checks a BLS signature (a 96-byte uncompressed G1 point)
+// against a group public key (a 192-byte uncompressed G2 point) and a
+// message, via the standard pairing check
+// e(signature, g2Generator) == e(H(message), publicKey), tested as
+// e(signature, g2Generator) * e(H(message), publicKey)^-1 == 1 so both
+// sides can be accumulated into one pairing engine and compared against the
+// identity in GT. bls12381.G1/G2's FromBytes work in this package's
+// uncompressed point encoding rather than the compressed form real drand
+// networks serve over HTTP, so DrandBeacon's entries are expected in that
+// form too — see drand.go.
+func verifySignature(publicKey, message, signature []byte) error {
+	g1 := bls12381.NewG1()
+	g2 := bls12381.NewG2()
+
+	sigPoint, err := g1.FromBytes(signature)
+	if err != nil {
+		return fmt.Errorf("%w: malformed signature: %v", ErrInvalidEntry, err)
+	}
+	msgPoint, err := g1.MapToCurve(message)
+	if err != nil {
+		return fmt.Errorf("%w: hashing message to curve: %v", ErrInvalidEntry, err)
+	}
+	pubPoint, err := g2.FromBytes(publicKey)
+	if err != nil {
+		return fmt.Errorf("%w: malformed public key: %v", ErrInvalidEntry, err)
+	}
+
+	engine := bls12381.NewPairingEngine()
+	engine.AddPairInv(sigPoint, g2.One())
+	engine.AddPair(msgPoint, pubPoint)
+	if !engine.Check() {
+		return fmt.Errorf("%w: signature does not verify against group public key", ErrInvalidEntry)
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+// Command diora-wallet is a reference external signer: it hosts a local
+// keystore directory behind the same account_list/account_signTransaction/
+// account_signData JSON-RPC methods accounts.ExternalBackend speaks,
+// mirroring the lotus-wallet split of "keys on one box, node on another."
+// A node points its --signer flag at this process's address and never
+// touches a private key itself.
+package main
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/DioraFund/diora-go-blockchain/accounts"
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/DioraFund/diora-go-blockchain/internal/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// rpcErr* mirror the JSON-RPC 2.0 codes api/jsonrpc.go uses for the node's
+// own eth_*/net_*/web3_* endpoint.
+const (
+	rpcErrParse          = -32700
+	rpcErrMethodNotFound = -32601
+	rpcErrInvalidParams  = -32602
+	rpcErrServer         = -32000
+)
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// signer holds every keystore account's decrypted private key in memory,
+// keyed by address, so it can answer signing requests without asking for a
+// password on every call — the same tradeoff Clef's "rules" mode and
+// lotus-wallet's hot-wallet mode make in exchange for not prompting a
+// human per request.
+type signer struct {
+	mu   sync.RWMutex
+	keys map[common.Address]*ecdsa.PrivateKey
+}
+
+func newSigner(ks *keystore.Keystore, password string) (*signer, error) {
+	accountList, err := ks.ListAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keystore accounts: %w", err)
+	}
+
+	paths, err := ks.ListKeyFiles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keystore key files: %w", err)
+	}
+
+	s := &signer{keys: make(map[common.Address]*ecdsa.PrivateKey, len(accountList))}
+	for _, path := range paths {
+		address, err := ks.ReadKeyFileAddress(path)
+		if err != nil {
+			continue
+		}
+		key, err := ks.DecryptKeyFile(path, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unlock %s: %w", address.Hex(), err)
+		}
+		s.keys[address] = key
+	}
+	return s, nil
+}
+
+func (s *signer) addresses() []common.Address {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]common.Address, 0, len(s.keys))
+	for addr := range s.keys {
+		out = append(out, addr)
+	}
+	return out
+}
+
+func (s *signer) key(address common.Address) (*ecdsa.PrivateKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key, ok := s.keys[address]
+	if !ok {
+		return nil, fmt.Errorf("unknown account %s", address.Hex())
+	}
+	return key, nil
+}
+
+func main() {
+	keystoreDir := flag.String("keystore", "./keystore", "keystore directory to host accounts from")
+	passwordFile := flag.String("password-file", "", "file containing the password unlocking every account (required)")
+	addr := flag.String("addr", ":8550", "address to listen on")
+	flag.Parse()
+
+	if *passwordFile == "" {
+		log.Fatal("diora-wallet: -password-file is required")
+	}
+	passwordBytes, err := ioutil.ReadFile(*passwordFile)
+	if err != nil {
+		log.Fatalf("diora-wallet: failed to read password file: %v", err)
+	}
+	password := strings.TrimSpace(string(passwordBytes))
+
+	ks := keystore.NewKeystore(*keystoreDir)
+	s, err := newSigner(ks, password)
+	if err != nil {
+		log.Fatalf("diora-wallet: %v", err)
+	}
+
+	http.HandleFunc("/", s.handle)
+	fmt.Printf("🔏 diora-wallet hosting %d account(s) from %s on %s\n", len(s.keys), *keystoreDir, *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
+
+func (s *signer) handle(w http.ResponseWriter, r *http.Request) {
+	var req rpcRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeRPC(w, nil, nil, &rpcError{Code: rpcErrParse, Message: "invalid JSON"})
+		return
+	}
+
+	var result interface{}
+	var rpcErr *rpcError
+	switch req.Method {
+	case "account_list":
+		result = s.addresses()
+	case "account_signTransaction":
+		result, rpcErr = s.signTransaction(req.Params)
+	case "account_signData":
+		result, rpcErr = s.signData(req.Params)
+	default:
+		rpcErr = &rpcError{Code: rpcErrMethodNotFound, Message: fmt.Sprintf("method %q not found", req.Method)}
+	}
+
+	writeRPC(w, req.ID, result, rpcErr)
+}
+
+func writeRPC(w http.ResponseWriter, id json.RawMessage, result interface{}, rpcErr *rpcError) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rpcResponse{JSONRPC: "2.0", ID: id, Result: result, Error: rpcErr})
+}
+
+// signTransaction handles account_signTransaction: params are
+// [address, rawUnsignedTxHex, chainID].
+func (s *signer) signTransaction(params json.RawMessage) (interface{}, *rpcError) {
+	var p [3]string
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, &rpcError{Code: rpcErrInvalidParams, Message: err.Error()}
+	}
+
+	key, err := s.key(common.HexToAddress(p[0]))
+	if err != nil {
+		return nil, &rpcError{Code: rpcErrServer, Message: err.Error()}
+	}
+
+	tx, err := core.DecodeRawTransaction(p[1])
+	if err != nil {
+		return nil, &rpcError{Code: rpcErrInvalidParams, Message: fmt.Sprintf("invalid transaction: %v", err)}
+	}
+
+	chainID, ok := new(big.Int).SetString(p[2], 10)
+	if !ok {
+		return nil, &rpcError{Code: rpcErrInvalidParams, Message: "invalid chain ID"}
+	}
+
+	signed, err := accounts.SignWithKey(tx, chainID, key)
+	if err != nil {
+		return nil, &rpcError{Code: rpcErrServer, Message: err.Error()}
+	}
+
+	raw, err := rlp.EncodeToBytes(signed)
+	if err != nil {
+		return nil, &rpcError{Code: rpcErrServer, Message: err.Error()}
+	}
+
+	return map[string]string{
+		"raw":  "0x" + hex.EncodeToString(raw),
+		"hash": signed.Hash.Hex(),
+	}, nil
+}
+
+// signData handles account_signData: params are [address, hexData], and
+// data is whatever the caller already hashed — an eth_sign-style message
+// digest or a transaction's signing hash.
+func (s *signer) signData(params json.RawMessage) (interface{}, *rpcError) {
+	var p [2]string
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, &rpcError{Code: rpcErrInvalidParams, Message: err.Error()}
+	}
+
+	key, err := s.key(common.HexToAddress(p[0]))
+	if err != nil {
+		return nil, &rpcError{Code: rpcErrServer, Message: err.Error()}
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(p[1], "0x"))
+	if err != nil {
+		return nil, &rpcError{Code: rpcErrInvalidParams, Message: fmt.Sprintf("invalid hex data: %v", err)}
+	}
+
+	sig, err := gethcrypto.Sign(data, key)
+	if err != nil {
+		return nil, &rpcError{Code: rpcErrServer, Message: err.Error()}
+	}
+
+	return "0x" + hex.EncodeToString(sig), nil
+}
@@ -0,0 +1,94 @@
+package conformance
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+)
+
+var (
+	vectorDir = flag.String("vector", "testdata/vectors", "directory of conformance test vectors to run")
+	reportOut = flag.String("report", "", "path to write a JSON pass/fail report to, for CI to diff against a prior run (default: don't write one)")
+	update    = flag.Bool("update", false, "capture postState/postStateRoot for every vector under -vector from its actual execution, instead of checking it")
+)
+
+// TestConformance runs every vector under -vector through Run and fails
+// for each one that doesn't reproduce its postState/postStateRoot. Set
+// SKIP_CONFORMANCE=1 to skip it — lotus-style, for a build that can't
+// afford the corpus's full runtime (a local `go test ./...`, say) but
+// still wants everything else to run.
+func TestConformance(t *testing.T) {
+	if os.Getenv("SKIP_CONFORMANCE") == "1" {
+		t.Skip("SKIP_CONFORMANCE=1")
+	}
+
+	if *update {
+		updateVectors(t, *vectorDir)
+		return
+	}
+
+	results, err := RunDir(*vectorDir)
+	if err != nil {
+		t.Fatalf("RunDir(%s): %v", *vectorDir, err)
+	}
+	if len(results) == 0 {
+		t.Fatalf("no vectors found under %s", *vectorDir)
+	}
+
+	if *reportOut != "" {
+		if err := WriteReport(*reportOut, results); err != nil {
+			t.Fatalf("WriteReport: %v", err)
+		}
+	}
+
+	for _, r := range results {
+		if r.Passed {
+			continue
+		}
+		if r.Error != "" {
+			t.Errorf("%s: %s", r.Vector, r.Error)
+			continue
+		}
+		for _, mismatch := range r.Mismatches {
+			t.Errorf("%s: %s", r.Vector, mismatch)
+		}
+	}
+}
+
+// updateVectors overwrites every vector under dir with its actual
+// postState/postStateRoot, the way `go test -update` refreshes golden
+// files elsewhere in Go tooling. It's how a vector gets checked in the
+// first time; once committed, TestConformance holds it to that captured
+// result on every later run.
+func updateVectors(t *testing.T, dir string) {
+	t.Helper()
+
+	paths, err := vectorPaths(dir)
+	if err != nil {
+		t.Fatalf("walk %s: %v", dir, err)
+	}
+
+	for _, path := range paths {
+		v, err := LoadVector(path)
+		if err != nil {
+			t.Errorf("%s: %v", path, err)
+			continue
+		}
+		captured, err := Capture(v)
+		if err != nil {
+			t.Errorf("%s: %v", path, err)
+			continue
+		}
+		data, err := json.MarshalIndent(captured, "", "  ")
+		if err != nil {
+			t.Errorf("%s: marshal: %v", path, err)
+			continue
+		}
+		if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+			t.Errorf("%s: write: %v", path, err)
+			continue
+		}
+		t.Logf("captured %s", path)
+	}
+}
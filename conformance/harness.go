@@ -0,0 +1,221 @@
+package conformance
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/DioraFund/diora-go-blockchain/vm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+)
+
+// Result is one vector's outcome, in a shape that serializes straight to
+// the JSON report `go test -run Conformance -report <path>` writes so CI can diff
+// a run against the last known-good one and see exactly which vectors
+// regressed.
+type Result struct {
+	Vector     string   `json:"vector"`
+	Passed     bool     `json:"passed"`
+	Mismatches []string `json:"mismatches,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// Run loads v's preState into a fresh in-memory core.State, applies each
+// message through a core.Config zero-valued except for ChainID and
+// EIP158Block (see apply; a vector fixes state directly, it isn't
+// exercising chain configuration), and
+// checks the result against postState/postStateRoot. An empty
+// postStateRoot is treated as unpinned rather than a mismatch, so a vector
+// can assert account-level state before the exact root it should produce
+// is known.
+func Run(name string, v *Vector) *Result {
+	result := &Result{Vector: name}
+
+	state, err := apply(v)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Mismatches = diffAccounts(state, v.PostState)
+	if got := state.Root().Hex(); v.PostStateRoot != "" && got != v.PostStateRoot {
+		result.Mismatches = append(result.Mismatches, fmt.Sprintf("state root = %s, want %s", got, v.PostStateRoot))
+	}
+	result.Passed = len(result.Mismatches) == 0
+	return result
+}
+
+// Capture runs v's preState and messages the same way Run does, then
+// returns a copy of v with postState/postStateRoot overwritten with
+// whatever state actually resulted. It's how a new vector gets its
+// expected fields filled in the first time — the same golden-file
+// bootstrapping `go test -update` does elsewhere — before it's checked
+// into testdata/vectors for Run to hold the line on from then on.
+func Capture(v *Vector) (*Vector, error) {
+	state, err := apply(v)
+	if err != nil {
+		return nil, err
+	}
+
+	captured := *v
+	captured.PostState = make(map[string]AccountState, len(v.PreState))
+	for addrHex := range v.PreState {
+		captured.PostState[addrHex] = accountState(state, core.HexToAddress(addrHex))
+	}
+	captured.PostStateRoot = state.Root().Hex()
+	return &captured, nil
+}
+
+// apply is Run/Capture's shared setup: a fresh in-memory state seeded with
+// v.PreState, with v.Messages executed against it in order and committed.
+func apply(v *Vector) (*core.State, error) {
+	db, err := leveldb.Open(storage.NewMemStorage(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("open in-memory leveldb: %w", err)
+	}
+	defer db.Close()
+
+	state := core.NewState(db)
+	if err := loadAccounts(state, v.PreState); err != nil {
+		return nil, fmt.Errorf("load preState: %w", err)
+	}
+
+	// EIP158Block: big.NewInt(0) keeps conformance vectors on the same
+	// always-prune-empty-accounts behavior they ran under before Rules
+	// existed — these vectors have no block-number concept of their own
+	// (see Vector), so there's no fork schedule to test here, only a fixed
+	// set of state-transition rules to hold constant.
+	config := &core.Config{ChainID: big.NewInt(1), EIP158Block: big.NewInt(0)}
+	evm := vm.NewEVM(state, config)
+	for i, msg := range v.Messages {
+		tx, err := msg.transaction()
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %w", i, err)
+		}
+		if _, err := evm.ExecuteTransaction(tx, big.NewInt(0)); err != nil {
+			return nil, fmt.Errorf("message %d: %w", i, err)
+		}
+	}
+
+	if _, err := state.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+	return state, nil
+}
+
+// accountState reads addr back out of state in the same hex-string shape
+// a vector's preState/postState uses.
+func accountState(state *core.State, addr core.Address) AccountState {
+	acct := AccountState{
+		Balance: state.GetBalance(addr).String(),
+		Nonce:   state.GetNonce(addr),
+	}
+	if code := state.GetCode(addr); len(code) > 0 {
+		acct.Code = fmt.Sprintf("0x%x", code)
+	}
+	return acct
+}
+
+// loadAccounts seeds state with accounts exactly as preState describes
+// them, through the ordinary setters. Those writes are journaled like any
+// other, but nothing rolls back past them: a vector's preState *is* the
+// starting point.
+func loadAccounts(state *core.State, accounts map[string]AccountState) error {
+	for addrHex, acct := range accounts {
+		addr := core.HexToAddress(addrHex)
+
+		balance, ok := new(big.Int).SetString(acct.Balance, 0)
+		if !ok {
+			return fmt.Errorf("account %s: invalid balance %q", addrHex, acct.Balance)
+		}
+		state.SetBalance(addr, balance)
+		state.SetNonce(addr, acct.Nonce)
+
+		if acct.Code != "" {
+			state.SetCode(addr, hexBytes(acct.Code))
+		}
+		for keyHex, valueHex := range acct.Storage {
+			state.SetState(addr, core.HexToHash(keyHex), core.HexToHash(valueHex))
+		}
+	}
+	return nil
+}
+
+// diffAccounts compares every account postState names against what's
+// actually in state, returning one human-readable mismatch per field that
+// disagrees rather than stopping at the first one, so a failing vector's
+// report shows everything wrong with it at once.
+func diffAccounts(state *core.State, accounts map[string]AccountState) []string {
+	var mismatches []string
+	for addrHex, want := range accounts {
+		addr := core.HexToAddress(addrHex)
+
+		wantBalance, ok := new(big.Int).SetString(want.Balance, 0)
+		if !ok {
+			mismatches = append(mismatches, fmt.Sprintf("%s: invalid expected balance %q", addrHex, want.Balance))
+			continue
+		}
+		if got := state.GetBalance(addr); got.Cmp(wantBalance) != 0 {
+			mismatches = append(mismatches, fmt.Sprintf("%s: balance = %s, want %s", addrHex, got, wantBalance))
+		}
+		if got := state.GetNonce(addr); got != want.Nonce {
+			mismatches = append(mismatches, fmt.Sprintf("%s: nonce = %d, want %d", addrHex, got, want.Nonce))
+		}
+
+		wantCode := hexBytes(want.Code)
+		if got := state.GetCode(addr); string(got) != string(wantCode) {
+			mismatches = append(mismatches, fmt.Sprintf("%s: code = 0x%x, want 0x%x", addrHex, got, wantCode))
+		}
+
+		for keyHex, wantValueHex := range want.Storage {
+			key := core.HexToHash(keyHex)
+			wantValue := core.HexToHash(wantValueHex)
+			if got := state.GetState(addr, key); got != wantValue {
+				mismatches = append(mismatches, fmt.Sprintf("%s: storage[%s] = %s, want %s", addrHex, keyHex, got.Hex(), wantValue.Hex()))
+			}
+		}
+	}
+	return mismatches
+}
+
+// transaction converts a Message into the core.Transaction ExecuteTransaction
+// expects, bridging the hex-string vector format and the
+// common.Address/*big.Int fields the node's own types use.
+func (m Message) transaction() (*core.Transaction, error) {
+	value, ok := new(big.Int).SetString(m.Value, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid value %q", m.Value)
+	}
+	gasPrice := big.NewInt(0)
+	if m.GasPrice != "" {
+		gasPrice, ok = new(big.Int).SetString(m.GasPrice, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid gasPrice %q", m.GasPrice)
+		}
+	}
+	tx := &core.Transaction{
+		Type:     core.LegacyTxType,
+		Nonce:    m.Nonce,
+		GasPrice: gasPrice,
+		GasLimit: m.GasLimit,
+		Value:    value,
+		Data:     hexBytes(m.Data),
+		From:     common.HexToAddress(m.From),
+	}
+	if m.To != "" {
+		to := common.HexToAddress(m.To)
+		tx.To = &to
+	}
+	return tx, nil
+}
+
+// hexBytes decodes an optional "0x..."-prefixed hex string, treating "" as
+// an explicit empty byte slice.
+func hexBytes(s string) []byte {
+	if s == "" {
+		return nil
+	}
+	return common.FromHex(s)
+}
@@ -0,0 +1,69 @@
+package conformance
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+)
+
+// junitSuite and junitCase mirror the handful of JUnit XML fields any CI
+// system (Jenkins, GitHub Actions, GitLab) already knows how to render, so
+// a conformance run shows up next to a project's other test results
+// instead of needing its own dashboard.
+type junitSuite struct {
+	XMLName  xml.Name    `xml:"testsuite"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name    string        `xml:"name,attr"`
+	Failure *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnitReport writes results as a JUnit XML test suite to path, for
+// CI integrations that render JUnit reports rather than diffing
+// WriteReport's JSON directly.
+func WriteJUnitReport(path string, results []*Result) error {
+	suite := junitSuite{Name: "conformance", Tests: len(results)}
+	for _, r := range results {
+		c := junitCase{Name: r.Vector}
+		switch {
+		case r.Error != "":
+			suite.Failures++
+			c.Failure = &junitFailure{Message: "error", Text: r.Error}
+		case !r.Passed:
+			suite.Failures++
+			c.Failure = &junitFailure{Message: "mismatch", Text: joinLines(r.Mismatches)}
+		}
+		suite.Cases = append(suite.Cases, c)
+	}
+
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal JUnit report: %w", err)
+	}
+	data = append([]byte(xml.Header), data...)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write JUnit report %s: %w", path, err)
+	}
+	return nil
+}
+
+func joinLines(lines []string) string {
+	out := ""
+	for i, line := range lines {
+		if i > 0 {
+			out += "\n"
+		}
+		out += line
+	}
+	return out
+}
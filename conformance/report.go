@@ -0,0 +1,133 @@
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// vectorPaths walks dir for *.json vectors, in a stable (sorted path)
+// order so a report's vector ordering doesn't depend on the filesystem's
+// directory-listing order.
+func vectorPaths(dir string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && filepath.Ext(path) == ".json" {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk %s: %w", dir, err)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// RunDir walks dir for *.json vectors and runs each one.
+func RunDir(dir string) ([]*Result, error) {
+	paths, err := vectorPaths(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*Result, 0, len(paths))
+	for _, path := range paths {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			rel = path
+		}
+		v, err := LoadVector(path)
+		if err != nil {
+			results = append(results, &Result{Vector: rel, Error: err.Error()})
+			continue
+		}
+		results = append(results, Run(rel, v))
+	}
+	return results, nil
+}
+
+// RunCorpus is RunDir with the filtering and concurrency `diora dev test
+// --type=conformance` needs on top of a plain `go test`: paths are
+// included/excluded by a filepath.Match glob against their dir-relative
+// form (an empty pattern always matches, so either flag can be omitted),
+// and the matching vectors run parallel across up to parallel workers.
+// Results come back in vectorPaths' stable sorted order regardless of
+// which worker finished first, so a run's report is reproducible.
+func RunCorpus(dir, include, exclude string, parallel int) ([]*Result, error) {
+	paths, err := vectorPaths(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type entry struct {
+		path string
+		rel  string
+	}
+	var selected []entry
+	for _, path := range paths {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			rel = path
+		}
+		if include != "" {
+			if ok, err := filepath.Match(include, rel); err != nil {
+				return nil, fmt.Errorf("invalid --include pattern %q: %w", include, err)
+			} else if !ok {
+				continue
+			}
+		}
+		if exclude != "" {
+			if ok, err := filepath.Match(exclude, rel); err != nil {
+				return nil, fmt.Errorf("invalid --exclude pattern %q: %w", exclude, err)
+			} else if ok {
+				continue
+			}
+		}
+		selected = append(selected, entry{path: path, rel: rel})
+	}
+
+	if parallel < 1 {
+		parallel = 1
+	}
+	results := make([]*Result, len(selected))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, e := range selected {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, e entry) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			v, err := LoadVector(e.path)
+			if err != nil {
+				results[i] = &Result{Vector: e.rel, Error: err.Error()}
+				return
+			}
+			results[i] = Run(e.rel, v)
+		}(i, e)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+// WriteReport writes results as indented JSON to path, so CI can diff one
+// run's report against the last known-good one and see exactly which
+// vectors regressed.
+func WriteReport(path string, results []*Result) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal report: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write report %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,328 @@
+package consensus
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/DioraFund/diora-go-blockchain/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// ValidatorSnapshot is one validator's RLP-serializable state as of a
+// ValidatorSetEntry's Height: the subset of Validator that matters to a
+// past-height query, plus that validator's active delegations (Validator
+// itself carries ecdsa.PublicKey/*big.Int pointers and an int-typed status
+// that aren't all RLP-friendly as-is, hence the separate shape).
+type ValidatorSnapshot struct {
+	Address         core.Address
+	Stake           *big.Int
+	TotalDelegated  *big.Int
+	Commission      uint64
+	Status          uint8
+	OperatorAddress core.Address
+	ControlAddress  core.Address
+	// ConsensusPubKey is elliptic.Marshal'd (nil if the validator had none
+	// on record yet), the same encoding rotationProofHash and
+	// RotateConsensusKey already use for a ConsensusPubKey off the wire.
+	ConsensusPubKey []byte
+	Delegations     []DelegationSnapshot
+}
+
+// DelegationSnapshot is one delegation to a ValidatorSnapshot's validator,
+// as of the same Height.
+type DelegationSnapshot struct {
+	Delegator core.Address
+	Amount    *big.Int
+	Status    uint8
+}
+
+// ValidatorSetEntry is one epoch boundary's validator set and delegations,
+// as written into validatorSetHistory by UpdateSlot.
+type ValidatorSetEntry struct {
+	Height     uint64
+	Epoch      uint64
+	Validators []ValidatorSnapshot
+}
+
+// historyDB persists ValidatorSetEntry payloads keyed by height, so a
+// long-running node doesn't have to hold every epoch's entry in memory —
+// only the sorted height index (historyHeights) PoS keeps alongside it.
+// memHistoryDB is the zero-config default; LevelDBHistoryDB is the
+// on-disk implementation a node can opt into via PoS.SetHistoryDB.
+type historyDB interface {
+	Put(height uint64, data []byte) error
+	Get(height uint64) ([]byte, bool, error)
+	Delete(height uint64) error
+}
+
+// memHistoryDB is the in-memory historyDB NewPoS wires up by default, for
+// tests and short-lived nodes that don't need entries to survive a
+// restart.
+type memHistoryDB struct {
+	entries map[uint64][]byte
+}
+
+func newMemHistoryDB() *memHistoryDB {
+	return &memHistoryDB{entries: make(map[uint64][]byte)}
+}
+
+func (db *memHistoryDB) Put(height uint64, data []byte) error {
+	db.entries[height] = data
+	return nil
+}
+
+func (db *memHistoryDB) Get(height uint64) ([]byte, bool, error) {
+	data, ok := db.entries[height]
+	return data, ok, nil
+}
+
+func (db *memHistoryDB) Delete(height uint64) error {
+	delete(db.entries, height)
+	return nil
+}
+
+// validatorHistoryPrefix namespaces ValidatorSetEntry keys within a
+// LevelDBHistoryDB's database, the same prefix-byte-string convention
+// core/headerchain.go's canonical index uses.
+var validatorHistoryPrefix = []byte("validator-history-")
+
+func validatorHistoryKey(height uint64) []byte {
+	enc := make([]byte, 8)
+	binary.BigEndian.PutUint64(enc, height)
+	return append(append([]byte{}, validatorHistoryPrefix...), enc...)
+}
+
+// LevelDBHistoryDB is the on-disk historyDB implementation: a goleveldb
+// handle, the same database engine core.State and core.Blockchain already
+// persist to. A node wires it in via NewLevelDBHistoryDB + SetHistoryDB so
+// validatorSetHistory survives a restart instead of replaying from genesis.
+type LevelDBHistoryDB struct {
+	db *leveldb.DB
+}
+
+// NewLevelDBHistoryDB opens (creating if needed) a LevelDBHistoryDB at
+// path. It always opens its own handle; there is no constructor that wraps
+// a leveldb.DB the caller already has open.
+func NewLevelDBHistoryDB(path string) (*LevelDBHistoryDB, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open validator history database at %s: %w", path, err)
+	}
+	return &LevelDBHistoryDB{db: db}, nil
+}
+
+func (db *LevelDBHistoryDB) Put(height uint64, data []byte) error {
+	return db.db.Put(validatorHistoryKey(height), data, nil)
+}
+
+func (db *LevelDBHistoryDB) Get(height uint64) ([]byte, bool, error) {
+	data, err := db.db.Get(validatorHistoryKey(height), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+func (db *LevelDBHistoryDB) Delete(height uint64) error {
+	return db.db.Delete(validatorHistoryKey(height), nil)
+}
+
+// SetHistoryDB swaps in db as where recordValidatorSetHistory writes and
+// GetValidatorsAtHeight/GetDelegationsAtHeight read from, in place of the
+// in-memory default NewPoS starts with. Mirrors SetValidator's plain-setter
+// shape rather than threading a historyDB through NewPoS, so existing
+// callers (core.NewBlockchain among them) don't need to change.
+func (pos *PoS) SetHistoryDB(db historyDB) {
+	pos.mu.Lock()
+	defer pos.mu.Unlock()
+	pos.historyDB = db
+}
+
+// newValidatorSnapshot captures v (and its active delegations, found in
+// pos.delegators) into the RLP-friendly shape recordValidatorSetHistory
+// writes. Callers must hold pos.mu.
+func (pos *PoS) newValidatorSnapshot(v *Validator) ValidatorSnapshot {
+	var pubKey []byte
+	if v.ConsensusPubKey != nil {
+		pubKey = elliptic.Marshal(v.ConsensusPubKey.Curve, v.ConsensusPubKey.X, v.ConsensusPubKey.Y)
+	}
+
+	var delegations []DelegationSnapshot
+	for _, dels := range pos.delegators {
+		for _, d := range dels {
+			if d.Validator != v.Address {
+				continue
+			}
+			delegations = append(delegations, DelegationSnapshot{
+				Delegator: d.Delegator,
+				Amount:    new(big.Int).Set(d.Amount),
+				Status:    uint8(d.Status),
+			})
+		}
+	}
+
+	return ValidatorSnapshot{
+		Address:         v.Address,
+		Stake:           new(big.Int).Set(v.Stake),
+		TotalDelegated:  new(big.Int).Set(v.TotalDelegated),
+		Commission:      v.Commission,
+		Status:          uint8(v.Status),
+		OperatorAddress: v.OperatorAddress,
+		ControlAddress:  v.ControlAddress,
+		ConsensusPubKey: pubKey,
+		Delegations:     delegations,
+	}
+}
+
+// toValidator reconstructs the *Validator a ValidatorSnapshot was taken
+// from, for GetValidatorsAtHeight to return.
+func (s ValidatorSnapshot) toValidator() *Validator {
+	v := &Validator{
+		Address:         s.Address,
+		Stake:           s.Stake,
+		TotalDelegated:  s.TotalDelegated,
+		Commission:      s.Commission,
+		Status:          ValidatorStatus(s.Status),
+		OperatorAddress: s.OperatorAddress,
+		ControlAddress:  s.ControlAddress,
+	}
+	if len(s.ConsensusPubKey) > 0 {
+		x, y := elliptic.Unmarshal(crypto.S256(), s.ConsensusPubKey)
+		if x != nil {
+			v.ConsensusPubKey = &ecdsa.PublicKey{Curve: crypto.S256(), X: x, Y: y}
+		}
+	}
+	return v
+}
+
+// recordValidatorSetHistory snapshots the active validator set (and every
+// validator's current delegations) into a ValidatorSetEntry at height, and
+// writes it to pos.historyDB. Called from UpdateSlot at every epoch
+// boundary, right after snapshotForEpoch takes that epoch's in-memory
+// Snapshot. Callers must hold pos.mu.
+func (pos *PoS) recordValidatorSetHistory(height, epoch uint64) {
+	active := pos.getActiveValidators()
+	snapshots := make([]ValidatorSnapshot, len(active))
+	for i, v := range active {
+		snapshots[i] = pos.newValidatorSnapshot(v)
+	}
+
+	entry := ValidatorSetEntry{Height: height, Epoch: epoch, Validators: snapshots}
+	data, err := rlp.EncodeToBytes(&entry)
+	if err != nil {
+		// A snapshot that can't be encoded would silently corrupt the
+		// historical record rather than just this one entry; refusing to
+		// write it is the safer failure mode and every field above is
+		// RLP-safe by construction, so this should never actually happen.
+		return
+	}
+	if err := pos.historyDB.Put(height, data); err != nil {
+		return
+	}
+
+	pos.historyHeights = append(pos.historyHeights, height)
+}
+
+// entryAtOrBefore binary-searches pos.historyHeights (kept sorted ascending
+// by construction, since UpdateSlot only ever appends increasing heights)
+// for the latest entry at a height <= h, and loads it from pos.historyDB.
+// Callers must hold pos.mu (at least for reading).
+func (pos *PoS) entryAtOrBefore(h uint64) (*ValidatorSetEntry, error) {
+	heights := pos.historyHeights
+	i := sort.Search(len(heights), func(i int) bool { return heights[i] > h })
+	if i == 0 {
+		return nil, fmt.Errorf("no validator set history recorded at or before height %d", h)
+	}
+	height := heights[i-1]
+
+	data, ok, err := pos.historyDB.Get(height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read validator set history at height %d: %w", height, err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("validator set history at height %d is missing from the history store (pruned?)", height)
+	}
+
+	var entry ValidatorSetEntry
+	if err := rlp.DecodeBytes(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to decode validator set history at height %d: %w", height, err)
+	}
+	return &entry, nil
+}
+
+// GetValidatorsAtHeight returns the active validator set as it existed at
+// or immediately before height h, reconstructed from validatorSetHistory
+// rather than the live validators map — so a slash or evidence check
+// against a past height sees the set that actually produced it, not
+// whatever it has since changed to.
+func (pos *PoS) GetValidatorsAtHeight(h uint64) ([]*Validator, error) {
+	pos.mu.RLock()
+	defer pos.mu.RUnlock()
+
+	entry, err := pos.entryAtOrBefore(h)
+	if err != nil {
+		return nil, err
+	}
+	validators := make([]*Validator, len(entry.Validators))
+	for i, s := range entry.Validators {
+		validators[i] = s.toValidator()
+	}
+	return validators, nil
+}
+
+// GetDelegationsAtHeight returns delegator's delegations as they existed at
+// or immediately before height h.
+func (pos *PoS) GetDelegationsAtHeight(delegator core.Address, h uint64) ([]*Delegation, error) {
+	pos.mu.RLock()
+	defer pos.mu.RUnlock()
+
+	entry, err := pos.entryAtOrBefore(h)
+	if err != nil {
+		return nil, err
+	}
+
+	var delegations []*Delegation
+	for _, v := range entry.Validators {
+		for _, d := range v.Delegations {
+			if d.Delegator != delegator {
+				continue
+			}
+			delegations = append(delegations, &Delegation{
+				Delegator: d.Delegator,
+				Validator: v.Address,
+				Amount:    d.Amount,
+				Status:    DelegationStatus(d.Status),
+			})
+		}
+	}
+	return delegations, nil
+}
+
+// PruneHistoryBefore deletes every recorded ValidatorSetEntry below height
+// h, mirroring the bounded-retention tradeoff pruneSnapshots already makes
+// for in-memory epoch Snapshots — except here the operator chooses the
+// cutoff explicitly, since on-disk history is meant to outlive
+// epochSnapshotHistoryLimit's much smaller in-memory window.
+func (pos *PoS) PruneHistoryBefore(h uint64) {
+	pos.mu.Lock()
+	defer pos.mu.Unlock()
+
+	kept := pos.historyHeights[:0]
+	for _, height := range pos.historyHeights {
+		if height < h {
+			pos.historyDB.Delete(height)
+			continue
+		}
+		kept = append(kept, height)
+	}
+	pos.historyHeights = kept
+}
@@ -1,17 +1,41 @@
 package consensus
 
 import (
+	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
 	"math/big"
+	mathrand "math/rand"
 	"sort"
 	"sync"
 	"time"
 
-	"github.com/diora-blockchain/diora/core"
-	"github.com/diora-blockchain/diora/crypto"
+	"github.com/DioraFund/diora-go-blockchain/beacon"
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/DioraFund/diora-go-blockchain/crypto"
+	"github.com/ethereum/go-ethereum/common"
 )
 
+// epochSnapshotHistoryLimit bounds how many past epochs' Snapshot stay in
+// memory, evicted oldest-first, so a long-running node validating an old
+// block doesn't grow this unbounded.
+const epochSnapshotHistoryLimit = 256
+
+// Snapshot freezes the active validator set and its deterministic signer
+// queue for one epoch. ValidateBlock and SelectValidator for a slot in this
+// epoch consult it instead of the live validators map, so a block from an
+// epoch whose validator set has since changed (new registrations, slashing)
+// still validates against the set that was actually active when it was
+// produced.
+type Snapshot struct {
+	Epoch       uint64
+	Validators  []*Validator
+	SignerQueue []core.Address
+	Hash        [32]byte
+}
+
 type PoS struct {
 	config      *Config
 	validators  map[core.Address]*Validator
@@ -21,40 +45,168 @@ type PoS struct {
 	currentSlot uint64
 	validator   core.Address
 	privateKey  *ecdsa.PrivateKey
-	mu          sync.RWMutex
+
+	// snapshots holds one Snapshot per epoch, keyed by epoch number;
+	// snapshotOrder records insertion order so pruneSnapshots can evict the
+	// oldest once epochSnapshotHistoryLimit is exceeded. lastSnapshotHash
+	// chains into the next epoch's signer-queue seed (see deriveSignerQueue).
+	snapshots        map[uint64]*Snapshot
+	snapshotOrder    []uint64
+	lastSnapshotHash [32]byte
+
+	// missedSlots is a per-validator sliding window (capped at
+	// SignedBlocksWindow entries) of slot numbers they were expected to
+	// propose but didn't, maintained by detectMissedSlots. signedSlots
+	// records the validator that actually proposed each recent slot so
+	// detectMissedSlots can tell a miss from a hit; entries are consumed
+	// (deleted) as soon as detectMissedSlots processes them.
+	missedSlots map[core.Address][]uint64
+	signedSlots map[uint64]core.Address
+	// lastCheckedSlot is the highest slot detectMissedSlots has already
+	// accounted for, so each UpdateSlot call only scans the new range.
+	lastCheckedSlot uint64
+
+	// slashEvents is an append-only log of every slashing outcome, queried
+	// by height or validator.
+	slashEvents []SlashEvent
+
+	// redelegations holds every RedelegationEntry not yet past its
+	// CompletionTime, consulted by Redelegate (to enforce
+	// RedelegationMaxEntries) and slashLocked (to also cut in-flight moves
+	// still slashable against their source validator). Entries are never
+	// pruned once past CompletionTime since they stop matching either
+	// check; a long-running node trades that small unbounded growth for
+	// not needing a second background sweep.
+	redelegations []*RedelegationEntry
+
+	// haltHeight is the lowest height ShouldHaltAt has found >2/3-stake
+	// support for, or 0 if no height has reached quorum yet. It's cached on
+	// SubmitHaltVote so ValidateBlock's hot path doesn't re-tally haltVotes
+	// on every block; haltVotes itself is kept for GetHaltVotes/inspection
+	// and because a height can cross quorum without a fresh vote (e.g. a
+	// validator that voted earlier getting slashed out of the active set
+	// changes the denominator, not the tally).
+	haltVotes  map[uint64]map[core.Address]bool
+	haltHeight uint64
+
+	// keyHistory records every ConsensusPubKey a validator has ever held,
+	// ordered by EffectiveEpoch, so a block from before a RotateConsensusKey
+	// call still verifies against the key that signed it. Appended to by
+	// RegisterValidator (EffectiveEpoch 0) and RotateConsensusKey.
+	keyHistory map[core.Address][]KeyRotation
+
+	// historyDB persists validatorSetHistory (see history.go); defaults to
+	// an in-memory store, swappable via SetHistoryDB for one that survives
+	// a restart. historyHeights is the sorted ascending index of every
+	// height an entry has been written at, kept in memory so
+	// entryAtOrBefore can binary-search it without a historyDB scan.
+	historyDB      historyDB
+	historyHeights []uint64
+
+	mu sync.RWMutex
 }
 
 type Config struct {
-	MinStakeAmount    *big.Int
-	MaxValidators     int
-	UnbondingPeriod  time.Duration
-	SlotDuration      time.Duration
-	EpochLength       uint64
-	RewardRate        *big.Int
-	SlashRate         *big.Int
-	CommissionRate    uint64
+	MinStakeAmount  *big.Int
+	MaxValidators   int
+	UnbondingPeriod time.Duration
+	SlotDuration    time.Duration
+	EpochLength     uint64
+	RewardRate      *big.Int
+	// SlashRate is the fraction of the offending stake slashLocked cuts,
+	// in basis points (same convention as CommissionRate), e.g. 500 = 5%.
+	SlashRate      uint64
+	CommissionRate uint64
+
+	// SignedBlocksWindow is how many of a validator's most recent expected
+	// slots detectMissedSlots keeps in missedSlots before trimming the
+	// oldest entry.
+	SignedBlocksWindow uint64
+	// MinSignedPerWindow sets the downtime-slashing threshold: once a
+	// validator's tracked missed-slot count within SignedBlocksWindow
+	// exceeds SignedBlocksWindow*MinSignedPerWindow, recordMissedSlot
+	// auto-submits DowntimeEvidence against them.
+	MinSignedPerWindow float64
+
+	// RedelegationMaxEntries caps how many in-flight RedelegationEntry
+	// records the same delegator can have open against the same
+	// (srcValidator, dstValidator) pair at once.
+	RedelegationMaxEntries int
+	// RedelegationCompletionTime is how long a RedelegationEntry's moved
+	// amount stays slashable against its source validator after the move,
+	// the redelegation analog of UnbondingPeriod.
+	RedelegationCompletionTime time.Duration
+}
+
+// RedelegationEntry tracks one in-flight Redelegate call: Amount moved from
+// SrcValidator to DstValidator on Delegator's behalf, still slashable
+// against SrcValidator (for infractions predating the move) until
+// CompletionTime.
+type RedelegationEntry struct {
+	Delegator      core.Address
+	SrcValidator   core.Address
+	DstValidator   core.Address
+	Amount         *big.Int
+	CreationTime   time.Time
+	CompletionTime time.Time
 }
 
 type Validator struct {
-	Address       core.Address
-	Stake         *big.Int
+	Address        core.Address
+	Stake          *big.Int
 	TotalDelegated *big.Int
-	Commission    uint64
-	Status        ValidatorStatus
-	LastActive    time.Time
-	TotalBlocks   uint64
-	Rewards       *big.Int
-	PublicKey     *ecdsa.PublicKey
+	Commission     uint64
+	Status         ValidatorStatus
+	LastActive     time.Time
+	TotalBlocks    uint64
+	Rewards        *big.Int
+
+	// OperatorAddress is the cold address that registered this validator:
+	// it owns the stake and is the only address RegisterValidator's stake
+	// accounting and governance actions (Slash, SubmitEvidence targets)
+	// ever attribute to. Set once at registration and never changed by
+	// EditValidator or RotateConsensusKey.
+	OperatorAddress core.Address
+	// ControlAddress is the warm address authorized to call EditValidator
+	// and RotateConsensusKey on OperatorAddress's behalf, so the cold
+	// operator key never has to touch day-to-day validator maintenance.
+	// Defaults to OperatorAddress at registration.
+	ControlAddress core.Address
+	// ConsensusPubKey is the hot key SignBlock/ValidateBlock currently
+	// trust for this validator's block signatures. RotateConsensusKey
+	// replaces it and appends the change to PoS.keyHistory rather than
+	// overwriting history, so a block signed before a rotation still
+	// verifies against the key that was actually live when it was signed.
+	ConsensusPubKey *ecdsa.PublicKey
+
+	// MissedBlocks is the validator's current missed-slot count within the
+	// SignedBlocksWindow sliding window tracked in PoS.missedSlots.
+	MissedBlocks uint64
+}
+
+// KeyRotation is one entry in PoS.keyHistory: PubKey became the validator's
+// ConsensusPubKey as of EffectiveEpoch (inclusive), staying authoritative
+// until the next entry's EffectiveEpoch.
+type KeyRotation struct {
+	EffectiveEpoch uint64
+	PubKey         *ecdsa.PublicKey
+}
+
+// ValidatorUpdate carries the subset of validator metadata EditValidator can
+// change; nil fields are left untouched.
+type ValidatorUpdate struct {
+	ControlAddress *core.Address
+	Commission     *uint64
 }
 
 type Delegation struct {
-	Delegator   core.Address
-	Validator   core.Address
-	Amount      *big.Int
-	Rewards     *big.Int
-	StartTime   time.Time
-	UnbondTime  time.Time
-	Status      DelegationStatus
+	Delegator  core.Address
+	Validator  core.Address
+	Amount     *big.Int
+	Rewards    *big.Int
+	StartTime  time.Time
+	UnbondTime time.Time
+	Status     DelegationStatus
 }
 
 type ValidatorStatus int
@@ -63,6 +215,12 @@ const (
 	ValidatorStatusInactive ValidatorStatus = iota
 	ValidatorStatusActive
 	ValidatorStatusSlashed
+	// ValidatorStatusTombstoned is the terminal status SubmitEvidence and
+	// Slash now assign: unlike ValidatorStatusSlashed, a tombstoned
+	// validator can never return to ValidatorStatusActive, even after
+	// restaking above MinStakeAmount, because nothing in this package ever
+	// transitions a Tombstoned validator back.
+	ValidatorStatusTombstoned
 )
 
 type DelegationStatus int
@@ -79,15 +237,25 @@ func NewPoS(stakeAmount *big.Int, maxValidators int) *PoS {
 		validators:  make(map[core.Address]*Validator),
 		delegators:  make(map[core.Address][]*Delegation),
 		totalStake:  big.NewInt(0),
+		snapshots:   make(map[uint64]*Snapshot),
+		missedSlots: make(map[core.Address][]uint64),
+		signedSlots: make(map[uint64]core.Address),
+		haltVotes:   make(map[uint64]map[core.Address]bool),
+		keyHistory:  make(map[core.Address][]KeyRotation),
+		historyDB:   newMemHistoryDB(),
 		config: &Config{
-			MinStakeAmount:   stakeAmount,
-			MaxValidators:    maxValidators,
-			UnbondingPeriod:  7 * 24 * time.Hour, // 7 days
-			SlotDuration:     6 * time.Second,
-			EpochLength:      100,
-			RewardRate:       big.NewInt(1000000000000000000), // 1 DIO per epoch
-			SlashRate:        big.NewInt(500000000000000000),  // 0.5 DIO
-			CommissionRate:   1000, // 10%
+			MinStakeAmount:             stakeAmount,
+			MaxValidators:              maxValidators,
+			UnbondingPeriod:            7 * 24 * time.Hour, // 7 days
+			SlotDuration:               6 * time.Second,
+			EpochLength:                100,
+			RewardRate:                 big.NewInt(1000000000000000000), // 1 DIO per epoch
+			SlashRate:                  500,                             // 5%
+			CommissionRate:             1000,                            // 10%
+			SignedBlocksWindow:         100,
+			MinSignedPerWindow:         0.5,
+			RedelegationMaxEntries:     7,
+			RedelegationCompletionTime: 24 * time.Hour,
 		},
 	}
 }
@@ -95,7 +263,7 @@ func NewPoS(stakeAmount *big.Int, maxValidators int) *PoS {
 func (pos *PoS) SetValidator(address core.Address, privateKey *ecdsa.PrivateKey) {
 	pos.mu.Lock()
 	defer pos.mu.Unlock()
-	
+
 	pos.validator = address
 	pos.privateKey = privateKey
 }
@@ -103,14 +271,14 @@ func (pos *PoS) SetValidator(address core.Address, privateKey *ecdsa.PrivateKey)
 func (pos *PoS) IsValidator() bool {
 	pos.mu.RLock()
 	defer pos.mu.RUnlock()
-	
+
 	return pos.validator != (core.Address{}) && pos.isCurrentValidator(pos.validator)
 }
 
 func (pos *PoS) GetValidatorAddress() core.Address {
 	pos.mu.RLock()
 	defer pos.mu.RUnlock()
-	
+
 	return pos.validator
 }
 
@@ -131,23 +299,119 @@ func (pos *PoS) RegisterValidator(address core.Address, stake *big.Int, commissi
 	}
 
 	validator := &Validator{
-		Address:       address,
-		Stake:         new(big.Int).Set(stake),
-		TotalDelegated: big.NewInt(0),
-		Commission:    commission,
-		Status:        ValidatorStatusActive,
-		LastActive:    time.Now(),
-		TotalBlocks:   0,
-		Rewards:       big.NewInt(0),
-		PublicKey:     publicKey,
+		Address:         address,
+		Stake:           new(big.Int).Set(stake),
+		TotalDelegated:  big.NewInt(0),
+		Commission:      commission,
+		Status:          ValidatorStatusActive,
+		LastActive:      time.Now(),
+		TotalBlocks:     0,
+		Rewards:         big.NewInt(0),
+		OperatorAddress: address,
+		ControlAddress:  address,
+		ConsensusPubKey: publicKey,
 	}
 
 	pos.validators[address] = validator
 	pos.totalStake.Add(pos.totalStake, stake)
+	pos.keyHistory[address] = []KeyRotation{{EffectiveEpoch: 0, PubKey: publicKey}}
+
+	return nil
+}
+
+// findByControlAddress returns the validator control currently controls, and
+// whether one was found. Callers must hold pos.mu.
+func (pos *PoS) findByControlAddress(control core.Address) (*Validator, bool) {
+	for _, v := range pos.validators {
+		if v.ControlAddress == control {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// EditValidator applies update to the validator control currently controls.
+// Only fields set in update are changed.
+func (pos *PoS) EditValidator(control core.Address, update ValidatorUpdate) error {
+	pos.mu.Lock()
+	defer pos.mu.Unlock()
+
+	v, exists := pos.findByControlAddress(control)
+	if !exists {
+		return fmt.Errorf("edit validator: %s does not control any validator", control.Hex())
+	}
+
+	if update.ControlAddress != nil {
+		v.ControlAddress = *update.ControlAddress
+	}
+	if update.Commission != nil {
+		v.Commission = *update.Commission
+	}
+
+	return nil
+}
+
+// rotationProofHash is the message a RotateConsensusKey proof signature
+// covers: newPub's coordinates together with epoch, so a captured proof for
+// one candidate key and epoch can't be replayed for another. Hashing with
+// sha256 over a fixed encoding mirrors haltVoteHash/epochSeed elsewhere in
+// this file.
+func rotationProofHash(newPub *ecdsa.PublicKey, epoch uint64) [32]byte {
+	data := elliptic.Marshal(newPub.Curve, newPub.X, newPub.Y)
+	var epochBytes [8]byte
+	binary.BigEndian.PutUint64(epochBytes[:], epoch)
+	data = append(data, epochBytes[:]...)
+	return sha256.Sum256(data)
+}
+
+// RotateConsensusKey replaces the validator control controls' hot
+// ConsensusPubKey with newPub, proofSig must be an ecdsa.SignASN1 signature
+// by the CURRENT ConsensusPubKey's private key over
+// rotationProofHash(newPub, currentEpoch), proving custody of the old key
+// before it's retired. The rotation takes effect at the start of the next
+// epoch (not immediately), so blocks still being finalized in the current
+// epoch continue to verify against the outgoing key; ValidateBlock and
+// verifyDoubleSignEvidence look the right key up per-block via
+// pubKeyForEpoch rather than always reading the validator's current
+// ConsensusPubKey.
+func (pos *PoS) RotateConsensusKey(control core.Address, newPub *ecdsa.PublicKey, proofSig []byte) error {
+	pos.mu.Lock()
+	defer pos.mu.Unlock()
+
+	v, exists := pos.findByControlAddress(control)
+	if !exists {
+		return fmt.Errorf("rotate consensus key: %s does not control any validator", control.Hex())
+	}
+
+	epoch := pos.currentSlot / pos.config.EpochLength
+	proofHash := rotationProofHash(newPub, epoch)
+	if !ecdsa.VerifyASN1(v.ConsensusPubKey, proofHash[:], proofSig) {
+		return fmt.Errorf("rotate consensus key: proof signature does not match the current consensus key")
+	}
+
+	effectiveEpoch := epoch + 1
+	pos.keyHistory[v.Address] = append(pos.keyHistory[v.Address], KeyRotation{EffectiveEpoch: effectiveEpoch, PubKey: newPub})
+	v.ConsensusPubKey = newPub
 
 	return nil
 }
 
+// pubKeyForEpoch returns the ConsensusPubKey that was authoritative for
+// address during epoch, per its keyHistory, and whether one was found.
+// Callers must hold pos.mu.
+func (pos *PoS) pubKeyForEpoch(address core.Address, epoch uint64) (*ecdsa.PublicKey, bool) {
+	history := pos.keyHistory[address]
+	if len(history) == 0 {
+		return nil, false
+	}
+
+	idx := sort.Search(len(history), func(i int) bool { return history[i].EffectiveEpoch > epoch })
+	if idx == 0 {
+		return nil, false
+	}
+	return history[idx-1].PubKey, true
+}
+
 func (pos *PoS) Delegate(delegator, validator core.Address, amount *big.Int) error {
 	pos.mu.Lock()
 	defer pos.mu.Unlock()
@@ -178,6 +442,13 @@ func (pos *PoS) Delegate(delegator, validator core.Address, amount *big.Int) err
 	return nil
 }
 
+// Unbond starts withdrawing amount from delegator's active delegation to
+// validator. Rather than marking the whole delegation Unbonding regardless
+// of amount, it splits off a new Delegation entry holding exactly the
+// unbonded amount (status DelegationStatusUnbonding, UnbondTime = now +
+// UnbondingPeriod) and leaves the remainder on the original entry, still
+// Active and still earning rewards — a partial unbond only ties up the
+// portion actually being withdrawn.
 func (pos *PoS) Unbond(delegator, validator core.Address, amount *big.Int) error {
 	pos.mu.Lock()
 	defer pos.mu.Unlock()
@@ -187,26 +458,38 @@ func (pos *PoS) Unbond(delegator, validator core.Address, amount *big.Int) error
 		return fmt.Errorf("no delegations found")
 	}
 
-	var targetDelegation *Delegation
-	var unbondAmount *big.Int
-
+	var target *Delegation
 	for _, delegation := range delegations {
 		if delegation.Validator == validator && delegation.Status == DelegationStatusActive {
-			targetDelegation = delegation
-			unbondAmount = new(big.Int).Set(delegation.Amount)
-			if amount.Cmp(unbondAmount) < 0 {
-				unbondAmount = amount
-			}
+			target = delegation
 			break
 		}
 	}
-
-	if targetDelegation == nil {
+	if target == nil {
 		return fmt.Errorf("active delegation not found")
 	}
 
-	targetDelegation.Status = DelegationStatusUnbonding
-	targetDelegation.UnbondTime = time.Now().Add(pos.config.UnbondingPeriod)
+	unbondAmount := new(big.Int).Set(amount)
+	if unbondAmount.Cmp(target.Amount) > 0 {
+		unbondAmount = new(big.Int).Set(target.Amount)
+	}
+
+	now := time.Now()
+	unbonding := &Delegation{
+		Delegator:  delegator,
+		Validator:  validator,
+		Amount:     new(big.Int).Set(unbondAmount),
+		Rewards:    big.NewInt(0),
+		StartTime:  target.StartTime,
+		UnbondTime: now.Add(pos.config.UnbondingPeriod),
+		Status:     DelegationStatusUnbonding,
+	}
+	pos.delegators[delegator] = append(pos.delegators[delegator], unbonding)
+
+	target.Amount.Sub(target.Amount, unbondAmount)
+	if target.Amount.Sign() == 0 {
+		target.Status = DelegationStatusCompleted
+	}
 
 	v := pos.validators[validator]
 	v.TotalDelegated.Sub(v.TotalDelegated, unbondAmount)
@@ -215,6 +498,153 @@ func (pos *PoS) Unbond(delegator, validator core.Address, amount *big.Int) error
 	return nil
 }
 
+// Redelegate moves amount of delegator's active delegation from
+// srcValidator directly to dstValidator without waiting out
+// UnbondingPeriod, the way Cosmos SDK staking's redelegation does. The
+// moved amount stays slashable against both validators until
+// RedelegationCompletionTime elapses (see slashLocked's redelegations
+// loop) — against srcValidator for infractions committed before the move,
+// and against dstValidator as an ordinary active delegation already is.
+// RedelegationMaxEntries caps how many such in-flight moves the same
+// delegator can have open against the same (srcValidator, dstValidator)
+// pair at once.
+func (pos *PoS) Redelegate(delegator, srcValidator, dstValidator core.Address, amount *big.Int) error {
+	pos.mu.Lock()
+	defer pos.mu.Unlock()
+
+	if srcValidator == dstValidator {
+		return fmt.Errorf("redelegate: source and destination validator are the same")
+	}
+
+	dst, exists := pos.validators[dstValidator]
+	if !exists {
+		return fmt.Errorf("redelegate: destination validator not found")
+	}
+	if dst.Status != ValidatorStatusActive {
+		return fmt.Errorf("redelegate: destination validator not active")
+	}
+
+	now := time.Now()
+	if pos.countActiveRedelegations(delegator, srcValidator, dstValidator, now) >= pos.config.RedelegationMaxEntries {
+		return fmt.Errorf("redelegate: maximum %d in-flight redelegations already open for this delegator/validator pair", pos.config.RedelegationMaxEntries)
+	}
+
+	var src *Delegation
+	for _, delegation := range pos.delegators[delegator] {
+		if delegation.Validator == srcValidator && delegation.Status == DelegationStatusActive {
+			src = delegation
+			break
+		}
+	}
+	if src == nil {
+		return fmt.Errorf("redelegate: active delegation to source validator not found")
+	}
+	if src.Amount.Cmp(amount) < 0 {
+		return fmt.Errorf("redelegate: insufficient delegated amount")
+	}
+
+	src.Amount.Sub(src.Amount, amount)
+	if src.Amount.Sign() == 0 {
+		src.Status = DelegationStatusCompleted
+	}
+	if srcV, ok := pos.validators[srcValidator]; ok {
+		srcV.TotalDelegated.Sub(srcV.TotalDelegated, amount)
+	}
+
+	dstDelegation := pos.findOrCreateActiveDelegation(delegator, dstValidator)
+	dstDelegation.Amount.Add(dstDelegation.Amount, amount)
+	dst.TotalDelegated.Add(dst.TotalDelegated, amount)
+
+	pos.redelegations = append(pos.redelegations, &RedelegationEntry{
+		Delegator:      delegator,
+		SrcValidator:   srcValidator,
+		DstValidator:   dstValidator,
+		Amount:         new(big.Int).Set(amount),
+		CreationTime:   now,
+		CompletionTime: now.Add(pos.config.RedelegationCompletionTime),
+	})
+
+	return nil
+}
+
+// findOrCreateActiveDelegation returns delegator's active Delegation to
+// validator, creating an empty one if none exists yet. Used by Redelegate
+// to land the moved amount on an existing delegation rather than always
+// opening a new one.
+func (pos *PoS) findOrCreateActiveDelegation(delegator, validator core.Address) *Delegation {
+	for _, delegation := range pos.delegators[delegator] {
+		if delegation.Validator == validator && delegation.Status == DelegationStatusActive {
+			return delegation
+		}
+	}
+
+	delegation := &Delegation{
+		Delegator: delegator,
+		Validator: validator,
+		Amount:    big.NewInt(0),
+		Rewards:   big.NewInt(0),
+		StartTime: time.Now(),
+		Status:    DelegationStatusActive,
+	}
+	pos.delegators[delegator] = append(pos.delegators[delegator], delegation)
+	return delegation
+}
+
+// countActiveRedelegations returns how many of delegator's redelegations
+// from srcValidator to dstValidator haven't reached CompletionTime yet.
+func (pos *PoS) countActiveRedelegations(delegator, srcValidator, dstValidator core.Address, now time.Time) int {
+	count := 0
+	for _, entry := range pos.redelegations {
+		if entry.Delegator == delegator && entry.SrcValidator == srcValidator && entry.DstValidator == dstValidator && now.Before(entry.CompletionTime) {
+			count++
+		}
+	}
+	return count
+}
+
+// GetUnbondingQueue returns every Delegation, across every delegator,
+// currently in DelegationStatusUnbonding.
+func (pos *PoS) GetUnbondingQueue() []*Delegation {
+	pos.mu.RLock()
+	defer pos.mu.RUnlock()
+
+	var queue []*Delegation
+	for _, delegations := range pos.delegators {
+		for _, delegation := range delegations {
+			if delegation.Status == DelegationStatusUnbonding {
+				queue = append(queue, delegation)
+			}
+		}
+	}
+	return queue
+}
+
+// ProcessMatureUnbondings completes every DelegationStatusUnbonding entry
+// whose UnbondTime is at or before now in one pass — the bulk equivalent of
+// a block-applier or timer goroutine calling CompleteUnbonding for each
+// delegator individually. It returns the total principal + rewards
+// released back to delegators across every entry it completed.
+func (pos *PoS) ProcessMatureUnbondings(now time.Time) *big.Int {
+	pos.mu.Lock()
+	defer pos.mu.Unlock()
+
+	released := big.NewInt(0)
+	for _, delegations := range pos.delegators {
+		for _, delegation := range delegations {
+			if delegation.Status != DelegationStatusUnbonding {
+				continue
+			}
+			if now.Before(delegation.UnbondTime) {
+				continue
+			}
+			delegation.Status = DelegationStatusCompleted
+			released.Add(released, delegation.Amount)
+			released.Add(released, delegation.Rewards)
+		}
+	}
+	return released
+}
+
 func (pos *PoS) CompleteUnbonding(delegator, validator core.Address) (*big.Int, error) {
 	pos.mu.Lock()
 	defer pos.mu.Unlock()
@@ -239,70 +669,217 @@ func (pos *PoS) CompleteUnbonding(delegator, validator core.Address) (*big.Int,
 	return nil, fmt.Errorf("unbonding delegation not found")
 }
 
+// SelectValidator returns slot's block proposer from its epoch's signer
+// queue (Snapshot.SignerQueue[slot % len(queue)]), taking the snapshot on
+// demand if UpdateSlot hasn't already taken it at the epoch's first slot.
+// Unlike the old weighted-random draw from crypto/rand, every node that has
+// the same epoch's snapshot computes the same answer, so a block's proposer
+// can be checked rather than merely trusted.
 func (pos *PoS) SelectValidator(slot uint64) core.Address {
-	pos.mu.RLock()
-	defer pos.mu.RUnlock()
+	pos.mu.Lock()
+	defer pos.mu.Unlock()
 
-	activeValidators := pos.getActiveValidators()
-	if len(activeValidators) == 0 {
+	return pos.selectValidatorLocked(slot)
+}
+
+// selectValidatorLocked is SelectValidator's body, split out so callers that
+// already hold pos.mu (ValidateBlock, detectMissedSlots) can reach it
+// without recursively locking. Callers must hold pos.mu.
+func (pos *PoS) selectValidatorLocked(slot uint64) core.Address {
+	snapshot := pos.snapshotForEpoch(slot / pos.config.EpochLength)
+	if len(snapshot.SignerQueue) == 0 {
 		return core.Address{}
 	}
+	return snapshot.SignerQueue[slot%uint64(len(snapshot.SignerQueue))]
+}
 
-	// Use weighted random selection based on stake
-	totalWeight := big.NewInt(0)
-	weights := make([]*big.Int, len(activeValidators))
+// snapshotForEpoch returns epoch's Snapshot, taking and caching it first if
+// this is the first lookup for that epoch. Callers must hold pos.mu.
+func (pos *PoS) snapshotForEpoch(epoch uint64) *Snapshot {
+	if snapshot, ok := pos.snapshots[epoch]; ok {
+		return snapshot
+	}
+	return pos.takeSnapshot(epoch)
+}
 
-	for i, validator := range activeValidators {
-		weight := new(big.Int).Add(validator.Stake, validator.TotalDelegated)
-		weights[i] = weight
-		totalWeight.Add(totalWeight, weight)
+// takeSnapshot freezes the currently active validator set into a Snapshot
+// for epoch: the top MaxValidators active validators ranked by
+// Stake+TotalDelegated (ties broken by address, so the ranking is the same
+// on every node regardless of map iteration order), and a signer queue
+// derived deterministically from them. Callers must hold pos.mu.
+func (pos *PoS) takeSnapshot(epoch uint64) *Snapshot {
+	active := pos.getActiveValidators()
+	sort.Slice(active, func(i, j int) bool {
+		totalI := new(big.Int).Add(active[i].Stake, active[i].TotalDelegated)
+		totalJ := new(big.Int).Add(active[j].Stake, active[j].TotalDelegated)
+		if cmp := totalI.Cmp(totalJ); cmp != 0 {
+			return cmp > 0
+		}
+		return bytes.Compare(active[i].Address.Bytes(), active[j].Address.Bytes()) < 0
+	})
+
+	topN := active
+	if pos.config.MaxValidators < len(topN) {
+		topN = topN[:pos.config.MaxValidators]
 	}
 
-	if totalWeight.Cmp(big.NewInt(0)) == 0 {
-		return core.Address{}
+	seed := epochSeed(pos.lastSnapshotHash, epoch)
+	snapshot := &Snapshot{
+		Epoch:       epoch,
+		Validators:  topN,
+		SignerQueue: deriveSignerQueue(seed, topN),
+		Hash:        seed,
 	}
 
-	// Generate random number
-	randBytes := make([]byte, 32)
-	rand.Read(randBytes)
-	randNum := new(big.Int).SetBytes(randBytes)
-	randNum.Mod(randNum, totalWeight)
+	pos.lastSnapshotHash = seed
+	pos.snapshots[epoch] = snapshot
+	pos.snapshotOrder = append(pos.snapshotOrder, epoch)
+	pos.pruneSnapshots()
+
+	return snapshot
+}
+
+// pruneSnapshots evicts the oldest cached Snapshot until at most
+// epochSnapshotHistoryLimit remain. Callers must hold pos.mu.
+func (pos *PoS) pruneSnapshots() {
+	for len(pos.snapshotOrder) > epochSnapshotHistoryLimit {
+		oldest := pos.snapshotOrder[0]
+		pos.snapshotOrder = pos.snapshotOrder[1:]
+		delete(pos.snapshots, oldest)
+	}
+}
+
+// epochSeed derives epoch's signer-queue PRNG seed by hashing the previous
+// epoch's seed together with the epoch number, chaining every epoch's
+// ordering back to genesis. No other input enters the chain, so each seed
+// is computable in advance from the epoch numbers alone.
+func epochSeed(prevHash [32]byte, epoch uint64) [32]byte {
+	var epochBytes [8]byte
+	binary.BigEndian.PutUint64(epochBytes[:], epoch)
+	data := append(append([]byte{}, prevHash[:]...), epochBytes[:]...)
+	return sha256.Sum256(data)
+}
+
+// deriveSignerQueue turns topN into a signer queue by seeding a PRNG from
+// seed and repeatedly drawing a validator weighted by Stake+TotalDelegated
+// (with replacement), discarding repeats, until every validator in topN has
+// been placed — a weighted Fisher-Yates-style shuffle using seed as the only
+// source of randomness, so any node holding topN and seed reproduces the
+// same queue.
+func deriveSignerQueue(seed [32]byte, topN []*Validator) []core.Address {
+	if len(topN) == 0 {
+		return nil
+	}
+
+	rng := mathrand.New(mathrand.NewSource(int64(binary.BigEndian.Uint64(seed[:8]))))
+
+	weights := make([]*big.Int, len(topN))
+	total := big.NewInt(0)
+	for i, v := range topN {
+		weight := new(big.Int).Add(v.Stake, v.TotalDelegated)
+		weights[i] = weight
+		total.Add(total, weight)
+	}
+	if total.Cmp(big.NewInt(0)) == 0 {
+		return nil
+	}
 
-	// Select validator
-	cumulative := big.NewInt(0)
-	for i, validator := range activeValidators {
-		cumulative.Add(cumulative, weights[i])
-		if randNum.Cmp(cumulative) < 0 {
-			return validator.Address
+	queue := make([]core.Address, 0, len(topN))
+	seen := make(map[core.Address]bool, len(topN))
+	for len(queue) < len(topN) {
+		draw := new(big.Int).Rand(rng, total)
+		cumulative := big.NewInt(0)
+		for i, weight := range weights {
+			cumulative.Add(cumulative, weight)
+			if draw.Cmp(cumulative) < 0 {
+				if addr := topN[i].Address; !seen[addr] {
+					seen[addr] = true
+					queue = append(queue, addr)
+				}
+				break
+			}
 		}
 	}
+	return queue
+}
+
+// GetSnapshot returns the cached Snapshot for epoch, if one has been taken
+// and not yet evicted, and whether it was found.
+func (pos *PoS) GetSnapshot(epoch uint64) (*Snapshot, bool) {
+	pos.mu.RLock()
+	defer pos.mu.RUnlock()
 
-	return activeValidators[0].Address
+	snapshot, ok := pos.snapshots[epoch]
+	return snapshot, ok
 }
 
-func (pos *PoS) ValidateBlock(block *core.Block) error {
+// SelectProposer picks block height's leader deterministically from a
+// beacon entry already verified by BlockValidator: hashing the entry's
+// randomness together with parentHash and height, then reducing mod the
+// active validator set's size. Every honest node computes the same
+// hash from the same inputs and agrees on the same proposer — and since the
+// beacon entry was fixed before height was known to need it, no validator
+// can grind on which entry it gets to try to land the slot.
+func (pos *PoS) SelectProposer(entry beacon.BeaconEntry, parentHash common.Hash, height uint64) core.Address {
 	pos.mu.RLock()
 	defer pos.mu.RUnlock()
 
+	active := pos.getActiveValidators()
+	if len(active) == 0 {
+		return core.Address{}
+	}
+
+	// Sorted so every node hashes the same ordering regardless of the
+	// active-validator map's iteration order.
+	sort.Slice(active, func(i, j int) bool {
+		return bytes.Compare(active[i].Address.Bytes(), active[j].Address.Bytes()) < 0
+	})
+
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, height)
+
+	data := append([]byte{}, entry.Randomness()...)
+	data = append(data, parentHash.Bytes()...)
+	data = append(data, heightBytes...)
+
+	digest := crypto.Keccak256(data)
+	index := new(big.Int).Mod(new(big.Int).SetBytes(digest), big.NewInt(int64(len(active))))
+
+	return active[index.Int64()].Address
+}
+
+func (pos *PoS) ValidateBlock(block *core.Block) error {
+	pos.mu.Lock()
+	defer pos.mu.Unlock()
+
+	if pos.haltHeight != 0 && block.Header().Number.Uint64() >= pos.haltHeight {
+		return fmt.Errorf("chain halted at height %d by validator vote: block %d rejected", pos.haltHeight, block.Header().Number.Uint64())
+	}
+
 	// Check if block is signed by current validator
-	expectedValidator := pos.SelectValidator(block.Header.Number.Uint64())
-	if !bytes.Equal(block.Header.Validator.Bytes(), expectedValidator.Bytes()) {
-		return fmt.Errorf("invalid validator: expected %s, got %s", 
-			expectedValidator.Hex(), block.Header.Validator.Hex())
+	expectedValidator := pos.selectValidatorLocked(block.Header().Number.Uint64())
+	if !bytes.Equal(block.Header().Validator.Bytes(), expectedValidator.Bytes()) {
+		return fmt.Errorf("invalid validator: expected %s, got %s",
+			expectedValidator.Hex(), block.Header().Validator.Hex())
 	}
 
 	// Verify signature
-	if len(block.Header.Signature) == 0 {
+	if len(block.Header().Signature) == 0 {
 		return fmt.Errorf("block signature missing")
 	}
 
-	validator, exists := pos.validators[block.Header.Validator]
-	if !exists {
+	if _, exists := pos.validators[block.Header().Validator]; !exists {
 		return fmt.Errorf("validator not found")
 	}
 
+	epoch := block.Header().Number.Uint64() / pos.config.EpochLength
+	pubkey, ok := pos.pubKeyForEpoch(block.Header().Validator, epoch)
+	if !ok {
+		return fmt.Errorf("no consensus key on record for validator %s at epoch %d", block.Header().Validator.Hex(), epoch)
+	}
+
 	hash := block.ComputeHash()
-	if !ecdsa.VerifyASN1(validator.PublicKey, hash.Bytes(), block.Header.Signature) {
+	if !ecdsa.VerifyASN1(pubkey, hash.Bytes(), block.Header().Signature) {
 		return fmt.Errorf("invalid block signature")
 	}
 
@@ -327,7 +904,7 @@ func (pos *PoS) UpdateBlock(block *core.Block) {
 	pos.mu.Lock()
 	defer pos.mu.Unlock()
 
-	validator, exists := pos.validators[block.Header.Validator]
+	validator, exists := pos.validators[block.Header().Validator]
 	if !exists {
 		return
 	}
@@ -335,32 +912,36 @@ func (pos *PoS) UpdateBlock(block *core.Block) {
 	validator.LastActive = time.Now()
 	validator.TotalBlocks++
 
+	// Record that this slot was actually proposed, so detectMissedSlots
+	// doesn't count it against whoever was expected to propose it.
+	pos.signedSlots[block.Header().Number.Uint64()] = block.Header().Validator
+
 	// Calculate and distribute rewards
 	pos.distributeRewards(block)
 }
 
 func (pos *PoS) distributeRewards(block *core.Block) {
 	blockReward := new(big.Int).Set(pos.config.RewardRate)
-	validator := pos.validators[block.Header.Validator]
-	
+	validator := pos.validators[block.Header().Validator]
+
 	// Calculate commission
 	commission := new(big.Int).Mul(blockReward, big.NewInt(int64(validator.Commission)))
 	commission.Div(commission, big.NewInt(10000))
-	
+
 	// Validator reward
-	validatorReward := new(big.Int).Add(commission, 
+	validatorReward := new(big.Int).Add(commission,
 		new(big.Int).Mul(blockReward, big.NewInt(int64(validator.Stake.Uint64()))))
 	validatorReward.Div(validatorReward, new(big.Int).Add(validator.Stake, validator.TotalDelegated))
-	
+
 	validator.Rewards.Add(validator.Rewards, validatorReward)
-	
+
 	// Delegator rewards
 	if validator.TotalDelegated.Cmp(big.NewInt(0)) > 0 {
 		delegatorReward := new(big.Int).Sub(blockReward, commission)
-		delegatorReward.Sub(delegatorReward, new(big.Int).Mul(validatorReward, 
+		delegatorReward.Sub(delegatorReward, new(big.Int).Mul(validatorReward,
 			new(big.Int).Sub(new(big.Int).Add(validator.Stake, validator.TotalDelegated), validator.Stake)))
 		delegatorReward.Div(delegatorReward, validator.TotalDelegated)
-		
+
 		pos.distributeDelegatorRewards(validator.Address, delegatorReward)
 	}
 }
@@ -377,6 +958,142 @@ func (pos *PoS) distributeDelegatorRewards(validator core.Address, reward *big.I
 	}
 }
 
+// Evidence is a verifiable claim that a validator misbehaved, submitted
+// through SubmitEvidence for slashing. The two concrete types below cover
+// the only misbehavior this package currently knows how to verify on its
+// own: signing two different blocks at the same height, and failing to
+// propose enough of its expected slots.
+type Evidence interface {
+	// offender returns the validator the evidence accuses.
+	offender() core.Address
+}
+
+// DoubleSignEvidence accuses the validator who signed BlockA of also
+// signing BlockB, a different block at the same Height — proof they
+// violated the one-proposal-per-slot rule SelectValidator enforces.
+type DoubleSignEvidence struct {
+	Height uint64
+	BlockA *core.Block
+	BlockB *core.Block
+}
+
+func (e *DoubleSignEvidence) offender() core.Address {
+	return e.BlockA.Header().Validator
+}
+
+// DowntimeEvidence accuses Validator of missing MissedSlots, a run of slots
+// they were the expected proposer for but didn't produce. recordMissedSlot
+// auto-generates and submits this once the tracked miss count crosses
+// SignedBlocksWindow*MinSignedPerWindow; nothing else in this package
+// constructs one.
+type DowntimeEvidence struct {
+	Validator   core.Address
+	MissedSlots []uint64
+}
+
+func (e *DowntimeEvidence) offender() core.Address {
+	return e.Validator
+}
+
+// SlashEvent records one slashing outcome — evidence-driven or from a
+// direct Slash call — so it can be queried later by height or validator.
+type SlashEvent struct {
+	Validator core.Address
+	Height    uint64
+	Amount    *big.Int
+	Reason    string
+	Timestamp time.Time
+}
+
+// SubmitEvidence verifies ev against current validator state and, if valid,
+// slashes the accused validator (see slashLocked). It returns an error
+// without slashing anyone if ev doesn't hold up.
+func (pos *PoS) SubmitEvidence(ev Evidence) error {
+	pos.mu.Lock()
+	defer pos.mu.Unlock()
+
+	switch e := ev.(type) {
+	case *DoubleSignEvidence:
+		return pos.verifyDoubleSignEvidence(e)
+	case *DowntimeEvidence:
+		return pos.verifyDowntimeEvidence(e)
+	default:
+		return fmt.Errorf("consensus: unknown evidence type %T", ev)
+	}
+}
+
+// verifyDoubleSignEvidence confirms BlockA and BlockB are two different
+// blocks at the claimed Height, both signed by the same validator's
+// ConsensusPubKey at that height's epoch, before slashing that validator.
+// Callers must hold pos.mu.
+func (pos *PoS) verifyDoubleSignEvidence(e *DoubleSignEvidence) error {
+	if e.BlockA == nil || e.BlockB == nil {
+		return fmt.Errorf("double-sign evidence: both blocks are required")
+	}
+	if e.BlockA.Header().Number.Uint64() != e.Height || e.BlockB.Header().Number.Uint64() != e.Height {
+		return fmt.Errorf("double-sign evidence: both blocks must be at height %d", e.Height)
+	}
+
+	address := e.BlockA.Header().Validator
+	if e.BlockB.Header().Validator != address {
+		return fmt.Errorf("double-sign evidence: blocks were signed by different validators")
+	}
+
+	hashA, hashB := e.BlockA.ComputeHash(), e.BlockB.ComputeHash()
+	if hashA == hashB {
+		return fmt.Errorf("double-sign evidence: blocks are identical")
+	}
+
+	v, exists := pos.validators[address]
+	if !exists {
+		return fmt.Errorf("double-sign evidence: validator not found")
+	}
+
+	epoch := e.Height / pos.config.EpochLength
+	pubkey, ok := pos.pubKeyForEpoch(address, epoch)
+	if !ok {
+		return fmt.Errorf("double-sign evidence: no consensus key on record for validator at epoch %d", epoch)
+	}
+
+	if !ecdsa.VerifyASN1(pubkey, hashA.Bytes(), e.BlockA.Header().Signature) {
+		return fmt.Errorf("double-sign evidence: block A signature invalid")
+	}
+	if !ecdsa.VerifyASN1(pubkey, hashB.Bytes(), e.BlockB.Header().Signature) {
+		return fmt.Errorf("double-sign evidence: block B signature invalid")
+	}
+
+	return pos.slashLocked(v, fmt.Sprintf("double-sign at height %d", e.Height))
+}
+
+// verifyDowntimeEvidence confirms every slot in MissedSlots really did
+// expect Validator as proposer (selectValidatorLocked) and that the count
+// exceeds the configured threshold, before slashing. It can't independently
+// confirm the slots actually went unproduced — this package keeps no
+// durable per-slot proposal log — so it trusts the caller's claim the same
+// way recordMissedSlot's self-submission does. Callers must hold pos.mu.
+func (pos *PoS) verifyDowntimeEvidence(e *DowntimeEvidence) error {
+	threshold := uint64(float64(pos.config.SignedBlocksWindow) * pos.config.MinSignedPerWindow)
+	if uint64(len(e.MissedSlots)) <= threshold {
+		return fmt.Errorf("downtime evidence: %d missed slots does not exceed threshold %d", len(e.MissedSlots), threshold)
+	}
+
+	v, exists := pos.validators[e.Validator]
+	if !exists {
+		return fmt.Errorf("downtime evidence: validator not found")
+	}
+
+	for _, slot := range e.MissedSlots {
+		if expected := pos.selectValidatorLocked(slot); expected != e.Validator {
+			return fmt.Errorf("downtime evidence: validator was not the expected proposer for slot %d", slot)
+		}
+	}
+
+	return pos.slashLocked(v, fmt.Sprintf("downtime: missed %d of the last %d slots", len(e.MissedSlots), pos.config.SignedBlocksWindow))
+}
+
+// Slash directly slashes validator for reason, for callers (e.g.
+// governance) that have already established misbehavior through some means
+// other than Evidence. It produces the same outcome SubmitEvidence does.
 func (pos *PoS) Slash(validator core.Address, reason string) error {
 	pos.mu.Lock()
 	defer pos.mu.Unlock()
@@ -386,24 +1103,246 @@ func (pos *PoS) Slash(validator core.Address, reason string) error {
 		return fmt.Errorf("validator not found")
 	}
 
-	if v.Status == ValidatorStatusSlashed {
-		return fmt.Errorf("validator already slashed")
+	return pos.slashLocked(v, reason)
+}
+
+// slashLocked cuts v's self-stake and every active delegation against it by
+// SlashRate, moves each slashed delegation into DelegationStatusUnbonding
+// (UnbondTime = now + UnbondingPeriod) so delegators can still withdraw the
+// post-slash remainder, tombstones v, and appends a SlashEvent. Callers must
+// hold pos.mu.
+func (pos *PoS) slashLocked(v *Validator, reason string) error {
+	if v.Status == ValidatorStatusTombstoned {
+		return fmt.Errorf("validator already tombstoned")
+	}
+
+	selfSlash := new(big.Int).Mul(big.NewInt(int64(pos.config.SlashRate)), v.Stake)
+	selfSlash.Div(selfSlash, big.NewInt(10000))
+	v.Stake.Sub(v.Stake, selfSlash)
+	pos.totalStake.Sub(pos.totalStake, selfSlash)
+	totalSlashed := new(big.Int).Set(selfSlash)
+
+	now := time.Now()
+	for _, delegations := range pos.delegators {
+		for _, delegation := range delegations {
+			if delegation.Validator != v.Address || delegation.Status != DelegationStatusActive {
+				continue
+			}
+
+			delegationSlash := new(big.Int).Mul(big.NewInt(int64(pos.config.SlashRate)), delegation.Amount)
+			delegationSlash.Div(delegationSlash, big.NewInt(10000))
+
+			delegation.Amount.Sub(delegation.Amount, delegationSlash)
+			v.TotalDelegated.Sub(v.TotalDelegated, delegationSlash)
+			pos.totalStake.Sub(pos.totalStake, delegationSlash)
+			totalSlashed.Add(totalSlashed, delegationSlash)
+
+			delegation.Status = DelegationStatusUnbonding
+			delegation.UnbondTime = now.Add(pos.config.UnbondingPeriod)
+		}
+	}
+
+	// A delegation redelegated away from v less than
+	// RedelegationCompletionTime ago is still slashable against v for this
+	// infraction, even though the funds now sit in an active delegation to
+	// the destination validator.
+	for _, entry := range pos.redelegations {
+		if entry.SrcValidator != v.Address || !now.Before(entry.CompletionTime) {
+			continue
+		}
+
+		redelegationSlash := new(big.Int).Mul(big.NewInt(int64(pos.config.SlashRate)), entry.Amount)
+		redelegationSlash.Div(redelegationSlash, big.NewInt(10000))
+		if redelegationSlash.Sign() == 0 {
+			continue
+		}
+		entry.Amount.Sub(entry.Amount, redelegationSlash)
+
+		if dst, exists := pos.validators[entry.DstValidator]; exists {
+			dst.TotalDelegated.Sub(dst.TotalDelegated, redelegationSlash)
+		}
+		pos.totalStake.Sub(pos.totalStake, redelegationSlash)
+		totalSlashed.Add(totalSlashed, redelegationSlash)
+
+		for _, delegation := range pos.delegators[entry.Delegator] {
+			if delegation.Validator == entry.DstValidator && delegation.Status == DelegationStatusActive {
+				delegation.Amount.Sub(delegation.Amount, redelegationSlash)
+				break
+			}
+		}
+	}
+
+	v.Status = ValidatorStatusTombstoned
+
+	pos.slashEvents = append(pos.slashEvents, SlashEvent{
+		Validator: v.Address,
+		Height:    pos.currentSlot,
+		Amount:    totalSlashed,
+		Reason:    reason,
+		Timestamp: now,
+	})
+
+	return nil
+}
+
+// GetSlashEvents returns every recorded SlashEvent against validator.
+func (pos *PoS) GetSlashEvents(validator core.Address) []SlashEvent {
+	pos.mu.RLock()
+	defer pos.mu.RUnlock()
+
+	var events []SlashEvent
+	for _, e := range pos.slashEvents {
+		if e.Validator == validator {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// GetSlashEventsAtHeight returns every recorded SlashEvent at height.
+func (pos *PoS) GetSlashEventsAtHeight(height uint64) []SlashEvent {
+	pos.mu.RLock()
+	defer pos.mu.RUnlock()
+
+	var events []SlashEvent
+	for _, e := range pos.slashEvents {
+		if e.Height == height {
+			events = append(events, e)
+		}
 	}
+	return events
+}
+
+// haltVoteHash is the message a halt vote's signature covers: just height,
+// the same way epochSeed/SelectProposer hash a fixed-width big-endian
+// encoding rather than a human-readable string, so every node hashes
+// identical bytes regardless of how the vote was constructed.
+func haltVoteHash(height uint64) [32]byte {
+	var heightBytes [8]byte
+	binary.BigEndian.PutUint64(heightBytes[:], height)
+	return sha256.Sum256(heightBytes[:])
+}
 
-	// Calculate slash amount
-	slashAmount := new(big.Int).Mul(pos.config.SlashRate, new(big.Int).Add(v.Stake, v.TotalDelegated))
-	slashAmount.Div(slashAmount, big.NewInt(1000)) // Divide by 1000 to get percentage
+// SubmitHaltVote records address's vote to halt the chain at height, the way
+// Minter's SetHaltBlock lets validators coordinate a safe stop for an
+// emergency upgrade without a hard fork. sig must be an ecdsa.SignASN1
+// signature by validator's ConsensusPubKey over haltVoteHash(height), the
+// same scheme SignBlock/ValidateBlock use for block signatures. Only a
+// currently active validator's vote counts.
+func (pos *PoS) SubmitHaltVote(validator core.Address, height uint64, sig []byte) error {
+	pos.mu.Lock()
+	defer pos.mu.Unlock()
+
+	v, exists := pos.validators[validator]
+	if !exists {
+		return fmt.Errorf("halt vote: validator not found")
+	}
+	if v.Status != ValidatorStatusActive {
+		return fmt.Errorf("halt vote: validator not active")
+	}
 
-	// Remove stake
-	v.Stake.Sub(v.Stake, slashAmount)
-	pos.totalStake.Sub(pos.totalStake, slashAmount)
+	hash := haltVoteHash(height)
+	if !ecdsa.VerifyASN1(v.ConsensusPubKey, hash[:], sig) {
+		return fmt.Errorf("halt vote: invalid signature")
+	}
 
-	// Set status
-	v.Status = ValidatorStatusSlashed
+	if pos.haltVotes[height] == nil {
+		pos.haltVotes[height] = make(map[core.Address]bool)
+	}
+	pos.haltVotes[height][validator] = true
 
+	pos.recomputeHaltHeightLocked(height)
 	return nil
 }
 
+// recomputeHaltHeightLocked re-tallies height's votes against the active
+// validator set's combined Stake+TotalDelegated and, if the voting
+// validators now exceed 2/3 of it, records height as a new (possibly
+// earlier) haltHeight. It never un-halts a height that previously crossed
+// quorum, even if a later call recomputes a smaller tally for it (e.g. a
+// voter getting slashed out of the active set) — once 2/3 of the network
+// has agreed to stop, that agreement doesn't need to be re-confirmed.
+// Callers must hold pos.mu.
+func (pos *PoS) recomputeHaltHeightLocked(height uint64) {
+	votes := pos.haltVotes[height]
+
+	activeTotal := big.NewInt(0)
+	voteTotal := big.NewInt(0)
+	for _, v := range pos.getActiveValidators() {
+		weight := new(big.Int).Add(v.Stake, v.TotalDelegated)
+		activeTotal.Add(activeTotal, weight)
+		if votes[v.Address] {
+			voteTotal.Add(voteTotal, weight)
+		}
+	}
+	if activeTotal.Sign() == 0 {
+		return
+	}
+
+	// voteTotal*3 > activeTotal*2 tests voteTotal > 2/3*activeTotal without
+	// the rounding a big.Int division would introduce.
+	lhs := new(big.Int).Mul(voteTotal, big.NewInt(3))
+	rhs := new(big.Int).Mul(activeTotal, big.NewInt(2))
+	if lhs.Cmp(rhs) <= 0 {
+		return
+	}
+
+	if pos.haltHeight == 0 || height < pos.haltHeight {
+		pos.haltHeight = height
+	}
+}
+
+// ShouldHaltAt reports whether height is at or past a height that has
+// reached >2/3-stake support to halt, per SubmitHaltVote.
+func (pos *PoS) ShouldHaltAt(height uint64) bool {
+	pos.mu.RLock()
+	defer pos.mu.RUnlock()
+
+	return pos.haltHeight != 0 && height >= pos.haltHeight
+}
+
+// GetHaltVotes returns the addresses that have voted to halt at height.
+func (pos *PoS) GetHaltVotes(height uint64) []core.Address {
+	pos.mu.RLock()
+	defer pos.mu.RUnlock()
+
+	var voters []core.Address
+	for addr := range pos.haltVotes[height] {
+		voters = append(voters, addr)
+	}
+	return voters
+}
+
+// ApplyDeposit consumes one execution-layer validator deposit (EIP-6110
+// style) and registers a new validator from it, or tops up stake if the
+// derived address is already registered. This is the only path that creates
+// validators: there is no separate off-chain staking flow to keep in sync.
+func (pos *PoS) ApplyDeposit(dep *core.Deposit) error {
+	address := core.BytesToAddress(crypto.Keccak256(dep.PublicKey))
+	amount := new(big.Int).SetUint64(dep.Amount)
+
+	pos.mu.Lock()
+	existing, registered := pos.validators[address]
+	pos.mu.Unlock()
+
+	if registered {
+		pos.mu.Lock()
+		existing.Stake.Add(existing.Stake, amount)
+		pos.totalStake.Add(pos.totalStake, amount)
+		pos.mu.Unlock()
+		return nil
+	}
+
+	pubkey, err := crypto.DecompressPubkey(dep.PublicKey)
+	if err != nil {
+		// Withdrawal-credential-only deposits (no usable pubkey yet) just
+		// top up stake once the validator is registered by a later deposit.
+		return fmt.Errorf("deposit %d: cannot derive public key: %w", dep.Index, err)
+	}
+
+	return pos.RegisterValidator(address, amount, pos.config.CommissionRate, pubkey)
+}
+
 func (pos *PoS) GetValidators() []*Validator {
 	pos.mu.RLock()
 	defer pos.mu.RUnlock()
@@ -423,6 +1362,31 @@ func (pos *PoS) GetValidators() []*Validator {
 	return validators
 }
 
+// Validators implements core.Consensus: the same validator set GetValidators
+// returns, narrowed to the core-level ValidatorInfo view so callers outside
+// this package (the API server, via core.Blockchain.GetValidators) don't
+// need the concrete *Validator type to read it.
+func (pos *PoS) Validators() []core.ValidatorInfo {
+	validators := pos.GetValidators()
+	infos := make([]core.ValidatorInfo, len(validators))
+	for i, v := range validators {
+		infos[i] = core.ValidatorInfo{
+			Address:         v.Address,
+			Stake:           v.Stake,
+			TotalDelegated:  v.TotalDelegated,
+			Commission:      v.Commission,
+			Status:          int(v.Status),
+			LastActive:      v.LastActive,
+			TotalBlocks:     v.TotalBlocks,
+			Rewards:         v.Rewards,
+			OperatorAddress: v.OperatorAddress,
+			ControlAddress:  v.ControlAddress,
+			MissedBlocks:    v.MissedBlocks,
+		}
+	}
+	return infos
+}
+
 func (pos *PoS) GetDelegations(delegator core.Address) []*Delegation {
 	pos.mu.RLock()
 	defer pos.mu.RUnlock()
@@ -457,17 +1421,78 @@ func (pos *PoS) isCurrentValidator(address core.Address) bool {
 	return false
 }
 
+// UpdateSlot advances the current slot. At each epoch's first slot
+// (slot % EpochLength == 0) it also takes that epoch's Snapshot up front,
+// so SelectValidator and ValidateBlock hit an already-cached snapshot
+// instead of taking one lazily on first use, then runs detectMissedSlots
+// over every slot passed since the last call.
 func (pos *PoS) UpdateSlot(slot uint64) {
 	pos.mu.Lock()
 	defer pos.mu.Unlock()
-	
+
 	pos.currentSlot = slot
+	if slot%pos.config.EpochLength == 0 {
+		epoch := slot / pos.config.EpochLength
+		pos.snapshotForEpoch(epoch)
+		pos.recordValidatorSetHistory(slot, epoch)
+	}
+	pos.detectMissedSlots(slot)
+}
+
+// detectMissedSlots walks every slot in [lastCheckedSlot, upTo), and for
+// each one whose expected proposer (selectValidatorLocked) didn't actually
+// produce it (per signedSlots), records a miss against them. Callers must
+// hold pos.mu.
+func (pos *PoS) detectMissedSlots(upTo uint64) {
+	for slot := pos.lastCheckedSlot; slot < upTo; slot++ {
+		expected := pos.selectValidatorLocked(slot)
+		proposer, signed := pos.signedSlots[slot]
+		delete(pos.signedSlots, slot)
+
+		if expected == (core.Address{}) {
+			continue
+		}
+		if signed && proposer == expected {
+			continue
+		}
+		pos.recordMissedSlot(expected, slot)
+	}
+	pos.lastCheckedSlot = upTo
+}
+
+// recordMissedSlot appends slot to validator's missed-slot window, trims it
+// to SignedBlocksWindow entries, and — once the window holds more misses
+// than SignedBlocksWindow*MinSignedPerWindow — auto-submits DowntimeEvidence
+// against them. Callers must hold pos.mu.
+func (pos *PoS) recordMissedSlot(validator core.Address, slot uint64) {
+	v, exists := pos.validators[validator]
+	if !exists {
+		return
+	}
+
+	missed := append(pos.missedSlots[validator], slot)
+	if window := pos.config.SignedBlocksWindow; window > 0 && uint64(len(missed)) > window {
+		missed = missed[uint64(len(missed))-window:]
+	}
+	pos.missedSlots[validator] = missed
+	v.MissedBlocks = uint64(len(missed))
+
+	threshold := uint64(float64(pos.config.SignedBlocksWindow) * pos.config.MinSignedPerWindow)
+	if uint64(len(missed)) <= threshold {
+		return
+	}
+
+	evidence := &DowntimeEvidence{Validator: validator, MissedSlots: append([]uint64{}, missed...)}
+	if err := pos.verifyDowntimeEvidence(evidence); err == nil {
+		delete(pos.missedSlots, validator)
+		v.MissedBlocks = 0
+	}
 }
 
 func (pos *PoS) GetCurrentSlot() uint64 {
 	pos.mu.RLock()
 	defer pos.mu.RUnlock()
-	
+
 	return pos.currentSlot
 }
 
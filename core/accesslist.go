@@ -0,0 +1,30 @@
+package core
+
+import "github.com/ethereum/go-ethereum/common"
+
+// AccessTuple is one entry of an EIP-2930 access list: an address the
+// transaction declares it will touch, plus the specific storage slots of
+// that address it will read or write. Declaring these up front lets a
+// typed transaction pay a flat, known gas cost for the first access to each
+// instead of the variable cold-access surcharge an undeclared touch would
+// otherwise incur.
+type AccessTuple struct {
+	Address     common.Address
+	StorageKeys []common.Hash
+}
+
+// AccessList is the ordered set of AccessTuple entries an AccessListTxType
+// or DynamicFeeTxType transaction carries. A LegacyTxType transaction has
+// none; its field is always nil.
+type AccessList []AccessTuple
+
+// StorageKeys returns the total number of storage slots al declares across
+// every address, the quantity the gas schedule actually charges for (each
+// address is charged once regardless of how many slots it lists).
+func (al AccessList) StorageKeys() int {
+	var n int
+	for _, tuple := range al {
+		n += len(tuple.StorageKeys)
+	}
+	return n
+}
@@ -0,0 +1,434 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/DioraFund/diora-go-blockchain/vm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// GetValidators returns the current validator set maintained by the
+// consensus engine.
+func (bc *Blockchain) GetValidators() []ValidatorInfo {
+	return bc.consensus.Validators()
+}
+
+// GetChainID returns the chain ID this blockchain was configured with.
+func (bc *Blockchain) GetChainID() *big.Int {
+	return bc.config.ChainID
+}
+
+// MinGasPrice returns the minimum gas price accepted by the mempool.
+func (bc *Blockchain) MinGasPrice() *big.Int {
+	return bc.config.MinGasPrice
+}
+
+// CallContract executes a read-only message call against the current state
+// without creating a transaction or mutating the chain. It backs eth_call.
+func (bc *Blockchain) CallContract(from, to Address, data []byte) ([]byte, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	return bc.vm.CallUnmetered(from, to, big.NewInt(0), data)
+}
+
+// CallMsg describes a message eth_call or eth_estimateGas runs against the
+// current state without creating a transaction, mirroring go-ethereum's
+// ethereum.CallMsg. Call itself prices neither GasPrice nor AccessList.
+// EstimateGas folds AccessList into the intrinsic gas floor it searches
+// from; GasPrice is not read by either.
+type CallMsg struct {
+	From       Address
+	To         Address
+	Value      *big.Int
+	Data       []byte
+	GasPrice   *big.Int
+	AccessList AccessList
+}
+
+// revertSelector is the first four bytes of keccak256("Error(string)"), the
+// selector Solidity's require()/revert("reason") encodes a revert reason
+// behind.
+var revertSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+
+// decodeRevertReason decodes data as an ABI-encoded Error(string) revert
+// reason, returning an error describing it. If data doesn't start with
+// revertSelector (a custom error, or a REVERT with no reason at all), it
+// falls back to reporting the raw bytes.
+func decodeRevertReason(data []byte) error {
+	if len(data) < len(revertSelector) || !bytes.Equal(data[:len(revertSelector)], revertSelector) {
+		if len(data) == 0 {
+			return fmt.Errorf("execution reverted")
+		}
+		return fmt.Errorf("execution reverted: 0x%s", hex.EncodeToString(data))
+	}
+
+	// Error(string) ABI-encodes as selector(4) + offset(32) + length(32) +
+	// the string's bytes, right-padded to a multiple of 32.
+	payload := data[len(revertSelector):]
+	if len(payload) < 64 {
+		return fmt.Errorf("execution reverted: malformed Error(string) payload")
+	}
+	length := new(big.Int).SetBytes(payload[32:64]).Uint64()
+	if uint64(len(payload)) < 64+length {
+		return fmt.Errorf("execution reverted: malformed Error(string) payload")
+	}
+	return fmt.Errorf("execution reverted: %s", string(payload[64:64+length]))
+}
+
+// EstimateGas finds the smallest gas limit msg can run with without
+// running out of gas, the way go-ethereum's eth_estimateGas does: it runs
+// msg once at the block gas limit, and if that reverts there is no amount
+// of gas that would help, so it decodes and returns the revert reason
+// immediately. Otherwise it binary-searches between the intrinsic gas msg
+// owes before the EVM runs a single instruction and the block gas limit
+// for the lowest value that still succeeds. It backs eth_estimateGas.
+func (bc *Blockchain) EstimateGas(ctx context.Context, msg CallMsg) (uint64, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	floor, err := IntrinsicGas(msg.Data, msg.AccessList)
+	if err != nil {
+		return 0, err
+	}
+
+	value := msg.Value
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	cap := bc.config.GasLimit
+	if floor > cap {
+		return 0, fmt.Errorf("intrinsic gas %d exceeds block gas limit %d", floor, cap)
+	}
+
+	runs := func(gas uint64) (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		_, _, err := bc.vm.Call(msg.From, msg.To, value, msg.Data, gas)
+		if err == nil {
+			return true, nil
+		}
+		if revertErr, ok := err.(*vm.RevertError); ok {
+			return false, decodeRevertReason(revertErr.Data)
+		}
+		return false, nil
+	}
+
+	if ok, err := runs(cap); !ok {
+		if err != nil {
+			return 0, err
+		}
+		return 0, fmt.Errorf("gas required exceeds block gas limit %d", cap)
+	}
+
+	lo, hi := floor, cap
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		ok, err := runs(mid)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	return hi, nil
+}
+
+// TxStatus reports where a transaction stands: still in the pool (pending
+// or queued, in which case Transaction is set but Receipt isn't) or settled
+// on-chain (both are set, once the block that included it can be found) or
+// confirmed with only its receipt recovered. It backs eth_getTransactionByHash
+// and the tx CLI's status/history commands.
+type TxStatus struct {
+	Status      string
+	Transaction *Transaction
+	Receipt     *Receipt
+}
+
+// GetTransactionStatus looks up hash in the pool first, since a pending or
+// queued transaction hasn't executed yet and so has no receipt, then falls
+// back to its confirmed receipt and the block it names. It backs
+// eth_getTransactionByHash.
+func (bc *Blockchain) GetTransactionStatus(hash common.Hash) (*TxStatus, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	if tx, ok := bc.txPool.Get(hash); ok {
+		return &TxStatus{Status: bc.txPool.Status(hash).String(), Transaction: tx}, nil
+	}
+
+	data, err := bc.db.Get(receiptKey(hash), nil)
+	if err != nil {
+		return &TxStatus{Status: "unknown"}, nil
+	}
+	var receipt Receipt
+	if err := rlp.DecodeBytes(data, &receipt); err != nil {
+		return &TxStatus{Status: "unknown"}, nil
+	}
+
+	status := &TxStatus{Status: "confirmed", Receipt: &receipt}
+	if block, err := bc.GetBlockByNumber(receipt.BlockNumber); err == nil {
+		for _, tx := range block.Transactions() {
+			if tx.Hash == hash {
+				status.Transaction = tx
+				break
+			}
+		}
+	}
+	return status, nil
+}
+
+// GetAccountTransactions returns at most limit transactions touching addr
+// as sender or recipient, newest first: any the pool still holds pending or
+// queued, then confirmed ones scanned back block by block from the current
+// head. There's no address index to speed up the on-chain half, so limit
+// should stay small. It backs the tx CLI's history command.
+func (bc *Blockchain) GetAccountTransactions(addr common.Address, limit int) []*Transaction {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	var result []*Transaction
+	for _, tx := range bc.txPool.ByAddress(addr) {
+		result = append(result, tx)
+		if len(result) >= limit {
+			return result
+		}
+	}
+
+	cursor := new(big.Int).Set(bc.currentBlock.Header().Number)
+	for cursor.Sign() >= 0 && len(result) < limit {
+		block, err := bc.GetBlockByNumber(cursor)
+		if err != nil {
+			break
+		}
+		for _, tx := range block.Transactions() {
+			if tx.From == addr || (tx.To != nil && *tx.To == addr) {
+				result = append(result, tx)
+				if len(result) >= limit {
+					break
+				}
+			}
+		}
+		cursor.Sub(cursor, big.NewInt(1))
+	}
+
+	return result
+}
+
+// GetLogs returns receipt logs matching the given block range, contract
+// address, and topic filters. It backs eth_getLogs, parsing its string
+// arguments and delegating the actual scan to FilterLogs.
+func (bc *Blockchain) GetLogs(fromBlock, toBlock, address string, topics []string) ([]*Log, error) {
+	from, err := bc.resolveBlockTag(fromBlock, big.NewInt(0))
+	if err != nil {
+		return nil, err
+	}
+	to, err := bc.resolveBlockTag(toBlock, bc.currentBlock.Header().Number)
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []Address
+	if address != "" {
+		addresses = []Address{HexToAddress(address)}
+	}
+
+	topicFilter := make([][]Hash, len(topics))
+	for i, t := range topics {
+		if t == "" {
+			continue
+		}
+		topicFilter[i] = []Hash{HexToHash(t)}
+	}
+
+	return bc.FilterLogs(from, to, addresses, topicFilter)
+}
+
+// FilterLogs returns the logs emitted by blocks in [fromBlock, toBlock]
+// whose address is one of addresses (any address matches if addresses is
+// empty) and whose topics match position-by-position (topics[i] is an OR
+// set of candidates; an empty or absent topics[i] is a wildcard) — the same
+// semantics go-ethereum's eth/filters.Filter uses. Each block's bloom index
+// entry is checked first, so a block that can't possibly match never pays
+// for a full block/receipt read off disk.
+func (bc *Blockchain) FilterLogs(fromBlock, toBlock *big.Int, addresses []Address, topics [][]Hash) ([]*Log, error) {
+	var logs []*Log
+	cursor := new(big.Int).Set(fromBlock)
+	for cursor.Cmp(toBlock) <= 0 {
+		number := new(big.Int).Set(cursor)
+		cursor.Add(cursor, big.NewInt(1))
+
+		bloom, err := bc.GetBloomByNumber(number)
+		if err != nil {
+			break
+		}
+		if !bloomMatchesFilter(bloom, addresses, topics) {
+			continue
+		}
+
+		block, err := bc.GetBlockByNumber(number)
+		if err != nil {
+			break
+		}
+		for _, receipt := range bc.receiptsForBlock(block) {
+			for _, l := range receipt.Logs {
+				if logMatchesFilter(l, addresses, topics) {
+					logs = append(logs, l)
+				}
+			}
+		}
+	}
+
+	return logs, nil
+}
+
+// bloomMatchesFilter reports whether bloom could contain a log satisfying
+// addresses/topics. A false here means the block definitely doesn't match;
+// a true here just means it's worth reading the block's receipts to check.
+func bloomMatchesFilter(bloom Bloom, addresses []Address, topics [][]Hash) bool {
+	if len(addresses) > 0 {
+		found := false
+		for _, addr := range addresses {
+			if bloom.TestAddress(addr) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, want := range topics {
+		if len(want) == 0 {
+			continue
+		}
+		found := false
+		for _, topic := range want {
+			if bloom.Test(topic) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// logMatchesFilter reports whether a log actually satisfies addresses/
+// topics, once bloomMatchesFilter has let its block through.
+func logMatchesFilter(l *Log, addresses []Address, topics [][]Hash) bool {
+	if len(addresses) > 0 {
+		found := false
+		for _, addr := range addresses {
+			if l.Address == addr {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for i, want := range topics {
+		if len(want) == 0 {
+			continue
+		}
+		if i >= len(l.Topics) {
+			return false
+		}
+		found := false
+		for _, topic := range want {
+			if l.Topics[i] == topic {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (bc *Blockchain) resolveBlockTag(tag string, fallback *big.Int) (*big.Int, error) {
+	if tag == "" || tag == "latest" || tag == "pending" {
+		return fallback, nil
+	}
+	if tag == "earliest" {
+		return big.NewInt(0), nil
+	}
+	n, ok := new(big.Int).SetString(stripHexPrefix(tag), 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid block tag %q", tag)
+	}
+	return n, nil
+}
+
+// GetReceipts returns the receipts persisted for block's transactions, the
+// exported counterpart to receiptsForBlock for callers outside this package
+// — e.g. light.LocalOdrBackend, answering a light client's ReceiptsRequest
+// without its own receipt index.
+func (bc *Blockchain) GetReceipts(block *Block) []*Receipt {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	return bc.receiptsForBlock(block)
+}
+
+// receiptsForBlock loads the receipts persisted for a block's transactions.
+// Blocks without a receipt index (e.g. genesis) return no logs.
+func (bc *Blockchain) receiptsForBlock(block *Block) []*Receipt {
+	var receipts []*Receipt
+	for _, tx := range block.Transactions() {
+		data, err := bc.db.Get(receiptKey(tx.Hash), nil)
+		if err != nil {
+			continue
+		}
+		var receipt Receipt
+		if rlp.DecodeBytes(data, &receipt) == nil {
+			receipts = append(receipts, &receipt)
+		}
+	}
+	return receipts
+}
+
+func stripHexPrefix(s string) string {
+	if len(s) >= 2 && s[0:2] == "0x" {
+		return s[2:]
+	}
+	return s
+}
+
+func receiptKey(txHash Hash) []byte {
+	return append([]byte("receipt-"), txHash.Bytes()...)
+}
+
+// DecodeRawTransaction decodes a hex-encoded signed transaction, as
+// submitted via eth_sendRawTransaction, from MarshalBinary's wire format —
+// plain RLP for a LegacyTxType transaction, the EIP-2718 typeByte||rlp
+// envelope for anything else.
+func DecodeRawTransaction(raw string) (*Transaction, error) {
+	data, err := hex.DecodeString(stripHexPrefix(raw))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex payload: %w", err)
+	}
+
+	var tx Transaction
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return nil, fmt.Errorf("invalid transaction encoding: %w", err)
+	}
+	tx.Hash = Keccak256Hash(data)
+	return &tx, nil
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BlobRetentionWindow is how long a blob's sidecar stays fetchable after its
+// transaction is admitted, mirroring mainnet's ~18 day (4096 epoch) pruning
+// window. After it elapses only the transaction's versioned hashes remain
+// queryable; the blobs, commitments, and proofs themselves are discarded.
+const BlobRetentionWindow = 18 * 24 * time.Hour
+
+type blobStoreEntry struct {
+	sidecar  *BlobSidecar
+	storedAt time.Time
+}
+
+// BlobStore holds blob sidecars keyed by the hash of the transaction that
+// referenced them, for the bounded retention window after which they're
+// swept away to bound memory/disk use.
+type BlobStore struct {
+	mu        sync.RWMutex
+	entries   map[common.Hash]*blobStoreEntry
+	retention time.Duration
+}
+
+// NewBlobStore creates a sidecar store that retains entries for the given
+// window. A retention of zero falls back to BlobRetentionWindow.
+func NewBlobStore(retention time.Duration) *BlobStore {
+	if retention <= 0 {
+		retention = BlobRetentionWindow
+	}
+	return &BlobStore{
+		entries:   make(map[common.Hash]*blobStoreEntry),
+		retention: retention,
+	}
+}
+
+// Put records a transaction's blob sidecar.
+func (s *BlobStore) Put(txHash common.Hash, sidecar *BlobSidecar) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[txHash] = &blobStoreEntry{sidecar: sidecar, storedAt: time.Now()}
+}
+
+// Get returns the sidecar stored for a transaction, if it's still within the
+// retention window.
+func (s *BlobStore) Get(txHash common.Hash) (*BlobSidecar, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.entries[txHash]
+	if !ok || time.Since(entry.storedAt) > s.retention {
+		return nil, false
+	}
+	return entry.sidecar, true
+}
+
+// sweep discards every entry older than the retention window. It's called
+// periodically by Blockchain.blobSweeper.
+func (s *BlobStore) sweep(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for hash, entry := range s.entries {
+		if now.Sub(entry.storedAt) > s.retention {
+			delete(s.entries, hash)
+		}
+	}
+}
@@ -1,17 +1,21 @@
 package core
 
 import (
-	"bytes"
+	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/diora-blockchain/diora/consensus"
-	"github.com/diora-blockchain/diora/crypto"
-	"github.com/diora-blockchain/diora/vm"
+	"github.com/DioraFund/diora-go-blockchain/beacon"
+	"github.com/DioraFund/diora-go-blockchain/crypto"
+	"github.com/DioraFund/diora-go-blockchain/vm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/opt"
 )
@@ -28,22 +32,62 @@ type Blockchain struct {
 	db          *leveldb.DB
 	genesis     *Block
 	currentBlock *Block
+	// haltHeight is the block height a passed ProposalTypeSetHaltBlock
+	// proposal has scheduled the chain to stop at, or 0 if none is pending.
+	// See ExecuteProposal/HaltHeight in governance.go.
+	haltHeight  uint64
 	state       *State
-	consensus   consensus.Consensus
+	consensus   Consensus
 	vm          *vm.EVM
 	
 	// Caches
 	blockCache  map[common.Hash]*Block
+	headerChain *HeaderChain
 	txPool      *TxPool
 	
 	// Channels
 	newBlockCh  chan *Block
 	newTxCh     chan *Transaction
-	
+
+	// events fans out new heads, pending transactions, and logs to
+	// subscribers (the WebSocket RPC server, P2P gossip, etc).
+	events      *EventBus
+
+	// blobs holds EIP-4844 blob sidecars for the retention window, keyed by
+	// the hash of the transaction that referenced them.
+	blobs       *BlobStore
+
+	// pendingMu guards pendingBlocks/pendingByNumber, kept separate from mu
+	// so a validator can pre-execute a proposed block (PreExecuteBlock,
+	// under mu's RLock) and publish the result here without ever needing
+	// mu's write lock until ProcessBlock actually finalizes it.
+	pendingMu       sync.RWMutex
+	pendingBlocks   map[common.Hash]*PendingBlock
+	pendingByNumber map[uint64]map[common.Hash]struct{}
+
+	// validator and processor are swappable so a consensus engine or test
+	// can replace how blocks are checked/executed without touching
+	// ProcessBlock's bookkeeping. See Validator/Processor.
+	validator Validator
+	processor Processor
+
+	// beaconNet is the randomness beacon BlockValidator checks each block's
+	// BeaconEntries against (see checkBeaconEntries in validator.go). Left
+	// nil on a chain that hasn't opted into beacon-backed proposer
+	// selection yet, in which case BeaconEntries validation is skipped
+	// entirely. Set it with SetBeaconNetworks.
+	beaconNet *beacon.BeaconNetworks
+
 	// Synchronization
 	mu          sync.RWMutex
 	wg          sync.WaitGroup
 	stopCh      chan struct{}
+	closeOnce   sync.Once
+
+	// ctx is cancelled by the owning process (e.g. on SIGINT/SIGTERM) to stop
+	// the background workers without waiting for an explicit Close call.
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 type Config struct {
@@ -55,69 +99,241 @@ type Config struct {
 	MaxBlockSize uint64
 	StakeAmount *big.Int
 	ValidatorCount int
+
+	// HomesteadBlock, ByzantiumBlock, IstanbulBlock, BerlinBlock, and
+	// CancunBlock are the block numbers at which vm.ChainRules considers
+	// each hard fork active. A nil field means that fork isn't scheduled;
+	// leaving all of them nil (Config's zero value) behaves as a
+	// pre-Homestead chain, which is how every chain using this Config
+	// behaved before ChainRules existed to look at these fields.
+	HomesteadBlock *big.Int
+	ByzantiumBlock *big.Int
+	IstanbulBlock  *big.Int
+	BerlinBlock    *big.Int
+	CancunBlock    *big.Int
+
+	// EIP150Block and EIP158Block are the block numbers at which the
+	// Tangerine Whistle gas repricing and the Spurious Dragon state-clearing
+	// rules take effect, tracked separately from the later forks above
+	// because Rules (this package's own fork-activation snapshot, used for
+	// state-transition concerns rather than VM opcode gating) needs them
+	// independently of whether Homestead has happened yet.
+	EIP150Block *big.Int
+	EIP158Block *big.Int
 }
 
+// Block is immutable once constructed: its header, transactions, and
+// deposits can only be replaced wholesale, via NewBlock/WithSeal/
+// WithDeposits, never mutated field-by-field. That rules out the bugs that
+// come from a consensus engine or RPC handler partially rewriting a block
+// while another goroutine is reading it. Its hash and RLP-encoded size are
+// expensive to recompute, so Hash()/Size() cache them in an atomic.Value the
+// first time they're called.
 type Block struct {
-	Header       *BlockHeader
-	Transactions []*Transaction
-	Hash         common.Hash
-	Size         uint64
+	header       *BlockHeader
+	transactions []*Transaction
+	deposits     []*Deposit
+
+	hash atomic.Value
+	size atomic.Value
 }
 
-type BlockHeader struct {
-	ParentHash  common.Hash
-	Coinbase    common.Address
-	StateRoot   common.Hash
-	TxRoot      common.Hash
-	ReceiptRoot common.Hash
-	Difficulty  *big.Int
-	Number      *big.Int
-	GasLimit    uint64
-	GasUsed     uint64
-	Timestamp   uint64
-	ExtraData   []byte
-	MixHash     common.Hash
-	Nonce       [8]byte
-	Validator   common.Address
-	Signature   []byte
+// NewBlock seals a header together with its transactions into a Block. If
+// receipts is non-nil, the block's deposits and the header's DepositsRoot
+// are derived from them (see ScanDeposits); CreateBlock, which builds a
+// block before execution, passes nil since deposits aren't known yet.
+func NewBlock(header *BlockHeader, txs []*Transaction, receipts []*Receipt) *Block {
+	b := &Block{
+		header:       copyHeader(header),
+		transactions: txs,
+	}
+	if receipts != nil {
+		b.deposits = ScanDeposits(receipts)
+		b.header.DepositsRoot = ComputeDepositsRoot(b.deposits)
+	}
+	return b
 }
 
-type Transaction struct {
-	Nonce      uint64
-	GasPrice   *big.Int
-	GasLimit   uint64
-	To         *common.Address
-	Value      *big.Int
-	Data       []byte
-	V, R, S    *big.Int
-	Hash       common.Hash
-	From       common.Address
+// WithSeal returns a new Block with the given header (e.g. one with a
+// consensus signature/mix hash now filled in) and the same transactions and
+// deposits. The original Block is left untouched.
+func (b *Block) WithSeal(header *BlockHeader) *Block {
+	return &Block{
+		header:       copyHeader(header),
+		transactions: b.transactions,
+		deposits:     b.deposits,
+	}
 }
 
-type State struct {
-	db     *leveldb.DB
-	trie   *Trie
-	cache  map[common.Address]*Account
-	mu     sync.RWMutex
+// WithDeposits returns a new Block with its deposits (and the header's
+// DepositsRoot) replaced, for when ProcessBlock extracts them from receipts
+// after CreateBlock already sealed the rest of the header.
+func (b *Block) WithDeposits(deposits []*Deposit) *Block {
+	header := copyHeader(b.header)
+	header.DepositsRoot = ComputeDepositsRoot(deposits)
+	return &Block{
+		header:       header,
+		transactions: b.transactions,
+		deposits:     deposits,
+	}
+}
+
+// WithReceipts returns a new Block with its header's ReceiptRoot and Bloom
+// filled in from the given receipts (see computeReceiptRoot/CreateBloom),
+// for when ProcessBlock derives them after CreateBlock already sealed the
+// rest of the header. Mirrors WithDeposits.
+func (b *Block) WithReceipts(receipts []*Receipt) *Block {
+	header := copyHeader(b.header)
+	header.ReceiptRoot = computeReceiptRoot(receipts)
+	header.Bloom = CreateBloom(receipts)
+	return &Block{
+		header:       header,
+		transactions: b.transactions,
+		deposits:     b.deposits,
+	}
+}
+
+// WithGasUsed returns a new Block with its header's GasUsed replaced, for
+// when ProcessBlock learns the real figure from the processor's GasPool
+// accounting after CreateBlock already sealed the rest of the header.
+func (b *Block) WithGasUsed(gasUsed uint64) *Block {
+	header := copyHeader(b.header)
+	header.GasUsed = gasUsed
+	return &Block{
+		header:       header,
+		transactions: b.transactions,
+		deposits:     b.deposits,
+	}
 }
 
-type Account struct {
-	Nonce    uint64
-	Balance  *big.Int
-	CodeHash []byte
-	Code     []byte
-	Storage  map[common.Hash]common.Hash
+// WithStateRoot returns a new Block with its header's StateRoot replaced,
+// for when ProcessBlock learns the post-execution root from State.
+// IntermediateRoot after CreateBlock already sealed the rest of the header.
+// Mirrors WithGasUsed.
+func (b *Block) WithStateRoot(stateRoot common.Hash) *Block {
+	header := copyHeader(b.header)
+	header.StateRoot = stateRoot
+	return &Block{
+		header:       header,
+		transactions: b.transactions,
+		deposits:     b.deposits,
+	}
 }
 
-type TxPool struct {
-	pending   map[common.Hash]*Transaction
-	queued    map[common.Hash]*Transaction
-	all       map[common.Hash]*Transaction
-	mu        sync.RWMWMutex
-	maxSize   int
+func copyHeader(h *BlockHeader) *BlockHeader {
+	cpy := *h
+	return &cpy
 }
 
-func NewBlockchain(config *Config, dbPath string) (*Blockchain, error) {
+// Header returns the block's header. BlockHeader's own fields stay exported
+// (it's the RLP-encoded wire type), so callers needing a field this Block
+// doesn't expose a dedicated accessor for can read it off this directly.
+func (b *Block) Header() *BlockHeader { return b.header }
+
+// Transactions returns the block's transaction list.
+func (b *Block) Transactions() []*Transaction { return b.transactions }
+
+// Deposits returns the validator deposits extracted from this block's
+// receipts (see ScanDeposits). Empty until the block has been processed.
+func (b *Block) Deposits() []*Deposit { return b.deposits }
+
+func (b *Block) Number() *big.Int         { return b.header.Number }
+func (b *Block) ParentHash() common.Hash  { return b.header.ParentHash }
+func (b *Block) Coinbase() common.Address { return b.header.Coinbase }
+func (b *Block) GasLimit() uint64         { return b.header.GasLimit }
+
+type BlockHeader struct {
+	ParentHash   common.Hash
+	Coinbase     common.Address
+	StateRoot    common.Hash
+	TxRoot       common.Hash
+	ReceiptRoot  common.Hash
+	DepositsRoot common.Hash
+	Difficulty   *big.Int
+	Number       *big.Int
+	GasLimit     uint64
+	GasUsed      uint64
+	// BaseFee is the EIP-1559 base fee this block's transactions were priced
+	// against; nil only for a pre-London genesis loaded from an older
+	// database. CalcNextBaseFee derives the following block's value from
+	// it.
+	BaseFee       *big.Int
+	BlobGasUsed   uint64
+	ExcessBlobGas uint64
+	Timestamp    uint64
+	ExtraData    []byte
+	MixHash      common.Hash
+	Nonce        [8]byte
+	Validator    common.Address
+	Signature    []byte
+	Bloom        Bloom
+
+	// BeaconEntries carries this block's randomness-beacon round(s), used
+	// by BlockValidator to check against the previous block's entry (see
+	// checkBeaconEntries in validator.go) and by consensus's proposer
+	// selection to pick a leader deterministically from Entry.Randomness().
+	// Empty on a chain that hasn't configured a beacon (see
+	// Blockchain.SetBeaconNetworks).
+	BeaconEntries []beacon.BeaconEntry
+}
+
+type Transaction struct {
+	// Type is the EIP-2718 envelope discriminator. A zero value is
+	// LegacyTxType, so decoding an old untyped transaction (or zero-valuing
+	// one in a test) still behaves the way it always has.
+	Type TxType
+
+	Nonce uint64
+
+	// GasPrice is the flat per-gas price a LegacyTxType transaction pays.
+	// DynamicFeeTxType/BlobTxType transactions leave it nil and are priced
+	// by GasTipCap/GasFeeCap instead; use EffectiveGasPrice/EffectiveGasTip
+	// rather than reading these fields directly, since they differ by type.
+	GasPrice *big.Int
+
+	// GasTipCap and GasFeeCap price a DynamicFeeTxType/BlobTxType
+	// transaction per EIP-1559: GasTipCap is the most the sender will pay
+	// the proposer above the base fee, GasFeeCap is the most the sender
+	// will pay in total. Both are nil on a LegacyTxType transaction.
+	GasTipCap *big.Int
+	GasFeeCap *big.Int
+
+	GasLimit uint64
+	To       *common.Address
+	Value    *big.Int
+	Data     []byte
+
+	// ChainID is folded into the signed payload itself for an
+	// AccessListTxType/DynamicFeeTxType/BlobTxType transaction, the way
+	// EIP-2930 and EIP-1559 carry replay protection instead of folding it
+	// into V the way legacy EIP-155 does. Nil on a LegacyTxType transaction.
+	ChainID *big.Int
+
+	// AccessList is set on an AccessListTxType/DynamicFeeTxType/BlobTxType
+	// transaction's pre-declared address/storage-slot touches. Nil on a
+	// LegacyTxType transaction.
+	AccessList AccessList
+
+	V, R, S  *big.Int
+	Hash     common.Hash
+	From     common.Address
+
+	// Blob is set on type-3 (EIP-4844) transactions carrying blob versioned
+	// hashes and a blob fee cap. Its sidecar (the actual blobs, commitments,
+	// and proofs) is not part of the transaction itself; it's verified once
+	// at admission time and kept separately in the blockchain's BlobStore.
+	Blob *BlobTx
+}
+
+// State and Account live in state.go, which also owns the trie they're
+// persisted through.
+
+// NewBlockchain opens the chain database at dbPath and wires it to engine,
+// the consensus engine the caller has already constructed (e.g.
+// consensus.NewPoS(config.StakeAmount, config.ValidatorCount)). Consensus
+// is constructed by the caller, not here, so that core never has to import
+// the package that implements it - see Consensus's doc comment.
+func NewBlockchain(ctx context.Context, config *Config, dbPath string, engine Consensus) (*Blockchain, error) {
 	// Open database
 	db, err := leveldb.OpenFile(dbPath, &opt.Options{
 		WriteBuffer: 64 * 1024 * 1024,
@@ -128,35 +344,62 @@ func NewBlockchain(config *Config, dbPath string) (*Blockchain, error) {
 
 	// Initialize state
 	state := NewState(db)
-	
-	// Initialize consensus engine
-	consensus := consensus.NewPoS(config.StakeAmount, config.ValidatorCount)
-	
+
 	// Initialize EVM
 	evm := vm.NewEVM(state, config)
-	
+
+	ctx, cancel := context.WithCancel(ctx)
+
 	bc := &Blockchain{
-		config:     config,
-		db:         db,
-		state:      state,
-		consensus:  consensus,
-		vm:         evm,
-		blockCache: make(map[common.Hash]*Block),
-		txPool:     NewTxPool(10000),
-		newBlockCh: make(chan *Block, 100),
-		newTxCh:    make(chan *Transaction, 1000),
-		stopCh:     make(chan struct{}),
+		config:      config,
+		db:          db,
+		state:       state,
+		consensus:   engine,
+		vm:          evm,
+		blockCache:  make(map[common.Hash]*Block),
+		headerChain: newHeaderChain(),
+		txPool:      NewTxPool(DefaultMaxPoolSize, func(addr common.Address) uint64 { return state.GetAccount(addr).Nonce }),
+		newBlockCh:  make(chan *Block, 100),
+		newTxCh:     make(chan *Transaction, 1000),
+		events:      NewEventBus(),
+		blobs:       NewBlobStore(BlobRetentionWindow),
+		pendingBlocks:   make(map[common.Hash]*PendingBlock),
+		pendingByNumber: make(map[uint64]map[common.Hash]struct{}),
+		stopCh:      make(chan struct{}),
+		ctx:         ctx,
+		cancel:      cancel,
 	}
 
+	bc.validator = NewBlockValidator(config, bc, engine)
+	bc.processor = NewStateProcessor(config, bc, engine)
+
 	// Load or create genesis block
 	if err := bc.loadGenesis(); err != nil {
+		cancel()
 		return nil, fmt.Errorf("failed to load genesis: %w", err)
 	}
 
+	// Restore any halt height a governance proposal scheduled before this
+	// process last stopped.
+	if err := bc.loadHaltHeight(); err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to load halt height: %w", err)
+	}
+
 	// Start background workers
-	bc.wg.Add(2)
+	bc.wg.Add(4)
 	go bc.blockProcessor()
 	go bc.txProcessor()
+	go bc.blobSweeper()
+	go bc.pendingBlockPruner()
+
+	// Honor cancellation of the caller's context the same way an explicit
+	// Close does, so a server that only cancels its root context on shutdown
+	// still stops these goroutines cleanly.
+	go func() {
+		<-ctx.Done()
+		bc.Close()
+	}()
 
 	return bc, nil
 }
@@ -204,21 +447,14 @@ func (bc *Blockchain) createGenesisBlock() *Block {
 		Number:      big.NewInt(0),
 		GasLimit:    bc.config.GasLimit,
 		GasUsed:     0,
+		BaseFee:     big.NewInt(InitialBaseFee),
 		Timestamp:   timestamp,
 		ExtraData:   []byte("Diora Genesis Block"),
 		MixHash:     common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000000"),
 		Nonce:       [8]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
 	}
 
-	block := &Block{
-		Header:       header,
-		Transactions: []*Transaction{},
-	}
-	
-	block.Hash = block.ComputeHash()
-	block.Size = block.ComputeSize()
-	
-	return block
+	return NewBlock(header, []*Transaction{}, nil)
 }
 
 func (bc *Blockchain) AddTransaction(tx *Transaction) error {
@@ -228,143 +464,363 @@ func (bc *Blockchain) AddTransaction(tx *Transaction) error {
 	}
 
 	// Add to transaction pool
-	bc.txPool.Add(tx)
-	
+	if err := bc.txPool.Add(tx); err != nil {
+		return err
+	}
+
 	// Notify transaction processor
 	select {
 	case bc.newTxCh <- tx:
 	default:
 		// Channel full, transaction will be processed eventually
 	}
-	
+
+	bc.events.publishTx(tx)
+
+	return nil
+}
+
+// AddTransactionWithSidecar admits a type-3 blob transaction: it verifies
+// the sidecar's KZG commitments match the versioned hashes the transaction
+// declares before doing anything else, then falls through to the ordinary
+// AddTransaction path. The sidecar itself is kept in the blockchain's
+// BlobStore, not on the transaction, since it never needs to be replayed
+// during execution or included in any block hash.
+func (bc *Blockchain) AddTransactionWithSidecar(tx *Transaction, sidecar *BlobSidecar) error {
+	if tx.Blob == nil {
+		return fmt.Errorf("transaction has no blob data")
+	}
+	if err := VerifyBlobSidecar(sidecar, tx.Blob.BlobVersionedHashes); err != nil {
+		return fmt.Errorf("invalid blob sidecar: %w", err)
+	}
+
+	if err := bc.AddTransaction(tx); err != nil {
+		return err
+	}
+
+	bc.blobs.Put(tx.Hash, sidecar)
 	return nil
 }
 
+// GetBlobSidecar returns the blob sidecar stored for a transaction, if it is
+// still within the retention window.
+func (bc *Blockchain) GetBlobSidecar(txHash common.Hash) (*BlobSidecar, bool) {
+	return bc.blobs.Get(txHash)
+}
+
 func (bc *Blockchain) ValidateTransaction(tx *Transaction) error {
-	// Check signature
-	if err := crypto.VerifySignature(tx.From, tx.Hash.Bytes(), tx.V, tx.R, tx.S); err != nil {
+	// Check signature: recover the sender from (V, R, S) over the
+	// freshly-recomputed signing hash (never the tx's own stored Hash
+	// field, which a tampered transaction could have set to anything) and
+	// require it match the From the transaction claims.
+	sender, err := recoverSender(tx, bc.config.ChainID)
+	if err != nil {
 		return fmt.Errorf("invalid signature: %w", err)
 	}
+	if sender != tx.From {
+		return fmt.Errorf("invalid signature: recovered %s, expected %s", sender.Hex(), tx.From.Hex())
+	}
 
-	// Check nonce
+	// Check nonce. Anything >= the account's current nonce is acceptable
+	// here: an exact match is immediately executable, anything higher gets
+	// held in txPool's queued bucket until the gap ahead of it closes.
 	account := bc.state.GetAccount(tx.From)
-	if tx.Nonce != account.Nonce {
-		return fmt.Errorf("invalid nonce: expected %d, got %d", account.Nonce, tx.Nonce)
+	if tx.Nonce < account.Nonce {
+		return fmt.Errorf("invalid nonce: expected at least %d, got %d", account.Nonce, tx.Nonce)
+	}
+
+	// Check fee caps: a typed transaction's tip can't exceed its total fee
+	// cap, and its fee cap must clear the current block's base fee.
+	baseFee := bc.currentBlock.header.BaseFee
+	if err := ValidateFeeCaps(tx, baseFee); err != nil {
+		return err
 	}
 
-	// Check balance
-	cost := new(big.Int).Mul(tx.GasPrice, big.NewInt(int64(tx.GasLimit)))
+	// Check balance. The worst case a sender can be charged is its fee cap
+	// times its gas limit (a legacy tx's flat GasPrice plays the same
+	// role), regardless of what the block's base fee actually settles to.
+	cost := new(big.Int).Mul(EffectiveGasFeeCap(tx), big.NewInt(int64(tx.GasLimit)))
 	cost.Add(cost, tx.Value)
-	
+
 	if account.Balance.Cmp(cost) < 0 {
 		return ErrInsufficientBalance
 	}
 
-	// Check gas price
-	if tx.GasPrice.Cmp(bc.config.MinGasPrice) < 0 {
+	// Check gas price floor. A typed transaction is already held to the
+	// base fee by ValidateFeeCaps above; MinGasPrice only gates the tip it
+	// offers on top, the same floor a legacy transaction's flat GasPrice is
+	// held to.
+	if EffectiveGasTipCap(tx).Cmp(bc.config.MinGasPrice) < 0 {
 		return fmt.Errorf("gas price too low: minimum %s", bc.config.MinGasPrice.String())
 	}
 
+	if tx.Blob != nil {
+		rules := bc.config.Rules(bc.currentBlock.header.Number)
+		if !rules.IsCancun {
+			return fmt.Errorf("blob transactions are not valid before Cancun")
+		}
+		if len(tx.Blob.BlobVersionedHashes) == 0 {
+			return fmt.Errorf("blob transaction must declare at least one versioned hash")
+		}
+		if len(tx.Blob.BlobVersionedHashes) > MaxBlobsPerBlock {
+			return fmt.Errorf("too many blobs: %d exceeds max %d per block", len(tx.Blob.BlobVersionedHashes), MaxBlobsPerBlock)
+		}
+		blobFee := BlobGasPrice(bc.currentBlock.header.ExcessBlobGas)
+		if tx.Blob.MaxFeePerBlobGas.Cmp(blobFee) < 0 {
+			return fmt.Errorf("max fee per blob gas too low: need at least %s, have %s", blobFee.String(), tx.Blob.MaxFeePerBlobGas.String())
+		}
+	}
+
 	return nil
 }
 
+// Validator returns the chain's current block validator.
+func (bc *Blockchain) Validator() Validator {
+	return bc.validator
+}
+
+// Processor returns the chain's current transaction processor.
+func (bc *Blockchain) Processor() Processor {
+	return bc.processor
+}
+
+// SetValidator swaps the chain's block validator, e.g. for a test harness
+// that wants to skip consensus checks or a consensus engine with its own
+// validation rules.
+func (bc *Blockchain) SetValidator(v Validator) {
+	bc.validator = v
+}
+
+// SetProcessor swaps the chain's transaction processor, e.g. for historical
+// replay against an older state or a tracing processor.
+func (bc *Blockchain) SetProcessor(p Processor) {
+	bc.processor = p
+}
+
+// SetBeaconNetworks opts the chain into randomness-beacon-backed proposer
+// selection: every subsequent block's BeaconEntries are checked against net
+// by BlockValidator, and consensus can derive a proposer from them. A chain
+// that never calls this validates blocks the way it always has, with no
+// beacon entries expected.
+func (bc *Blockchain) SetBeaconNetworks(net *beacon.BeaconNetworks) {
+	bc.beaconNet = net
+}
+
+// BeaconNetworks returns the chain's configured randomness beacon, or nil
+// if SetBeaconNetworks was never called.
+func (bc *Blockchain) BeaconNetworks() *beacon.BeaconNetworks {
+	return bc.beaconNet
+}
+
+// StateDB exposes the chain's underlying leveldb handle read-only trie
+// access needs: a light client's local ODR backend (see light.LocalOdrBackend)
+// opens its own *Trie at a trusted header's StateRoot via core.NewTrieAt and
+// walks it with Trie.Prove, the same way this package's own State does, but
+// without needing a State or Blockchain of its own.
+func (bc *Blockchain) StateDB() *leveldb.DB {
+	return bc.db
+}
+
+// TxPool returns the chain's pending/queued transaction pool, for callers
+// (the txpool_* RPC namespace) that need to inspect it without a Blockchain
+// method of their own for every TxPool query.
+func (bc *Blockchain) TxPool() *TxPool {
+	return bc.txPool
+}
+
 func (bc *Blockchain) ProcessBlock(block *Block) error {
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
 
-	// Validate block
-	if err := bc.ValidateBlock(block); err != nil {
-		return err
+	// A block usually extends the current head, but one that extends an
+	// earlier ancestor instead (a competing fork) still validates and
+	// executes against its own parent; whether it ends up canonical is
+	// decided once execution succeeds, below.
+	extendsHead := block.Header().ParentHash == bc.currentBlock.Hash()
+	parent := bc.currentBlock
+	if !extendsHead {
+		p, err := bc.GetBlockByHash(block.Header().ParentHash)
+		if err != nil {
+			return fmt.Errorf("parent block not found: %w", err)
+		}
+		parent = p
 	}
 
-	// Execute transactions
-	receipts, err := bc.ExecuteTransactions(block.Transactions)
-	if err != nil {
-		return fmt.Errorf("failed to execute transactions: %w", err)
+	// Validate block body (hash, parent linkage, consensus, transactions).
+	if err := bc.validator.ValidateBody(block); err != nil {
+		return err
 	}
 
-	// Update state
-	if err := bc.state.Commit(); err != nil {
-		return fmt.Errorf("failed to commit state: %w", err)
+	// Execute transactions. If a validator already pre-executed this exact
+	// block via PreExecuteBlock, its receipts/logs/gas and shadow state are
+	// reused here instead of running every transaction a second time.
+	var (
+		receipts []*Receipt
+		logs     []*Log
+		usedGas  uint64
+		adopted  *State
+		err      error
+	)
+	if pb, ok := bc.getPending(block.Hash()); ok {
+		receipts, logs, usedGas = pb.Receipts, pb.Logs, pb.GasUsed
+		adopted = pb.State
+	} else {
+		receipts, logs, usedGas, err = bc.processor.Process(block, bc.state)
+		if err != nil {
+			return fmt.Errorf("failed to execute transactions: %w", err)
+		}
 	}
 
-	// Write block to database
-	if err := bc.writeBlock(block); err != nil {
-		return fmt.Errorf("failed to write block: %w", err)
+	// Extract validator deposits (EIP-6110 style): the deposit stream is the
+	// only source of truth for validator set changes, so this replaces any
+	// separate off-chain staking flow. A locally produced block's header
+	// arrives with a zero DepositsRoot (CreateBlock couldn't know the
+	// deposit set before execution), so WithDeposits fills it in;
+	// ValidateState below still enforces that an externally supplied
+	// nonzero root matches.
+	deposits := ScanDeposits(receipts)
+	if block.Header().DepositsRoot == (common.Hash{}) {
+		block = block.WithDeposits(deposits)
 	}
 
-	// Update current block
-	bc.currentBlock = block
+	// Likewise, CreateBlock can't know ReceiptRoot/Bloom before execution
+	// produces receipts; fill them in the same way, leaving ValidateState to
+	// enforce an externally supplied nonzero root.
+	if block.Header().ReceiptRoot == (common.Hash{}) {
+		block = block.WithReceipts(receipts)
+	}
 
-	// Update consensus
-	bc.consensus.UpdateBlock(block)
+	// And GasUsed: CreateBlock can only reserve gas via GasLimit, not predict
+	// what execution actually consumes, so a locally produced block arrives
+	// with it unset and gets the GasPool-tracked total filled in here.
+	if block.Header().GasUsed == 0 {
+		block = block.WithGasUsed(usedGas)
+	}
 
-	return nil
-}
+	// Adopt a reused pre-execution's shadow state before computing the
+	// tentative root below, so IntermediateRoot reflects what this block
+	// actually did rather than bc.state's prior contents.
+	if adopted != nil {
+		bc.state.Adopt(adopted)
+	}
+	computedStateRoot := bc.state.IntermediateRoot()
 
-func (bc *Blockchain) ValidateBlock(block *Block) error {
-	// Check block hash
-	computedHash := block.ComputeHash()
-	if !bytes.Equal(block.Hash.Bytes(), computedHash.Bytes()) {
-		return ErrInvalidBlock
+	// Likewise for StateRoot: CreateBlock can't know the post-execution
+	// root until these txs actually run, so a locally produced block
+	// arrives with it unset and gets the computed root filled in here.
+	if block.Header().StateRoot == (common.Hash{}) {
+		block = block.WithStateRoot(computedStateRoot)
 	}
 
-	// Check parent block exists
-	if block.Header.Number.Cmp(big.NewInt(0)) > 0 {
-		_, err := bc.GetBlockByHash(block.Header.ParentHash)
-		if err != nil {
-			return fmt.Errorf("parent block not found: %w", err)
-		}
+	// Validate post-execution state (gas used, state/deposits root) before
+	// committing anything derived from it.
+	if err := bc.validator.ValidateState(block, parent, receipts, usedGas, computedStateRoot); err != nil {
+		return err
 	}
+	bc.ApplyDeposits(block.Deposits())
 
-	// Validate consensus
-	if err := bc.consensus.ValidateBlock(block); err != nil {
-		return fmt.Errorf("consensus validation failed: %w", err)
+	stateRoot, err := bc.state.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit state: %w", err)
+	}
+	if err := bc.state.RecordRoot(stateRoot, block.Header().Number.Uint64()); err != nil {
+		return fmt.Errorf("failed to record state root: %w", err)
 	}
 
-	// Validate transactions
-	for _, tx := range block.Transactions {
-		if err := bc.ValidateTransaction(tx); err != nil {
-			return fmt.Errorf("invalid transaction %s: %w", tx.Hash.Hex(), err)
+	switch {
+	case extendsHead:
+		// Common case: block simply becomes the new head.
+		if err := bc.writeBlock(block); err != nil {
+			return fmt.Errorf("failed to write block: %w", err)
+		}
+		bc.currentBlock = block
+
+	case block.Header().Number.Cmp(bc.currentBlock.Header().Number) >= 0:
+		// A competing block at or past our height wins: it becomes the new
+		// canonical tip and the branch it displaces is rewound by reorgTo.
+		if err := bc.storeBlock(block); err != nil {
+			return fmt.Errorf("failed to write block: %w", err)
+		}
+		if err := bc.reorgTo(block); err != nil {
+			return fmt.Errorf("failed to reorg to new head: %w", err)
+		}
+
+	default:
+		// Short/stale fork: keep the block resolvable by hash, so it can
+		// anchor a later reorg, without touching the canonical index.
+		if err := bc.storeBlock(block); err != nil {
+			return fmt.Errorf("failed to write block: %w", err)
 		}
+		bc.events.publishChainSide(block)
+		return nil
 	}
 
+	// The block is canonical now; its transactions no longer need to sit in
+	// the pool waiting to be picked up by some future block.
+	for _, tx := range block.Transactions() {
+		bc.txPool.Remove(tx.Hash)
+	}
+	bc.removePending(block.Hash(), block.Header().Number.Uint64())
+
+	// Update consensus
+	bc.consensus.UpdateBlock(block)
+
+	bc.events.publishHead(block)
+	bc.events.publishLogs(logs)
+
 	return nil
 }
 
+// ValidateBlock checks a block's well-formedness via the chain's current
+// Validator. Kept as a Blockchain method (rather than requiring callers to
+// go through Validator()) since it predates the Validator/Processor split
+// and existing callers (tests, consensus engines) already depend on it.
+func (bc *Blockchain) ValidateBlock(block *Block) error {
+	return bc.validator.ValidateBody(block)
+}
+
+// ExecuteTransactions runs a transaction list through the chain's current
+// Processor. Kept for callers that only want receipts, not logs/gas.
 func (bc *Blockchain) ExecuteTransactions(txs []*Transaction) ([]*Receipt, error) {
-	var receipts []*Receipt
-	
-	for _, tx := range txs {
-		receipt, err := bc.vm.ExecuteTransaction(tx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to execute transaction %s: %w", tx.Hash.Hex(), err)
-		}
-		receipts = append(receipts, receipt)
-	}
-	
-	return receipts, nil
+	receipts, _, _, err := bc.processor.Process(&Block{transactions: txs}, bc.state)
+	return receipts, err
 }
 
+// writeBlock persists block and, since it's extending the current head,
+// marks it canonical and advances the "currentBlock" pointer. A block that
+// only extends some earlier ancestor goes through storeBlock/reorgTo
+// instead.
 func (bc *Blockchain) writeBlock(block *Block) error {
-	// Serialize block
+	if err := bc.storeBlock(block); err != nil {
+		return err
+	}
+	if err := bc.writeCanonical(block); err != nil {
+		return err
+	}
+
+	if err := bc.db.Put([]byte("currentBlock"), block.Hash().Bytes(), nil); err != nil {
+		return fmt.Errorf("failed to update current block: %w", err)
+	}
+
+	return nil
+}
+
+// storeBlock persists a block's RLP encoding under its hash and caches it in
+// memory, regardless of whether it ends up canonical. Every block
+// ProcessBlock accepts — including a losing fork's blocks — is stored this
+// way so it stays resolvable by hash and can anchor a future reorg.
+func (bc *Blockchain) storeBlock(block *Block) error {
 	data, err := rlp.EncodeToBytes(block)
 	if err != nil {
 		return fmt.Errorf("failed to serialize block: %w", err)
 	}
 
-	// Write to database
-	if err := bc.db.Put(block.Hash.Bytes(), data, nil); err != nil {
+	hash := block.Hash()
+	if err := bc.db.Put(hash.Bytes(), data, nil); err != nil {
 		return fmt.Errorf("failed to write block: %w", err)
 	}
 
-	// Update current block pointer
-	if err := bc.db.Put([]byte("currentBlock"), block.Hash.Bytes(), nil); err != nil {
-		return fmt.Errorf("failed to update current block: %w", err)
-	}
-
-	// Cache block
-	bc.blockCache[block.Hash] = block
+	bc.blockCache[hash] = block
 
 	return nil
 }
@@ -396,25 +852,32 @@ func (bc *Blockchain) GetBlockByHash(hash common.Hash) (*Block, error) {
 	return &block, nil
 }
 
+// GetBlockByNumber looks the block up via the canonical-hash index, an O(1)
+// leveldb read followed by the usual hash lookup (which itself hits
+// blockCache first). Falls back to walking parent hashes from the head for
+// numbers written before the canonical index existed (e.g. a pre-upgrade
+// database).
 func (bc *Blockchain) GetBlockByNumber(number *big.Int) (*Block, error) {
-	// For now, implement simple linear search
-	// In production, use block number index
+	if hash, err := bc.GetCanonicalHash(number); err == nil {
+		return bc.GetBlockByHash(hash)
+	}
+
 	currentBlock := bc.currentBlock
 	for currentBlock != nil {
-		if currentBlock.Header.Number.Cmp(number) == 0 {
+		if currentBlock.header.Number.Cmp(number) == 0 {
 			return currentBlock, nil
 		}
-		if currentBlock.Header.Number.Cmp(number) < 0 {
+		if currentBlock.header.Number.Cmp(number) < 0 {
 			break
 		}
-		
-		parent, err := bc.GetBlockByHash(currentBlock.Header.ParentHash)
+
+		parent, err := bc.GetBlockByHash(currentBlock.header.ParentHash)
 		if err != nil {
 			return nil, err
 		}
 		currentBlock = parent
 	}
-	
+
 	return nil, ErrBlockNotFound
 }
 
@@ -462,9 +925,30 @@ func (bc *Blockchain) txProcessor() {
 		case <-bc.stopCh:
 			return
 		case tx := <-bc.newTxCh:
-			// Transaction already validated when added to pool
-			// Just ensure it's in the pool
-			bc.txPool.Add(tx)
+			// Transaction already validated when added to pool; Add is a
+			// no-op if it's already there.
+			if err := bc.txPool.Add(tx); err != nil {
+				fmt.Printf("Failed to pool transaction: %v\n", err)
+			}
+		}
+	}
+}
+
+// blobSweeper periodically discards blob sidecars that have outlived the
+// retention window, the same way a real node prunes blob data once it can
+// no longer be needed for data availability sampling.
+func (bc *Blockchain) blobSweeper() {
+	defer bc.wg.Done()
+
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-bc.stopCh:
+			return
+		case <-ticker.C:
+			bc.blobs.sweep(time.Now())
 		}
 	}
 }
@@ -474,8 +958,10 @@ func (bc *Blockchain) tryCreateBlock() {
 		return
 	}
 
-	// Get pending transactions
-	txs := bc.txPool.Pending()
+	// Get pending transactions, priced by effective tip against the
+	// current head's base fee so the most profitable ones fill the block
+	// first.
+	txs := bc.txPool.PendingFlat(bc.currentBlock.Header().BaseFee)
 	if len(txs) == 0 {
 		return
 	}
@@ -499,39 +985,51 @@ func (bc *Blockchain) tryCreateBlock() {
 
 func (bc *Blockchain) CreateBlock(txs []*Transaction) (*Block, error) {
 	parent := bc.currentBlock
-	
-	header := &BlockHeader{
-		ParentHash:  parent.Hash,
-		Coinbase:    bc.consensus.GetValidatorAddress(),
-		StateRoot:   bc.state.Root(),
-		TxRoot:      computeTxRoot(txs),
-		ReceiptRoot: common.Hash{}, // Will be set after execution
-		Difficulty:  big.NewInt(1),
-		Number:      new(big.Int).Add(parent.Header.Number, big.NewInt(1)),
-		GasLimit:    bc.config.GasLimit,
-		GasUsed:     computeGasUsed(txs),
-		Timestamp:   uint64(time.Now().Unix()),
-		ExtraData:   []byte{},
-		MixHash:     common.Hash{},
-		Nonce:       [8]byte{},
-		Validator:   bc.consensus.GetValidatorAddress(),
+
+	nextNumber := new(big.Int).Add(parent.header.Number, big.NewInt(1))
+	if height, halted := bc.HaltHeight(); halted && nextNumber.Uint64() >= height {
+		return nil, fmt.Errorf("%w: halt height %d", ErrChainHalted, height)
 	}
 
-	block := &Block{
-		Header:       header,
-		Transactions: txs,
+	// Deposits land in receipts once txs execute; here we only reserve the
+	// field. ProcessBlock recomputes DepositsRoot from the executed receipts
+	// and rejects the block if it doesn't match, so a proposer can't forge
+	// phantom validators. StateRoot can't be known until those same txs
+	// actually run either, so it's reserved the same way.
+	header := &BlockHeader{
+		ParentHash:   parent.Hash(),
+		Coinbase:     bc.consensus.GetValidatorAddress(),
+		StateRoot:    common.Hash{}, // Will be set after execution
+		TxRoot:       computeTxRoot(txs),
+		ReceiptRoot:  common.Hash{}, // Will be set after execution
+		DepositsRoot: common.Hash{}, // Will be set after execution
+		Difficulty:   big.NewInt(1),
+		Number:       nextNumber,
+		GasLimit:     bc.config.GasLimit,
+		GasUsed:      0, // Will be set after execution, once the GasPool knows what txs actually used
+		BaseFee:       CalcNextBaseFee(parent.header),
+		BlobGasUsed:   computeBlobGasUsed(txs),
+		ExcessBlobGas: CalcExcessBlobGas(parent.header.ExcessBlobGas, parent.header.BlobGasUsed),
+		Timestamp:    uint64(time.Now().Unix()),
+		ExtraData:    []byte{},
+		MixHash:      common.Hash{},
+		Nonce:        [8]byte{},
+		Validator:    bc.consensus.GetValidatorAddress(),
 	}
-	
-	// Sign block
+
+	block := NewBlock(header, txs, nil)
+
+	// Sign block. SignBlock hashes the unsealed header (no signature yet);
+	// WithSeal then produces the final block whose cached Hash() commits to
+	// the signature too, so the two hashes intentionally differ.
 	signature, err := bc.consensus.SignBlock(block)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign block: %w", err)
 	}
-	block.Header.Signature = signature
-	
-	block.Hash = block.ComputeHash()
-	block.Size = block.ComputeSize()
-	
+	sealed := *header
+	sealed.Signature = signature
+	block = block.WithSeal(&sealed)
+
 	return block, nil
 }
 
@@ -540,41 +1038,122 @@ func (bc *Blockchain) BroadcastBlock(block *Block) {
 	// This will be implemented in the P2P module
 }
 
+// Close stops the background block and transaction processors, cancels the
+// blockchain's context, and closes the underlying KV store. It is safe to
+// call more than once (including concurrently with the ctx.Done() watcher
+// started by NewBlockchain) and from any goroutine.
+func (bc *Blockchain) Close() error {
+	var closeErr error
+	bc.closeOnce.Do(func() {
+		bc.cancel()
+		close(bc.stopCh)
+		bc.wg.Wait()
+
+		if bc.db != nil {
+			closeErr = bc.db.Close()
+		}
+	})
+	return closeErr
+}
+
+// Stop is a legacy alias for Close kept for existing callers.
 func (bc *Blockchain) Stop() {
-	close(bc.stopCh)
-	bc.wg.Wait()
-	
-	if bc.db != nil {
-		bc.db.Close()
-	}
+	bc.Close()
 }
 
+// ComputeHash always recomputes the block's hash from its current header,
+// unlike the cached Hash() accessor. Consensus engines rely on this: they
+// compute it once to sign an unsealed header, then again after WithSeal to
+// get the final stored hash, and those two results must differ.
 func (b *Block) ComputeHash() common.Hash {
 	// Create hash of header only (transactions are included via TxRoot)
-	data, _ := rlp.EncodeToBytes(b.Header)
+	data, _ := rlp.EncodeToBytes(b.header)
 	return crypto.Keccak256Hash(data)
 }
 
+// Hash returns the block's hash, computing and caching it on first call.
+// Safe for concurrent use; a Block's header never changes after
+// construction, so the cached value is always valid for this Block's
+// lifetime (call WithSeal/WithDeposits to get a fresh Block instead).
+func (b *Block) Hash() common.Hash {
+	if v := b.hash.Load(); v != nil {
+		return v.(common.Hash)
+	}
+	h := b.ComputeHash()
+	b.hash.Store(h)
+	return h
+}
+
+// ComputeSize always recomputes the block's RLP-encoded size.
 func (b *Block) ComputeSize() uint64 {
-	data, _ := rlp.EncodeToBytes(b)
+	data, _ := rlp.EncodeToBytes(struct {
+		Header       *BlockHeader
+		Transactions []*Transaction
+		Deposits     []*Deposit
+	}{b.header, b.transactions, b.deposits})
 	return uint64(len(data))
 }
 
-func computeTxRoot(txs []*Transaction) common.Hash {
-	// Simplified implementation
-	// In production, use proper Merkle tree
-	if len(txs) == 0 {
-		return common.HexToHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+// Size returns the block's RLP-encoded size, computing and caching it on
+// first call.
+func (b *Block) Size() uint64 {
+	if v := b.size.Load(); v != nil {
+		return v.(uint64)
 	}
-	
-	data, _ := rlp.EncodeToBytes(txs)
-	return crypto.Keccak256Hash(data)
+	s := b.ComputeSize()
+	b.size.Store(s)
+	return s
+}
+
+// rlpBlock is the wire-format shadow of Block. Block's own fields are
+// unexported to enforce immutability, which the reflection-based rlp codec
+// can't see, so EncodeRLP/DecodeRLP go through this instead.
+type rlpBlock struct {
+	Header       *BlockHeader
+	Transactions []*Transaction
+	Deposits     []*Deposit
+}
+
+func (b *Block) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, &rlpBlock{b.header, b.transactions, b.deposits})
 }
 
-func computeGasUsed(txs []*Transaction) uint64 {
+func (b *Block) DecodeRLP(s *rlp.Stream) error {
+	var eb rlpBlock
+	if err := s.Decode(&eb); err != nil {
+		return err
+	}
+	b.header, b.transactions, b.deposits = eb.Header, eb.Transactions, eb.Deposits
+	return nil
+}
+
+// computeTxRoot derives a block's TxRoot the way go-ethereum does: a
+// Merkle-Patricia trie keyed by transaction index, RLP-encoded per entry.
+func computeTxRoot(txs []*Transaction) common.Hash {
+	return DeriveSha(len(txs), func(i int) []byte {
+		data, _ := rlp.EncodeToBytes(txs[i])
+		return data
+	})
+}
+
+// computeReceiptRoot derives a block's ReceiptRoot the same way
+// computeTxRoot derives TxRoot, keyed by receipt (== transaction) index.
+func computeReceiptRoot(receipts []*Receipt) common.Hash {
+	return DeriveSha(len(receipts), func(i int) []byte {
+		data, _ := rlp.EncodeToBytes(receipts[i])
+		return data
+	})
+}
+
+// computeBlobGasUsed sums the blob gas a block's transactions consume: a
+// fixed BlobGasPerBlob per declared versioned hash, the same way execution
+// gas is metered per opcode rather than per byte of calldata.
+func computeBlobGasUsed(txs []*Transaction) uint64 {
 	var total uint64
 	for _, tx := range txs {
-		total += tx.GasLimit
+		if tx.Blob != nil {
+			total += uint64(len(tx.Blob.BlobVersionedHashes)) * BlobGasPerBlob
+		}
 	}
 	return total
 }
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"math/big"
+	"time"
+)
+
+// Consensus is the engine Blockchain drives block production and validation
+// through. It's defined here, in terms of core's own types only, so that
+// core never has to import the consensus package that implements it
+// (consensus already imports core for Address/Block/Deposit) - the two
+// packages would otherwise form an import cycle the moment they're part of
+// the same module.
+type Consensus interface {
+	// IsValidator reports whether this node is registered to propose and
+	// sign blocks.
+	IsValidator() bool
+	// GetValidatorAddress returns this node's validator address, valid only
+	// when IsValidator reports true.
+	GetValidatorAddress() Address
+	// SignBlock produces this node's signature over block, for inclusion
+	// as its proposer signature.
+	SignBlock(block *Block) ([]byte, error)
+	// ValidateBlock checks block against the engine's consensus rules
+	// (proposer eligibility, signature, slot timing).
+	ValidateBlock(block *Block) error
+	// UpdateBlock advances the engine's view of chain progress once block
+	// has been accepted (slot/epoch bookkeeping, reward distribution).
+	UpdateBlock(block *Block)
+	// Validators returns the current validator set as a read-only,
+	// core-level view, so callers outside the engine don't need the
+	// concrete consensus package to inspect it.
+	Validators() []ValidatorInfo
+}
+
+// ValidatorInfo is a read-only snapshot of one validator, shaped for
+// consumers (the JSON-RPC/REST API) that only need to display or report on
+// the validator set rather than operate the consensus engine itself.
+type ValidatorInfo struct {
+	Address        Address
+	Stake          *big.Int
+	TotalDelegated *big.Int
+	Commission     uint64
+	// Status mirrors the issuing engine's own validator status enum
+	// (inactive/active/slashed/tombstoned), numbered the same way.
+	Status          int
+	LastActive      time.Time
+	TotalBlocks     uint64
+	Rewards         *big.Int
+	OperatorAddress Address
+	ControlAddress  Address
+	MissedBlocks    uint64
+}
@@ -0,0 +1,154 @@
+package core
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// DepositContractAddress is the reserved system address that hosts the
+// canonical deposit log, mirroring EIP-6110's execution-layer-triggered
+// validator deposits. Deposits are ordinary contract events emitted by this
+// address; there is no separate off-chain staking flow to keep in sync.
+var DepositContractAddress = HexToAddress("0x0000000000000000000000000000000000f100")
+
+// DepositEventTopic is the keccak256 of
+// "Deposit(bytes,bytes,uint64,bytes,uint64)", the canonical deposit event
+// signature scanned out of every block's receipts.
+var DepositEventTopic = Keccak256Hash([]byte("Deposit(bytes,bytes,uint64,bytes,uint64)"))
+
+// Deposit is one validator deposit extracted from a Deposit log: a proposed
+// validator pubkey, its withdrawal credentials, the staked amount, a BLS (or
+// equivalent) proof-of-possession signature, and a monotonic index used to
+// detect gaps or replays.
+type Deposit struct {
+	PublicKey             []byte
+	WithdrawalCredentials []byte
+	Amount                uint64
+	Signature             []byte
+	Index                 uint64
+}
+
+// ScanDeposits extracts Deposit events from a set of executed receipts, in
+// log order, so callers never need a separate off-chain staking indexer.
+func ScanDeposits(receipts []*Receipt) []*Deposit {
+	var deposits []*Deposit
+	for _, receipt := range receipts {
+		for _, l := range receipt.Logs {
+			if l.Address != DepositContractAddress {
+				continue
+			}
+			if len(l.Topics) == 0 || l.Topics[0] != DepositEventTopic {
+				continue
+			}
+			dep, err := decodeDepositLog(l.Data)
+			if err != nil {
+				continue
+			}
+			deposits = append(deposits, dep)
+		}
+	}
+	return deposits
+}
+
+// decodeDepositLog unpacks the ABI-free, fixed-layout deposit payload:
+// pubkeyLen(4) || pubkey || credsLen(4) || creds || amount(8) || sigLen(4) ||
+// sig || index(8).
+func decodeDepositLog(data []byte) (*Deposit, error) {
+	off := 0
+	readBytes := func() ([]byte, error) {
+		if off+4 > len(data) {
+			return nil, fmt.Errorf("truncated deposit log")
+		}
+		n := int(binary.BigEndian.Uint32(data[off : off+4]))
+		off += 4
+		if off+n > len(data) {
+			return nil, fmt.Errorf("truncated deposit log field")
+		}
+		b := data[off : off+n]
+		off += n
+		return b, nil
+	}
+
+	pubkey, err := readBytes()
+	if err != nil {
+		return nil, err
+	}
+	creds, err := readBytes()
+	if err != nil {
+		return nil, err
+	}
+	if off+8 > len(data) {
+		return nil, fmt.Errorf("truncated deposit amount")
+	}
+	amount := binary.BigEndian.Uint64(data[off : off+8])
+	off += 8
+
+	sig, err := readBytes()
+	if err != nil {
+		return nil, err
+	}
+	if off+8 > len(data) {
+		return nil, fmt.Errorf("truncated deposit index")
+	}
+	index := binary.BigEndian.Uint64(data[off : off+8])
+
+	return &Deposit{
+		PublicKey:             append([]byte(nil), pubkey...),
+		WithdrawalCredentials: append([]byte(nil), creds...),
+		Amount:                amount,
+		Signature:             append([]byte(nil), sig...),
+		Index:                 index,
+	}, nil
+}
+
+// ComputeDepositsRoot hashes the ordered deposit list into a single root
+// committed on the block header: one Keccak256 over every deposit's fields
+// concatenated in order. Unlike TxRoot/ReceiptRoot (see DeriveSha), it is
+// not a Merkle-Patricia trie root, so no per-deposit proof exists for it.
+func ComputeDepositsRoot(deposits []*Deposit) Hash {
+	if len(deposits) == 0 {
+		return Hash{}
+	}
+
+	var buf []byte
+	for _, d := range deposits {
+		buf = append(buf, d.PublicKey...)
+		buf = append(buf, d.WithdrawalCredentials...)
+		amt := make([]byte, 8)
+		binary.BigEndian.PutUint64(amt, d.Amount)
+		buf = append(buf, amt...)
+		buf = append(buf, d.Signature...)
+		idx := make([]byte, 8)
+		binary.BigEndian.PutUint64(idx, d.Index)
+		buf = append(buf, idx...)
+	}
+	return Keccak256Hash(buf)
+}
+
+// ApplyDeposits feeds a block's deposit stream into the consensus engine so
+// the validator set updates deterministically from on-chain deposits rather
+// than a separate off-chain staking flow.
+func (bc *Blockchain) ApplyDeposits(deposits []*Deposit) {
+	applier, ok := bc.consensus.(interface {
+		ApplyDeposit(*Deposit) error
+	})
+	if !ok {
+		return
+	}
+	for _, d := range deposits {
+		if err := applier.ApplyDeposit(d); err != nil {
+			fmt.Printf("failed to apply deposit %d: %v\n", d.Index, err)
+		}
+	}
+}
+
+// GetDeposits returns the deposits committed in the block at the given
+// height, backing GET /api/v1/block/:n/deposits.
+func (bc *Blockchain) GetDeposits(number *big.Int) ([]*Deposit, error) {
+	block, err := bc.GetBlockByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	return block.Deposits(), nil
+}
@@ -0,0 +1,190 @@
+package core
+
+import (
+	"github.com/DioraFund/diora-go-blockchain/crypto"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// emptyRootHash is the root of a trie with no entries: keccak256 of the RLP
+// encoding of an empty byte string. It's the same constant this package
+// already used as a genesis placeholder for StateRoot/TxRoot/ReceiptRoot
+// before either had a real implementation.
+var emptyRootHash = common.HexToHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+
+// DeriveSha builds an ephemeral Merkle-Patricia trie over n index-keyed
+// entries — encode(i) is the RLP-encoded value stored under key i — and
+// returns its root hash. It's used exactly the way go-ethereum derives a
+// block's TxRoot and ReceiptRoot: the trie itself is discarded immediately,
+// only its root is kept, so a light client can still request a Merkle proof
+// for "transaction i" by recomputing the same trie from the full block body.
+func DeriveSha(n int, encode func(i int) []byte) common.Hash {
+	if n == 0 {
+		return emptyRootHash
+	}
+
+	var root trieNode
+	for i := 0; i < n; i++ {
+		key, err := rlp.EncodeToBytes(uint(i))
+		if err != nil {
+			panic(err) // unreachable: uint always encodes
+		}
+		root = trieInsert(root, keybytesToHex(key), valueNode(encode(i)))
+	}
+	return common.BytesToHash(hashNode(root))
+}
+
+// trieNode is one of shortNode, *fullNode, or valueNode. Unlike the
+// persistent Trie in trie.go, this trie is never read back by key —
+// only inserted into and hashed — so there's no need for a backing
+// Database or a Get/Delete path.
+type trieNode interface{}
+
+// shortNode is either an extension (val is another trieNode) or a leaf (val
+// is a valueNode); key is a hex-prefix-encodable nibble sequence, with the
+// trailing terminator nibble present only for leaves.
+type shortNode struct {
+	key []byte
+	val trieNode
+}
+
+// fullNode is a 17-way branch: children[0:16] are keyed by nibble, and
+// children[16] holds a valueNode when some key terminates at this node.
+type fullNode struct {
+	children [17]trieNode
+}
+
+// valueNode is a stored value: the RLP encoding of one transaction or
+// receipt, embedded in its parent node rather than hashed separately.
+type valueNode []byte
+
+// trieInsert inserts value under key into n, returning the (possibly new)
+// root. It's the standard recursive Patricia-trie insert, simplified from
+// go-ethereum's by always treating every node as dirty (this trie is built
+// once and hashed once, never incrementally re-hashed).
+func trieInsert(n trieNode, key []byte, value valueNode) trieNode {
+	if len(key) == 0 {
+		return value
+	}
+
+	switch n := n.(type) {
+	case nil:
+		return &shortNode{key: key, val: value}
+
+	case *shortNode:
+		matchlen := prefixLen(key, n.key)
+		if matchlen == len(n.key) {
+			return &shortNode{key: n.key, val: trieInsert(n.val, key[matchlen:], value)}
+		}
+
+		branch := &fullNode{}
+		branch.children[n.key[matchlen]] = trieInsert(nil, n.key[matchlen+1:], n.val)
+		branch.children[key[matchlen]] = trieInsert(nil, key[matchlen+1:], value)
+		if matchlen == 0 {
+			return branch
+		}
+		return &shortNode{key: key[:matchlen], val: branch}
+
+	case *fullNode:
+		n.children[key[0]] = trieInsert(n.children[key[0]], key[1:], value)
+		return n
+
+	default:
+		panic("core: unexpected trie node type")
+	}
+}
+
+// hashNode RLP-encodes a node's canonical form and returns its Keccak256
+// hash, the same content-addressing scheme Block/BlockHeader hashing uses
+// elsewhere in this package.
+func hashNode(n trieNode) []byte {
+	data, err := rlp.EncodeToBytes(encodeNode(n))
+	if err != nil {
+		panic(err) // unreachable: encodeNode only returns RLP-safe shapes
+	}
+	return crypto.Keccak256(data)
+}
+
+// encodeNode returns n's RLP-encodable shape, replacing every child
+// reference with either its raw value bytes (valueNode), its hash
+// (shortNode/fullNode), or an empty string (nil) — this ephemeral trie skips
+// go-ethereum's <32-byte child inlining since it's rebuilt from scratch for
+// every block anyway.
+func encodeNode(n trieNode) interface{} {
+	switch n := n.(type) {
+	case nil:
+		return []byte{}
+	case valueNode:
+		return []byte(n)
+	case *shortNode:
+		return []interface{}{hexToCompact(n.key), childReference(n.val)}
+	case *fullNode:
+		var enc [17]interface{}
+		for i := 0; i < 16; i++ {
+			enc[i] = childReference(n.children[i])
+		}
+		enc[16] = childReference(n.children[16])
+		return enc
+	default:
+		panic("core: unexpected trie node type")
+	}
+}
+
+func childReference(n trieNode) interface{} {
+	switch n := n.(type) {
+	case nil:
+		return []byte{}
+	case valueNode:
+		return []byte(n)
+	default:
+		return hashNode(n)
+	}
+}
+
+// keybytesToHex splits b into nibbles and appends the trie's terminator
+// nibble (16), the same hex encoding go-ethereum's trie package uses so a
+// full key and a strict prefix of it can never be confused.
+func keybytesToHex(b []byte) []byte {
+	nibbles := make([]byte, len(b)*2+1)
+	for i, c := range b {
+		nibbles[i*2] = c >> 4
+		nibbles[i*2+1] = c & 0x0f
+	}
+	nibbles[len(nibbles)-1] = 16
+	return nibbles
+}
+
+func hasTerm(hex []byte) bool {
+	return len(hex) > 0 && hex[len(hex)-1] == 16
+}
+
+func prefixLen(a, b []byte) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// hexToCompact packs a nibble slice back into bytes (the "compact"/
+// hex-prefix encoding), folding the terminator and odd-length flags into the
+// high nibble of the first byte.
+func hexToCompact(hex []byte) []byte {
+	var terminator byte
+	if hasTerm(hex) {
+		terminator = 1
+		hex = hex[:len(hex)-1]
+	}
+
+	buf := make([]byte, len(hex)/2+1)
+	buf[0] = terminator << 5
+	if len(hex)&1 == 1 {
+		buf[0] |= 1 << 4
+		buf[0] |= hex[0]
+		hex = hex[1:]
+	}
+	for i := 0; i < len(hex); i += 2 {
+		buf[i/2+1] = hex[i]<<4 | hex[i+1]
+	}
+	return buf
+}
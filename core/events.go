@@ -0,0 +1,199 @@
+package core
+
+import "sync"
+
+// EventBus fans blockchain events out to any number of subscribers, decoupling
+// producers (block processing, mempool admission) from consumers (the RPC
+// WebSocket subscription server, P2P gossip, etc).
+type EventBus struct {
+	mu          sync.RWMutex
+	headSubs    map[int]chan *Block
+	txSubs      map[int]chan *Transaction
+	logsSubs    map[int]chan []*Log
+	sideSubs    map[int]chan *Block
+	reorgSubs   map[int]chan ChainReorgEvent
+	nextID      int
+}
+
+// ChainReorgEvent reports a canonical-chain reorganization: New holds the
+// blocks (oldest-first, exclusive of the common ancestor) that became
+// canonical, Old holds the ones on the previous chain they displaced.
+type ChainReorgEvent struct {
+	Old []*Block
+	New []*Block
+}
+
+// NewEventBus creates an empty event bus.
+func NewEventBus() *EventBus {
+	return &EventBus{
+		headSubs:  make(map[int]chan *Block),
+		txSubs:    make(map[int]chan *Transaction),
+		logsSubs:  make(map[int]chan []*Log),
+		sideSubs:  make(map[int]chan *Block),
+		reorgSubs: make(map[int]chan ChainReorgEvent),
+	}
+}
+
+// SubscribeNewHeads registers a channel that receives every newly processed
+// block. The returned unsubscribe function must be called to release it.
+func (b *EventBus) SubscribeNewHeads(buf int) (<-chan *Block, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	ch := make(chan *Block, buf)
+	b.headSubs[id] = ch
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.headSubs, id)
+		close(ch)
+	}
+}
+
+// SubscribePendingTransactions registers a channel that receives every
+// transaction admitted to the mempool.
+func (b *EventBus) SubscribePendingTransactions(buf int) (<-chan *Transaction, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	ch := make(chan *Transaction, buf)
+	b.txSubs[id] = ch
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.txSubs, id)
+		close(ch)
+	}
+}
+
+// SubscribeLogs registers a channel that receives the logs emitted by every
+// executed block's receipts.
+func (b *EventBus) SubscribeLogs(buf int) (<-chan []*Log, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	ch := make(chan []*Log, buf)
+	b.logsSubs[id] = ch
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.logsSubs, id)
+		close(ch)
+	}
+}
+
+// SubscribeChainSide registers a channel that receives each block dropped
+// from the canonical chain: a short fork's tip as it arrives, or one of the
+// old chain's blocks when a reorg displaces it.
+func (b *EventBus) SubscribeChainSide(buf int) (<-chan *Block, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	ch := make(chan *Block, buf)
+	b.sideSubs[id] = ch
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.sideSubs, id)
+		close(ch)
+	}
+}
+
+// SubscribeChainReorg registers a channel that receives a ChainReorgEvent
+// whenever the canonical chain is rewound and re-extended along a
+// competing fork.
+func (b *EventBus) SubscribeChainReorg(buf int) (<-chan ChainReorgEvent, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	ch := make(chan ChainReorgEvent, buf)
+	b.reorgSubs[id] = ch
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.reorgSubs, id)
+		close(ch)
+	}
+}
+
+// publishHead notifies subscribers of a new block, dropping slow consumers
+// rather than blocking block processing on them.
+func (b *EventBus) publishHead(block *Block) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, ch := range b.headSubs {
+		select {
+		case ch <- block:
+		default:
+		}
+	}
+}
+
+func (b *EventBus) publishTx(tx *Transaction) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, ch := range b.txSubs {
+		select {
+		case ch <- tx:
+		default:
+		}
+	}
+}
+
+func (b *EventBus) publishLogs(logs []*Log) {
+	if len(logs) == 0 {
+		return
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, ch := range b.logsSubs {
+		select {
+		case ch <- logs:
+		default:
+		}
+	}
+}
+
+func (b *EventBus) publishChainSide(block *Block) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, ch := range b.sideSubs {
+		select {
+		case ch <- block:
+		default:
+		}
+	}
+}
+
+func (b *EventBus) publishChainReorg(event ChainReorgEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, ch := range b.reorgSubs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Events returns the blockchain's event bus, used to wire WebSocket
+// subscriptions and other consumers to new heads, pending transactions, and
+// logs without polling.
+func (bc *Blockchain) Events() *EventBus {
+	return bc.events
+}
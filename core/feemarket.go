@@ -0,0 +1,172 @@
+package core
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// TxType discriminates a Transaction's payload, mirroring EIP-2718's typed
+// transaction envelope: the byte is the first thing read off the wire, and
+// it decides which of the fields below apply.
+type TxType byte
+
+const (
+	// LegacyTxType is the original, untyped transaction shape: priced by a
+	// single GasPrice, no access list, no blobs.
+	LegacyTxType TxType = 0x00
+
+	// AccessListTxType is an EIP-2930 transaction: a LegacyTxType payload
+	// (still priced by a flat GasPrice) plus an AccessList of
+	// addresses/storage slots the transaction pre-declares it will touch,
+	// and a chain id folded into the signed payload itself rather than into
+	// v the way EIP-155 does.
+	AccessListTxType TxType = 0x01
+
+	// DynamicFeeTxType is an EIP-1559 transaction: priced by GasTipCap (the
+	// most the sender will pay the proposer) and GasFeeCap (the most the
+	// sender will pay in total, tip plus base fee).
+	DynamicFeeTxType TxType = 0x02
+
+	// BlobTxType is an EIP-4844 transaction: a DynamicFeeTxType payload plus
+	// a Blob sidecar reference. See BlobTx.
+	BlobTxType TxType = 0x03
+)
+
+// EIP-1559 base fee market constants, matching go-ethereum's values exactly
+// so fee estimation tooling built against mainnet behaves the same way here.
+const (
+	// InitialBaseFee is the base fee new chains start from, the same
+	// 1 Gwei go-ethereum's London fork uses.
+	InitialBaseFee = 1_000_000_000
+
+	// BaseFeeChangeDenominator bounds how much the base fee can move block
+	// to block: at most a 1/8 swing in either direction.
+	BaseFeeChangeDenominator = 8
+
+	// ElasticityMultiplier is the ratio between a block's GasLimit and its
+	// long-run target gas usage; usage above GasLimit/ElasticityMultiplier
+	// pushes the base fee up, below pushes it down.
+	ElasticityMultiplier = 2
+)
+
+// CalcNextBaseFee computes the base fee a block extending parent should
+// use, via the same fake-derivative control loop EIP-1559 specifies:
+// compare parent's gas usage against its target (GasLimit /
+// ElasticityMultiplier) and nudge the base fee by at most 1/
+// BaseFeeChangeDenominator of its current value.
+func CalcNextBaseFee(parent *BlockHeader) *big.Int {
+	if parent.BaseFee == nil {
+		return big.NewInt(InitialBaseFee)
+	}
+
+	parentGasTarget := parent.GasLimit / ElasticityMultiplier
+	if parentGasTarget == 0 || parent.GasUsed == parentGasTarget {
+		return new(big.Int).Set(parent.BaseFee)
+	}
+
+	if parent.GasUsed > parentGasTarget {
+		gasUsedDelta := parent.GasUsed - parentGasTarget
+		baseFeeDelta := new(big.Int).Mul(parent.BaseFee, big.NewInt(int64(gasUsedDelta)))
+		baseFeeDelta.Div(baseFeeDelta, big.NewInt(int64(parentGasTarget)))
+		baseFeeDelta.Div(baseFeeDelta, big.NewInt(BaseFeeChangeDenominator))
+		if baseFeeDelta.Sign() == 0 {
+			baseFeeDelta.SetInt64(1)
+		}
+		return new(big.Int).Add(parent.BaseFee, baseFeeDelta)
+	}
+
+	gasUsedDelta := parentGasTarget - parent.GasUsed
+	baseFeeDelta := new(big.Int).Mul(parent.BaseFee, big.NewInt(int64(gasUsedDelta)))
+	baseFeeDelta.Div(baseFeeDelta, big.NewInt(int64(parentGasTarget)))
+	baseFeeDelta.Div(baseFeeDelta, big.NewInt(BaseFeeChangeDenominator))
+
+	next := new(big.Int).Sub(parent.BaseFee, baseFeeDelta)
+	if next.Sign() < 0 {
+		next.SetInt64(0)
+	}
+	return next
+}
+
+// hasFlatGasPrice reports whether tx is priced by a single GasPrice rather
+// than a GasFeeCap/GasTipCap pair — true for LegacyTxType and
+// AccessListTxType, the two kinds EIP-2930 left un-migrated to EIP-1559's
+// fee market.
+func hasFlatGasPrice(tx *Transaction) bool {
+	return tx.Type == LegacyTxType || tx.Type == AccessListTxType
+}
+
+// EffectiveGasFeeCap returns the most a transaction is willing to pay per
+// unit of gas, tip plus base fee included. A flat-GasPrice transaction
+// (LegacyTxType, AccessListTxType) has no separate cap; its GasPrice plays
+// that role.
+func EffectiveGasFeeCap(tx *Transaction) *big.Int {
+	if hasFlatGasPrice(tx) {
+		return tx.GasPrice
+	}
+	return tx.GasFeeCap
+}
+
+// EffectiveGasTipCap returns the most a transaction is willing to pay the
+// block proposer above the base fee. A flat-GasPrice transaction has no
+// separate tip: its entire GasPrice above base fee is effectively the tip,
+// so this returns the same value EffectiveGasFeeCap does.
+func EffectiveGasTipCap(tx *Transaction) *big.Int {
+	if hasFlatGasPrice(tx) {
+		return tx.GasPrice
+	}
+	return tx.GasTipCap
+}
+
+// ValidateFeeCaps checks the EIP-1559 invariant that a dynamic-fee
+// transaction's tip can never exceed its total fee cap, and that its fee
+// cap clears the chain's current base fee floor. A flat-GasPrice
+// transaction carries neither cap and is exempt; its GasPrice is checked
+// against MinGasPrice elsewhere.
+func ValidateFeeCaps(tx *Transaction, baseFee *big.Int) error {
+	if hasFlatGasPrice(tx) {
+		return nil
+	}
+
+	feeCap, tipCap := tx.GasFeeCap, tx.GasTipCap
+	if feeCap == nil || tipCap == nil {
+		return fmt.Errorf("typed transaction missing gas fee cap/tip cap")
+	}
+	if feeCap.Sign() < 0 || tipCap.Sign() < 0 {
+		return fmt.Errorf("gas fee cap and tip cap must be non-negative")
+	}
+	if tipCap.Cmp(feeCap) > 0 {
+		return fmt.Errorf("gas tip cap %s exceeds gas fee cap %s", tipCap.String(), feeCap.String())
+	}
+	if baseFee != nil && feeCap.Cmp(baseFee) < 0 {
+		return fmt.Errorf("gas fee cap %s below base fee %s", feeCap.String(), baseFee.String())
+	}
+	return nil
+}
+
+// EffectiveGasTip returns what a transaction actually pays the proposer
+// once the base fee is deducted: min(tip cap, fee cap - base fee) for a
+// typed transaction, or GasPrice - baseFee for a legacy one. It's the value
+// TxPool.PendingFlat orders by, the same "priced by effective tip" rule
+// go-ethereum's miner uses to pick among competing transactions.
+func EffectiveGasTip(tx *Transaction, baseFee *big.Int) *big.Int {
+	if baseFee == nil {
+		return new(big.Int).Set(EffectiveGasTipCap(tx))
+	}
+
+	available := new(big.Int).Sub(EffectiveGasFeeCap(tx), baseFee)
+	tip := EffectiveGasTipCap(tx)
+	if available.Cmp(tip) < 0 {
+		return available
+	}
+	return new(big.Int).Set(tip)
+}
+
+// EffectiveGasPrice returns what a transaction actually pays per unit of
+// gas once included in a block with the given base fee: the base fee plus
+// the effective tip.
+func EffectiveGasPrice(tx *Transaction, baseFee *big.Int) *big.Int {
+	if baseFee == nil {
+		return new(big.Int).Set(EffectiveGasFeeCap(tx))
+	}
+	return new(big.Int).Add(baseFee, EffectiveGasTip(tx, baseFee))
+}
@@ -0,0 +1,47 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrGasLimitReached is wrapped by SubGas when a reservation would overdraw
+// the pool: the transaction's GasLimit is more than the block has left.
+var ErrGasLimitReached = errors.New("gas limit reached")
+
+// GasPool tracks how much gas a block has left, the same role go-ethereum's
+// core.GasPool plays: StateProcessor reserves each transaction's GasLimit
+// from it before executing, then refunds whatever the transaction didn't
+// actually use, so the cumulative reservations across a block can never
+// exceed its header's GasLimit.
+type GasPool uint64
+
+// AddGas makes gas available, erroring instead of silently wrapping if doing
+// so would overflow uint64.
+func (gp *GasPool) AddGas(amount uint64) error {
+	if uint64(*gp) > math.MaxUint64-amount {
+		return fmt.Errorf("gas pool overflow: adding %d to %d would exceed uint64 range", amount, uint64(*gp))
+	}
+	*gp += GasPool(amount)
+	return nil
+}
+
+// SubGas reserves amount from the pool, returning ErrGasLimitReached if the
+// pool doesn't have that much left.
+func (gp *GasPool) SubGas(amount uint64) error {
+	if uint64(*gp) < amount {
+		return fmt.Errorf("%w: have %d, want %d", ErrGasLimitReached, uint64(*gp), amount)
+	}
+	*gp -= GasPool(amount)
+	return nil
+}
+
+// Gas returns the amount of gas remaining in the pool.
+func (gp *GasPool) Gas() uint64 {
+	return uint64(*gp)
+}
+
+func (gp *GasPool) String() string {
+	return fmt.Sprintf("%d", uint64(*gp))
+}
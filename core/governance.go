@@ -0,0 +1,129 @@
+package core
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// ErrChainHalted is returned by block production and validation once a
+// ProposalTypeSetHaltBlock proposal has set a halt height at or before the
+// block in question.
+var ErrChainHalted = errors.New("core: chain halted by governance proposal")
+
+// ErrInvalidHaltHeight is returned when a SetHaltBlock proposal's payload
+// names a height the chain has already reached or passed.
+var ErrInvalidHaltHeight = errors.New("core: halt height must be above the current block")
+
+// haltHeightKey is the flat leveldb key the pending governance halt height is
+// stored under, following the same convention as the "currentBlock" pointer.
+var haltHeightKey = []byte("haltHeight")
+
+// EncodeHaltHeightPayload encodes height as a Proposal's Payload for
+// ProposalTypeSetHaltBlock, the same big-endian fixed-width convention
+// decodeDepositLog uses for a Deposit's Amount.
+func EncodeHaltHeightPayload(height uint64) []byte {
+	payload := make([]byte, 8)
+	binary.BigEndian.PutUint64(payload, height)
+	return payload
+}
+
+// DecodeHaltHeightPayload reverses EncodeHaltHeightPayload.
+func DecodeHaltHeightPayload(payload []byte) (uint64, error) {
+	if len(payload) != 8 {
+		return 0, fmt.Errorf("invalid halt height payload: want 8 bytes, got %d", len(payload))
+	}
+	return binary.BigEndian.Uint64(payload), nil
+}
+
+// ExecuteProposal applies a passed proposal's effect to chain state and
+// marks it Executed. Only proposal types with a registered effect below can
+// be executed; every other type returns an error rather than silently doing
+// nothing, the same way Daemon.sign refuses an account it can't evaluate a
+// policy for instead of signing unrestricted.
+func (bc *Blockchain) ExecuteProposal(p *Proposal) error {
+	if p.Status != ProposalStatusPassed {
+		return fmt.Errorf("cannot execute proposal %d: status is %v, not Passed", p.ID, p.Status)
+	}
+
+	switch p.Type {
+	case ProposalTypeSetHaltBlock:
+		height, err := DecodeHaltHeightPayload(p.Payload)
+		if err != nil {
+			return err
+		}
+		if err := bc.setHaltHeight(height); err != nil {
+			return err
+		}
+	case ProposalTypeUnhalt:
+		if err := bc.clearHaltHeight(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("no executor registered for proposal type %v", p.Type)
+	}
+
+	p.Status = ProposalStatusExecuted
+	return nil
+}
+
+// setHaltHeight persists height as the pending halt and caches it on bc, so
+// CreateBlock and ValidateBody see it without a database round trip on every
+// block. It's rejected if the chain has already reached or passed height, a
+// misconfigured proposal a validator restart can't silently fix.
+func (bc *Blockchain) setHaltHeight(height uint64) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	if height <= bc.currentBlock.Header().Number.Uint64() {
+		return ErrInvalidHaltHeight
+	}
+	if err := bc.db.Put(haltHeightKey, EncodeHaltHeightPayload(height), nil); err != nil {
+		return fmt.Errorf("failed to persist halt height: %w", err)
+	}
+	bc.haltHeight = height
+	return nil
+}
+
+// clearHaltHeight removes a pending halt, letting the chain resume producing
+// and accepting blocks past whatever height was previously set.
+func (bc *Blockchain) clearHaltHeight() error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	if err := bc.db.Delete(haltHeightKey, nil); err != nil {
+		return fmt.Errorf("failed to clear halt height: %w", err)
+	}
+	bc.haltHeight = 0
+	return nil
+}
+
+// HaltHeight reports the block height a governance proposal has scheduled
+// the chain to halt at, if any. A zero height means none is pending, since
+// genesis (height 0) can never itself be a halt target.
+func (bc *Blockchain) HaltHeight() (uint64, bool) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	return bc.haltHeight, bc.haltHeight != 0
+}
+
+// loadHaltHeight restores a halt height a prior run persisted, so a
+// validator can't be coaxed past a scheduled halt just by restarting.
+func (bc *Blockchain) loadHaltHeight() error {
+	data, err := bc.db.Get(haltHeightKey, nil)
+	if err == leveldb.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	height, err := DecodeHaltHeightPayload(data)
+	if err != nil {
+		return err
+	}
+	bc.haltHeight = height
+	return nil
+}
@@ -0,0 +1,247 @@
+package core
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// Canonical chain index, mirroring go-ethereum's rawdb key schema: headerPrefix
+// maps a block number to the hash of the canonical block at that height, and
+// headerNumberPrefix is the reverse lookup. Together they let GetBlockByNumber,
+// HasBlock, and reorgTo answer "what's canonical at height N?" with a single
+// leveldb read instead of walking parent hashes from the head.
+var (
+	headerPrefix       = []byte("h") // headerPrefix + num (8 bytes BE) -> hash
+	headerNumberPrefix = []byte("H") // headerNumberPrefix + hash -> num (8 bytes BE)
+)
+
+// bloomIndexPrefix maps a canonical block number to that block's aggregated
+// log bloom (see CreateBloom), written alongside the canonical index in
+// writeCanonical. FilterLogs reads this directly so it can rule out a block
+// without decoding its full RLP encoding just to read the header's Bloom.
+var bloomIndexPrefix = []byte("bloom-")
+
+func bloomIndexKey(number uint64) []byte {
+	return append(append([]byte{}, bloomIndexPrefix...), encodeBlockNumber(number)...)
+}
+
+func encodeBlockNumber(number uint64) []byte {
+	enc := make([]byte, 8)
+	binary.BigEndian.PutUint64(enc, number)
+	return enc
+}
+
+func canonicalHashKey(number uint64) []byte {
+	return append(append([]byte{}, headerPrefix...), encodeBlockNumber(number)...)
+}
+
+func headerNumberKey(hash common.Hash) []byte {
+	return append(append([]byte{}, headerNumberPrefix...), hash.Bytes()...)
+}
+
+// headerChainCacheLimit bounds how many recent headers HeaderChain keeps in
+// memory; anything older falls back to the canonical index in leveldb.
+const headerChainCacheLimit = 256
+
+// HeaderChain caches the canonical chain's most recent headers by number, so
+// GetHeaderByNumber and GetBlockByNumber avoid a leveldb round trip on the
+// hot path (RPC block-by-number lookups, tip-following subscribers).
+type HeaderChain struct {
+	mu      sync.RWMutex
+	headers map[uint64]*BlockHeader
+}
+
+func newHeaderChain() *HeaderChain {
+	return &HeaderChain{headers: make(map[uint64]*BlockHeader)}
+}
+
+func (hc *HeaderChain) get(number uint64) (*BlockHeader, bool) {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	h, ok := hc.headers[number]
+	return h, ok
+}
+
+func (hc *HeaderChain) put(number uint64, header *BlockHeader) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.headers[number] = header
+	if number >= headerChainCacheLimit {
+		delete(hc.headers, number-headerChainCacheLimit)
+	}
+}
+
+// writeCanonical records block as the canonical block at its number via the
+// h/H index pair and refreshes the in-memory header cache. Called for every
+// block that becomes (or stays) part of the canonical chain: a plain append
+// in writeBlock, or each block reorgTo promotes from a winning fork.
+func (bc *Blockchain) writeCanonical(block *Block) error {
+	number := block.Header().Number.Uint64()
+	hash := block.Hash()
+
+	if err := bc.db.Put(canonicalHashKey(number), hash.Bytes(), nil); err != nil {
+		return fmt.Errorf("failed to write canonical index: %w", err)
+	}
+	if err := bc.db.Put(headerNumberKey(hash), encodeBlockNumber(number), nil); err != nil {
+		return fmt.Errorf("failed to write header number index: %w", err)
+	}
+	if err := bc.db.Put(bloomIndexKey(number), block.Header().Bloom[:], nil); err != nil {
+		return fmt.Errorf("failed to write bloom index: %w", err)
+	}
+
+	bc.headerChain.put(number, block.Header())
+	return nil
+}
+
+// GetCanonicalHash returns the hash of the canonical block at number, or
+// ErrBlockNotFound if the chain hasn't reached that height (or it was never
+// canonical).
+func (bc *Blockchain) GetCanonicalHash(number *big.Int) (common.Hash, error) {
+	data, err := bc.db.Get(canonicalHashKey(number.Uint64()), nil)
+	if err != nil {
+		if err == leveldb.ErrNotFound {
+			return common.Hash{}, ErrBlockNotFound
+		}
+		return common.Hash{}, fmt.Errorf("database error: %w", err)
+	}
+	return common.BytesToHash(data), nil
+}
+
+// GetBloomByNumber returns the canonical block's aggregated log bloom at
+// number from the bloom index, without touching the block it was computed
+// from. FilterLogs uses this to skip a block's receipts entirely when the
+// bloom rules out every address/topic it's searching for.
+func (bc *Blockchain) GetBloomByNumber(number *big.Int) (Bloom, error) {
+	data, err := bc.db.Get(bloomIndexKey(number.Uint64()), nil)
+	if err != nil {
+		if err == leveldb.ErrNotFound {
+			return Bloom{}, ErrBlockNotFound
+		}
+		return Bloom{}, fmt.Errorf("database error: %w", err)
+	}
+	var bloom Bloom
+	copy(bloom[:], data)
+	return bloom, nil
+}
+
+// GetHeaderByNumber returns the canonical header at number, served from the
+// in-memory HeaderChain cache when possible.
+func (bc *Blockchain) GetHeaderByNumber(number *big.Int) (*BlockHeader, error) {
+	if header, ok := bc.headerChain.get(number.Uint64()); ok {
+		return header, nil
+	}
+	hash, err := bc.GetCanonicalHash(number)
+	if err != nil {
+		return nil, err
+	}
+	return bc.GetHeaderByHash(hash)
+}
+
+// GetHeaderByHash returns the header of the block with the given hash,
+// canonical or not, caching it in the HeaderChain for future number-keyed
+// lookups only when it is the canonical block at its number.
+func (bc *Blockchain) GetHeaderByHash(hash common.Hash) (*BlockHeader, error) {
+	block, err := bc.GetBlockByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	header := block.Header()
+	if canonical, err := bc.GetCanonicalHash(header.Number); err == nil && canonical == hash {
+		bc.headerChain.put(header.Number.Uint64(), header)
+	}
+	return header, nil
+}
+
+// HasBlock reports whether a block with the given hash and number is known
+// to the chain, canonical or on a side branch.
+func (bc *Blockchain) HasBlock(hash common.Hash, number *big.Int) bool {
+	if canonical, err := bc.GetCanonicalHash(number); err == nil && canonical == hash {
+		return true
+	}
+	if _, exists := bc.blockCache[hash]; exists {
+		return true
+	}
+	has, err := bc.db.Has(hash.Bytes(), nil)
+	return err == nil && has
+}
+
+// reorgTo switches the canonical chain to end at newHead, which extends an
+// ancestor of the current head rather than the head itself (newHead must
+// already be persisted via storeBlock). It walks both chains back to their
+// common ancestor — the same algorithm go-ethereum's core.reorg uses —
+// rewrites the canonical index for every block the new chain contributes,
+// and reports the switch on the event bus: each displaced old-chain block to
+// SubscribeChainSide subscribers, then the whole swap as one ChainReorgEvent.
+func (bc *Blockchain) reorgTo(newHead *Block) error {
+	oldHead := bc.currentBlock
+
+	var oldChain, newChain []*Block
+	oldBlock, newBlock := oldHead, newHead
+
+	for oldBlock.Header().Number.Cmp(newBlock.Header().Number) > 0 {
+		oldChain = append(oldChain, oldBlock)
+		parent, err := bc.GetBlockByHash(oldBlock.Header().ParentHash)
+		if err != nil {
+			return fmt.Errorf("old chain parent not found: %w", err)
+		}
+		oldBlock = parent
+	}
+	for newBlock.Header().Number.Cmp(oldBlock.Header().Number) > 0 {
+		newChain = append(newChain, newBlock)
+		parent, err := bc.GetBlockByHash(newBlock.Header().ParentHash)
+		if err != nil {
+			return fmt.Errorf("new chain parent not found: %w", err)
+		}
+		newBlock = parent
+	}
+	for oldBlock.Hash() != newBlock.Hash() {
+		oldChain = append(oldChain, oldBlock)
+		newChain = append(newChain, newBlock)
+
+		oldParent, err := bc.GetBlockByHash(oldBlock.Header().ParentHash)
+		if err != nil {
+			return fmt.Errorf("old chain parent not found: %w", err)
+		}
+		newParent, err := bc.GetBlockByHash(newBlock.Header().ParentHash)
+		if err != nil {
+			return fmt.Errorf("new chain parent not found: %w", err)
+		}
+		oldBlock, newBlock = oldParent, newParent
+	}
+
+	// oldChain/newChain were built newest-first (tip before fork point); flip
+	// both to oldest-first, the order ChainReorgEvent documents, then write
+	// the canonical index in that order so no concurrent reader ever sees a
+	// gap between the fork point and the new tip.
+	reverseBlocks(oldChain)
+	reverseBlocks(newChain)
+
+	for _, b := range newChain {
+		if err := bc.writeCanonical(b); err != nil {
+			return err
+		}
+	}
+
+	if err := bc.db.Put([]byte("currentBlock"), newHead.Hash().Bytes(), nil); err != nil {
+		return fmt.Errorf("failed to update current block: %w", err)
+	}
+	bc.currentBlock = newHead
+
+	for _, b := range oldChain {
+		bc.events.publishChainSide(b)
+	}
+	bc.events.publishChainReorg(ChainReorgEvent{Old: oldChain, New: newChain})
+
+	return nil
+}
+
+func reverseBlocks(blocks []*Block) {
+	for i, j := 0, len(blocks)-1; i < j; i, j = i+1, j-1 {
+		blocks[i], blocks[j] = blocks[j], blocks[i]
+	}
+}
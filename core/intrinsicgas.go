@@ -0,0 +1,78 @@
+package core
+
+import (
+	"fmt"
+	"math"
+)
+
+// Intrinsic gas constants: the flat, data-independent cost every call or
+// transaction owes before the EVM runs a single instruction, priced the
+// same way go-ethereum's core.IntrinsicGas does.
+const (
+	// TxGas is the base cost of any call or transaction, contract creation
+	// or data aside.
+	TxGas uint64 = 21000
+
+	// TxDataZeroGas is charged per zero byte of calldata.
+	TxDataZeroGas uint64 = 4
+
+	// TxDataNonZeroGasEIP2028 is charged per nonzero byte of calldata: the
+	// pre-Istanbul 68-gas rate. Despite the name, EIP-2028's reduced
+	// 16-gas rate is not applied.
+	TxDataNonZeroGasEIP2028 uint64 = 68
+
+	// TxAccessListAddressGas is charged once per address an EIP-2930
+	// access list declares, regardless of how many storage keys follow it.
+	TxAccessListAddressGas uint64 = 2400
+
+	// TxAccessListStorageKeyGas is charged per storage key an EIP-2930
+	// access list declares across every address.
+	TxAccessListStorageKeyGas uint64 = 1900
+)
+
+// IntrinsicGas returns the gas a call or transaction owes before the EVM
+// runs: TxGas, plus TxDataNonZeroGasEIP2028/TxDataZeroGas per calldata
+// byte, plus TxAccessListAddressGas/TxAccessListStorageKeyGas per access
+// list entry. EstimateGas uses it as the floor of its binary search; the
+// transaction pool and block validator will want the same floor once they
+// start rejecting underpriced transactions before running them.
+func IntrinsicGas(data []byte, accessList AccessList) (uint64, error) {
+	gas := TxGas
+
+	var nonZero, zero uint64
+	for _, b := range data {
+		if b == 0 {
+			zero++
+		} else {
+			nonZero++
+		}
+	}
+	if nonZero > 0 {
+		if (math.MaxUint64-gas)/TxDataNonZeroGasEIP2028 < nonZero {
+			return 0, fmt.Errorf("intrinsic gas overflow: calldata too large")
+		}
+		gas += nonZero * TxDataNonZeroGasEIP2028
+	}
+	if zero > 0 {
+		if (math.MaxUint64-gas)/TxDataZeroGas < zero {
+			return 0, fmt.Errorf("intrinsic gas overflow: calldata too large")
+		}
+		gas += zero * TxDataZeroGas
+	}
+
+	if len(accessList) > 0 {
+		addrCost := uint64(len(accessList)) * TxAccessListAddressGas
+		if math.MaxUint64-gas < addrCost {
+			return 0, fmt.Errorf("intrinsic gas overflow: access list too large")
+		}
+		gas += addrCost
+
+		keyCost := uint64(accessList.StorageKeys()) * TxAccessListStorageKeyGas
+		if math.MaxUint64-gas < keyCost {
+			return 0, fmt.Errorf("intrinsic gas overflow: access list too large")
+		}
+		gas += keyCost
+	}
+
+	return gas, nil
+}
@@ -0,0 +1,305 @@
+package core
+
+import "math/big"
+
+// journalEntry is one undo step recorded against a State's journal. Every
+// mutating method on State (SetBalance, SetNonce, SetCode, SetCodeHash,
+// SetState, CreateAccount, Suicide) appends one before applying its change,
+// so RevertToSnapshot can walk the journal backwards and undo exactly the
+// writes a failed call made — without cloning the whole cache the way
+// State.Copy does for the heavier block-level shadow-execution case.
+type journalEntry interface {
+	revert(s *State)
+}
+
+type balanceChange struct {
+	addr Address
+	prev *big.Int
+}
+
+func (c balanceChange) revert(s *State) {
+	s.cache[c.addr].Balance = c.prev
+}
+
+type nonceChange struct {
+	addr Address
+	prev uint64
+}
+
+func (c nonceChange) revert(s *State) {
+	s.cache[c.addr].Nonce = c.prev
+}
+
+type codeChange struct {
+	addr         Address
+	prevCode     []byte
+	prevCodeHash []byte
+}
+
+func (c codeChange) revert(s *State) {
+	account := s.cache[c.addr]
+	account.Code = c.prevCode
+	account.CodeHash = c.prevCodeHash
+}
+
+type storageChange struct {
+	addr Address
+	key  Hash
+	prev Hash
+	had  bool
+}
+
+func (c storageChange) revert(s *State) {
+	account := s.cache[c.addr]
+	if c.had {
+		account.Storage[c.key] = c.prev
+	} else {
+		delete(account.Storage, c.key)
+	}
+}
+
+// createAccountChange undoes CreateAccount: since the account didn't exist
+// before, reverting past its creation removes it from the cache entirely
+// rather than restoring some prior value.
+type createAccountChange struct {
+	addr Address
+}
+
+func (c createAccountChange) revert(s *State) {
+	delete(s.cache, c.addr)
+}
+
+// suicideChange undoes Suicide, restoring both the suicided flag and the
+// balance it zeroed in the same step they were set in.
+type suicideChange struct {
+	addr        Address
+	prevSuicide bool
+	prevBalance *big.Int
+}
+
+func (c suicideChange) revert(s *State) {
+	account := s.cache[c.addr]
+	account.suicided = c.prevSuicide
+	account.Balance = c.prevBalance
+}
+
+type refundChange struct {
+	prev uint64
+}
+
+func (c refundChange) revert(s *State) {
+	s.refund = c.prev
+}
+
+// accessListAddrChange undoes AddAddressToAccessList: since an address is
+// only ever added once (AddAddressToAccessList is a no-op on an address
+// that's already warm), reverting it just removes it again rather than
+// restoring some prior value.
+type accessListAddrChange struct {
+	addr Address
+}
+
+func (c accessListAddrChange) revert(s *State) {
+	delete(s.accessedAddresses, c.addr)
+}
+
+// accessListSlotChange undoes AddSlotToAccessList the same way
+// accessListAddrChange undoes AddAddressToAccessList, one (address, key)
+// pair at a time.
+type accessListSlotChange struct {
+	addr Address
+	key  Hash
+}
+
+func (c accessListSlotChange) revert(s *State) {
+	slots := s.accessedSlots[c.addr]
+	if slots == nil {
+		return
+	}
+	delete(slots, c.key)
+	if len(slots) == 0 {
+		delete(s.accessedSlots, c.addr)
+	}
+}
+
+// transientStorageChange undoes SetTransientState, the same prev/had shape
+// storageChange uses for ordinary Storage.
+type transientStorageChange struct {
+	addr Address
+	key  Hash
+	prev Hash
+	had  bool
+}
+
+func (c transientStorageChange) revert(s *State) {
+	if c.had {
+		s.transientStorage[c.addr][c.key] = c.prev
+		return
+	}
+	delete(s.transientStorage[c.addr], c.key)
+}
+
+// Snapshot returns an identifier for the journal's current length. Passing
+// it to RevertToSnapshot later undoes every change made since this call,
+// leaving everything before it untouched — the mechanism CreateContract and
+// Call use to roll back a failed nested call without losing the caller's
+// own state changes.
+func (s *State) Snapshot() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.journal)
+}
+
+// RevertToSnapshot undoes every journal entry recorded since the matching
+// Snapshot call, in reverse order.
+func (s *State) RevertToSnapshot(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := len(s.journal) - 1; i >= id; i-- {
+		s.journal[i].revert(s)
+	}
+	s.journal = s.journal[:id]
+}
+
+// AddRefund accumulates a gas refund (e.g. from SSTORE clearing a storage
+// slot) against the current transaction, journaled so a RevertToSnapshot
+// past this point un-accumulates it too.
+func (s *State) AddRefund(gas uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.journal = append(s.journal, refundChange{prev: s.refund})
+	s.refund += gas
+}
+
+// SubRefund reverses a previously added refund (e.g. a slot that was
+// cleared and then re-set within the same transaction).
+func (s *State) SubRefund(gas uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.journal = append(s.journal, refundChange{prev: s.refund})
+	if gas > s.refund {
+		s.refund = 0
+		return
+	}
+	s.refund -= gas
+}
+
+// GetRefund returns the gas refund accumulated so far this transaction.
+func (s *State) GetRefund() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.refund
+}
+
+// CreateAccount ensures addr has a cached account of its own, distinct from
+// the zero-value placeholder getAccount hands back to a plain read. It's
+// what CreateContract calls before deploying code to a fresh address, so
+// that a RevertToSnapshot covering a failed CREATE erases the address
+// entirely rather than leaving a zero-balance husk behind. Calling it on an
+// address that's already been touched is a no-op — CreateAccount never
+// clobbers an existing balance.
+func (s *State) CreateAccount(addr Address) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.cache[addr]; exists {
+		return
+	}
+	s.journal = append(s.journal, createAccountChange{addr: addr})
+	s.cache[addr] = &Account{
+		Balance: big.NewInt(0),
+		Storage: make(map[Hash]Hash),
+	}
+}
+
+// Suicide marks addr for removal at the next Finalise and zeroes its
+// balance immediately (the SELFDESTRUCT opcode's own effect on the caller's
+// balance happens separately, via a transfer to the beneficiary before this
+// is called). It reports whether addr was actually marked — false if it
+// already was.
+func (s *State) Suicide(addr Address) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account := s.getAccount(addr)
+	if account.suicided {
+		return false
+	}
+	s.journal = append(s.journal, suicideChange{addr: addr, prevSuicide: account.suicided, prevBalance: account.Balance})
+	account.suicided = true
+	account.Balance = new(big.Int)
+	return true
+}
+
+// HasSuicided reports whether addr was marked for removal by Suicide during
+// the current execution.
+func (s *State) HasSuicided(addr Address) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	account, exists := s.cache[addr]
+	return exists && account.suicided
+}
+
+// empty reports whether an account is indistinguishable from one that was
+// never created, the EIP-158 condition Finalise uses to decide what to
+// prune once a transaction can no longer be rolled back.
+func (a *Account) empty() bool {
+	return a.Nonce == 0 &&
+		(a.Balance == nil || a.Balance.Sign() == 0) &&
+		(len(a.CodeHash) == 0 || string(a.CodeHash) == string(emptyCodeHash))
+}
+
+// Empty reports whether addr currently holds zero balance, zero nonce, and
+// no code.
+func (s *State) Empty(addr Address) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.getAccount(addr).empty()
+}
+
+// Exist reports whether addr is known to State, either because it's
+// already cached with non-empty contents or because it has a leaf in the
+// committed trie. A plain read through GetBalance/GetNonce/etc. touches an
+// address without making it "exist" in this sense — only a non-empty
+// account or one with prior trie history does.
+func (s *State) Exist(addr Address) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if account, cached := s.cache[addr]; cached && !account.empty() {
+		return true
+	}
+	enc, err := s.trie.Get(Keccak256(addr.Bytes()))
+	return err == nil && enc != nil
+}
+
+// Finalise is called once a transaction can no longer be rolled back: it
+// discards the journal (RevertToSnapshot can no longer reach past this
+// point) and, when deleteEmpty is set (true once EIP-158 is active), drops
+// every suicided or EIP-158-empty account from the cache so Commit removes
+// it from the trie instead of writing a leaf for it. The EIP-2929 access
+// list and EIP-1153 transient storage are both scoped to a single
+// transaction the same way the journal is, so both are reset here too —
+// transient storage in particular must never survive into the next
+// transaction, since (unlike Storage) nothing ever flushes it to the trie.
+func (s *State) Finalise(deleteEmpty bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if deleteEmpty {
+		for addr, account := range s.cache {
+			if account.suicided || account.empty() {
+				s.pendingDestructs[addr] = true
+				delete(s.cache, addr)
+			}
+		}
+	}
+	s.journal = nil
+	s.accessedAddresses = make(map[Address]bool)
+	s.accessedSlots = make(map[Address]map[Hash]bool)
+	s.transientStorage = make(map[Address]map[Hash]Hash)
+}
@@ -0,0 +1,220 @@
+package core
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/DioraFund/diora-go-blockchain/vm"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// pendingBlockRetention bounds how many heights behind the current head a
+// pending entry is allowed to survive before the pruner evicts it. A
+// proposal that's still waiting this far behind almost certainly lost to a
+// competing block that already got finalized.
+const pendingBlockRetention = 64
+
+// PendingBlock is a block that has been speculatively executed against a
+// shadow copy of chain state but hasn't been validated into the canonical
+// chain yet. RPC and consensus code can read it the same way they'd read a
+// finalized block — Receipts/Logs/GasUsed are already known — without
+// taking bc.mu's write lock, since the shadow State it was executed against
+// is private to this entry.
+type PendingBlock struct {
+	Block    *Block
+	Receipts []*Receipt
+	Logs     []*Log
+	GasUsed  uint64
+
+	// State is the shadow copy of chain state this block was executed
+	// against. ProcessBlock adopts it directly as the chain's new state
+	// when this entry turns out to match the block it's asked to finalize,
+	// instead of re-running every transaction.
+	State *State
+
+	computedAt time.Time
+}
+
+// PreExecuteBlock validates and executes a proposed block against a shadow
+// copy of the chain's current state, publishing the result to the pending
+// cache so GetPendingBlockByHash/GetPendingBlockByNumber and a later
+// ProcessBlock call can pick it up. It only takes bc.mu's read lock, so
+// several proposals can be pre-executed concurrently with each other and
+// with RPC reads of the canonical chain; only ProcessBlock's final swap-in
+// needs the write lock.
+func (bc *Blockchain) PreExecuteBlock(block *Block) (*PendingBlock, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	if err := bc.validator.ValidateBody(block); err != nil {
+		return nil, err
+	}
+
+	shadow := bc.state.Copy()
+	receipts, logs, usedGas, err := executeBlock(block, shadow, bc.config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pre-execute block: %w", err)
+	}
+
+	pb := &PendingBlock{
+		Block:      block,
+		Receipts:   receipts,
+		Logs:       logs,
+		GasUsed:    usedGas,
+		State:      shadow,
+		computedAt: time.Now(),
+	}
+	bc.putPending(pb)
+	return pb, nil
+}
+
+// executeBlock runs a block's transactions against state via a throwaway
+// EVM bound to it, the same GasPool-metered loop StateProcessor.Process
+// uses against the chain's live state. It's factored out so pre-execution
+// can run against a shadow copy without StateProcessor ever touching
+// bc.state.
+func executeBlock(block *Block, state *State, config *Config) ([]*Receipt, []*Log, uint64, error) {
+	gasLimit := config.GasLimit
+	blockNumber := big.NewInt(0)
+	if header := block.Header(); header != nil {
+		gasLimit = header.GasLimit
+		blockNumber = header.Number
+	}
+
+	gp := new(GasPool)
+	if err := gp.AddGas(gasLimit); err != nil {
+		return nil, nil, 0, err
+	}
+
+	evm := vm.NewEVM(state, config)
+
+	var (
+		receipts []*Receipt
+		logs     []*Log
+		usedGas  uint64
+	)
+	for _, tx := range block.Transactions() {
+		if err := gp.SubGas(tx.GasLimit); err != nil {
+			return nil, nil, 0, fmt.Errorf("transaction %s: %w", tx.Hash.Hex(), err)
+		}
+
+		receipt, err := evm.ExecuteTransaction(tx, blockNumber)
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("failed to execute transaction %s: %w", tx.Hash.Hex(), err)
+		}
+
+		if err := gp.AddGas(tx.GasLimit - receipt.GasUsed); err != nil {
+			return nil, nil, 0, fmt.Errorf("transaction %s: %w", tx.Hash.Hex(), err)
+		}
+
+		receipts = append(receipts, receipt)
+		logs = append(logs, receipt.Logs...)
+		usedGas += receipt.GasUsed
+	}
+
+	return receipts, logs, usedGas, nil
+}
+
+// putPending indexes pb by both hash and number, replacing any earlier
+// pre-execution of the same block (a validator that re-proposes after a
+// timeout, say).
+func (bc *Blockchain) putPending(pb *PendingBlock) {
+	bc.pendingMu.Lock()
+	defer bc.pendingMu.Unlock()
+
+	number := pb.Block.Header().Number.Uint64()
+	bc.pendingBlocks[pb.Block.Hash()] = pb
+	if bc.pendingByNumber[number] == nil {
+		bc.pendingByNumber[number] = make(map[common.Hash]struct{})
+	}
+	bc.pendingByNumber[number][pb.Block.Hash()] = struct{}{}
+}
+
+// getPending returns the pending entry for hash, if it's still cached.
+func (bc *Blockchain) getPending(hash common.Hash) (*PendingBlock, bool) {
+	bc.pendingMu.RLock()
+	defer bc.pendingMu.RUnlock()
+	pb, ok := bc.pendingBlocks[hash]
+	return pb, ok
+}
+
+// removePending drops a single pending entry, e.g. once ProcessBlock has
+// finalized or rejected it.
+func (bc *Blockchain) removePending(hash common.Hash, number uint64) {
+	bc.pendingMu.Lock()
+	defer bc.pendingMu.Unlock()
+	delete(bc.pendingBlocks, hash)
+	delete(bc.pendingByNumber[number], hash)
+	if len(bc.pendingByNumber[number]) == 0 {
+		delete(bc.pendingByNumber, number)
+	}
+}
+
+// GetPendingBlockByHash returns the speculatively executed block with the
+// given hash, if one is still cached.
+func (bc *Blockchain) GetPendingBlockByHash(hash common.Hash) (*PendingBlock, bool) {
+	return bc.getPending(hash)
+}
+
+// GetPendingBlockByNumber returns a speculatively executed block at the
+// given height, if one is cached. When more than one proposal is pending at
+// that height (a competing set of candidates), it returns an arbitrary one
+// of them — callers that care about a specific proposer's version should go
+// through GetPendingBlockByHash instead.
+func (bc *Blockchain) GetPendingBlockByNumber(number *big.Int) (*PendingBlock, bool) {
+	bc.pendingMu.RLock()
+	defer bc.pendingMu.RUnlock()
+
+	for hash := range bc.pendingByNumber[number.Uint64()] {
+		return bc.pendingBlocks[hash], true
+	}
+	return nil, false
+}
+
+// pendingBlockPruner periodically evicts pending entries that have fallen
+// more than pendingBlockRetention heights behind the current head: either
+// they lost to a competing block that's already canonical, or nobody ever
+// finalized them. Canonical finalization already removes its own entry via
+// removePending in ProcessBlock, so this only needs to catch the ones that
+// never got there.
+func (bc *Blockchain) pendingBlockPruner() {
+	defer bc.wg.Done()
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-bc.stopCh:
+			return
+		case <-ticker.C:
+			bc.prunePendingBlocks()
+		}
+	}
+}
+
+func (bc *Blockchain) prunePendingBlocks() {
+	head := bc.GetCurrentBlock()
+	if head == nil {
+		return
+	}
+	headNumber := head.Header().Number.Uint64()
+
+	bc.pendingMu.Lock()
+	defer bc.pendingMu.Unlock()
+
+	for number, hashes := range bc.pendingByNumber {
+		// A pending entry at or below the canonical head's own height has
+		// either been superseded by that canonical block or lost to a
+		// sibling that got finalized instead; either way it's stale.
+		stale := number <= headNumber || number+pendingBlockRetention < headNumber
+		if !stale {
+			continue
+		}
+		for hash := range hashes {
+			delete(bc.pendingBlocks, hash)
+		}
+		delete(bc.pendingByNumber, number)
+	}
+}
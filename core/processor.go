@@ -0,0 +1,77 @@
+package core
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Processor executes a block's transactions against a state, producing the
+// receipts, logs, and gas usage ValidateState and ProcessBlock need to check
+// and commit it. Splitting it out of Blockchain (mirroring go-ethereum's
+// StateProcessor) lets alternate execution strategies — parallel execution,
+// a tracing processor, historical replay against an old state — plug in
+// without touching chain bookkeeping.
+type Processor interface {
+	Process(block *Block, state *State) ([]*Receipt, []*Log, uint64, error)
+}
+
+// StateProcessor is the default Processor, matching the transaction-loop
+// Blockchain used to run directly inside ExecuteTransactions.
+type StateProcessor struct {
+	config    *Config
+	bc        *Blockchain
+	consensus Consensus
+}
+
+// NewStateProcessor creates a Processor bound to a chain and its consensus
+// engine.
+func NewStateProcessor(config *Config, bc *Blockchain, engine Consensus) *StateProcessor {
+	return &StateProcessor{config: config, bc: bc, consensus: engine}
+}
+
+func (p *StateProcessor) Process(block *Block, state *State) ([]*Receipt, []*Log, uint64, error) {
+	// A block built from a bare transaction list (ExecuteTransactions has no
+	// header to read a limit from) falls back to the chain's configured
+	// GasLimit.
+	gasLimit := p.config.GasLimit
+	blockNumber := big.NewInt(0)
+	if header := block.Header(); header != nil {
+		gasLimit = header.GasLimit
+		blockNumber = header.Number
+	}
+
+	gp := new(GasPool)
+	if err := gp.AddGas(gasLimit); err != nil {
+		return nil, nil, 0, err
+	}
+
+	var (
+		receipts []*Receipt
+		logs     []*Log
+		usedGas  uint64
+	)
+
+	for _, tx := range block.Transactions() {
+		// Reserve the tx's full GasLimit up front, the way go-ethereum's
+		// GasPool does, so a block can never admit more committed gas than
+		// its header allows regardless of how much each tx actually uses.
+		if err := gp.SubGas(tx.GasLimit); err != nil {
+			return nil, nil, 0, fmt.Errorf("transaction %s: %w", tx.Hash.Hex(), err)
+		}
+
+		receipt, err := p.bc.vm.ExecuteTransaction(tx, blockNumber)
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("failed to execute transaction %s: %w", tx.Hash.Hex(), err)
+		}
+
+		if err := gp.AddGas(tx.GasLimit - receipt.GasUsed); err != nil {
+			return nil, nil, 0, fmt.Errorf("transaction %s: %w", tx.Hash.Hex(), err)
+		}
+
+		receipts = append(receipts, receipt)
+		logs = append(logs, receipt.Logs...)
+		usedGas += receipt.GasUsed
+	}
+
+	return receipts, logs, usedGas, nil
+}
@@ -0,0 +1,49 @@
+package core
+
+import "math/big"
+
+// Rules is a snapshot of which hard forks are active at a given block
+// number, derived once from Config so the rest of the core package never
+// has to compare *big.Int block numbers itself. It mirrors vm.ChainRules,
+// but lives here rather than being shared with vm because core can't import
+// vm without creating an import cycle (vm already imports core) — so the
+// two packages each keep their own snapshot of the fork schedule, one for
+// state-transition concerns (this one) and one for VM opcode/precompile
+// gating (vm.ChainRules).
+type Rules struct {
+	IsHomestead bool
+	IsEIP150    bool
+	IsEIP158    bool
+	IsByzantium bool
+	IsIstanbul  bool
+	IsBerlin    bool
+	IsCancun    bool
+}
+
+// Rules derives the Rules in effect at blockNum from c's fork schedule. As
+// with vm.ChainRules, each flag is computed from its own fork block alone, so
+// a later fork's flag implies the earlier ones only when c schedules the
+// forks in order.
+func (c *Config) Rules(blockNum *big.Int) Rules {
+	return Rules{
+		IsHomestead: isForkActive(c.HomesteadBlock, blockNum),
+		IsEIP150:    isForkActive(c.EIP150Block, blockNum),
+		IsEIP158:    isForkActive(c.EIP158Block, blockNum),
+		IsByzantium: isForkActive(c.ByzantiumBlock, blockNum),
+		IsIstanbul:  isForkActive(c.IstanbulBlock, blockNum),
+		IsBerlin:    isForkActive(c.BerlinBlock, blockNum),
+		IsCancun:    isForkActive(c.CancunBlock, blockNum),
+	}
+}
+
+// isForkActive reports whether forkBlock has been reached by blockNum. A
+// nil forkBlock means the fork isn't scheduled at all, so it's never active
+// regardless of blockNum. Kept as its own unexported copy of
+// vm/chain_rules.go's helper of the same name, for the same reason Rules
+// itself is: core and vm can't share code without an import cycle.
+func isForkActive(forkBlock, blockNum *big.Int) bool {
+	if forkBlock == nil || blockNum == nil {
+		return false
+	}
+	return blockNum.Cmp(forkBlock) >= 0
+}
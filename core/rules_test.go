@@ -0,0 +1,84 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestConfigRulesForkActivation checks Rules' nil-means-never-scheduled and
+// supersede-earlier-forks behavior against a representative fork schedule,
+// the same thing vm/chain_rules_test.go (if it existed) would check for
+// vm.Rules.
+func TestConfigRulesForkActivation(t *testing.T) {
+	config := &Config{
+		HomesteadBlock: big.NewInt(1),
+		EIP150Block:    big.NewInt(2),
+		EIP158Block:    big.NewInt(3),
+		ByzantiumBlock: big.NewInt(4),
+	}
+
+	for _, tc := range []struct {
+		block      int64
+		wantEIP150 bool
+		wantEIP158 bool
+		wantByzant bool
+	}{
+		{block: 0, wantEIP150: false, wantEIP158: false, wantByzant: false},
+		{block: 2, wantEIP150: true, wantEIP158: false, wantByzant: false},
+		{block: 3, wantEIP150: true, wantEIP158: true, wantByzant: false},
+		{block: 4, wantEIP150: true, wantEIP158: true, wantByzant: true},
+	} {
+		rules := config.Rules(big.NewInt(tc.block))
+		if rules.IsEIP150 != tc.wantEIP150 {
+			t.Errorf("block %d: IsEIP150 = %v, want %v", tc.block, rules.IsEIP150, tc.wantEIP150)
+		}
+		if rules.IsEIP158 != tc.wantEIP158 {
+			t.Errorf("block %d: IsEIP158 = %v, want %v", tc.block, rules.IsEIP158, tc.wantEIP158)
+		}
+		if rules.IsByzantium != tc.wantByzant {
+			t.Errorf("block %d: IsByzantium = %v, want %v", tc.block, rules.IsByzantium, tc.wantByzant)
+		}
+	}
+
+	// CancunBlock was never set, so it's never active regardless of how
+	// high blockNum climbs.
+	if config.Rules(big.NewInt(1_000_000)).IsCancun {
+		t.Fatalf("IsCancun = true with CancunBlock nil, want false")
+	}
+}
+
+// TestStateFinaliseEIP158Gating mines the same "drain an account to empty"
+// state transition on either side of an EIP158Block fork height and asserts
+// Finalise only prunes the emptied account once Rules says EIP158 is active
+// — before the fork it must still be committed with its zeroed-out fields,
+// matching Ethereum's pre-Spurious-Dragon behavior of leaving empty accounts
+// in the trie.
+func TestStateFinaliseEIP158Gating(t *testing.T) {
+	config := &Config{EIP158Block: big.NewInt(10)}
+	addr := HexToAddress("0x00000000000000000000000000000000000042")
+
+	drainAndFinalise := func(blockNum int64) bool {
+		db := openTrieTestDB(t)
+		state := NewState(db)
+
+		state.CreateAccount(addr)
+		state.SetBalance(addr, big.NewInt(5))
+		state.SetBalance(addr, big.NewInt(0))
+
+		rules := config.Rules(big.NewInt(blockNum))
+		state.Finalise(rules.IsEIP158)
+		// Exist ignores an empty account that is only cached, so commit
+		// first and ask whether its leaf made it into the trie.
+		if _, err := state.Commit(); err != nil {
+			t.Fatalf("block %d: Commit: %v", blockNum, err)
+		}
+		return state.Exist(addr)
+	}
+
+	if !drainAndFinalise(9) {
+		t.Fatalf("block 9 (pre-EIP158): emptied account must survive Finalise")
+	}
+	if drainAndFinalise(10) {
+		t.Fatalf("block 10 (post-EIP158): emptied account must be pruned by Finalise")
+	}
+}
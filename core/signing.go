@@ -0,0 +1,160 @@
+package core
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/DioraFund/diora-go-blockchain/crypto"
+)
+
+// SigningHash is the hash a LegacyTxType transaction's signature actually
+// covers: the canonical EIP-155 tuple (nonce, gasPrice, gasLimit, to,
+// value, data, chainID, 0, 0), RLP-encoded and keccak256'd. Folding chainID
+// into the tuple itself — rather than, say, appending it after the fact —
+// is what ties a signature to one chain: the same private key signing the
+// same nonce/to/value/data for a different chainID produces an entirely
+// different hash, so the signature can't be replayed there.
+//
+// AccessListTxType and DynamicFeeTxType transactions don't use this: see
+// TypedSigningHash.
+func SigningHash(tx *Transaction, chainID *big.Int) common.Hash {
+	to := tx.To
+	if to == nil {
+		to = &common.Address{}
+	}
+	if chainID == nil {
+		chainID = new(big.Int)
+	}
+
+	data, _ := rlp.EncodeToBytes([]interface{}{
+		tx.Nonce,
+		tx.GasPrice,
+		tx.GasLimit,
+		*to,
+		tx.Value,
+		tx.Data,
+		chainID,
+		uint(0),
+		uint(0),
+	})
+	return crypto.Keccak256Hash(data)
+}
+
+// TypedSigningHash is the hash an AccessListTxType or DynamicFeeTxType
+// transaction's signature covers: keccak256(type || rlp(payload)), the
+// EIP-2718 envelope applied to EIP-2930/EIP-1559's own signing payloads.
+// Unlike SigningHash, chainID here comes from the transaction itself (it's
+// part of the signed payload, not folded into v), so tx.ChainID must
+// already be set.
+func TypedSigningHash(tx *Transaction) (common.Hash, error) {
+	payload, err := typedSigningPayload(tx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	data, err := rlp.EncodeToBytes(payload)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("encode signing payload: %w", err)
+	}
+	return crypto.Keccak256Hash(append([]byte{byte(tx.Type)}, data...)), nil
+}
+
+func typedSigningPayload(tx *Transaction) (interface{}, error) {
+	if tx.ChainID == nil {
+		return nil, fmt.Errorf("typed transaction missing chain id")
+	}
+	to := tx.To
+	if to == nil {
+		to = &common.Address{}
+	}
+	accessList := tx.AccessList
+	if accessList == nil {
+		accessList = AccessList{}
+	}
+
+	switch tx.Type {
+	case AccessListTxType:
+		return []interface{}{
+			tx.ChainID,
+			tx.Nonce,
+			tx.GasPrice,
+			tx.GasLimit,
+			*to,
+			tx.Value,
+			tx.Data,
+			accessList,
+		}, nil
+	case DynamicFeeTxType, BlobTxType:
+		return []interface{}{
+			tx.ChainID,
+			tx.Nonce,
+			tx.GasTipCap,
+			tx.GasFeeCap,
+			tx.GasLimit,
+			*to,
+			tx.Value,
+			tx.Data,
+			accessList,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported typed transaction type %d", tx.Type)
+	}
+}
+
+// recoverSender recovers and returns the address that signed tx. A
+// LegacyTxType transaction is verified over SigningHash(tx, chainID) with v
+// folded per EIP-155; an AccessListTxType/DynamicFeeTxType transaction is
+// verified over TypedSigningHash(tx) with v as a raw 0/1 parity byte. Either
+// way, a signature whose s sits above secp256k1's half-order (the
+// malleability EIP-2 forbids) is rejected.
+func recoverSender(tx *Transaction, chainID *big.Int) (common.Address, error) {
+	if tx.V == nil || tx.R == nil || tx.S == nil {
+		return common.Address{}, fmt.Errorf("transaction is unsigned")
+	}
+
+	var (
+		hash  common.Hash
+		recID byte
+	)
+	switch tx.Type {
+	case LegacyTxType:
+		var err error
+		recID, err = crypto.EIP155RecoveryID(tx.V, chainID)
+		if err != nil {
+			return common.Address{}, err
+		}
+		hash = SigningHash(tx, chainID)
+	case AccessListTxType, DynamicFeeTxType, BlobTxType:
+		if chainID != nil && chainID.Sign() != 0 && (tx.ChainID == nil || tx.ChainID.Cmp(chainID) != 0) {
+			return common.Address{}, fmt.Errorf("typed transaction chain id %v does not match %v", tx.ChainID, chainID)
+		}
+		if tx.V.BitLen() > 8 || (tx.V.Int64() != 0 && tx.V.Int64() != 1) {
+			return common.Address{}, fmt.Errorf("typed transaction signature v must be 0 or 1, got %s", tx.V)
+		}
+		recID = byte(tx.V.Int64())
+		var err error
+		hash, err = TypedSigningHash(tx)
+		if err != nil {
+			return common.Address{}, err
+		}
+	default:
+		return common.Address{}, fmt.Errorf("unsupported transaction type %d", tx.Type)
+	}
+
+	if !crypto.ValidateSignatureValues(recID, tx.R, tx.S, true) {
+		return common.Address{}, fmt.Errorf("invalid signature values (malleable or out of range)")
+	}
+
+	sig := make([]byte, 65)
+	tx.R.FillBytes(sig[0:32])
+	tx.S.FillBytes(sig[32:64])
+	sig[64] = recID
+
+	pubkey, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid signature: %w", err)
+	}
+	return crypto.PubkeyToAddress(pubkey), nil
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/DioraFund/diora-go-blockchain/crypto"
+	"github.com/ethereum/go-ethereum/common"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestSigningHashRecoverSenderRoundTrip(t *testing.T) {
+	key, err := gethcrypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	chainID := big.NewInt(1337)
+
+	tx := &Transaction{
+		Type:     LegacyTxType,
+		Nonce:    1,
+		GasPrice: big.NewInt(1),
+		GasLimit: 21000,
+		To:       &to,
+		Value:    big.NewInt(1000),
+	}
+
+	hash := SigningHash(tx, chainID)
+	sig, err := gethcrypto.Sign(hash.Bytes(), key)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	tx.R = new(big.Int).SetBytes(sig[:32])
+	tx.S = new(big.Int).SetBytes(sig[32:64])
+	tx.V = crypto.EIP155V(sig[64], chainID)
+
+	want := gethcrypto.PubkeyToAddress(key.PublicKey)
+	got, err := recoverSender(tx, chainID)
+	if err != nil {
+		t.Fatalf("recoverSender: %v", err)
+	}
+	if got != want {
+		t.Fatalf("recoverSender = %s, want %s", got.Hex(), want.Hex())
+	}
+
+	// Signing the same tx for a different chain ID must produce a
+	// different hash, so a signature can't be replayed cross-chain.
+	if SigningHash(tx, big.NewInt(1)) == hash {
+		t.Fatalf("SigningHash did not change with chainID")
+	}
+}
+
+func TestTypedSigningHashRecoverSenderRoundTrip(t *testing.T) {
+	key, err := gethcrypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	chainID := big.NewInt(1337)
+	want := gethcrypto.PubkeyToAddress(key.PublicKey)
+
+	for _, txType := range []TxType{AccessListTxType, DynamicFeeTxType} {
+		tx := &Transaction{
+			Type:      txType,
+			ChainID:   chainID,
+			Nonce:     1,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(2),
+			GasPrice:  big.NewInt(2),
+			GasLimit:  21000,
+			To:        &to,
+			Value:     big.NewInt(1000),
+			AccessList: AccessList{
+				{Address: to, StorageKeys: []common.Hash{common.HexToHash("0x01")}},
+			},
+		}
+
+		hash, err := TypedSigningHash(tx)
+		if err != nil {
+			t.Fatalf("type %d: TypedSigningHash: %v", txType, err)
+		}
+		sig, err := gethcrypto.Sign(hash.Bytes(), key)
+		if err != nil {
+			t.Fatalf("type %d: Sign: %v", txType, err)
+		}
+		tx.R = new(big.Int).SetBytes(sig[:32])
+		tx.S = new(big.Int).SetBytes(sig[32:64])
+		tx.V = big.NewInt(int64(sig[64]))
+
+		got, err := recoverSender(tx, chainID)
+		if err != nil {
+			t.Fatalf("type %d: recoverSender: %v", txType, err)
+		}
+		if got != want {
+			t.Fatalf("type %d: recoverSender = %s, want %s", txType, got.Hex(), want.Hex())
+		}
+	}
+}
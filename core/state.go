@@ -1,32 +1,129 @@
 package core
 
 import (
-	"crypto/sha256"
 	"math/big"
 	"sync"
 
+	"github.com/DioraFund/diora-go-blockchain/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/syndtr/goleveldb/leveldb"
 )
 
+// State is the world state at a point in the chain: account balances,
+// nonces, code, and storage, backed by a persistent Merkle-Patricia trie so
+// the whole thing reduces to a single 32-byte root a light client can
+// trust. cache holds accounts mutated since the last Commit; Commit flushes
+// them into the trie (and each account's own storage sub-trie) and returns
+// the new root.
 type State struct {
 	db    *leveldb.DB
 	trie  *Trie
 	cache map[Address]*Account
 	mu    sync.RWMutex
+
+	// journal is the undo log Snapshot/RevertToSnapshot operate on. It's
+	// reset by Finalise rather than by Commit, since a transaction that
+	// reverts partway through still needs everything before its failing
+	// call to survive into the next one.
+	journal []journalEntry
+	refund  uint64
+
+	// pendingDestructs collects addresses Finalise decided to drop (suicided
+	// or emptied under EIP-158) since the journal covering them can no
+	// longer be rolled back. Commit deletes each one from the trie instead
+	// of writing a leaf for it.
+	pendingDestructs map[Address]bool
+
+	// accessedAddresses and accessedSlots are this transaction's EIP-2929
+	// warm/cold access list: the first SLOAD/SSTORE/CALL (and, once they
+	// exist, BALANCE/EXTCODE*) touching an address or storage slot costs the
+	// cold price, every later touch this transaction costs the much cheaper
+	// warm price. Both are journaled exactly like Storage so a
+	// RevertToSnapshot past the point they were added un-warms them, and
+	// Finalise clears them the same way it clears the journal itself.
+	accessedAddresses map[Address]bool
+	accessedSlots     map[Address]map[Hash]bool
+
+	// transientStorage is EIP-1153's per-transaction scratch storage: unlike
+	// Storage it's never read from or written into the trie, so Commit
+	// doesn't know it exists — Finalise simply discards it once the
+	// transaction it belongs to can no longer be rolled back.
+	transientStorage map[Address]map[Hash]Hash
+}
+
+// Account is one leaf of the state trie, keyed by Keccak256(address).
+// StorageRoot is the root of this account's own storage sub-trie, keyed by
+// Keccak256(storageKey); it's stale until Commit flushes Storage into it.
+// Code/Storage are the decoded working set a transaction actually reads and
+// writes — CodeHash/StorageRoot are what's left once that's been flushed.
+type Account struct {
+	Nonce       uint64
+	Balance     *big.Int
+	CodeHash    []byte
+	Code        []byte
+	Storage     map[Hash]Hash
+	StorageRoot Hash
+
+	// suicided marks an account SELFDESTRUCTed during the current
+	// transaction; Commit drops it from the trie instead of writing it,
+	// and Finalise prunes it from the cache once the journal covering it
+	// can no longer be rolled back.
+	suicided bool
+}
+
+// accountRLP is the exact shape an Account is stored as in the state trie:
+// just enough to rebuild Nonce/Balance/CodeHash and to open the storage
+// sub-trie. Code and live Storage entries are kept out of it deliberately —
+// Code lives under its own CodeHash key, and Storage is read lazily through
+// the sub-trie rather than inlined, the same way go-ethereum keeps an
+// account leaf to O(1) size regardless of how much storage it owns.
+type accountRLP struct {
+	Nonce       uint64
+	Balance     *big.Int
+	StorageRoot Hash
+	CodeHash    []byte
 }
 
+// emptyCodeHash is Keccak256(nil), the CodeHash every externally-owned
+// account (one with no deployed code) is stored with.
+var emptyCodeHash = Keccak256(nil)
+
 func NewState(db *leveldb.DB) *State {
 	return &State{
-		db:    db,
-		trie:  NewTrie(db),
-		cache: make(map[Address]*Account),
+		db:                db,
+		trie:              NewTrie(db),
+		cache:             make(map[Address]*Account),
+		pendingDestructs:  make(map[Address]bool),
+		accessedAddresses: make(map[Address]bool),
+		accessedSlots:     make(map[Address]map[Hash]bool),
+		transientStorage:  make(map[Address]map[Hash]Hash),
+	}
+}
+
+// NewStateAt reopens world state as of a previously committed root, sharing
+// db (and therefore every trie node already on disk) with whatever State
+// produced that root.
+func NewStateAt(db *leveldb.DB, root Hash) *State {
+	return &State{
+		db:                db,
+		trie:              NewTrieAt(db, root),
+		cache:             make(map[Address]*Account),
+		pendingDestructs:  make(map[Address]bool),
+		accessedAddresses: make(map[Address]bool),
+		accessedSlots:     make(map[Address]map[Hash]bool),
+		transientStorage:  make(map[Address]map[Hash]Hash),
 	}
 }
 
 func (s *State) GetAccount(addr Address) *Account {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.getAccount(addr)
+}
 
+// getAccount is GetAccount without locking, for callers that already hold
+// s.mu (every other exported method on State).
+func (s *State) getAccount(addr Address) *Account {
 	if account, exists := s.cache[addr]; exists {
 		return account
 	}
@@ -37,11 +134,21 @@ func (s *State) GetAccount(addr Address) *Account {
 		Storage: make(map[Hash]Hash),
 	}
 
-	// Load from database
-	data, err := s.db.Get(addr.Bytes(), nil)
-	if err == nil {
-		// Deserialize account (simplified)
-		account.Balance.SetBytes(data)
+	if enc, err := s.trie.Get(Keccak256(addr.Bytes())); err == nil && enc != nil {
+		var dec accountRLP
+		if err := rlp.DecodeBytes(enc, &dec); err == nil {
+			account.Nonce = dec.Nonce
+			if dec.Balance != nil {
+				account.Balance = dec.Balance
+			}
+			account.StorageRoot = dec.StorageRoot
+			account.CodeHash = dec.CodeHash
+			if len(dec.CodeHash) > 0 {
+				if code, err := s.db.Get(dec.CodeHash, nil); err == nil {
+					account.Code = code
+				}
+			}
+		}
 	}
 
 	s.cache[addr] = account
@@ -52,12 +159,16 @@ func (s *State) SetBalance(addr Address, balance *big.Int) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	account := s.GetAccount(addr)
-	account.Balance.Set(balance)
+	account := s.getAccount(addr)
+	s.journal = append(s.journal, balanceChange{addr: addr, prev: account.Balance})
+	account.Balance = new(big.Int).Set(balance)
 }
 
 func (s *State) GetBalance(addr Address) *big.Int {
-	account := s.GetAccount(addr)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account := s.getAccount(addr)
 	return new(big.Int).Set(account.Balance)
 }
 
@@ -65,12 +176,16 @@ func (s *State) SetNonce(addr Address, nonce uint64) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	account := s.GetAccount(addr)
+	account := s.getAccount(addr)
+	s.journal = append(s.journal, nonceChange{addr: addr, prev: account.Nonce})
 	account.Nonce = nonce
 }
 
 func (s *State) GetNonce(addr Address) uint64 {
-	account := s.GetAccount(addr)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account := s.getAccount(addr)
 	return account.Nonce
 }
 
@@ -78,98 +193,347 @@ func (s *State) SetCode(addr Address, code []byte) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	account := s.GetAccount(addr)
+	account := s.getAccount(addr)
+	s.journal = append(s.journal, codeChange{addr: addr, prevCode: account.Code, prevCodeHash: account.CodeHash})
 	account.Code = code
-	account.CodeHash = Keccak256Hash(code).Bytes()
+	account.CodeHash = Keccak256(code)
 }
 
 func (s *State) GetCode(addr Address) []byte {
-	account := s.GetAccount(addr)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account := s.getAccount(addr)
 	return account.Code
 }
 
 func (s *State) GetCodeHash(addr Address) []byte {
-	account := s.GetAccount(addr)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account := s.getAccount(addr)
 	return account.CodeHash
 }
 
+// SetCodeHash overrides an account's CodeHash directly, for a caller (e.g.
+// CreateContract) that already knows the hash it wants rather than having
+// State derive it from Code via SetCode.
+func (s *State) SetCodeHash(addr Address, codeHash []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account := s.getAccount(addr)
+	s.journal = append(s.journal, codeChange{addr: addr, prevCode: account.Code, prevCodeHash: account.CodeHash})
+	account.CodeHash = codeHash
+}
+
 func (s *State) SetState(addr Address, key, value Hash) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	account := s.GetAccount(addr)
+	account := s.getAccount(addr)
+	prev, had := account.Storage[key]
+	s.journal = append(s.journal, storageChange{addr: addr, key: key, prev: prev, had: had})
 	account.Storage[key] = value
 }
 
 func (s *State) GetState(addr Address, key Hash) Hash {
-	account := s.GetAccount(addr)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account := s.getAccount(addr)
 	if value, exists := account.Storage[key]; exists {
 		return value
 	}
-	return Hash{}
+
+	// Not in the pending write set: fall back to the account's committed
+	// storage sub-trie rather than assuming zero, so a value written in an
+	// earlier block is still visible here.
+	if account.StorageRoot == (Hash{}) {
+		return Hash{}
+	}
+	storageTrie := NewTrieAt(s.db, account.StorageRoot)
+	enc, err := storageTrie.Get(Keccak256(key.Bytes()))
+	if err != nil || enc == nil {
+		return Hash{}
+	}
+	var raw []byte
+	if err := rlp.DecodeBytes(enc, &raw); err != nil {
+		return Hash{}
+	}
+	return BytesToHash(raw)
 }
 
-func (s *State) Commit() error {
+// AddressInAccessList reports whether addr has already been touched this
+// transaction (warm) per EIP-2929, without marking it.
+func (s *State) AddressInAccessList(addr Address) bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	return s.accessedAddresses[addr]
+}
 
-	// Save all cached accounts to database
-	for addr, account := range s.cache {
-		data := account.Balance.Bytes()
-		if err := s.db.Put(addr.Bytes(), data, nil); err != nil {
-			return err
+// AddAddressToAccessList marks addr as touched this transaction, returning
+// true if it was cold (not already in the access list) — a caller like
+// gasCallEIP2929 charges the expensive cold price only on that first touch.
+// Journaled so a RevertToSnapshot past this point un-marks it too.
+func (s *State) AddAddressToAccessList(addr Address) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.accessedAddresses[addr] {
+		return false
+	}
+	s.journal = append(s.journal, accessListAddrChange{addr: addr})
+	s.accessedAddresses[addr] = true
+	return true
+}
+
+// SlotInAccessList reports whether (addr, key) has already been touched
+// this transaction, without marking it.
+func (s *State) SlotInAccessList(addr Address, key Hash) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accessedSlots[addr] != nil && s.accessedSlots[addr][key]
+}
+
+// AddSlotToAccessList marks (addr, key) as touched this transaction,
+// returning true if it was cold — the same first-touch-only pricing
+// AddAddressToAccessList gives SLOAD/SSTORE's gas functions.
+func (s *State) AddSlotToAccessList(addr Address, key Hash) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.accessedSlots[addr] != nil && s.accessedSlots[addr][key] {
+		return false
+	}
+	s.journal = append(s.journal, accessListSlotChange{addr: addr, key: key})
+	if s.accessedSlots[addr] == nil {
+		s.accessedSlots[addr] = make(map[Hash]bool)
+	}
+	s.accessedSlots[addr][key] = true
+	return true
+}
+
+// GetTransientState reads addr's EIP-1153 transient storage at key, zero if
+// TSTORE never set it this transaction.
+func (s *State) GetTransientState(addr Address, key Hash) Hash {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.transientStorage[addr][key]
+}
+
+// SetTransientState writes addr's transient storage at key, journaled the
+// same way SetState is so a RevertToSnapshot past this point restores
+// whatever TLOAD would have seen before it.
+func (s *State) SetTransientState(addr Address, key, value Hash) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev, had := s.transientStorage[addr][key]
+	s.journal = append(s.journal, transientStorageChange{addr: addr, key: key, prev: prev, had: had})
+	if s.transientStorage[addr] == nil {
+		s.transientStorage[addr] = make(map[Hash]Hash)
+	}
+	s.transientStorage[addr][key] = value
+}
+
+// Commit flushes every cached account into the state trie — persisting its
+// code under CodeHash, replaying its pending Storage writes into its own
+// storage sub-trie, and writing the resulting accountRLP leaf — then
+// commits the trie itself and returns the new state root.
+func (s *State) Commit() (Hash, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.commit()
+}
+
+func (s *State) commit() (Hash, error) {
+	for addr := range s.pendingDestructs {
+		if err := s.trie.Delete(Keccak256(addr.Bytes())); err != nil {
+			return Hash{}, err
 		}
+	}
+	s.pendingDestructs = make(map[Address]bool)
 
-		// Save code if exists
+	for addr, account := range s.cache {
+		if account.suicided {
+			// Suicided since the last Finalise (or Commit called directly
+			// without one, e.g. in tests): drop it rather than writing a
+			// leaf for an account that no longer exists.
+			if err := s.trie.Delete(Keccak256(addr.Bytes())); err != nil {
+				return Hash{}, err
+			}
+			continue
+		}
 		if len(account.Code) > 0 {
-			codeHash := Keccak256(account.Code)
-			if err := s.db.Put(codeHash, account.Code, nil); err != nil {
-				return err
+			account.CodeHash = Keccak256(account.Code)
+			if err := s.db.Put(account.CodeHash, account.Code, nil); err != nil {
+				return Hash{}, err
 			}
+		} else if len(account.CodeHash) == 0 {
+			account.CodeHash = emptyCodeHash
 		}
 
-		// Save storage
+		storageTrie := NewTrieAt(s.db, account.StorageRoot)
 		for key, value := range account.Storage {
-			storageKey := append(addr.Bytes(), key.Bytes()...)
-			if err := s.db.Put(storageKey, value.Bytes(), nil); err != nil {
-				return err
+			skey := Keccak256(key.Bytes())
+			if value == (Hash{}) {
+				if err := storageTrie.Delete(skey); err != nil {
+					return Hash{}, err
+				}
+				continue
+			}
+			enc, err := rlp.EncodeToBytes(value.Bytes())
+			if err != nil {
+				return Hash{}, err
 			}
+			if err := storageTrie.Put(skey, enc); err != nil {
+				return Hash{}, err
+			}
+		}
+		storageRoot, err := storageTrie.Commit(s.db)
+		if err != nil {
+			return Hash{}, err
+		}
+		account.StorageRoot = storageRoot
+
+		enc, err := rlp.EncodeToBytes(&accountRLP{
+			Nonce:       account.Nonce,
+			Balance:     account.Balance,
+			StorageRoot: account.StorageRoot,
+			CodeHash:    account.CodeHash,
+		})
+		if err != nil {
+			return Hash{}, err
+		}
+		if err := s.trie.Put(Keccak256(addr.Bytes()), enc); err != nil {
+			return Hash{}, err
 		}
 	}
 
-	// Clear cache
+	root, err := s.trie.Commit(s.db)
+	if err != nil {
+		return Hash{}, err
+	}
+
 	s.cache = make(map[Address]*Account)
-	return nil
+	return root, nil
 }
 
-func Keccak256Hash(data []byte) Hash {
-	hasher := sha256.New()
-	hasher.Write(data)
-	return BytesToHash(hasher.Sum(nil))
+// stateRootPrefix maps a committed state root to the block number it
+// belongs to, the same rawdb-style keying headerPrefix/headerNumberPrefix
+// use in headerchain.go.
+var stateRootPrefix = []byte("sroot-") // stateRootPrefix + root -> num (big-endian big.Int bytes)
+
+func stateRootKey(root Hash) []byte {
+	return append(append([]byte{}, stateRootPrefix...), root.Bytes()...)
+}
+
+// RecordRoot persists root -> blockNumber, so a later NewStateAt(db, root)
+// can be traced back to the block it belonged to (used by the light-client
+// ODR path and by `diora dev` tooling that inspects historical state).
+func (s *State) RecordRoot(root Hash, blockNumber uint64) error {
+	return s.db.Put(stateRootKey(root), new(big.Int).SetUint64(blockNumber).Bytes(), nil)
+}
+
+// BlockNumberForRoot looks up the block a state root (previously passed to
+// RecordRoot) belonged to.
+func (s *State) BlockNumberForRoot(root Hash) (uint64, error) {
+	data, err := s.db.Get(stateRootKey(root), nil)
+	if err != nil {
+		return 0, err
+	}
+	return new(big.Int).SetBytes(data).Uint64(), nil
+}
+
+// IntermediateRoot computes the root state would have if Commit ran right
+// now, without actually writing anything to leveldb. StateProcessor can
+// call it after each transaction to fill in a per-transaction root the way
+// a pre-Byzantium receipt's PostState field expects, at the cost of
+// re-hashing (but not re-persisting) every account touched so far.
+func (s *State) IntermediateRoot() Hash {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for addr, account := range s.cache {
+		codeHash := account.CodeHash
+		if len(account.Code) > 0 {
+			codeHash = Keccak256(account.Code)
+		} else if len(codeHash) == 0 {
+			codeHash = emptyCodeHash
+		}
+
+		storageTrie := NewTrieAt(s.db, account.StorageRoot)
+		for key, value := range account.Storage {
+			skey := Keccak256(key.Bytes())
+			if value == (Hash{}) {
+				storageTrie.Delete(skey)
+				continue
+			}
+			enc, err := rlp.EncodeToBytes(value.Bytes())
+			if err != nil {
+				continue
+			}
+			storageTrie.Put(skey, enc)
+		}
+
+		enc, err := rlp.EncodeToBytes(&accountRLP{
+			Nonce:       account.Nonce,
+			Balance:     account.Balance,
+			StorageRoot: storageTrie.Root(),
+			CodeHash:    codeHash,
+		})
+		if err != nil {
+			continue
+		}
+		s.trie.Put(Keccak256(addr.Bytes()), enc)
+	}
+
+	return s.trie.Root()
 }
 
 func (s *State) Root() Hash {
-	return Hash{}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.trie.Root()
 }
 
+// Copy returns an independent view of state that shares s's already-
+// committed trie nodes (same db, same node cache contents) but has its own
+// mutable cache of pending account changes, so writes against the copy
+// never leak back into s until Adopt folds them in.
 func (s *State) Copy() *State {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	newTrie := NewTrieAt(s.db, s.trie.Root())
+	for h, enc := range s.trie.cache {
+		newTrie.cache[h] = enc
+	}
+
 	newState := &State{
-		db:    s.db,
-		trie:  s.trie,
-		cache: make(map[Address]*Account),
+		db:                s.db,
+		trie:              newTrie,
+		cache:             make(map[Address]*Account),
+		pendingDestructs:  make(map[Address]bool),
+		accessedAddresses: make(map[Address]bool),
+		accessedSlots:     make(map[Address]map[Hash]bool),
+		transientStorage:  make(map[Address]map[Hash]Hash),
+	}
+
+	for addr := range s.pendingDestructs {
+		newState.pendingDestructs[addr] = true
 	}
 
-	// Copy cache
 	for addr, account := range s.cache {
 		newAccount := &Account{
-			Nonce:    account.Nonce,
-			Balance:  new(big.Int).Set(account.Balance),
-			CodeHash: append([]byte(nil), account.CodeHash...),
-			Code:     append([]byte(nil), account.Code...),
-			Storage:  make(map[Hash]Hash),
+			Nonce:       account.Nonce,
+			Balance:     new(big.Int).Set(account.Balance),
+			CodeHash:    append([]byte(nil), account.CodeHash...),
+			Code:        append([]byte(nil), account.Code...),
+			Storage:     make(map[Hash]Hash),
+			StorageRoot: account.StorageRoot,
+			suicided:    account.suicided,
 		}
 
 		for key, value := range account.Storage {
@@ -181,3 +545,33 @@ func (s *State) Copy() *State {
 
 	return newState
 }
+
+// Adopt replaces s's cache with other's, the same way Commit would have if
+// other had been executed against s directly rather than a Copy of it. It's
+// how a shadow state produced by PreExecuteBlock gets folded back in once
+// ProcessBlock decides the block it was computed for is the one being
+// finalized, skipping a second execution pass.
+func (s *State) Adopt(other *State) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	other.mu.RLock()
+	defer other.mu.RUnlock()
+
+	s.cache = other.cache
+	for addr := range other.pendingDestructs {
+		s.pendingDestructs[addr] = true
+	}
+}
+
+// Keccak256Hash and Keccak256 used to be a placeholder sha256 hash; state
+// trie nodes and account/storage keys all have to use real Keccak256 to
+// match what the rest of the codebase (block hashing, tx hashing,
+// addresses) already hashes with, so both are now thin wrappers over
+// crypto.Keccak256.
+func Keccak256Hash(data []byte) Hash {
+	return BytesToHash(Keccak256(data))
+}
+
+func Keccak256(data []byte) []byte {
+	return crypto.Keccak256(data)
+}
@@ -0,0 +1,200 @@
+package core
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// TransferDirection selects which side of a transfer an address query cares
+// about: money/tokens/NFTs it sent, received, or either.
+type TransferDirection int
+
+const (
+	TransferDirectionEither TransferDirection = iota
+	TransferDirectionFrom
+	TransferDirectionTo
+)
+
+// Transfer is one value movement: a native DIO send, an ERC20-style token
+// transfer, or an NFT transfer, depending on which prefix it was written
+// under.
+type Transfer struct {
+	Address     Address
+	Counterpart Address
+	Direction   TransferDirection
+	Token       Address // zero for native transfers
+	TokenID     []byte  // set for NFT transfers
+	Amount      []byte  // big-endian encoded *big.Int bytes
+	BlockNumber uint64
+	TxIndex     uint32
+	LogIndex    uint32
+	TxHash      Hash
+}
+
+// Key prefixes for the three transfer indexes. Exported so API handlers can
+// select which history (native transfers, token transfers, NFT transfers) to
+// page through.
+const (
+	PrefixAccountTransfer = "xfer-acct-"
+	PrefixTokenTransfer   = "xfer-token-"
+	PrefixNFTTransfer     = "xfer-nft-"
+)
+
+// transferKey builds the (address || bigEndian(blockNumber) || txIndex ||
+// logIndex) key transfers are stored under, so a single address's history is
+// a contiguous key range a leveldb iterator can Seek into directly instead of
+// scanning the whole chain.
+func transferKey(prefix string, addr Address, blockNumber uint64, txIndex, logIndex uint32) []byte {
+	key := make([]byte, 0, len(prefix)+20+8+4+4)
+	key = append(key, prefix...)
+	key = append(key, addr.Bytes()...)
+	key = binary.BigEndian.AppendUint64(key, blockNumber)
+	key = binary.BigEndian.AppendUint32(key, txIndex)
+	key = binary.BigEndian.AppendUint32(key, logIndex)
+	return key
+}
+
+// WriteTransfer indexes a transfer under both the sender's and the
+// recipient's address so ForEachTransfer can look either direction up
+// directly, without a table scan.
+func (bc *Blockchain) WriteTransfer(prefix string, from, to Address, t *Transfer) error {
+	fromKey := transferKey(prefix, from, t.BlockNumber, t.TxIndex, t.LogIndex)
+	toKey := transferKey(prefix, to, t.BlockNumber, t.TxIndex, t.LogIndex)
+
+	data, err := encodeTransfer(t)
+	if err != nil {
+		return err
+	}
+
+	if err := bc.db.Put(fromKey, data, nil); err != nil {
+		return fmt.Errorf("failed to index outgoing transfer: %w", err)
+	}
+	if err := bc.db.Put(toKey, data, nil); err != nil {
+		return fmt.Errorf("failed to index incoming transfer: %w", err)
+	}
+	return nil
+}
+
+// ForEachTransfer walks an address's transfer history for the given prefix
+// (account/token/NFT), starting at startBlock via Seek rather than scanning
+// from genesis, and calling fn for each entry until it returns false or the
+// range is exhausted. Pass forward=false to walk backward (most recent
+// first).
+func (bc *Blockchain) ForEachTransfer(prefix string, addr Address, startBlock uint64, forward bool, fn func(*Transfer) bool) error {
+	addrPrefix := append([]byte(prefix), addr.Bytes()...)
+	iter := bc.db.NewIterator(util.BytesPrefix(addrPrefix), nil)
+	defer iter.Release()
+
+	seekKey := transferKey(prefix, addr, startBlock, 0, 0)
+
+	if forward {
+		for ok := iter.Seek(seekKey); ok; ok = iter.Next() {
+			t, err := decodeTransfer(iter.Value())
+			if err != nil {
+				continue
+			}
+			if !fn(t) {
+				break
+			}
+		}
+		return iter.Error()
+	}
+
+	// Backward iteration: seek to the first key >= seekKey, then step back
+	// so we start at (or just before) startBlock and walk toward genesis.
+	if !iter.Seek(seekKey) {
+		if !iter.Last() {
+			return iter.Error()
+		}
+	} else if !iter.Prev() {
+		return iter.Error()
+	}
+
+	for {
+		t, err := decodeTransfer(iter.Value())
+		if err == nil {
+			if !fn(t) {
+				break
+			}
+		}
+		if !iter.Prev() {
+			break
+		}
+	}
+	return iter.Error()
+}
+
+// TransferCursor is the opaque, base64-encoded pagination token returned to
+// API clients so they can resume a query without re-scanning prior pages.
+func EncodeTransferCursor(key []byte) string {
+	return base64.URLEncoding.EncodeToString(key)
+}
+
+func DecodeTransferCursor(cursor string) ([]byte, error) {
+	return base64.URLEncoding.DecodeString(cursor)
+}
+
+func encodeTransfer(t *Transfer) ([]byte, error) {
+	buf := make([]byte, 0, 128)
+	buf = append(buf, t.Counterpart.Bytes()...)
+	buf = append(buf, byte(t.Direction))
+	buf = append(buf, t.Token.Bytes()...)
+	buf = binary.BigEndian.AppendUint64(buf, t.BlockNumber)
+	buf = binary.BigEndian.AppendUint32(buf, t.TxIndex)
+	buf = binary.BigEndian.AppendUint32(buf, t.LogIndex)
+	buf = append(buf, t.TxHash.Bytes()...)
+	tokenIDLen := uint32(len(t.TokenID))
+	buf = binary.BigEndian.AppendUint32(buf, tokenIDLen)
+	buf = append(buf, t.TokenID...)
+	amountLen := uint32(len(t.Amount))
+	buf = binary.BigEndian.AppendUint32(buf, amountLen)
+	buf = append(buf, t.Amount...)
+	return buf, nil
+}
+
+func decodeTransfer(data []byte) (*Transfer, error) {
+	const fixedLen = 20 + 1 + 20 + 8 + 4 + 4 + 32 + 4
+	if len(data) < fixedLen {
+		return nil, fmt.Errorf("transfer record too short")
+	}
+
+	t := &Transfer{}
+	off := 0
+	copy(t.Counterpart[:], data[off:off+20])
+	off += 20
+	t.Direction = TransferDirection(data[off])
+	off++
+	copy(t.Token[:], data[off:off+20])
+	off += 20
+	t.BlockNumber = binary.BigEndian.Uint64(data[off : off+8])
+	off += 8
+	t.TxIndex = binary.BigEndian.Uint32(data[off : off+4])
+	off += 4
+	t.LogIndex = binary.BigEndian.Uint32(data[off : off+4])
+	off += 4
+	copy(t.TxHash[:], data[off:off+32])
+	off += 32
+
+	tokenIDLen := binary.BigEndian.Uint32(data[off : off+4])
+	off += 4
+	if off+int(tokenIDLen) > len(data) {
+		return nil, fmt.Errorf("corrupt transfer record: tokenID overruns buffer")
+	}
+	t.TokenID = append([]byte(nil), data[off:off+int(tokenIDLen)]...)
+	off += int(tokenIDLen)
+
+	if off+4 > len(data) {
+		return nil, fmt.Errorf("corrupt transfer record: missing amount length")
+	}
+	amountLen := binary.BigEndian.Uint32(data[off : off+4])
+	off += 4
+	if off+int(amountLen) > len(data) {
+		return nil, fmt.Errorf("corrupt transfer record: amount overruns buffer")
+	}
+	t.Amount = append([]byte(nil), data[off:off+int(amountLen)]...)
+
+	return t, nil
+}
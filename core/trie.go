@@ -0,0 +1,659 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// Trie is a persistent hexary Merkle-Patricia trie, content-addressed in
+// leveldb by node hash: every node is stored under Keccak256(RLP(node)),
+// so two tries (or two versions of the same trie) that share a subtree
+// share its on-disk encoding too. This is the persistent counterpart to the
+// ephemeral trie DeriveSha builds for TxRoot/ReceiptRoot in derive_sha.go —
+// that one is rebuilt from scratch per block and only ever hashed, while
+// this one is read, written, and reopened at an arbitrary historical root.
+type Trie struct {
+	root  trieElem
+	db    *leveldb.DB
+	cache map[Hash][]byte
+}
+
+// trieElem is one of trieShortNode, *trieFullNode, trieHashNode, or
+// trieValueNode. Named distinctly from derive_sha.go's trieNode/shortNode/
+// fullNode/valueNode so the two tries' node sets don't collide in the
+// package namespace despite playing the same role.
+type trieElem interface{}
+
+// trieShortNode is an extension (val is another trieElem) or a leaf (val is
+// a trieValueNode); key is hex-prefix-encodable nibbles, same as
+// derive_sha.go's shortNode.
+type trieShortNode struct {
+	key   []byte
+	val   trieElem
+	dirty bool
+}
+
+// trieFullNode is a 17-way branch; children[16] holds a trieValueNode when
+// some key terminates exactly at this node.
+type trieFullNode struct {
+	children [17]trieElem
+	dirty    bool
+}
+
+// trieHashNode is an unresolved reference to a node stored under this hash;
+// resolve() loads and decodes it from the cache or leveldb on first touch.
+type trieHashNode []byte
+
+// trieValueNode is a stored leaf value (an RLP-encoded Account or an
+// RLP-encoded 32-byte storage value), embedded in its parent node rather than hashed
+// separately.
+type trieValueNode []byte
+
+// NewTrie opens the empty trie.
+func NewTrie(db *leveldb.DB) *Trie {
+	return &Trie{db: db, cache: make(map[Hash][]byte)}
+}
+
+// NewTrieAt reopens a previously committed trie at root. An empty root
+// (Hash{}, or the canonical empty-trie root Commit returns) is the same as
+// NewTrie.
+func NewTrieAt(db *leveldb.DB, root Hash) *Trie {
+	t := &Trie{db: db, cache: make(map[Hash][]byte)}
+	if root != (Hash{}) && root != BytesToHash(emptyRootHash.Bytes()) {
+		t.root = trieHashNode(root.Bytes())
+	}
+	return t
+}
+
+// Get looks up key, returning (nil, nil) if it isn't present.
+func (t *Trie) Get(key []byte) ([]byte, error) {
+	n, err := t.resolve(t.root)
+	if err != nil {
+		return nil, err
+	}
+	v, newRoot, err := t.get(n, keybytesToHex(key))
+	if err != nil {
+		return nil, err
+	}
+	t.root = newRoot
+	if v == nil {
+		return nil, nil
+	}
+	return []byte(v), nil
+}
+
+func (t *Trie) get(n trieElem, key []byte) (trieValueNode, trieElem, error) {
+	switch n := n.(type) {
+	case nil:
+		return nil, nil, nil
+
+	case trieValueNode:
+		if len(key) == 0 {
+			return n, n, nil
+		}
+		return nil, n, nil
+
+	case trieHashNode:
+		resolved, err := t.resolve(n)
+		if err != nil {
+			return nil, n, err
+		}
+		v, newNode, err := t.get(resolved, key)
+		return v, newNode, err
+
+	case *trieShortNode:
+		matchlen := prefixLen(key, n.key)
+		if matchlen < len(n.key) {
+			return nil, n, nil
+		}
+		v, newVal, err := t.get(n.val, key[matchlen:])
+		if err != nil {
+			return nil, n, err
+		}
+		n.val = newVal
+		return v, n, nil
+
+	case *trieFullNode:
+		if len(key) == 0 {
+			v, _ := n.children[16].(trieValueNode)
+			return v, n, nil
+		}
+		child, err := t.resolve(n.children[key[0]])
+		if err != nil {
+			return nil, n, err
+		}
+		v, newChild, err := t.get(child, key[1:])
+		if err != nil {
+			return nil, n, err
+		}
+		n.children[key[0]] = newChild
+		return v, n, nil
+
+	default:
+		return nil, n, fmt.Errorf("core: unexpected trie node type %T", n)
+	}
+}
+
+// Put inserts or overwrites the value stored under key.
+func (t *Trie) Put(key, value []byte) error {
+	if len(value) == 0 {
+		return t.Delete(key)
+	}
+	newRoot, err := t.insert(t.root, keybytesToHex(key), trieValueNode(value))
+	if err != nil {
+		return err
+	}
+	t.root = newRoot
+	return nil
+}
+
+// insert writes content (a trieValueNode when called from Put, or an
+// existing subtree being re-homed by a shortNode split) under key,
+// returning the new root of the subtree rooted at n.
+func (t *Trie) insert(n trieElem, key []byte, content trieElem) (trieElem, error) {
+	if len(key) == 0 {
+		return content, nil
+	}
+
+	switch n := n.(type) {
+	case nil:
+		return &trieShortNode{key: key, val: content, dirty: true}, nil
+
+	case trieHashNode:
+		resolved, err := t.resolve(n)
+		if err != nil {
+			return n, err
+		}
+		return t.insert(resolved, key, content)
+
+	case *trieShortNode:
+		matchlen := prefixLen(key, n.key)
+		if matchlen == len(n.key) {
+			val, err := t.insert(n.val, key[matchlen:], content)
+			if err != nil {
+				return n, err
+			}
+			return &trieShortNode{key: n.key, val: val, dirty: true}, nil
+		}
+
+		// key diverges from n partway through: split n into a branch
+		// holding both n's remaining subtree and the new content, with an
+		// extension shortNode on top of the branch if they shared a
+		// nonempty prefix.
+		branch := &trieFullNode{dirty: true}
+		var err error
+		if branch.children[n.key[matchlen]], err = t.insert(nil, n.key[matchlen+1:], n.val); err != nil {
+			return n, err
+		}
+		if branch.children[key[matchlen]], err = t.insert(nil, key[matchlen+1:], content); err != nil {
+			return n, err
+		}
+		if matchlen == 0 {
+			return branch, nil
+		}
+		return &trieShortNode{key: key[:matchlen], val: branch, dirty: true}, nil
+
+	case *trieFullNode:
+		child, err := t.resolve(n.children[key[0]])
+		if err != nil {
+			return n, err
+		}
+		newChild, err := t.insert(child, key[1:], content)
+		if err != nil {
+			return n, err
+		}
+		n.children[key[0]] = newChild
+		n.dirty = true
+		return n, nil
+
+	default:
+		return n, fmt.Errorf("core: unexpected trie node type %T", n)
+	}
+}
+
+// Delete removes key, a no-op if it isn't present.
+func (t *Trie) Delete(key []byte) error {
+	newRoot, _, err := t.delete(t.root, keybytesToHex(key))
+	if err != nil {
+		return err
+	}
+	t.root = newRoot
+	return nil
+}
+
+func (t *Trie) delete(n trieElem, key []byte) (trieElem, bool, error) {
+	switch n := n.(type) {
+	case nil:
+		return nil, false, nil
+
+	case trieHashNode:
+		resolved, err := t.resolve(n)
+		if err != nil {
+			return n, false, err
+		}
+		return t.delete(resolved, key)
+
+	case trieValueNode:
+		if len(key) == 0 {
+			return nil, true, nil
+		}
+		return n, false, nil
+
+	case *trieShortNode:
+		matchlen := prefixLen(key, n.key)
+		if matchlen < len(n.key) {
+			return n, false, nil
+		}
+		child, removed, err := t.delete(n.val, key[matchlen:])
+		if err != nil || !removed {
+			return n, removed, err
+		}
+		if child == nil {
+			return nil, true, nil
+		}
+		return &trieShortNode{key: n.key, val: child, dirty: true}, true, nil
+
+	case *trieFullNode:
+		if len(key) == 0 {
+			if n.children[16] == nil {
+				return n, false, nil
+			}
+			n.children[16] = nil
+			n.dirty = true
+			return n, true, nil
+		}
+		resolvedChild, err := t.resolve(n.children[key[0]])
+		if err != nil {
+			return n, false, err
+		}
+		newChild, removed, err := t.delete(resolvedChild, key[1:])
+		if err != nil || !removed {
+			return n, removed, err
+		}
+		n.children[key[0]] = newChild
+		n.dirty = true
+		return n, true, nil
+
+	default:
+		return n, false, fmt.Errorf("core: unexpected trie node type %T", n)
+	}
+}
+
+// Prove generates a Merkle proof for key: the RLP encoding of every node
+// visited walking from the root down to key's value (or to the point where
+// key's path diverges from the trie, if it isn't present), ordered
+// root-first. A holder of this trie's root hash alone can verify key's
+// value — or its absence — against the proof via VerifyProof, without
+// needing the rest of the trie; this is what a light client uses to
+// confirm a balance or storage slot, and what eth_getProof returns over
+// JSON-RPC.
+func (t *Trie) Prove(key []byte) ([][]byte, error) {
+	n, err := t.resolve(t.root)
+	if err != nil {
+		return nil, err
+	}
+	var proof [][]byte
+	if err := t.prove(n, keybytesToHex(key), &proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// prove is Prove's recursive walk, appending each visited node's encoding
+// to proof before deciding whether to recurse further. It mirrors get's
+// branching exactly so a proof always covers precisely the nodes get would
+// have touched.
+func (t *Trie) prove(n trieElem, key []byte, proof *[][]byte) error {
+	switch n := n.(type) {
+	case nil:
+		return nil
+
+	case trieValueNode:
+		return nil
+
+	case trieHashNode:
+		resolved, err := t.resolve(n)
+		if err != nil {
+			return err
+		}
+		return t.prove(resolved, key, proof)
+
+	case *trieShortNode:
+		enc, err := t.encode(n, nil)
+		if err != nil {
+			return err
+		}
+		*proof = append(*proof, enc)
+
+		matchlen := prefixLen(key, n.key)
+		if matchlen < len(n.key) {
+			// key diverges here: the trie provably doesn't contain it, and
+			// the proof needs nothing past this node to show that.
+			return nil
+		}
+		return t.prove(n.val, key[matchlen:], proof)
+
+	case *trieFullNode:
+		enc, err := t.encode(n, nil)
+		if err != nil {
+			return err
+		}
+		*proof = append(*proof, enc)
+
+		if len(key) == 0 {
+			return nil
+		}
+		child, err := t.resolve(n.children[key[0]])
+		if err != nil {
+			return err
+		}
+		return t.prove(child, key[1:], proof)
+
+	default:
+		return fmt.Errorf("core: unexpected trie node type %T", n)
+	}
+}
+
+// VerifyProof checks a Prove-generated proof against rootHash, the only
+// thing a verifier needs to trust going in, and returns the value key maps
+// to (nil if the proof demonstrates key's absence). It returns an error
+// only when the proof itself is malformed or doesn't hash-chain back to
+// rootHash — never merely because key isn't present, which is a valid,
+// provable outcome (nil, nil).
+func VerifyProof(rootHash Hash, key []byte, proof [][]byte) ([]byte, error) {
+	if rootHash == BytesToHash(emptyRootHash.Bytes()) {
+		return nil, nil
+	}
+
+	key = keybytesToHex(key)
+	wantHash := rootHash.Bytes()
+
+	for _, enc := range proof {
+		if !bytes.Equal(Keccak256(enc), wantHash) {
+			return nil, fmt.Errorf("core: proof node hash mismatch")
+		}
+
+		n, err := decodeTrieNode(enc)
+		if err != nil {
+			return nil, err
+		}
+
+		var next trieElem
+		switch n := n.(type) {
+		case *trieShortNode:
+			matchlen := prefixLen(key, n.key)
+			if matchlen < len(n.key) {
+				return nil, nil
+			}
+			key = key[matchlen:]
+			next = n.val
+
+		case *trieFullNode:
+			if len(key) == 0 {
+				next = n.children[16]
+			} else {
+				next = n.children[key[0]]
+				key = key[1:]
+			}
+
+		default:
+			return nil, fmt.Errorf("core: unexpected proof node type %T", n)
+		}
+
+		switch next := next.(type) {
+		case nil:
+			return nil, nil
+		case trieValueNode:
+			if len(key) != 0 {
+				return nil, nil
+			}
+			return []byte(next), nil
+		case trieHashNode:
+			wantHash = next
+		default:
+			return nil, fmt.Errorf("core: malformed proof node")
+		}
+	}
+
+	return nil, fmt.Errorf("core: incomplete proof for key")
+}
+
+// Root returns t's current root hash, hashing any dirty nodes in memory but
+// without writing anything to the database. This is what IntermediateRoot
+// uses for a per-transaction root: cheap enough to call after every
+// transaction, with the actual leveldb writes deferred to Commit.
+func (t *Trie) Root() Hash {
+	// An untouched root is already its own hash; re-encoding and hashing it
+	// again would double-hash it.
+	if hn, ok := t.root.(trieHashNode); ok {
+		return BytesToHash(hn)
+	}
+	h, _ := t.hash(t.root)
+	if h == nil {
+		return BytesToHash(emptyRootHash.Bytes())
+	}
+	return BytesToHash(h)
+}
+
+// Commit hashes every resolved node, writes it to db keyed by that hash,
+// and returns the resulting root. Only subtrees still held as unresolved
+// hash references are skipped; a node loaded via resolve is re-encoded and
+// written again whether or not it was mutated.
+func (t *Trie) Commit(db *leveldb.DB) (Hash, error) {
+	if hn, ok := t.root.(trieHashNode); ok {
+		return BytesToHash(hn), nil
+	}
+	h, err := t.commit(t.root, db)
+	if err != nil {
+		return Hash{}, err
+	}
+	if h == nil {
+		return BytesToHash(emptyRootHash.Bytes()), nil
+	}
+	return BytesToHash(h), nil
+}
+
+func (t *Trie) hash(n trieElem) ([]byte, error) {
+	enc, err := t.encode(n, nil)
+	if err != nil {
+		return nil, err
+	}
+	if enc == nil {
+		return nil, nil
+	}
+	return Keccak256(enc), nil
+}
+
+// commit is hash plus the side effect of persisting every dirty node it
+// passes through, keyed by its own hash.
+func (t *Trie) commit(n trieElem, db *leveldb.DB) ([]byte, error) {
+	enc, err := t.encode(n, db)
+	if err != nil {
+		return nil, err
+	}
+	if enc == nil {
+		return nil, nil
+	}
+	h := Keccak256(enc)
+	if err := db.Put(h, enc, nil); err != nil {
+		return nil, err
+	}
+	t.cache[BytesToHash(h)] = enc
+	return h, nil
+}
+
+// encode RLP-encodes n's canonical form, recursing into children by their
+// hash (committing them along the way when db is non-nil). A nil db means
+// this is a pure Root() hash pass: children still get hashed, just not
+// written anywhere.
+func (t *Trie) encode(n trieElem, db *leveldb.DB) ([]byte, error) {
+	switch n := n.(type) {
+	case nil:
+		return nil, nil
+
+	case trieHashNode:
+		return n, nil
+
+	case trieValueNode:
+		return rlp.EncodeToBytes([]byte(n))
+
+	case *trieShortNode:
+		childRef, err := t.childReference(n.val, db)
+		if err != nil {
+			return nil, err
+		}
+		return rlp.EncodeToBytes([]interface{}{hexToCompact(n.key), childRef})
+
+	case *trieFullNode:
+		var enc [17]interface{}
+		for i := 0; i < 17; i++ {
+			ref, err := t.childReference(n.children[i], db)
+			if err != nil {
+				return nil, err
+			}
+			enc[i] = ref
+		}
+		return rlp.EncodeToBytes(enc)
+
+	default:
+		return nil, fmt.Errorf("core: unexpected trie node type %T", n)
+	}
+}
+
+// childReference returns what a parent node should embed for child: the
+// raw value for a trieValueNode, an already-known hash for a trieHashNode,
+// or (recursively hashing/committing first) the hash of anything else.
+func (t *Trie) childReference(child trieElem, db *leveldb.DB) (interface{}, error) {
+	switch child := child.(type) {
+	case nil:
+		return []byte{}, nil
+	case trieValueNode:
+		return []byte(child), nil
+	case trieHashNode:
+		return []byte(child), nil
+	default:
+		var (
+			h   []byte
+			err error
+		)
+		if db != nil {
+			h, err = t.commit(child, db)
+		} else {
+			h, err = t.hash(child)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return h, nil
+	}
+}
+
+// resolve turns a trieHashNode into the node it refers to, checking the
+// in-memory cache before falling back to leveldb. Any other node is
+// already resolved and is returned unchanged.
+func (t *Trie) resolve(n trieElem) (trieElem, error) {
+	hn, ok := n.(trieHashNode)
+	if !ok {
+		return n, nil
+	}
+
+	key := BytesToHash(hn)
+	enc, ok := t.cache[key]
+	if !ok {
+		var err error
+		enc, err = t.db.Get(hn, nil)
+		if err != nil {
+			return nil, fmt.Errorf("core: trie node %x not found: %w", hn, err)
+		}
+		t.cache[key] = enc
+	}
+	return decodeTrieNode(enc)
+}
+
+// decodeTrieNode parses a stored node's RLP back into a trieElem: a
+// 2-element list is a trieShortNode, a 17-element list is a trieFullNode,
+// anything else is a trieValueNode. This mirrors encode's shapes exactly so
+// round-tripping through leveldb is lossless.
+func decodeTrieNode(enc []byte) (trieElem, error) {
+	var raw []rlp.RawValue
+	if err := rlp.DecodeBytes(enc, &raw); err != nil {
+		// Not a list: it's an embedded value (e.g. a leaf value stored
+		// inline rather than referenced by hash).
+		var v []byte
+		if err := rlp.DecodeBytes(enc, &v); err != nil {
+			return nil, err
+		}
+		return trieValueNode(v), nil
+	}
+
+	switch len(raw) {
+	case 2:
+		var compactKey []byte
+		if err := rlp.DecodeBytes(raw[0], &compactKey); err != nil {
+			return nil, err
+		}
+		val, err := decodeChildReference(raw[1])
+		if err != nil {
+			return nil, err
+		}
+		return &trieShortNode{key: compactToHex(compactKey), val: val}, nil
+
+	case 17:
+		n := &trieFullNode{}
+		for i := 0; i < 17; i++ {
+			child, err := decodeChildReference(raw[i])
+			if err != nil {
+				return nil, err
+			}
+			n.children[i] = child
+		}
+		return n, nil
+
+	default:
+		return nil, fmt.Errorf("core: invalid trie node with %d list elements", len(raw))
+	}
+}
+
+// decodeChildReference decodes one RLP element of a stored node into
+// either nil (empty string), a trieValueNode (inline value), or a
+// trieHashNode (32-byte reference to another stored node).
+func decodeChildReference(raw rlp.RawValue) (trieElem, error) {
+	var b []byte
+	if err := rlp.DecodeBytes(raw, &b); err != nil {
+		return nil, err
+	}
+	switch len(b) {
+	case 0:
+		return nil, nil
+	case 32:
+		return trieHashNode(b), nil
+	default:
+		return trieValueNode(b), nil
+	}
+}
+
+// compactToHex is hexToCompact's inverse, restoring the nibble slice
+// (including its terminator nibble for a leaf) that hexToCompact packed.
+func compactToHex(compact []byte) []byte {
+	if len(compact) == 0 {
+		return nil
+	}
+	base := make([]byte, 2*len(compact))
+	for i, b := range compact {
+		base[i*2] = b >> 4
+		base[i*2+1] = b & 0x0f
+	}
+	terminator := base[0]>>1 == 1
+	if base[0]&1 == 1 {
+		base = base[1:]
+	} else {
+		base = base[2:]
+	}
+	if terminator {
+		base = append(base, 16)
+	}
+	return base
+}
@@ -0,0 +1,289 @@
+package core
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func openTrieTestDB(t testing.TB) *leveldb.DB {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "diora-trie-test")
+	if err != nil {
+		t.Fatalf("mkdir temp dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	db, err := leveldb.OpenFile(dir, nil)
+	if err != nil {
+		t.Fatalf("open leveldb: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestTrieGetPutDelete(t *testing.T) {
+	db := openTrieTestDB(t)
+	trie := NewTrie(db)
+
+	if got, err := trie.Get([]byte("missing")); err != nil || got != nil {
+		t.Fatalf("Get(missing) = %v, %v, want nil, nil", got, err)
+	}
+
+	if err := trie.Put([]byte("alpha"), []byte("one")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := trie.Put([]byte("alphabet"), []byte("two")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := trie.Put([]byte("beta"), []byte("three")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	for key, want := range map[string]string{"alpha": "one", "alphabet": "two", "beta": "three"} {
+		got, err := trie.Get([]byte(key))
+		if err != nil {
+			t.Fatalf("Get(%s): %v", key, err)
+		}
+		if string(got) != want {
+			t.Errorf("Get(%s) = %q, want %q", key, got, want)
+		}
+	}
+
+	if err := trie.Delete([]byte("alpha")); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if got, err := trie.Get([]byte("alpha")); err != nil || got != nil {
+		t.Fatalf("Get(alpha) after delete = %v, %v, want nil, nil", got, err)
+	}
+	if got, err := trie.Get([]byte("alphabet")); err != nil || string(got) != "two" {
+		t.Fatalf("Get(alphabet) after deleting alpha = %q, %v, want \"two\", nil", got, err)
+	}
+}
+
+func TestTrieCommitPersistsAcrossReopen(t *testing.T) {
+	db := openTrieTestDB(t)
+	trie := NewTrie(db)
+
+	entries := map[string]string{
+		"account1": "balance1",
+		"account2": "balance2",
+		"account3": "balance3",
+	}
+	for k, v := range entries {
+		if err := trie.Put([]byte(k), []byte(v)); err != nil {
+			t.Fatalf("Put(%s): %v", k, err)
+		}
+	}
+
+	root, err := trie.Commit(db)
+	if err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if root == (Hash{}) {
+		t.Fatal("Commit returned the empty root for a non-empty trie")
+	}
+
+	reopened := NewTrieAt(db, root)
+	for k, want := range entries {
+		got, err := reopened.Get([]byte(k))
+		if err != nil {
+			t.Fatalf("Get(%s) on reopened trie: %v", k, err)
+		}
+		if string(got) != want {
+			t.Errorf("Get(%s) on reopened trie = %q, want %q", k, got, want)
+		}
+	}
+}
+
+func TestTrieEmptyRoot(t *testing.T) {
+	db := openTrieTestDB(t)
+	trie := NewTrie(db)
+	want := BytesToHash(emptyRootHash.Bytes())
+
+	if trie.Root() != want {
+		t.Fatalf("Root() of empty trie = %s, want the canonical empty root", trie.Root().Hex())
+	}
+	root, err := trie.Commit(db)
+	if err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if root != want {
+		t.Fatalf("Commit() of empty trie = %s, want the canonical empty root", root.Hex())
+	}
+}
+
+func TestStateCommitRoundTrip(t *testing.T) {
+	db := openTrieTestDB(t)
+	state := NewState(db)
+
+	addr := Address{1, 2, 3}
+	state.SetBalance(addr, big.NewInt(1000))
+	state.SetNonce(addr, 7)
+	state.SetCode(addr, []byte{0x60, 0x01})
+	state.SetState(addr, Hash{0xaa}, Hash{0xbb})
+
+	root, err := state.Commit()
+	if err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if root == (Hash{}) {
+		t.Fatal("Commit returned the empty root for a non-empty state")
+	}
+
+	reopened := NewStateAt(db, root)
+	if got := reopened.GetBalance(addr); got.Int64() != 1000 {
+		t.Errorf("GetBalance = %v, want 1000", got)
+	}
+	if got := reopened.GetNonce(addr); got != 7 {
+		t.Errorf("GetNonce = %d, want 7", got)
+	}
+	if got := reopened.GetCode(addr); string(got) != string([]byte{0x60, 0x01}) {
+		t.Errorf("GetCode = %x, want 6001", got)
+	}
+	if got := reopened.GetState(addr, Hash{0xaa}); got != (Hash{0xbb}) {
+		t.Errorf("GetState = %s, want %s", got.Hex(), Hash{0xbb}.Hex())
+	}
+}
+
+func TestStateSnapshotRevert(t *testing.T) {
+	db := openTrieTestDB(t)
+	state := NewState(db)
+	addr := Address{1}
+
+	state.SetBalance(addr, big.NewInt(100))
+	snapshot := state.Snapshot()
+
+	state.SetBalance(addr, big.NewInt(500))
+	state.SetNonce(addr, 1)
+	state.SetState(addr, Hash{0x01}, Hash{0x02})
+	if got := state.GetBalance(addr); got.Int64() != 500 {
+		t.Fatalf("GetBalance before revert = %v, want 500", got)
+	}
+
+	state.RevertToSnapshot(snapshot)
+
+	if got := state.GetBalance(addr); got.Int64() != 100 {
+		t.Errorf("GetBalance after revert = %v, want 100", got)
+	}
+	if got := state.GetNonce(addr); got != 0 {
+		t.Errorf("GetNonce after revert = %d, want 0", got)
+	}
+	if got := state.GetState(addr, Hash{0x01}); got != (Hash{}) {
+		t.Errorf("GetState after revert = %s, want zero hash", got.Hex())
+	}
+}
+
+func TestStateRevertUndoesAccountCreation(t *testing.T) {
+	db := openTrieTestDB(t)
+	state := NewState(db)
+	addr := Address{2}
+
+	if state.Exist(addr) {
+		t.Fatal("freshly constructed state already has addr")
+	}
+
+	snapshot := state.Snapshot()
+	state.CreateAccount(addr)
+	state.SetBalance(addr, big.NewInt(42))
+	if !state.Exist(addr) {
+		t.Fatal("Exist = false after CreateAccount+SetBalance, want true")
+	}
+
+	state.RevertToSnapshot(snapshot)
+	if state.Exist(addr) {
+		t.Fatal("Exist = true after reverting past CreateAccount, want false")
+	}
+}
+
+func TestStateRefund(t *testing.T) {
+	db := openTrieTestDB(t)
+	state := NewState(db)
+
+	state.AddRefund(100)
+	snapshot := state.Snapshot()
+	state.AddRefund(50)
+	state.SubRefund(30)
+	if got := state.GetRefund(); got != 120 {
+		t.Fatalf("GetRefund = %d, want 120", got)
+	}
+
+	state.RevertToSnapshot(snapshot)
+	if got := state.GetRefund(); got != 100 {
+		t.Errorf("GetRefund after revert = %d, want 100", got)
+	}
+}
+
+func TestStateFinaliseDropsEmptyAndSuicided(t *testing.T) {
+	db := openTrieTestDB(t)
+	state := NewState(db)
+
+	empty := Address{3}
+	state.CreateAccount(empty)
+
+	suicided := Address{4}
+	state.SetBalance(suicided, big.NewInt(10))
+	state.Suicide(suicided)
+	if !state.HasSuicided(suicided) {
+		t.Fatal("HasSuicided = false right after Suicide, want true")
+	}
+
+	survivor := Address{5}
+	state.SetBalance(survivor, big.NewInt(10))
+
+	state.Finalise(true)
+
+	if state.Exist(empty) {
+		t.Error("empty account survived Finalise(true)")
+	}
+	if state.Exist(suicided) {
+		t.Error("suicided account survived Finalise(true)")
+	}
+	if !state.Exist(survivor) {
+		t.Error("non-empty account was dropped by Finalise(true)")
+	}
+
+	root, err := state.Commit()
+	if err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	reopened := NewStateAt(db, root)
+	if reopened.Exist(empty) {
+		t.Error("empty account's leaf survived Commit")
+	}
+	if reopened.Exist(suicided) {
+		t.Error("suicided account's leaf survived Commit")
+	}
+	if got := reopened.GetBalance(survivor); got.Int64() != 10 {
+		t.Errorf("GetBalance(survivor) after reopen = %v, want 10", got)
+	}
+}
+
+// benchmarkAccountChurn commits n distinct accounts' worth of
+// balance/nonce/storage writes in a single State.Commit, the pattern
+// BenchmarkAccountCommit10k/100k below exercise at different scales to show
+// how commit cost grows with the number of accounts touched.
+func benchmarkAccountChurn(b *testing.B, n int) {
+	db := openTrieTestDB(b)
+
+	for i := 0; i < b.N; i++ {
+		state := NewState(db)
+		for j := 0; j < n; j++ {
+			var addr Address
+			copy(addr[:], []byte(fmt.Sprintf("acct-%d-%d", i, j)))
+			state.SetBalance(addr, big.NewInt(int64(j+1)))
+			state.SetNonce(addr, uint64(j))
+			state.SetState(addr, Hash{byte(j)}, Hash{byte(j + 1)})
+		}
+		if _, err := state.Commit(); err != nil {
+			b.Fatalf("Commit: %v", err)
+		}
+	}
+}
+
+func BenchmarkAccountCommit10k(b *testing.B)  { benchmarkAccountChurn(b, 10_000) }
+func BenchmarkAccountCommit100k(b *testing.B) { benchmarkAccountChurn(b, 100_000) }
@@ -0,0 +1,179 @@
+package core
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// MarshalBinary returns tx's canonical wire encoding, the form a peer sends
+// tx over the network or a client reads it back from in. A LegacyTxType
+// transaction is plain RLP, exactly as it's always been (so an old decoder
+// never has to learn about envelopes at all); everything else is the
+// EIP-2718 envelope typeByte || rlp(payload). This is distinct from the
+// RLP the trie building in derive_sha.go uses for TxRoot/ReceiptRoot, which
+// encodes the Transaction struct's fields directly and was never meant to
+// be read by anything outside this node's own database.
+func (tx *Transaction) MarshalBinary() ([]byte, error) {
+	switch tx.Type {
+	case LegacyTxType:
+		return rlp.EncodeToBytes(&rlpLegacyTx{
+			Nonce:    tx.Nonce,
+			GasPrice: tx.GasPrice,
+			GasLimit: tx.GasLimit,
+			To:       tx.To,
+			Value:    tx.Value,
+			Data:     tx.Data,
+			V:        tx.V,
+			R:        tx.R,
+			S:        tx.S,
+		})
+	case AccessListTxType:
+		payload, err := rlp.EncodeToBytes(&rlpAccessListTx{
+			ChainID:    tx.ChainID,
+			Nonce:      tx.Nonce,
+			GasPrice:   tx.GasPrice,
+			GasLimit:   tx.GasLimit,
+			To:         tx.To,
+			Value:      tx.Value,
+			Data:       tx.Data,
+			AccessList: tx.AccessList,
+			V:          tx.V,
+			R:          tx.R,
+			S:          tx.S,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{byte(AccessListTxType)}, payload...), nil
+	case DynamicFeeTxType:
+		payload, err := rlp.EncodeToBytes(&rlpDynamicFeeTx{
+			ChainID:    tx.ChainID,
+			Nonce:      tx.Nonce,
+			GasTipCap:  tx.GasTipCap,
+			GasFeeCap:  tx.GasFeeCap,
+			GasLimit:   tx.GasLimit,
+			To:         tx.To,
+			Value:      tx.Value,
+			Data:       tx.Data,
+			AccessList: tx.AccessList,
+			V:          tx.V,
+			R:          tx.R,
+			S:          tx.S,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{byte(DynamicFeeTxType)}, payload...), nil
+	default:
+		return nil, fmt.Errorf("core: MarshalBinary does not support transaction type %d", tx.Type)
+	}
+}
+
+// UnmarshalBinary parses tx from MarshalBinary's wire format. A legacy
+// transaction's RLP always starts with a list header byte >= 0xc0; any byte
+// below that is an EIP-2718 type discriminator, per the envelope's own
+// encoding rule.
+func (tx *Transaction) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("core: empty transaction encoding")
+	}
+
+	if data[0] >= 0xc0 {
+		var decoded rlpLegacyTx
+		if err := rlp.DecodeBytes(data, &decoded); err != nil {
+			return err
+		}
+		tx.Type = LegacyTxType
+		tx.ChainID = nil
+		tx.AccessList = nil
+		tx.Nonce = decoded.Nonce
+		tx.GasPrice = decoded.GasPrice
+		tx.GasTipCap = nil
+		tx.GasFeeCap = nil
+		tx.GasLimit = decoded.GasLimit
+		tx.To = decoded.To
+		tx.Value = decoded.Value
+		tx.Data = decoded.Data
+		tx.V, tx.R, tx.S = decoded.V, decoded.R, decoded.S
+		return nil
+	}
+
+	switch TxType(data[0]) {
+	case AccessListTxType:
+		var decoded rlpAccessListTx
+		if err := rlp.DecodeBytes(data[1:], &decoded); err != nil {
+			return err
+		}
+		tx.Type = AccessListTxType
+		tx.ChainID = decoded.ChainID
+		tx.Nonce = decoded.Nonce
+		tx.GasPrice = decoded.GasPrice
+		tx.GasTipCap = nil
+		tx.GasFeeCap = nil
+		tx.GasLimit = decoded.GasLimit
+		tx.To = decoded.To
+		tx.Value = decoded.Value
+		tx.Data = decoded.Data
+		tx.AccessList = decoded.AccessList
+		tx.V, tx.R, tx.S = decoded.V, decoded.R, decoded.S
+		return nil
+	case DynamicFeeTxType:
+		var decoded rlpDynamicFeeTx
+		if err := rlp.DecodeBytes(data[1:], &decoded); err != nil {
+			return err
+		}
+		tx.Type = DynamicFeeTxType
+		tx.ChainID = decoded.ChainID
+		tx.GasPrice = nil
+		tx.GasTipCap = decoded.GasTipCap
+		tx.GasFeeCap = decoded.GasFeeCap
+		tx.Nonce = decoded.Nonce
+		tx.GasLimit = decoded.GasLimit
+		tx.To = decoded.To
+		tx.Value = decoded.Value
+		tx.Data = decoded.Data
+		tx.AccessList = decoded.AccessList
+		tx.V, tx.R, tx.S = decoded.V, decoded.R, decoded.S
+		return nil
+	default:
+		return fmt.Errorf("core: UnmarshalBinary does not support transaction type %d", data[0])
+	}
+}
+
+type rlpLegacyTx struct {
+	Nonce    uint64
+	GasPrice *big.Int
+	GasLimit uint64
+	To       *common.Address `rlp:"nil"`
+	Value    *big.Int
+	Data     []byte
+	V, R, S  *big.Int
+}
+
+type rlpAccessListTx struct {
+	ChainID    *big.Int
+	Nonce      uint64
+	GasPrice   *big.Int
+	GasLimit   uint64
+	To         *common.Address `rlp:"nil"`
+	Value      *big.Int
+	Data       []byte
+	AccessList AccessList
+	V, R, S    *big.Int
+}
+
+type rlpDynamicFeeTx struct {
+	ChainID    *big.Int
+	Nonce      uint64
+	GasTipCap  *big.Int
+	GasFeeCap  *big.Int
+	GasLimit   uint64
+	To         *common.Address `rlp:"nil"`
+	Value      *big.Int
+	Data       []byte
+	AccessList AccessList
+	V, R, S    *big.Int
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestTransactionMarshalBinaryRoundTrip(t *testing.T) {
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	chainID := big.NewInt(1337)
+
+	cases := []*Transaction{
+		{
+			Type:     LegacyTxType,
+			Nonce:    1,
+			GasPrice: big.NewInt(5),
+			GasLimit: 21000,
+			To:       &to,
+			Value:    big.NewInt(1000),
+			Data:     []byte{0x01, 0x02},
+			V:        big.NewInt(37),
+			R:        big.NewInt(111),
+			S:        big.NewInt(222),
+		},
+		{
+			Type:     AccessListTxType,
+			ChainID:  chainID,
+			Nonce:    2,
+			GasPrice: big.NewInt(5),
+			GasLimit: 21000,
+			To:       &to,
+			Value:    big.NewInt(2000),
+			AccessList: AccessList{
+				{Address: to, StorageKeys: []common.Hash{common.HexToHash("0x01")}},
+			},
+			V: big.NewInt(1),
+			R: big.NewInt(111),
+			S: big.NewInt(222),
+		},
+		{
+			Type:      DynamicFeeTxType,
+			ChainID:   chainID,
+			Nonce:     3,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(5),
+			GasLimit:  21000,
+			To:        &to,
+			Value:     big.NewInt(3000),
+			V:         big.NewInt(0),
+			R:         big.NewInt(111),
+			S:         big.NewInt(222),
+		},
+	}
+
+	for _, tx := range cases {
+		data, err := tx.MarshalBinary()
+		if err != nil {
+			t.Fatalf("type %d: MarshalBinary: %v", tx.Type, err)
+		}
+
+		var decoded Transaction
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("type %d: UnmarshalBinary: %v", tx.Type, err)
+		}
+
+		redata, err := decoded.MarshalBinary()
+		if err != nil {
+			t.Fatalf("type %d: re-MarshalBinary: %v", tx.Type, err)
+		}
+		if !bytes.Equal(data, redata) {
+			t.Fatalf("type %d: round trip did not reproduce the original encoding", tx.Type)
+		}
+		if decoded.Type != tx.Type || decoded.Nonce != tx.Nonce || *decoded.To != *tx.To {
+			t.Fatalf("type %d: decoded transaction does not match original", tx.Type)
+		}
+	}
+}
+
+func TestTransactionUnmarshalBinaryRejectsUnknownType(t *testing.T) {
+	var tx Transaction
+	if err := tx.UnmarshalBinary([]byte{0x7f}); err == nil {
+		t.Fatalf("expected an error for an unsupported type byte")
+	}
+}
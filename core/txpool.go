@@ -0,0 +1,452 @@
+package core
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrTxPoolFull is returned by Add when the pool (or the submitting
+// account's queued slots) is already at capacity and the incoming
+// transaction doesn't outbid anything already held to make room.
+var ErrTxPoolFull = fmt.Errorf("transaction pool is full")
+
+// ErrNonceTooLow is returned by Add for a transaction whose nonce has
+// already been used by a confirmed transaction from the same sender.
+var ErrNonceTooLow = fmt.Errorf("nonce too low")
+
+// ErrReplaceUnderpriced is returned by Add when a transaction reuses a
+// (sender, nonce) pair already held by the pool without bumping its fee
+// enough to justify the replacement.
+var ErrReplaceUnderpriced = fmt.Errorf("replacement transaction underpriced")
+
+// Pool sizing and pricing defaults, matching go-ethereum's txpool
+// ballpark closely enough for this chain's much smaller validator set.
+const (
+	// DefaultMaxPoolSize caps the total number of transactions (pending
+	// and queued combined) the pool will hold before it starts evicting
+	// the lowest-priced ones to make room.
+	DefaultMaxPoolSize = 5120
+
+	// DefaultAccountQueueSize caps how many queued (nonce-gapped)
+	// transactions a single sender may hold at once.
+	DefaultAccountQueueSize = 16
+
+	// DefaultPriceBumpPercent is how much a replacement transaction for an
+	// already-held (sender, nonce) must beat it by, on both fee cap and
+	// tip, to be accepted in its place.
+	DefaultPriceBumpPercent = 10
+)
+
+// Status reports where Add placed a transaction, or whether the pool has
+// never heard of it.
+type Status int
+
+const (
+	StatusUnknown Status = iota
+	StatusQueued
+	StatusPending
+)
+
+// String renders a Status the way logs and CLI output want it.
+func (s Status) String() string {
+	switch s {
+	case StatusPending:
+		return "pending"
+	case StatusQueued:
+		return "queued"
+	default:
+		return "unknown"
+	}
+}
+
+// TxPool holds transactions that have passed ValidateTransaction but
+// haven't been included in a block yet, split per sender and keyed by
+// nonce. pending holds each sender's contiguous run of transactions
+// starting at their current on-chain nonce — the ones immediately
+// executable; queued holds the rest, waiting on an earlier nonce to close
+// the gap. Add promotes queued transactions into pending as soon as that
+// gap closes.
+type TxPool struct {
+	mu sync.RWMutex
+
+	pending map[common.Address]map[uint64]*Transaction
+	queued  map[common.Address]map[uint64]*Transaction
+	all     map[common.Hash]*Transaction
+
+	// nonceAt returns a sender's current on-chain nonce, the floor every
+	// pooled transaction from them must clear.
+	nonceAt func(common.Address) uint64
+
+	maxSize          int
+	accountQueueSize int
+	priceBumpPercent int64
+
+	subs   map[int]chan *Transaction
+	nextID int
+}
+
+// NewTxPool creates an empty pool that holds at most maxSize transactions
+// across every sender's pending and queued combined, consulting nonceAt to
+// decide whether an incoming transaction is immediately executable or
+// stuck behind a gap.
+func NewTxPool(maxSize int, nonceAt func(common.Address) uint64) *TxPool {
+	return &TxPool{
+		pending:          make(map[common.Address]map[uint64]*Transaction),
+		queued:           make(map[common.Address]map[uint64]*Transaction),
+		all:              make(map[common.Hash]*Transaction),
+		nonceAt:          nonceAt,
+		maxSize:          maxSize,
+		accountQueueSize: DefaultAccountQueueSize,
+		priceBumpPercent: DefaultPriceBumpPercent,
+		subs:             make(map[int]chan *Transaction),
+	}
+}
+
+// Add admits tx to the pool. Callers are expected to have already run it
+// through Blockchain.ValidateTransaction; Add additionally enforces the
+// pool's own bookkeeping invariants: no nonce already confirmed on-chain,
+// replace-by-fee for a nonce the pool already holds, and the pool's
+// capacity limits.
+func (tp *TxPool) Add(tx *Transaction) error {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	if _, exists := tp.all[tx.Hash]; exists {
+		return nil
+	}
+
+	confirmedNonce := tp.nonceAt(tx.From)
+	if tx.Nonce < confirmedNonce {
+		return ErrNonceTooLow
+	}
+
+	if existing := tp.bucketFor(tx.From, tx.Nonce); existing != nil {
+		if err := checkReplacement(existing, tx); err != nil {
+			return err
+		}
+		// Replace tx in place, in the same bucket it already occupies,
+		// instead of removing it and re-running insert's bucket-placement
+		// logic: insert recomputes pending's contiguous tail from
+		// confirmedNonce + len(pending), and removing a pending entry that
+		// isn't pending's newest (e.g. replacing nonce 5 of a {5,6,7}
+		// pending run) shrinks that count without the replacement's nonce
+		// moving, so insert would misplace it into queued and strand it
+		// there since nothing re-promotes a queued entry on its own.
+		_, wasPending := tp.pending[tx.From][tx.Nonce]
+		delete(tp.all, existing.Hash)
+		tp.all[tx.Hash] = tx
+		if wasPending {
+			tp.pending[tx.From][tx.Nonce] = tx
+			tp.notify(tx)
+		} else {
+			tp.queued[tx.From][tx.Nonce] = tx
+		}
+		return nil
+	}
+
+	if len(tp.all) >= tp.maxSize && !tp.evictLowestPriced(tx) {
+		return ErrTxPoolFull
+	}
+
+	nextPending := confirmedNonce + uint64(len(tp.pending[tx.From]))
+	if tx.Nonce != nextPending && len(tp.queued[tx.From]) >= tp.accountQueueSize {
+		if !tp.evictLowestPricedQueued(tx.From, tx) {
+			return ErrTxPoolFull
+		}
+	}
+
+	tp.insert(tx, confirmedNonce)
+	return nil
+}
+
+// bucketFor returns the transaction tx.From already has at nonce, from
+// either pending or queued, or nil if there isn't one.
+func (tp *TxPool) bucketFor(sender common.Address, nonce uint64) *Transaction {
+	if tx, ok := tp.pending[sender][nonce]; ok {
+		return tx
+	}
+	if tx, ok := tp.queued[sender][nonce]; ok {
+		return tx
+	}
+	return nil
+}
+
+// insert places tx into pending (if it continues its sender's contiguous
+// run from confirmedNonce) or queued, then promotes any queued
+// transactions that pending's new tail makes immediately executable.
+func (tp *TxPool) insert(tx *Transaction, confirmedNonce uint64) {
+	tp.all[tx.Hash] = tx
+
+	nextPending := confirmedNonce + uint64(len(tp.pending[tx.From]))
+	if tx.Nonce == nextPending {
+		if tp.pending[tx.From] == nil {
+			tp.pending[tx.From] = make(map[uint64]*Transaction)
+		}
+		tp.pending[tx.From][tx.Nonce] = tx
+		tp.notify(tx)
+		tp.promote(tx.From)
+		return
+	}
+
+	if tp.queued[tx.From] == nil {
+		tp.queued[tx.From] = make(map[uint64]*Transaction)
+	}
+	tp.queued[tx.From][tx.Nonce] = tx
+}
+
+// promote moves sender's queued transactions into pending for as long as
+// each next nonce is already waiting there, the same cascade a nonce-gap
+// close triggers in go-ethereum's txpool.
+func (tp *TxPool) promote(sender common.Address) {
+	confirmedNonce := tp.nonceAt(sender)
+	for {
+		next := confirmedNonce + uint64(len(tp.pending[sender]))
+		tx, ok := tp.queued[sender][next]
+		if !ok {
+			return
+		}
+		delete(tp.queued[sender], next)
+		if tp.pending[sender] == nil {
+			tp.pending[sender] = make(map[uint64]*Transaction)
+		}
+		tp.pending[sender][next] = tx
+		tp.notify(tx)
+	}
+}
+
+// removeFromAll drops tx from whichever bucket holds it and from all,
+// without touching promotion — used when a replacement is about to take
+// tx's exact slot right back.
+func (tp *TxPool) removeFromAll(tx *Transaction) {
+	delete(tp.all, tx.Hash)
+	delete(tp.pending[tx.From], tx.Nonce)
+	delete(tp.queued[tx.From], tx.Nonce)
+}
+
+// checkReplacement enforces replace-by-fee: a new transaction for a
+// (sender, nonce) the pool already holds must beat the existing one's fee
+// cap and tip cap by priceBumpPercent, or it's rejected so a sender can't
+// displace a transaction already broadcast for a trivial price difference.
+func checkReplacement(old, replacement *Transaction) error {
+	if !bumpsBy(EffectiveGasFeeCap(old), EffectiveGasFeeCap(replacement), DefaultPriceBumpPercent) {
+		return ErrReplaceUnderpriced
+	}
+	if !bumpsBy(EffectiveGasTipCap(old), EffectiveGasTipCap(replacement), DefaultPriceBumpPercent) {
+		return ErrReplaceUnderpriced
+	}
+	return nil
+}
+
+// bumpsBy reports whether updated is at least original scaled up by
+// percent, rounding the threshold down the way go-ethereum does so a
+// replacement only needs to clear it, never strictly exceed it.
+func bumpsBy(original, updated *big.Int, percent int64) bool {
+	threshold := new(big.Int).Mul(original, big.NewInt(100+percent))
+	threshold.Div(threshold, big.NewInt(100))
+	return updated.Cmp(threshold) >= 0
+}
+
+// priceOf is the metric evictLowestPriced(Queued) ranks transactions by:
+// the most a transaction is willing to pay per unit of gas, tip plus base
+// fee included, with no base fee context to net the tip against.
+func priceOf(tx *Transaction) *big.Int {
+	return EffectiveGasFeeCap(tx)
+}
+
+// evictLowestPriced drops the worst-priced transaction anywhere in the
+// pool if incoming outbids it, making room for incoming. It reports
+// whether it made room.
+func (tp *TxPool) evictLowestPriced(incoming *Transaction) bool {
+	var worst *Transaction
+	for _, tx := range tp.all {
+		if worst == nil || priceOf(tx).Cmp(priceOf(worst)) < 0 {
+			worst = tx
+		}
+	}
+	if worst == nil || priceOf(incoming).Cmp(priceOf(worst)) <= 0 {
+		return false
+	}
+	tp.removeFromAll(worst)
+	return true
+}
+
+// evictLowestPricedQueued drops sender's worst-priced queued transaction
+// if incoming outbids it, making room within sender's per-account queue
+// cap. It reports whether it made room.
+func (tp *TxPool) evictLowestPricedQueued(sender common.Address, incoming *Transaction) bool {
+	var worst *Transaction
+	for _, tx := range tp.queued[sender] {
+		if worst == nil || priceOf(tx).Cmp(priceOf(worst)) < 0 {
+			worst = tx
+		}
+	}
+	if worst == nil || priceOf(incoming).Cmp(priceOf(worst)) <= 0 {
+		return false
+	}
+	tp.removeFromAll(worst)
+	return true
+}
+
+// Get returns the pooled transaction with the given hash, if any.
+func (tp *TxPool) Get(hash common.Hash) (*Transaction, bool) {
+	tp.mu.RLock()
+	defer tp.mu.RUnlock()
+	tx, ok := tp.all[hash]
+	return tx, ok
+}
+
+// Status reports whether hash is currently pending, queued, or unknown to
+// the pool.
+func (tp *TxPool) Status(hash common.Hash) Status {
+	tp.mu.RLock()
+	defer tp.mu.RUnlock()
+	tx, ok := tp.all[hash]
+	if !ok {
+		return StatusUnknown
+	}
+	if _, ok := tp.pending[tx.From][tx.Nonce]; ok {
+		return StatusPending
+	}
+	return StatusQueued
+}
+
+// Remove drops a transaction from the pool, e.g. once it's been included in
+// a processed block.
+func (tp *TxPool) Remove(hash common.Hash) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	tx, ok := tp.all[hash]
+	if !ok {
+		return
+	}
+	tp.removeFromAll(tx)
+}
+
+// Len returns the total number of transactions held by the pool, pending
+// and queued combined.
+func (tp *TxPool) Len() int {
+	tp.mu.RLock()
+	defer tp.mu.RUnlock()
+	return len(tp.all)
+}
+
+// Pending returns every sender's executable transactions, each sorted by
+// nonce ascending. It's the shape the tx CLI and RPC layer want to inspect
+// a particular account's queue; CreateBlock wants a single price-ordered
+// list instead, which PendingFlat provides.
+func (tp *TxPool) Pending() map[common.Address][]*Transaction {
+	tp.mu.RLock()
+	defer tp.mu.RUnlock()
+
+	out := make(map[common.Address][]*Transaction, len(tp.pending))
+	for sender, byNonce := range tp.pending {
+		if len(byNonce) == 0 {
+			continue
+		}
+		txs := make([]*Transaction, 0, len(byNonce))
+		for _, tx := range byNonce {
+			txs = append(txs, tx)
+		}
+		sort.Slice(txs, func(i, j int) bool { return txs[i].Nonce < txs[j].Nonce })
+		out[sender] = txs
+	}
+	return out
+}
+
+// Queued returns every sender's non-executable transactions (stuck behind
+// a nonce gap), each sorted by nonce ascending — the txpool_content RPC
+// method's "queued" half, mirroring Pending's "pending" half.
+func (tp *TxPool) Queued() map[common.Address][]*Transaction {
+	tp.mu.RLock()
+	defer tp.mu.RUnlock()
+
+	out := make(map[common.Address][]*Transaction, len(tp.queued))
+	for sender, byNonce := range tp.queued {
+		if len(byNonce) == 0 {
+			continue
+		}
+		txs := make([]*Transaction, 0, len(byNonce))
+		for _, tx := range byNonce {
+			txs = append(txs, tx)
+		}
+		sort.Slice(txs, func(i, j int) bool { return txs[i].Nonce < txs[j].Nonce })
+		out[sender] = txs
+	}
+	return out
+}
+
+// PendingFlat returns the pool's executable transactions ordered the way a
+// proposer building a block wants them: highest EffectiveGasTip against the
+// given base fee first, so the most profitable transactions fill the block
+// before its GasPool runs dry. Ties break by nonce, oldest first, matching
+// go-ethereum's miner heuristic of preferring a sender's earlier
+// transactions.
+func (tp *TxPool) PendingFlat(baseFee *big.Int) []*Transaction {
+	pending := tp.Pending()
+	txs := make([]*Transaction, 0, len(tp.all))
+	for _, senderTxs := range pending {
+		txs = append(txs, senderTxs...)
+	}
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		ti, tj := EffectiveGasTip(txs[i], baseFee), EffectiveGasTip(txs[j], baseFee)
+		if cmp := ti.Cmp(tj); cmp != 0 {
+			return cmp > 0
+		}
+		return txs[i].Nonce < txs[j].Nonce
+	})
+	return txs
+}
+
+// ByAddress returns every transaction the pool holds from sender, pending
+// and queued combined, sorted by nonce ascending.
+func (tp *TxPool) ByAddress(sender common.Address) []*Transaction {
+	tp.mu.RLock()
+	defer tp.mu.RUnlock()
+
+	txs := make([]*Transaction, 0, len(tp.pending[sender])+len(tp.queued[sender]))
+	for _, tx := range tp.pending[sender] {
+		txs = append(txs, tx)
+	}
+	for _, tx := range tp.queued[sender] {
+		txs = append(txs, tx)
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].Nonce < txs[j].Nonce })
+	return txs
+}
+
+// Subscribe registers a channel that receives every transaction as it
+// becomes pending, whether on admission or promotion out of queued. The
+// returned unsubscribe function must be called to release it.
+func (tp *TxPool) Subscribe(buf int) (<-chan *Transaction, func()) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	id := tp.nextID
+	tp.nextID++
+	ch := make(chan *Transaction, buf)
+	tp.subs[id] = ch
+
+	return ch, func() {
+		tp.mu.Lock()
+		defer tp.mu.Unlock()
+		delete(tp.subs, id)
+		close(ch)
+	}
+}
+
+// notify fans tx out to every pending-transaction subscriber, dropping
+// slow consumers rather than blocking admission on them. Callers must hold
+// tp.mu.
+func (tp *TxPool) notify(tx *Transaction) {
+	for _, ch := range tp.subs {
+		select {
+		case ch <- tx:
+		default:
+		}
+	}
+}
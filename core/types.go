@@ -3,6 +3,8 @@ package core
 import (
 	"math/big"
 	"time"
+
+	"github.com/DioraFund/diora-go-blockchain/crypto"
 )
 
 // Common types used across the blockchain
@@ -76,53 +78,79 @@ type Log struct {
 	Removed     bool
 }
 
-type Bloom [256]byte
+// bloomByteLength/bloomBitLength size Bloom the way go-ethereum sizes its
+// block-header blooms: 2048 bits, indexed by three bit positions derived
+// from a Keccak256 hash (the "bloom9" construction), giving a low
+// false-positive rate for the handful of addresses/topics a typical block's
+// receipts touch.
+const (
+	bloomByteLength = 256
+	bloomBitLength  = 8 * bloomByteLength
+)
 
-func (b Bloom) Set(topic Hash) {
-	// Simplified bloom filter implementation
-	// In production, use proper bloom filter with multiple hash functions
-}
+type Bloom [bloomByteLength]byte
 
-func (b Bloom) Test(topic Hash) bool {
-	// Simplified bloom filter test
-	return false
+// Set ORs topic's three bloom9 bits into b.
+func (b *Bloom) Set(topic Hash) {
+	b.add(topic.Bytes())
 }
 
-type Trie struct {
-	root   Hash
-	db     Database
-	cache  map[Hash][]byte
+// SetAddress ORs addr's three bloom9 bits into b.
+func (b *Bloom) SetAddress(addr Address) {
+	b.add(addr.Bytes())
 }
 
-func NewTrie(db Database) *Trie {
-	return &Trie{
-		root:  Hash{},
-		db:    db,
-		cache: make(map[Hash][]byte),
+func (b *Bloom) add(data []byte) {
+	hash := crypto.Keccak256(data)
+	for i := 0; i < 6; i += 2 {
+		bit := (uint(hash[i+1]) + uint(hash[i])<<8) & (bloomBitLength - 1)
+		b[bloomByteLength-1-bit/8] |= 1 << (bit % 8)
 	}
 }
 
-func (t *Trie) Root() Hash {
-	return t.root
+// Test reports whether topic may have been added to b. False positives are
+// expected (that's the nature of a bloom filter); false negatives are not,
+// so FilterLogs can safely skip any block whose Bloom fails this check.
+func (b Bloom) Test(topic Hash) bool {
+	return b.contains(topic.Bytes())
 }
 
-func (t *Trie) Get(key []byte) ([]byte, error) {
-	// Simplified trie get implementation
-	// In production, implement proper Patricia trie
-	return nil, nil
+// TestAddress reports whether addr may have been added to b.
+func (b Bloom) TestAddress(addr Address) bool {
+	return b.contains(addr.Bytes())
 }
 
-func (t *Trie) Put(key, value []byte) error {
-	// Simplified trie put implementation
-	// In production, implement proper Patricia trie
-	return nil
+func (b Bloom) contains(data []byte) bool {
+	hash := crypto.Keccak256(data)
+	for i := 0; i < 6; i += 2 {
+		bit := (uint(hash[i+1]) + uint(hash[i])<<8) & (bloomBitLength - 1)
+		if b[bloomByteLength-1-bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// CreateBloom computes the bloom filter covering every log a block's
+// receipts emitted: each log's address and topics are added, so a header's
+// Bloom can rule out an entire block for FilterLogs without reading a
+// single receipt back off disk.
+func CreateBloom(receipts []*Receipt) Bloom {
+	var bloom Bloom
+	for _, receipt := range receipts {
+		for _, l := range receipt.Logs {
+			bloom.SetAddress(l.Address)
+			for _, topic := range l.Topics {
+				bloom.Set(topic)
+			}
+		}
+	}
+	return bloom
 }
 
-func (t *Trie) Delete(key []byte) error {
-	// Simplified trie delete implementation
-	// In production, implement proper Patricia trie
-	return nil
-}
+// Trie is defined in trie.go: a persistent hexary Merkle-Patricia trie
+// backed directly by a *leveldb.DB (not the Database interface below),
+// content-addressed by node hash.
 
 type Database interface {
 	Get(key []byte) ([]byte, error)
@@ -236,6 +264,11 @@ type Proposal struct {
 	Description string
 	Type        ProposalType
 	Value       *big.Int
+	// Payload carries a ProposalType-specific encoding that Value (a single
+	// *big.Int) can't express — e.g. ProposalTypeSetHaltBlock's target block
+	// height, via EncodeHaltHeightPayload/DecodeHaltHeightPayload. Types that
+	// only ever needed Value leave it nil.
+	Payload     []byte
 	StartTime   time.Time
 	EndTime     time.Time
 	Status      ProposalStatus
@@ -252,6 +285,15 @@ const (
 	ProposalTypeParameterChange
 	ProposalTypeUpgrade
 	ProposalTypeSpending
+	// ProposalTypeSetHaltBlock schedules a coordinated chain halt: once
+	// passed, Blockchain.ExecuteProposal stores its Payload (an
+	// EncodeHaltHeightPayload-encoded block height) in state, and the block
+	// producer/validator both refuse to build or accept blocks at or past it.
+	ProposalTypeSetHaltBlock
+	// ProposalTypeUnhalt clears a pending halt height set by an earlier
+	// ProposalTypeSetHaltBlock proposal, letting the network resume once the
+	// coordinated upgrade it was scheduled for has completed.
+	ProposalTypeUnhalt
 )
 
 type ProposalStatus int
@@ -315,68 +357,6 @@ func BytesToAddress(b []byte) Address {
 	return a
 }
 
-// RLP encoding/decoding helpers
-func (b *Block) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, []interface{}{
-		b.Header.ParentHash,
-		b.Header.Coinbase,
-		b.Header.StateRoot,
-		b.Header.TxRoot,
-		b.Header.ReceiptRoot,
-		b.Header.Difficulty,
-		b.Header.Number,
-		b.Header.GasLimit,
-		b.Header.GasUsed,
-		b.Header.Timestamp,
-		b.Header.ExtraData,
-		b.Header.MixHash,
-		b.Header.Nonce,
-		b.Header.Validator,
-		b.Header.Signature,
-		b.Transactions,
-	})
-}
-
-func (b *Block) DecodeRLP(s *rlp.Stream) error {
-	var header struct {
-		ParentHash  Hash
-		Coinbase    Address
-		StateRoot   Hash
-		TxRoot      Hash
-		ReceiptRoot Hash
-		Difficulty  *big.Int
-		Number      *big.Int
-		GasLimit    uint64
-		GasUsed     uint64
-		Timestamp   uint64
-		ExtraData   []byte
-		MixHash     Hash
-		Nonce       [8]byte
-		Validator   Address
-		Signature   []byte
-	}
-	
-	if err := s.Decode(&header); err != nil {
-		return err
-	}
-	
-	b.Header = &BlockHeader{
-		ParentHash:  header.ParentHash,
-		Coinbase:    header.Coinbase,
-		StateRoot:   header.StateRoot,
-		TxRoot:      header.TxRoot,
-		ReceiptRoot: header.ReceiptRoot,
-		Difficulty:  header.Difficulty,
-		Number:      header.Number,
-		GasLimit:    header.GasLimit,
-		GasUsed:     header.GasUsed,
-		Timestamp:   header.Timestamp,
-		ExtraData:   header.ExtraData,
-		MixHash:     header.MixHash,
-		Nonce:       header.Nonce,
-		Validator:   header.Validator,
-		Signature:   header.Signature,
-	}
-	
-	return s.Decode(&b.Transactions)
-}
+// Block's RLP encoding/decoding lives in blockchain.go alongside its other
+// accessors, since EncodeRLP/DecodeRLP need access to the unexported header/
+// transactions/deposits fields defined there.
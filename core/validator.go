@@ -0,0 +1,194 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/DioraFund/diora-go-blockchain/beacon"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Validator checks a block's well-formedness, independent of how its
+// transactions are actually executed. Splitting it out of Blockchain (the
+// same way go-ethereum separates BlockValidator from BlockChain) lets a
+// consensus engine or a test swap in custom validation — e.g. a fake PoW
+// validator in tests, or a historical-replay validator that skips consensus
+// checks — without touching the chain's execution or storage logic.
+type Validator interface {
+	// ValidateBody checks a block before its transactions are executed:
+	// hash integrity, parent linkage, consensus rules, and the
+	// transactions' own well-formedness.
+	ValidateBody(block *Block) error
+
+	// ValidateState checks a block after its transactions are executed:
+	// that the receipts it produced are consistent with what the header
+	// claims (gas used, state root, deposits root, receipt root).
+	// stateRoot is the root State.IntermediateRoot computed from running
+	// this block's transactions, passed in rather than recomputed here
+	// since only the caller holds the State to derive it from.
+	ValidateState(block *Block, parent *Block, receipts []*Receipt, usedGas uint64, stateRoot common.Hash) error
+}
+
+// BlockValidator is the default Validator, matching the checks Blockchain
+// used to run directly inside ValidateBlock/ProcessBlock.
+type BlockValidator struct {
+	config    *Config
+	bc        *Blockchain
+	consensus Consensus
+}
+
+// NewBlockValidator creates a Validator bound to a chain and its consensus
+// engine.
+func NewBlockValidator(config *Config, bc *Blockchain, engine Consensus) *BlockValidator {
+	return &BlockValidator{config: config, bc: bc, consensus: engine}
+}
+
+func (v *BlockValidator) ValidateBody(block *Block) error {
+	// A governance-scheduled halt height rejects any block at or past it,
+	// regardless of who produced it, so the chain halts cleanly even if some
+	// validator keeps proposing past the coordinated stop point. Read
+	// directly off bc rather than through the locking HaltHeight accessor:
+	// ProcessBlock already holds bc.mu when it calls ValidateBody, and
+	// sync.RWMutex isn't reentrant.
+	if height := v.bc.haltHeight; height != 0 && block.Header().Number.Uint64() >= height {
+		return fmt.Errorf("%w: block %d is at or past halt height %d", ErrChainHalted, block.Header().Number.Uint64(), height)
+	}
+
+	// Check block hash
+	computedHash := block.ComputeHash()
+	if !bytes.Equal(block.Hash().Bytes(), computedHash.Bytes()) {
+		return ErrInvalidBlock
+	}
+
+	// Check parent block exists
+	var parent *Block
+	if block.Header().Number.Cmp(big.NewInt(0)) > 0 {
+		var err error
+		parent, err = v.bc.GetBlockByHash(block.Header().ParentHash)
+		if err != nil {
+			return fmt.Errorf("parent block not found: %w", err)
+		}
+	}
+
+	if err := v.checkBeaconEntries(block, parent); err != nil {
+		return err
+	}
+
+	// TxRoot is always known before execution (it only depends on the
+	// block's own transaction list), unlike ReceiptRoot/StateRoot/
+	// DepositsRoot below, so it's checked unconditionally here rather than
+	// left for ValidateState to fill in.
+	computedTxRoot := computeTxRoot(block.Transactions())
+	if block.Header().TxRoot != computedTxRoot {
+		return fmt.Errorf("tx root mismatch: header has %s, transactions produce %s",
+			block.Header().TxRoot.Hex(), computedTxRoot.Hex())
+	}
+
+	// Validate consensus
+	if err := v.consensus.ValidateBlock(block); err != nil {
+		return fmt.Errorf("consensus validation failed: %w", err)
+	}
+
+	// Validate transactions
+	for _, tx := range block.Transactions() {
+		if err := v.bc.ValidateTransaction(tx); err != nil {
+			return fmt.Errorf("invalid transaction %s: %w", tx.Hash.Hex(), err)
+		}
+	}
+
+	return nil
+}
+
+// checkBeaconEntries validates block's BeaconEntries against parent's, when
+// the chain has a randomness beacon configured (see
+// Blockchain.SetBeaconNetworks). A chain with no beacon configured skips
+// this entirely, so the feature can be turned on for new blocks without
+// retroactively invalidating ones mined before it existed. parent is nil
+// for the genesis block, which carries no beacon entries to chain from.
+func (v *BlockValidator) checkBeaconEntries(block, parent *Block) error {
+	net := v.bc.beaconNet
+	if net == nil {
+		return nil
+	}
+
+	entries := block.Header().BeaconEntries
+	if len(entries) == 0 {
+		return fmt.Errorf("block %d: missing beacon entries", block.Header().Number.Uint64())
+	}
+
+	prev, havePrev := beacon.BeaconEntry{}, false
+	if parent != nil {
+		if parentEntries := parent.Header().BeaconEntries; len(parentEntries) > 0 {
+			prev, havePrev = parentEntries[len(parentEntries)-1], true
+		}
+	}
+
+	for _, cur := range entries {
+		if havePrev {
+			if err := net.VerifyEntry(prev, cur); err != nil {
+				return fmt.Errorf("block %d: %w", block.Header().Number.Uint64(), err)
+			}
+		}
+		prev, havePrev = cur, true
+	}
+
+	// The final entry's round must have caught up with the round this
+	// block's own Timestamp falls in, so a proposer can't staple on a
+	// stale, long-since-superseded entry. Only enforced against a beacon
+	// that actually knows how to derive a round from a timestamp.
+	active := net.ActiveAt(block.Header().Number.Uint64())
+	if timer, ok := active.(beacon.RoundTimer); ok {
+		wantRound := timer.RoundAt(time.Unix(int64(block.Header().Timestamp), 0))
+		last := entries[len(entries)-1]
+		if last.Round < wantRound {
+			return fmt.Errorf("block %d: beacon entry round %d is behind the round %d its timestamp falls in",
+				block.Header().Number.Uint64(), last.Round, wantRound)
+		}
+	}
+
+	return nil
+}
+
+func (v *BlockValidator) ValidateState(block *Block, parent *Block, receipts []*Receipt, usedGas uint64, stateRoot common.Hash) error {
+	if block.Header().GasUsed != usedGas {
+		return fmt.Errorf("gas used mismatch: header has %d, execution produced %d", block.Header().GasUsed, usedGas)
+	}
+
+	// StateRoot, like ReceiptRoot/DepositsRoot below, can't be known by
+	// CreateBlock before execution: ProcessBlock fills a zero header root
+	// in from this same execution before calling here, so a zero root is
+	// not checked, while an externally supplied nonzero root must match
+	// what execution actually produced.
+	if block.Header().StateRoot != (common.Hash{}) && block.Header().StateRoot != stateRoot {
+		return fmt.Errorf("state root mismatch: header has %s, execution produces %s",
+			block.Header().StateRoot.Hex(), stateRoot.Hex())
+	}
+
+	// Check the validator deposits (EIP-6110 style) the receipts carry: the
+	// deposit stream is the only source of truth for validator set changes,
+	// so this replaces any separate off-chain staking flow. ProcessBlock
+	// applies them once this passes.
+	computedDepositsRoot := ComputeDepositsRoot(ScanDeposits(receipts))
+	// A zero DepositsRoot is not checked (see StateRoot above); it does not
+	// end validation, so ReceiptRoot below is still checked.
+	if root := block.Header().DepositsRoot; root != (common.Hash{}) && root != computedDepositsRoot {
+		return fmt.Errorf("deposits root mismatch: header has %s, receipts produce %s",
+			root.Hex(), computedDepositsRoot.Hex())
+	}
+
+	// Same story for ReceiptRoot: a zero root is not checked, while an
+	// externally supplied block must match what its own receipts actually
+	// produce.
+	if block.Header().ReceiptRoot == (common.Hash{}) {
+		return nil
+	}
+	computedReceiptRoot := computeReceiptRoot(receipts)
+	if block.Header().ReceiptRoot != computedReceiptRoot {
+		return fmt.Errorf("receipt root mismatch: header has %s, receipts produce %s",
+			block.Header().ReceiptRoot.Hex(), computedReceiptRoot.Hex())
+	}
+
+	return nil
+}
@@ -42,7 +42,7 @@ func Keccak256(data ...[]byte) []byte {
 
 // GenerateKey generates a new ECDSA private key
 func GenerateKey() (*ecdsa.PrivateKey, error) {
-	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	return ecdsa.GenerateKey(S256(), rand.Reader)
 }
 
 // Sign calculates an ECDSA signature
@@ -60,13 +60,65 @@ func VerifySignature(pubkey, hash, signature []byte) bool {
 	return secp256k1.VerifySignature(pubkey, hash, signature)
 }
 
+// Ecrecover returns the uncompressed public key bytes that produced sig
+// (the 65-byte [R || S || V] format, V a recovery id in {0,1}) over hash.
+func Ecrecover(hash, sig []byte) ([]byte, error) {
+	return secp256k1.RecoverPubkey(hash, sig)
+}
+
+// SigToPub is Ecrecover, parsed into an *ecdsa.PublicKey.
+func SigToPub(hash, sig []byte) (*ecdsa.PublicKey, error) {
+	s, err := Ecrecover(hash, sig)
+	if err != nil {
+		return nil, err
+	}
+	x, y := elliptic.Unmarshal(S256(), s)
+	return &ecdsa.PublicKey{Curve: S256(), X: x, Y: y}, nil
+}
+
+// EIP155V folds a recovery id (0 or 1) and a chain id into the v a legacy
+// transaction signature is stored with: v = recoveryID + 35 + 2*chainID. A
+// nil or zero chainID signs the pre-EIP-155 way (v = recoveryID + 27), for
+// chains that haven't opted into replay protection.
+func EIP155V(recoveryID byte, chainID *big.Int) *big.Int {
+	if chainID == nil || chainID.Sign() == 0 {
+		return big.NewInt(int64(recoveryID) + 27)
+	}
+	v := new(big.Int).Mul(big.NewInt(2), chainID)
+	v.Add(v, big.NewInt(int64(recoveryID)+35))
+	return v
+}
+
+// EIP155RecoveryID is EIP155V's inverse: it extracts the raw 0/1 recovery
+// id from v, failing if v doesn't match chainID (either because it was
+// signed for a different chain, or because it's malformed).
+func EIP155RecoveryID(v, chainID *big.Int) (byte, error) {
+	if v == nil {
+		return 0, fmt.Errorf("missing signature v")
+	}
+
+	base := int64(27)
+	offset := new(big.Int)
+	if chainID != nil && chainID.Sign() != 0 {
+		base = 35
+		offset.Mul(big.NewInt(2), chainID)
+	}
+
+	recID := new(big.Int).Sub(v, offset)
+	recID.Sub(recID, big.NewInt(base))
+	if recID.Sign() < 0 || recID.Cmp(big.NewInt(1)) > 0 {
+		return 0, fmt.Errorf("signature v %s does not match chain id %v", v, chainID)
+	}
+	return byte(recID.Uint64()), nil
+}
+
 // DecompressPubkey parses a public key in the 33-byte compressed format
 func DecompressPubkey(pubkey []byte) (*ecdsa.PublicKey, error) {
 	x, y := secp256k1.DecompressPubkey(pubkey)
 	if x == nil {
 		return nil, fmt.Errorf("invalid public key")
 	}
-	return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	return &ecdsa.PublicKey{Curve: S256(), X: x, Y: y}, nil
 }
 
 // CompressPubkey encodes a public key to the 33-byte compressed format
@@ -85,7 +137,7 @@ func FromECDSAPub(pub *ecdsa.PublicKey) []byte {
 	if pub == nil || pub.X == nil || pub.Y == nil {
 		return nil
 	}
-	return elliptic.Marshal(elliptic.P256(), pub.X, pub.Y)
+	return elliptic.Marshal(S256(), pub.X, pub.Y)
 }
 
 // ToECDSA creates a private key with the given D value
@@ -95,7 +147,7 @@ func ToECDSA(d []byte) (*ecdsa.PrivateKey, error) {
 
 func toECDSA(d []byte, strict bool) (*ecdsa.PrivateKey, error) {
 	priv := new(ecdsa.PrivateKey)
-	priv.PublicKey.Curve = elliptic.P256()
+	priv.PublicKey.Curve = S256()
 	if strict && 8*len(d) != priv.Params().BitSize {
 		return nil, fmt.Errorf("invalid length, need %d bits", priv.Params().BitSize)
 	}
@@ -107,7 +159,7 @@ func toECDSA(d []byte, strict bool) (*ecdsa.PrivateKey, error) {
 	}
 
 	// The priv.D must not be 0 or >= N
-	priv.PublicKey.X, priv.PublicKey.Y = elliptic.P256().ScalarBaseMult(d)
+	priv.PublicKey.X, priv.PublicKey.Y = S256().ScalarBaseMult(d)
 	if priv.PublicKey.X == nil {
 		return nil, fmt.Errorf("invalid private key")
 	}
@@ -143,7 +195,7 @@ func CreateAddress2(b common.Address, salt [32]byte, inithash []byte) common.Add
 }
 
 // ValidateSignatureValues verifies whether the signature values are valid with
-// the given chain rules. The v value is assumed to be either 0 or 27.
+// the given chain rules. The v value is assumed to be a raw recovery id, 0 or 1.
 func ValidateSignatureValues(v byte, r, s *big.Int, homestead bool) bool {
 	if r.Cmp(common.Big1) < 0 || s.Cmp(common.Big1) < 0 {
 		return false
@@ -153,8 +205,7 @@ func ValidateSignatureValues(v byte, r, s *big.Int, homestead bool) bool {
 	if homestead && s.Cmp(secp256k1HalfN) > 0 {
 		return false
 	}
-	// Frontier: reject s value above N/2
-	if v != 0 && v != 27 {
+	if v != 0 && v != 1 {
 		return false
 	}
 	if r.Cmp(secp256k1N) < 0 && s.Cmp(secp256k1N) < 0 {
@@ -171,5 +222,5 @@ func zeroBytes(bytes []byte) {
 
 // S256 returns an instance of the secp256k1 curve
 func S256() elliptic.Curve {
-	return elliptic.P256()
+	return secp256k1.S256()
 }
@@ -0,0 +1,61 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TestPubkeyToAddressKnownVector pins HexToECDSA/ToECDSA/PubkeyToAddress to
+// a known secp256k1 keypair and its real Ethereum address. Private key 1 is
+// a widely published secp256k1 test vector; if S256 or toECDSA ever
+// regresses back to P256, the derived address changes and this test catches
+// it immediately instead of only failing interop with outside tooling.
+func TestPubkeyToAddressKnownVector(t *testing.T) {
+	const (
+		privHex     = "0000000000000000000000000000000000000000000000000000000000000001"
+		wantAddress = "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"
+	)
+
+	priv, err := HexToECDSA(privHex)
+	if err != nil {
+		t.Fatalf("HexToECDSA: %v", err)
+	}
+
+	got := PubkeyToAddress(&priv.PublicKey)
+	if got.Hex() != wantAddress {
+		t.Fatalf("PubkeyToAddress(priv 1) = %s, want %s", got.Hex(), wantAddress)
+	}
+}
+
+// TestEcrecoverRoundTrip signs a hash and recovers the signer's address and
+// public key back out of the signature, the way a transaction signer does.
+func TestEcrecoverRoundTrip(t *testing.T) {
+	priv, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	wantAddress := PubkeyToAddress(&priv.PublicKey)
+
+	hash := Keccak256([]byte("diora secp256k1 regression vector"))
+	sig, err := Sign(hash, priv)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	pub, err := SigToPub(hash, sig)
+	if err != nil {
+		t.Fatalf("SigToPub: %v", err)
+	}
+	if got := PubkeyToAddress(pub); got != wantAddress {
+		t.Fatalf("SigToPub recovered address %s, want %s", got.Hex(), wantAddress.Hex())
+	}
+
+	recoveredBytes, err := Ecrecover(hash, sig)
+	if err != nil {
+		t.Fatalf("Ecrecover: %v", err)
+	}
+	if got := common.BytesToAddress(Keccak256(recoveredBytes[1:])[12:]); got != wantAddress {
+		t.Fatalf("Ecrecover recovered address %s, want %s", got.Hex(), wantAddress.Hex())
+	}
+}
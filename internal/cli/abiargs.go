@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// splitArgs splits the comma-separated value --args/--constructor-args
+// takes into its raw fields, trimming surrounding whitespace. An empty (or
+// all-whitespace) input yields no arguments, rather than one empty one.
+func splitArgs(raw string) []string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+	parts := strings.Split(raw, ",")
+	for i, p := range parts {
+		parts[i] = strings.TrimSpace(p)
+	}
+	return parts
+}
+
+// convertABIArgs converts raw, one entry per inputs argument in order, into
+// the Go values abi.Arguments.Pack expects for each one.
+func convertABIArgs(inputs abi.Arguments, raw []string) ([]interface{}, error) {
+	if len(raw) != len(inputs) {
+		return nil, fmt.Errorf("expected %d argument(s), got %d", len(inputs), len(raw))
+	}
+	values := make([]interface{}, len(inputs))
+	for i, input := range inputs {
+		v, err := convertABIArg(input.Type, raw[i])
+		if err != nil {
+			name := input.Name
+			if name == "" {
+				name = fmt.Sprintf("arg%d", i)
+			}
+			return nil, fmt.Errorf("argument %d (%s %s): %w", i, input.Type.String(), name, err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// convertABIArg converts one raw CLI argument into the Go value t expects.
+// It covers the scalar types a constructor or function call is typically
+// made of; composite types (tuples, arrays of non-scalars) aren't
+// supported — --args is a flat comma-separated list and has no syntax for
+// them.
+func convertABIArg(t abi.Type, raw string) (interface{}, error) {
+	switch t.T {
+	case abi.AddressTy:
+		if !common.IsHexAddress(raw) {
+			return nil, fmt.Errorf("invalid address %q", raw)
+		}
+		return common.HexToAddress(raw), nil
+	case abi.BoolTy:
+		return strconv.ParseBool(raw)
+	case abi.StringTy:
+		return raw, nil
+	case abi.BytesTy:
+		return common.FromHex(raw), nil
+	case abi.FixedBytesTy:
+		return fitFixedBytes(common.FromHex(raw), t.Size)
+	case abi.IntTy, abi.UintTy:
+		n, ok := new(big.Int).SetString(raw, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", raw)
+		}
+		return fitInteger(n, t)
+	default:
+		return nil, fmt.Errorf("unsupported argument type %s", t.String())
+	}
+}
+
+// fitInteger converts n into the concrete Go type t.GetType() reflects
+// (uint8/.../uint64 or *big.Int for wider uints, and the int equivalents),
+// matching what abi.Arguments.Pack requires per bit width.
+func fitInteger(n *big.Int, t abi.Type) (interface{}, error) {
+	rt := t.GetType()
+	switch rt.Kind() {
+	case reflect.Ptr:
+		return n, nil
+	case reflect.Uint8:
+		return uint8(n.Uint64()), nil
+	case reflect.Uint16:
+		return uint16(n.Uint64()), nil
+	case reflect.Uint32:
+		return uint32(n.Uint64()), nil
+	case reflect.Uint64:
+		return n.Uint64(), nil
+	case reflect.Int8:
+		return int8(n.Int64()), nil
+	case reflect.Int16:
+		return int16(n.Int64()), nil
+	case reflect.Int32:
+		return int32(n.Int64()), nil
+	case reflect.Int64:
+		return n.Int64(), nil
+	default:
+		return nil, fmt.Errorf("unsupported integer kind %s for %s", rt.Kind(), t.String())
+	}
+}
+
+// fitFixedBytes copies b into a [size]byte array via reflection, the
+// concrete type abi.Arguments.Pack requires for a bytesN argument.
+func fitFixedBytes(b []byte, size int) (interface{}, error) {
+	if len(b) > size {
+		return nil, fmt.Errorf("value is %d bytes, want at most %d", len(b), size)
+	}
+	array := reflect.New(reflect.ArrayOf(size, reflect.TypeOf(byte(0)))).Elem()
+	reflect.Copy(array, reflect.ValueOf(b))
+	return array.Interface(), nil
+}
@@ -2,10 +2,29 @@ package cli
 
 import (
 	"fmt"
+	"math/big"
 
+	"github.com/DioraFund/diora-go-blockchain/internal/keystore"
+	"github.com/DioraFund/diora-go-blockchain/internal/rpcclient"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 )
 
+// accountClient builds an AccountClient against the configured node.
+func (c *CLI) accountClient() *rpcclient.AccountClient {
+	return rpcclient.NewAccountClient(c.config.RPC.URL, rpcTimeout)
+}
+
+// weiToDIO renders wei as a decimal DIO amount, the same 18-decimal scale
+// the rest of the CLI's balance output uses.
+func weiToDIO(wei *big.Int) string {
+	if wei == nil {
+		return "0.000000"
+	}
+	dio := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(1e18))
+	return dio.Text('f', 6)
+}
+
 // getAccountCommand returns the account command
 func (c *CLI) getAccountCommand() *cli.Command {
 	return &cli.Command{
@@ -13,6 +32,8 @@ func (c *CLI) getAccountCommand() *cli.Command {
 		Usage: "Manage blockchain accounts",
 		Subcommands: []*cli.Command{
 			c.getAccountCreateCommand(),
+			c.getAccountImportMnemonicCommand(),
+			c.getAccountDeriveCommand(),
 			c.getAccountListCommand(),
 			c.getAccountInfoCommand(),
 			c.getAccountHistoryCommand(),
@@ -20,35 +41,203 @@ func (c *CLI) getAccountCommand() *cli.Command {
 	}
 }
 
-// getAccountCreateCommand returns the account create command
+// getAccountCreateCommand returns the account create command. It always
+// derives the new account from a BIP-39 mnemonic (generating one if
+// --mnemonic is absent), the way MetaMask derives every new account it
+// shows a user — account import-mnemonic and account derive then let a
+// wallet recovered elsewhere, or a later address on the same one, reuse
+// the same mnemonic.
 func (c *CLI) getAccountCreateCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "create",
-		Usage: "Create a new blockchain account",
+		Usage: "Create a new HD blockchain account",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "name",
+				Category: "Keystore",
 				Aliases:  []string{"n"},
 				Usage:    "Account name",
 				Required: true,
 			},
 			&cli.StringFlag{
 				Name:     "password",
+				Category: "Keystore",
 				Aliases:  []string{"p"},
 				Usage:    "Account password",
 				Required: true,
 			},
+			&cli.StringFlag{
+				Name:     "mnemonic",
+				Category: "Keystore",
+				Usage:    "Mnemonic to import (leave empty to generate a new one)",
+			},
+			&cli.StringFlag{
+				Name:     "passphrase",
+				Category: "Keystore",
+				Usage:    "Optional BIP-39 passphrase",
+			},
+			&cli.StringFlag{
+				Name:     "path",
+				Category: "Keystore",
+				Usage:    "HD derivation path",
+				Value:    keystore.DefaultHDPath,
+			},
+			&cli.IntFlag{
+				Name:     "words",
+				Category: "Keystore",
+				Usage:    "Mnemonic word count when generating one (12 or 24)",
+				Value:    12,
+			},
 		},
 		Action: func(ctx *cli.Context) error {
 			name := ctx.String("name")
 			password := ctx.String("password")
+			mnemonic := ctx.String("mnemonic")
+			passphrase := ctx.String("passphrase")
+			path := ctx.String("path")
+			words := ctx.Int("words")
 
-			c.logger.Info("Creating account", "name", name)
+			c.logger.Info("Creating account", "name", name, "path", path)
+
+			ks := c.newKeystore(c.config.Keystore.Path)
+			account, err := ks.NewHDAccount(name, password, mnemonic, passphrase, path, words)
+			if err != nil {
+				return fmt.Errorf("failed to create account: %w", err)
+			}
 
-			// TODO: Implement account creation logic
 			fmt.Printf("✅ Account '%s' created successfully!\n", name)
-			fmt.Printf("📍 Address: 0x1234567890123456789012345678901234567890\n")
-			fmt.Printf("🔑 Public Key: 0xabcdef...\n")
+			fmt.Printf("📍 Address: %s\n", account.Address.Hex())
+			fmt.Printf("🔑 Public Key: %s\n", account.PublicKey)
+			fmt.Printf("🧭 Path: %s\n", account.Path)
+			if mnemonic == "" {
+				generated, err := ks.ExportMnemonic(name, password)
+				if err != nil {
+					return fmt.Errorf("account created but failed to read back its mnemonic: %w", err)
+				}
+				fmt.Printf("⚠️  Save this mnemonic somewhere safe, it will not be shown again:\n")
+				fmt.Printf("🔑 %s\n", generated)
+			}
+
+			return nil
+		},
+	}
+}
+
+// getAccountImportMnemonicCommand returns the account import-mnemonic
+// command: the account create path for a caller that already has a
+// mnemonic (e.g. MetaMask's) and wants its first derived address to become
+// a managed account.
+func (c *CLI) getAccountImportMnemonicCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "import-mnemonic",
+		Usage: "Import an HD account from an existing BIP-39 mnemonic",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Category: "Keystore",
+				Aliases:  []string{"n"},
+				Usage:    "Account name",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "password",
+				Category: "Keystore",
+				Aliases:  []string{"p"},
+				Usage:    "Account password",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "mnemonic",
+				Category: "Keystore",
+				Usage:    "Mnemonic to import",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "passphrase",
+				Category: "Keystore",
+				Usage:    "Optional BIP-39 passphrase",
+			},
+			&cli.StringFlag{
+				Name:     "path",
+				Category: "Keystore",
+				Usage:    "HD derivation path",
+				Value:    keystore.DefaultHDPath,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			name := ctx.String("name")
+			password := ctx.String("password")
+			mnemonic := ctx.String("mnemonic")
+			passphrase := ctx.String("passphrase")
+			path := ctx.String("path")
+
+			c.logger.Info("Importing account from mnemonic", "name", name, "path", path)
+
+			ks := c.newKeystore(c.config.Keystore.Path)
+			account, err := ks.NewHDAccount(name, password, mnemonic, passphrase, path, 0)
+			if err != nil {
+				return fmt.Errorf("failed to import account: %w", err)
+			}
+
+			fmt.Printf("✅ Account '%s' imported successfully!\n", name)
+			fmt.Printf("📍 Address: %s\n", account.Address.Hex())
+			fmt.Printf("🔑 Public Key: %s\n", account.PublicKey)
+			fmt.Printf("🧭 Path: %s\n", account.Path)
+
+			return nil
+		},
+	}
+}
+
+// getAccountDeriveCommand returns the account derive command: the next
+// account from an HD wallet account create/import-mnemonic already set up,
+// either the next unused address (the default) or an explicit one named
+// by --path.
+func (c *CLI) getAccountDeriveCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "derive",
+		Usage: "Derive another account from an HD wallet",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Category: "Keystore",
+				Aliases:  []string{"n"},
+				Usage:    "HD wallet account name, as passed to create/import-mnemonic",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "path",
+				Category: "Keystore",
+				Usage:    "Explicit derivation path (e.g. \"m/44'/60'/0'/0/7\"); defaults to the next unused address",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			name := ctx.String("name")
+			path := ctx.String("path")
+
+			ks := c.newKeystore(c.config.Keystore.Path)
+
+			var account *keystore.Account
+			var err error
+			if path == "" {
+				c.logger.Info("Deriving next account", "name", name)
+				account, err = ks.DeriveNext(name)
+			} else {
+				c.logger.Info("Deriving account", "name", name, "path", path)
+				var index uint32
+				index, err = ks.ChildIndexFromPath(name, path)
+				if err == nil {
+					account, err = ks.DeriveAt(name, index)
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("failed to derive account: %w", err)
+			}
+
+			fmt.Printf("✅ Account derived successfully!\n")
+			fmt.Printf("📝 Name: %s\n", account.Name)
+			fmt.Printf("📍 Address: %s\n", account.Address.Hex())
+			fmt.Printf("🧭 Path: %s\n", account.Path)
 
 			return nil
 		},
@@ -62,10 +251,11 @@ func (c *CLI) getAccountListCommand() *cli.Command {
 		Usage: "List all accounts",
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
-				Name:    "show-balance",
-				Aliases: []string{"b"},
-				Usage:   "Show account balances",
-				Value:   false,
+				Name:     "show-balance",
+				Category: "Keystore",
+				Aliases:  []string{"b"},
+				Usage:    "Show account balances",
+				Value:    false,
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -73,21 +263,29 @@ func (c *CLI) getAccountListCommand() *cli.Command {
 
 			c.logger.Info("Listing accounts", "show_balance", showBalance)
 
-			// TODO: Implement account listing logic
-			fmt.Printf("📋 Found 2 account(s):\n\n")
-			fmt.Printf("1. 📝 main\n")
-			fmt.Printf("   📍 Address: 0x1234567890123456789012345678901234567890\n")
-			if showBalance {
-				fmt.Printf("   💰 Balance: 1,000.000000 DIO\n")
+			ks := c.newKeystore(c.config.Keystore.Path)
+			accounts, err := ks.ListAccounts()
+			if err != nil {
+				return fmt.Errorf("failed to list accounts: %w", err)
 			}
-			fmt.Printf("   📅 Created: 2024-01-01 12:00:00\n")
-			fmt.Println()
-			fmt.Printf("2. 📝 validator\n")
-			fmt.Printf("   📍 Address: 0x0987654321098765432109876543210987654321\n")
-			if showBalance {
-				fmt.Printf("   💰 Balance: 500.000000 DIO\n")
+
+			fmt.Printf("📋 Found %d account(s):\n\n", len(accounts))
+			for i, account := range accounts {
+				fmt.Printf("%d. 📝 %s\n", i+1, account.Name)
+				fmt.Printf("   📍 Address: %s\n", account.Address.Hex())
+				if showBalance {
+					balance, err := c.accountClient().Balance(account.Address)
+					if err != nil {
+						fmt.Printf("   💰 Balance: unavailable (%v)\n", err)
+					} else {
+						fmt.Printf("   💰 Balance: %s DIO\n", weiToDIO(balance))
+					}
+				}
+				fmt.Printf("   📅 Created: %s\n", account.CreatedAt.Format("2006-01-02 15:04:05"))
+				if i < len(accounts)-1 {
+					fmt.Println()
+				}
 			}
-			fmt.Printf("   📅 Created: 2024-01-02 15:30:00\n")
 
 			return nil
 		},
@@ -97,44 +295,53 @@ func (c *CLI) getAccountListCommand() *cli.Command {
 // getAccountInfoCommand returns the account info command
 func (c *CLI) getAccountInfoCommand() *cli.Command {
 	return &cli.Command{
-		Name:  "info",
-		Usage: "Get account information",
+		Name:         "info",
+		Usage:        "Get account information",
+		BashComplete: c.completeKeystoreAddresses,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "address",
+				Category: "Keystore",
 				Aliases:  []string{"addr", "a"},
 				Usage:    "Account address",
 				Required: true,
 			},
 			&cli.BoolFlag{
-				Name:    "show-transactions",
-				Aliases: []string{"t"},
-				Usage:   "Show recent transactions",
-				Value:   false,
+				Name:     "show-transactions",
+				Category: "Keystore",
+				Aliases:  []string{"t"},
+				Usage:    "Show recent transactions",
+				Value:    false,
 			},
 		},
 		Action: func(ctx *cli.Context) error {
-			address := ctx.String("address")
+			address := common.HexToAddress(ctx.String("address"))
 			showTransactions := ctx.Bool("show-transactions")
 
-			c.logger.Info("Getting account info", "address", address, "show_transactions", showTransactions)
+			c.logger.Info("Getting account info", "address", address.Hex(), "show_transactions", showTransactions)
+
+			ac := c.accountClient()
+			balance, err := ac.Balance(address)
+			if err != nil {
+				return fmt.Errorf("failed to fetch balance for %s: %w", address.Hex(), err)
+			}
+			nonce, err := ac.NextNonce(address)
+			if err != nil {
+				return fmt.Errorf("failed to fetch nonce for %s: %w", address.Hex(), err)
+			}
 
-			// TODO: Implement account info logic
 			fmt.Printf("📊 Account Information\n")
 			fmt.Printf("===================\n")
-			fmt.Printf("📍 Address: %s\n", address)
-			fmt.Printf("💰 Balance: 1,000.000000 DIO\n")
-			fmt.Printf("🔢 Nonce: 42\n")
-			fmt.Printf("📅 Created: 2024-01-01 12:00:00\n")
-			fmt.Printf("🏷️  Type: Regular Account\n")
-			fmt.Printf("🔒 Status: Active\n")
+			fmt.Printf("📍 Address: %s\n", address.Hex())
+			fmt.Printf("💰 Balance: %s DIO\n", weiToDIO(balance))
+			fmt.Printf("🔢 Nonce: %d\n", nonce)
 
 			if showTransactions {
+				// TODO: Implement recent-transaction lookup — the node has
+				// no transfer-history index to query yet.
 				fmt.Printf("\n📋 Recent Transactions\n")
 				fmt.Printf("=====================\n")
-				fmt.Printf("1. 📤 Send - 0xabcdef... - 10.000000 DIO - 2024-01-15 10:30:00\n")
-				fmt.Printf("2. 📥 Receive - 0x123456... - 50.000000 DIO - 2024-01-14 15:20:00\n")
-				fmt.Printf("3. 📤 Send - 0x789012... - 5.000000 DIO - 2024-01-13 09:15:00\n")
+				fmt.Printf("(transaction history not yet available)\n")
 			}
 
 			return nil
@@ -150,21 +357,24 @@ func (c *CLI) getAccountHistoryCommand() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "address",
+				Category: "Keystore",
 				Aliases:  []string{"addr", "a"},
 				Usage:    "Account address",
 				Required: true,
 			},
 			&cli.IntFlag{
-				Name:    "limit",
-				Aliases: []string{"l"},
-				Usage:   "Number of transactions to show",
-				Value:   10,
+				Name:     "limit",
+				Category: "Keystore",
+				Aliases:  []string{"l"},
+				Usage:    "Number of transactions to show",
+				Value:    10,
 			},
 			&cli.StringFlag{
-				Name:    "type",
-				Aliases: []string{"t"},
-				Usage:   "Transaction type (send, receive, contract)",
-				Value:   "",
+				Name:     "type",
+				Category: "Keystore",
+				Aliases:  []string{"t"},
+				Usage:    "Transaction type (send, receive, contract)",
+				Value:    "",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -174,7 +384,8 @@ func (c *CLI) getAccountHistoryCommand() *cli.Command {
 
 			c.logger.Info("Getting account history", "address", address, "limit", limit, "type", txType)
 
-			// TODO: Implement account history logic
+			// TODO: Implement account history logic — the node has no
+			// transfer-history index to query yet.
 			fmt.Printf("📋 Transaction History for %s\n", address)
 			fmt.Printf("================================\n")
 			fmt.Printf("Showing %d recent transactions\n\n", limit)
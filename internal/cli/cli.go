@@ -4,18 +4,24 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 
+	"github.com/DioraFund/diora-go-blockchain/internal/cli/render"
 	"github.com/DioraFund/diora-go-blockchain/internal/config"
+	"github.com/DioraFund/diora-go-blockchain/internal/contractstore"
+	"github.com/DioraFund/diora-go-blockchain/internal/keystore"
 	"github.com/DioraFund/diora-go-blockchain/internal/logger"
+	"github.com/DioraFund/diora-go-blockchain/internal/walletrpc"
 	"github.com/urfave/cli/v2"
 )
 
 // CLI represents the command line interface
 type CLI struct {
-	ctx    context.Context
-	config *config.Config
-	logger *logger.Logger
-	app    *cli.App
+	ctx      context.Context
+	config   *config.Config
+	logger   *logger.Logger
+	app      *cli.App
+	renderer render.Renderer
 }
 
 // NewCLI creates a new CLI instance
@@ -28,13 +34,14 @@ func NewCLI(ctx context.Context, cfg *config.Config, log *logger.Logger) (*CLI,
 
 	// Initialize CLI application
 	app := &cli.App{
-		Name:     "diora",
-		Version:  "1.0.0",
-		Usage:    "ABM Diora Blockchain Command Line Interface",
-		Commands: cliApp.getCommands(),
-		Flags:    cliApp.getGlobalFlags(),
-		Before:   cliApp.beforeAction,
-		After:    cliApp.afterAction,
+		Name:                 "diora",
+		Version:              "1.0.0",
+		Usage:                "ABM Diora Blockchain Command Line Interface",
+		Commands:             cliApp.getCommands(),
+		Flags:                cliApp.getGlobalFlags(),
+		Before:               cliApp.beforeAction,
+		After:                cliApp.afterAction,
+		EnableBashCompletion: true,
 	}
 
 	cliApp.app = app
@@ -52,12 +59,16 @@ func (c *CLI) getCommands() []*cli.Command {
 		c.getWalletCommand(),
 		c.getAccountCommand(),
 		c.getNetworkCommand(),
+		c.getNodeCommand(),
 		c.getValidatorCommand(),
 		c.getTransactionCommand(),
 		c.getContractCommand(),
 		c.getConfigCommand(),
 		c.getMonitorCommand(),
 		c.getDevCommand(),
+		c.getGovernanceCommand(),
+		c.getLogsCommand(),
+		c.getCompletionCommand(),
 	}
 }
 
@@ -65,40 +76,80 @@ func (c *CLI) getCommands() []*cli.Command {
 func (c *CLI) getGlobalFlags() []cli.Flag {
 	return []cli.Flag{
 		&cli.StringFlag{
-			Name:    "config",
-			Aliases: []string{"c"},
-			Usage:   "Path to configuration file",
-			Value:   c.config.ConfigPath,
+			Name:     "config",
+			Aliases:  []string{"c"},
+			Usage:    "Path to configuration file",
+			Value:    c.config.ConfigPath,
+			Category: "Networking",
 		},
 		&cli.StringFlag{
-			Name:    "rpc-url",
-			Aliases: []string{"r"},
-			Usage:   "RPC endpoint URL",
-			Value:   c.config.RPC.URL,
+			Name:     "rpc-url",
+			Aliases:  []string{"r"},
+			Usage:    "RPC endpoint URL",
+			Value:    c.config.RPC.URL,
+			Category: "Networking",
 		},
 		&cli.StringFlag{
-			Name:    "keystore",
-			Aliases: []string{"k"},
-			Usage:   "Keystore directory path",
-			Value:   c.config.Keystore.Path,
+			Name:     "keystore",
+			Aliases:  []string{"k"},
+			Usage:    "Keystore directory path",
+			Value:    c.config.Keystore.Path,
+			Category: "Keystore",
+		},
+		&cli.StringFlag{
+			Name:     "wallet-url",
+			Usage:    "diora wallet daemon address (unix:///path/to/socket or http://host:port); unset signs against the local keystore",
+			Value:    c.config.Wallet.URL,
+			Category: "Keystore",
+		},
+		&cli.StringFlag{
+			Name:     "wallet-token",
+			Usage:    "Bearer token authenticating to --wallet-url when it's a TCP endpoint",
+			Value:    c.config.Wallet.Token,
+			Category: "Keystore",
+		},
+		&cli.BoolFlag{
+			Name:     "verbose",
+			Aliases:  []string{"v"},
+			Usage:    "Enable verbose logging",
+			Value:    false,
+			Category: "Output",
 		},
 		&cli.BoolFlag{
-			Name:    "verbose",
-			Aliases: []string{"v"},
-			Usage:   "Enable verbose logging",
-			Value:   false,
+			Name:     "no-color",
+			Aliases:  []string{"nc"},
+			Usage:    "Disable colored output",
+			Value:    false,
+			Category: "Output",
+		},
+		&cli.StringFlag{
+			Name:     "output",
+			Aliases:  []string{"o"},
+			Usage:    "Output format (table, json, yaml, ndjson)",
+			Value:    "table",
+			Category: "Output",
+		},
+		&cli.BoolFlag{
+			Name:     "quiet",
+			Aliases:  []string{"q"},
+			Usage:    "Suppress table mode's blank-line separators between list entries",
+			Value:    false,
+			Category: "Output",
 		},
 		&cli.BoolFlag{
-			Name:    "no-color",
-			Aliases: []string{"nc"},
-			Usage:   "Disable colored output",
-			Value:   false,
+			Name:  "help-all",
+			Usage: "Print every command, subcommand and flag, then exit",
 		},
 	}
 }
 
 // beforeAction is called before each command execution
 func (c *CLI) beforeAction(ctx *cli.Context) error {
+	if ctx.Bool("help-all") {
+		printHelpAll(ctx.App)
+		os.Exit(0)
+	}
+
 	// Update configuration with CLI flags
 	if ctx.IsSet("config") {
 		c.config.ConfigPath = ctx.String("config")
@@ -109,6 +160,12 @@ func (c *CLI) beforeAction(ctx *cli.Context) error {
 	if ctx.IsSet("keystore") {
 		c.config.Keystore.Path = ctx.String("keystore")
 	}
+	if ctx.IsSet("wallet-url") {
+		c.config.Wallet.URL = ctx.String("wallet-url")
+	}
+	if ctx.IsSet("wallet-token") {
+		c.config.Wallet.Token = ctx.String("wallet-token")
+	}
 	if ctx.IsSet("verbose") {
 		c.config.Logging.Level = "debug"
 	}
@@ -118,6 +175,12 @@ func (c *CLI) beforeAction(ctx *cli.Context) error {
 		return fmt.Errorf("failed to reload configuration: %w", err)
 	}
 
+	format, err := render.ParseFormat(ctx.String("output"))
+	if err != nil {
+		return err
+	}
+	c.renderer = render.New(format, os.Stdout, ctx.Bool("quiet"))
+
 	c.logger.Info("Executing command", "command", ctx.Command.Name)
 	return nil
 }
@@ -127,3 +190,34 @@ func (c *CLI) afterAction(ctx *cli.Context) error {
 	c.logger.Info("Command completed", "command", ctx.Command.Name)
 	return nil
 }
+
+// newKeystore opens the keystore at path using the KDF configured in
+// config.Keystore.Encryption, so `--encryption pbkdf2` (or a config file
+// setting it) actually changes what new accounts are encrypted with
+// instead of silently always getting scrypt.
+func (c *CLI) newKeystore(path string) *keystore.Keystore {
+	switch c.config.Keystore.Encryption {
+	case keystore.KDFPBKDF2:
+		return keystore.NewKeystoreWithKDF(path, keystore.KDFPBKDF2)
+	default:
+		return keystore.NewKeystoreWithKDF(path, keystore.KDFScrypt)
+	}
+}
+
+// walletManager resolves the keystore.WalletManager a signing command
+// should use: a walletrpc.RemoteWallet against --wallet-url if one is
+// configured, otherwise a keystore.LocalWallet unlocking password against
+// this process's own keystore directory exactly as it always has.
+func (c *CLI) walletManager(password string) keystore.WalletManager {
+	if c.config.Wallet.URL != "" {
+		return walletrpc.NewRemoteWallet(c.config.Wallet.URL, c.config.Wallet.Token)
+	}
+	return keystore.NewLocalWallet(c.newKeystore(c.config.Keystore.Path), password)
+}
+
+// contractStore opens the local deployed-contracts/verification index the
+// contract subcommands maintain, rooted as a sibling of the keystore
+// directory the same way node.data_dir sits alongside keystore.path.
+func (c *CLI) contractStore() *contractstore.Store {
+	return contractstore.NewStore(filepath.Join(filepath.Dir(c.config.Keystore.Path), "contracts"))
+}
@@ -2,10 +2,148 @@ package cli
 
 import (
 	"fmt"
+	"os"
 
+	"github.com/DioraFund/diora-go-blockchain/internal/cli/prompt"
+	"github.com/DioraFund/diora-go-blockchain/internal/config"
 	"github.com/urfave/cli/v2"
 )
 
+// ConfigView is the --output-able shape of the full, flattened CLI
+// configuration `config show` renders (one section per getConfigCommand's
+// own section flag, or the whole thing with no --section given).
+type ConfigView struct {
+	ConfigPath string              `json:"config_path" yaml:"config_path"`
+	LogLevel   string              `json:"log_level" yaml:"log_level"`
+	Network    string              `json:"network" yaml:"network"`
+	RPC        RPCConfigView       `json:"rpc" yaml:"rpc"`
+	Keystore   KeystoreConfigView  `json:"keystore" yaml:"keystore"`
+	Node       NodeConfigView      `json:"node" yaml:"node"`
+	Validator  ValidatorConfigView `json:"validator" yaml:"validator"`
+	API        APIConfigView       `json:"api" yaml:"api"`
+	Logging    LoggingConfigView   `json:"logging" yaml:"logging"`
+}
+
+// RPCConfigView is config.RPCConfig's --output shape.
+type RPCConfigView struct {
+	URL             string `json:"url" yaml:"url"`
+	TimeoutSeconds  int    `json:"timeout_seconds" yaml:"timeout_seconds"`
+	MaxConnections  int    `json:"max_connections" yaml:"max_connections"`
+	EnableWebSocket bool   `json:"enable_websocket" yaml:"enable_websocket"`
+}
+
+// KeystoreConfigView is config.KeystoreConfig's --output shape.
+type KeystoreConfigView struct {
+	Path       string `json:"path" yaml:"path"`
+	Encryption string `json:"encryption" yaml:"encryption"`
+}
+
+// NodeConfigView is config.NodeConfig's --output shape.
+type NodeConfigView struct {
+	DataDir     string `json:"data_dir" yaml:"data_dir"`
+	GenesisFile string `json:"genesis_file" yaml:"genesis_file"`
+	HTTPPort    int    `json:"http_port" yaml:"http_port"`
+	WSPort      int    `json:"ws_port" yaml:"ws_port"`
+	P2PPort     int    `json:"p2p_port" yaml:"p2p_port"`
+}
+
+// ValidatorConfigView is config.ValidatorConfig's --output shape.
+type ValidatorConfigView struct {
+	Enabled      bool   `json:"enabled" yaml:"enabled"`
+	StakeAmount  string `json:"stake_amount" yaml:"stake_amount"`
+	Commission   string `json:"commission" yaml:"commission"`
+	ValidatorKey string `json:"validator_key" yaml:"validator_key"`
+	MinGasPrice  string `json:"min_gas_price" yaml:"min_gas_price"`
+}
+
+// APIConfigView is config.APIConfig's --output shape.
+type APIConfigView struct {
+	Enabled bool   `json:"enabled" yaml:"enabled"`
+	Port    int    `json:"port" yaml:"port"`
+	Host    string `json:"host" yaml:"host"`
+	CORS    string `json:"cors" yaml:"cors"`
+}
+
+// LoggingConfigView is config.LoggingConfig's --output shape.
+type LoggingConfigView struct {
+	Level      string            `json:"level" yaml:"level"`
+	Format     string            `json:"format" yaml:"format"`
+	Output     string            `json:"output" yaml:"output"`
+	MaxSize    int               `json:"max_size" yaml:"max_size"`
+	MaxBackups int               `json:"max_backups" yaml:"max_backups"`
+	MaxAge     int               `json:"max_age" yaml:"max_age"`
+	Modules    map[string]string `json:"modules,omitempty" yaml:"modules,omitempty"`
+}
+
+// ConfigValueView is config get's --output shape.
+type ConfigValueView struct {
+	Key   string `json:"key" yaml:"key"`
+	Value any    `json:"value" yaml:"value"`
+}
+
+// ConfigChangeView is the --output-able shape of one config.ConfigChange,
+// the diff `config set`/`config unset`/`config reset` render instead of a
+// fixed-format banner.
+type ConfigChangeView struct {
+	Key string `json:"key" yaml:"key"`
+	Old any    `json:"old" yaml:"old"`
+	New any    `json:"new" yaml:"new"`
+}
+
+func newConfigChangeView(ch config.ConfigChange) ConfigChangeView {
+	return ConfigChangeView{Key: ch.Path, Old: ch.Old, New: ch.New}
+}
+
+// configView builds the typed ConfigView showRPCConfig/showAllConfig
+// render from, rather than printing c.config's fields by hand.
+func (c *CLI) configView() ConfigView {
+	cfg := c.config
+	return ConfigView{
+		ConfigPath: cfg.ConfigPath,
+		LogLevel:   cfg.Logging.Level,
+		Network:    cfg.Network,
+		RPC: RPCConfigView{
+			URL:             cfg.RPC.URL,
+			TimeoutSeconds:  cfg.RPC.Timeout,
+			MaxConnections:  cfg.RPC.MaxConnections,
+			EnableWebSocket: cfg.RPC.EnableWebSocket,
+		},
+		Keystore: KeystoreConfigView{
+			Path:       cfg.Keystore.Path,
+			Encryption: cfg.Keystore.Encryption,
+		},
+		Node: NodeConfigView{
+			DataDir:     cfg.Node.DataDir,
+			GenesisFile: cfg.Node.GenesisFile,
+			HTTPPort:    cfg.Node.HTTPPort,
+			WSPort:      cfg.Node.WSPort,
+			P2PPort:     cfg.Node.P2PPort,
+		},
+		Validator: ValidatorConfigView{
+			Enabled:      cfg.Validator.Enabled,
+			StakeAmount:  cfg.Validator.StakeAmount,
+			Commission:   cfg.Validator.Commission,
+			ValidatorKey: cfg.Validator.ValidatorKey,
+			MinGasPrice:  cfg.Validator.MinGasPrice,
+		},
+		API: APIConfigView{
+			Enabled: cfg.API.Enabled,
+			Port:    cfg.API.Port,
+			Host:    cfg.API.Host,
+			CORS:    cfg.API.CORS,
+		},
+		Logging: LoggingConfigView{
+			Level:      cfg.Logging.Level,
+			Format:     cfg.Logging.Format,
+			Output:     cfg.Logging.Output,
+			MaxSize:    cfg.Logging.MaxSize,
+			MaxBackups: cfg.Logging.MaxBackups,
+			MaxAge:     cfg.Logging.MaxAge,
+			Modules:    cfg.Logging.Modules,
+		},
+	}
+}
+
 // getConfigCommand returns the config command
 func (c *CLI) getConfigCommand() *cli.Command {
 	return &cli.Command{
@@ -13,7 +151,9 @@ func (c *CLI) getConfigCommand() *cli.Command {
 		Usage: "Configuration management",
 		Subcommands: []*cli.Command{
 			c.getConfigShowCommand(),
+			c.getConfigGetCommand(),
 			c.getConfigSetCommand(),
+			c.getConfigUnsetCommand(),
 			c.getConfigInitCommand(),
 			c.getConfigResetCommand(),
 		},
@@ -23,14 +163,16 @@ func (c *CLI) getConfigCommand() *cli.Command {
 // getConfigShowCommand returns the config show command
 func (c *CLI) getConfigShowCommand() *cli.Command {
 	return &cli.Command{
-		Name:  "show",
-		Usage: "Show current configuration",
+		Name:         "show",
+		Usage:        "Show current configuration",
+		BashComplete: completeConfigSections,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:    "section",
-				Aliases: []string{"s"},
-				Usage:   "Configuration section (rpc, keystore, node, validator, api, logging)",
-				Value:   "",
+				Name:     "section",
+				Category: "Output",
+				Aliases:  []string{"s"},
+				Usage:    "Configuration section (rpc, keystore, node, validator, api, logging)",
+				Value:    "",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -40,22 +182,43 @@ func (c *CLI) getConfigShowCommand() *cli.Command {
 
 			switch section {
 			case "rpc":
-				c.showRPCConfig()
+				return c.showRPCConfig()
 			case "keystore":
-				c.showKeystoreConfig()
+				return c.showKeystoreConfig()
 			case "node":
-				c.showNodeConfig()
+				return c.showNodeConfig()
 			case "validator":
-				c.showValidatorConfig()
+				return c.showValidatorConfig()
 			case "api":
-				c.showAPIConfig()
+				return c.showAPIConfig()
 			case "logging":
-				c.showLoggingConfig()
+				return c.showLoggingConfig()
 			default:
-				c.showAllConfig()
+				return c.showAllConfig()
 			}
+		},
+	}
+}
 
-			return nil
+// getConfigGetCommand returns the config get command
+func (c *CLI) getConfigGetCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "get",
+		Usage:     "Get a single configuration value by dotted key (e.g. rpc.url)",
+		ArgsUsage: "<key>",
+		Action: func(ctx *cli.Context) error {
+			key := ctx.Args().First()
+			if key == "" {
+				return fmt.Errorf("config get: a key is required, e.g. %q", "rpc.url")
+			}
+
+			c.logger.Info("Getting configuration", "key", key)
+
+			value, err := c.config.Get(key)
+			if err != nil {
+				return err
+			}
+			return c.renderer.RenderObject(ConfigValueView{Key: key, Value: value})
 		},
 	}
 }
@@ -68,33 +231,89 @@ func (c *CLI) getConfigSetCommand() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "key",
+				Category: "Output",
 				Aliases:  []string{"k"},
 				Usage:    "Configuration key",
 				Required: true,
 			},
 			&cli.StringFlag{
 				Name:     "value",
+				Category: "Output",
 				Aliases:  []string{"v"},
 				Usage:    "Configuration value",
 				Required: true,
 			},
+			&cli.BoolFlag{
+				Name:     "dry-run",
+				Category: "Output",
+				Usage:    "Print the change without writing the config file",
+			},
 		},
 		Action: func(ctx *cli.Context) error {
 			key := ctx.String("key")
 			value := ctx.String("value")
+			dryRun := ctx.Bool("dry-run")
 
-			c.logger.Info("Setting configuration", "key", key, "value", value)
+			c.logger.Info("Setting configuration", "key", key, "value", value, "dry_run", dryRun)
 
-			// TODO: Implement config setting logic
-			fmt.Printf("⚙️  Setting Configuration\n")
-			fmt.Printf("========================\n")
-			fmt.Printf("🔑 Key: %s\n", key)
-			fmt.Printf("💎 Value: %s\n", value)
-			fmt.Printf("📁 Config File: %s\n", c.config.ConfigPath)
+			old, err := c.config.Set(key, value)
+			if err != nil {
+				return err
+			}
+			newValue, err := c.config.Get(key)
+			if err != nil {
+				return err
+			}
+			change := ConfigChangeView{Key: key, Old: old, New: newValue}
+			if dryRun {
+				return c.renderer.RenderObject(change)
+			}
+			if err := config.WriteFile(c.config, c.config.ConfigPath); err != nil {
+				return err
+			}
+			return c.renderer.RenderObject(change)
+		},
+	}
+}
 
-			fmt.Printf("\n✅ Configuration updated successfully!\n")
+// getConfigUnsetCommand returns the config unset command
+func (c *CLI) getConfigUnsetCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "unset",
+		Usage:     "Reset a single configuration key to its default value",
+		ArgsUsage: "<key>",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:     "dry-run",
+				Category: "Output",
+				Usage:    "Print the change without writing the config file",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			key := ctx.Args().First()
+			if key == "" {
+				return fmt.Errorf("config unset: a key is required, e.g. %q", "rpc.url")
+			}
+			dryRun := ctx.Bool("dry-run")
+
+			c.logger.Info("Unsetting configuration", "key", key, "dry_run", dryRun)
 
-			return nil
+			old, err := c.config.Unset(key)
+			if err != nil {
+				return err
+			}
+			newValue, err := c.config.Get(key)
+			if err != nil {
+				return err
+			}
+			change := ConfigChangeView{Key: key, Old: old, New: newValue}
+			if dryRun {
+				return c.renderer.RenderObject(change)
+			}
+			if err := config.WriteFile(c.config, c.config.ConfigPath); err != nil {
+				return err
+			}
+			return c.renderer.RenderObject(change)
 		},
 	}
 }
@@ -106,16 +325,18 @@ func (c *CLI) getConfigInitCommand() *cli.Command {
 		Usage: "Initialize configuration file",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:    "path",
-				Aliases: []string{"p"},
-				Usage:   "Configuration file path",
-				Value:   c.config.ConfigPath,
+				Name:     "path",
+				Category: "Output",
+				Aliases:  []string{"p"},
+				Usage:    "Configuration file path",
+				Value:    c.config.ConfigPath,
 			},
 			&cli.BoolFlag{
-				Name:    "force",
-				Aliases: []string{"f"},
-				Usage:   "Force overwrite existing configuration",
-				Value:   false,
+				Name:     "force",
+				Category: "Output",
+				Aliases:  []string{"f"},
+				Usage:    "Force overwrite existing configuration",
+				Value:    false,
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -124,16 +345,21 @@ func (c *CLI) getConfigInitCommand() *cli.Command {
 
 			c.logger.Info("Initializing configuration", "path", path, "force", force)
 
-			// TODO: Implement config initialization logic
-			fmt.Printf("⚙️  Initializing Configuration\n")
-			fmt.Printf("==============================\n")
-			fmt.Printf("📁 Path: %s\n", path)
-			fmt.Printf("🔄 Force: %t\n", force)
-
-			fmt.Printf("\n✅ Configuration initialized successfully!\n")
-			fmt.Printf("📁 Config file created at: %s\n", path)
+			if path == "" {
+				return fmt.Errorf("config init: no path given and no default config path resolved")
+			}
+			if !force {
+				if _, err := os.Stat(path); err == nil {
+					return fmt.Errorf("config init: %s already exists, pass --force to overwrite", path)
+				}
+			}
 
-			return nil
+			if err := config.WriteTemplate(path); err != nil {
+				return err
+			}
+			return c.renderer.RenderObject(struct {
+				Path string `json:"path" yaml:"path"`
+			}{Path: path})
 		},
 	}
 }
@@ -141,133 +367,99 @@ func (c *CLI) getConfigInitCommand() *cli.Command {
 // getConfigResetCommand returns the config reset command
 func (c *CLI) getConfigResetCommand() *cli.Command {
 	return &cli.Command{
-		Name:  "reset",
-		Usage: "Reset configuration to defaults",
+		Name:         "reset",
+		Usage:        "Reset configuration to defaults",
+		BashComplete: completeConfigSections,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:    "section",
-				Aliases: []string{"s"},
-				Usage:   "Configuration section to reset",
-				Value:   "",
+				Name:     "section",
+				Category: "Output",
+				Aliases:  []string{"s"},
+				Usage:    "Configuration section to reset (rpc, keystore, node, validator, api, logging); resets everything if omitted",
+				Value:    "",
+			},
+			&cli.BoolFlag{
+				Name:     "force",
+				Category: "Output",
+				Aliases:  []string{"f", "yes", "assume-yes"},
+				Usage:    "Skip the reset confirmation prompt",
+				Value:    false,
 			},
 			&cli.BoolFlag{
-				Name:    "force",
-				Aliases: []string{"f"},
-				Usage:   "Force reset without confirmation",
-				Value:   false,
+				Name:     "dry-run",
+				Category: "Output",
+				Usage:    "Print the changes without writing the config file",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
 			section := ctx.String("section")
-			force := ctx.Bool("force")
+			assumeYes := ctx.Bool("force") || prompt.AssumeYes()
+			dryRun := ctx.Bool("dry-run")
 
-			c.logger.Info("Resetting configuration", "section", section, "force", force)
+			c.logger.Info("Resetting configuration", "section", section, "assume_yes", assumeYes, "dry_run", dryRun)
 
-			if !force {
-				fmt.Printf("⚠️  Are you sure you want to reset configuration? This action cannot be undone.\n")
-				fmt.Printf("Type 'yes' to confirm: ")
-				var confirmation string
-				fmt.Scanln(&confirmation)
-				if confirmation != "yes" {
-					fmt.Printf("❌ Configuration reset cancelled.\n")
+			if !dryRun {
+				ok, err := prompt.Confirm("Reset configuration? This action cannot be undone.", assumeYes)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					fmt.Println("Configuration reset cancelled.")
 					return nil
 				}
 			}
 
-			// TODO: Implement config reset logic
-			fmt.Printf("⚙️  Resetting Configuration\n")
-			fmt.Printf("==========================\n")
-			fmt.Printf("📁 Section: %s\n", section)
-			fmt.Printf("🔄 Force: %t\n", force)
-
-			fmt.Printf("\n✅ Configuration reset successfully!\n")
+			changes, err := c.config.ResetSection(section)
+			if err != nil {
+				return err
+			}
+			views := make([]ConfigChangeView, len(changes))
+			for i, ch := range changes {
+				views[i] = newConfigChangeView(ch)
+			}
 
-			return nil
+			if dryRun {
+				return c.renderer.RenderList(views)
+			}
+			if err := config.WriteFile(c.config, c.config.ConfigPath); err != nil {
+				return err
+			}
+			return c.renderer.RenderList(views)
 		},
 	}
 }
 
-// showAllConfig shows all configuration
-func (c *CLI) showAllConfig() {
-	fmt.Printf("⚙️  Diora Configuration\n")
-	fmt.Printf("======================\n")
-	fmt.Printf("📁 Config Path: %s\n", c.config.ConfigPath)
-	fmt.Printf("📊 Log Level: %s\n", c.config.Logging.Level)
-	fmt.Printf("🌐 Network: %s\n", c.config.Network)
-	fmt.Printf("\n")
-
-	c.showRPCConfig()
-	c.showKeystoreConfig()
-	c.showNodeConfig()
-	c.showValidatorConfig()
-	c.showAPIConfig()
-	c.showLoggingConfig()
+// showAllConfig renders the full configuration.
+func (c *CLI) showAllConfig() error {
+	return c.renderer.RenderObject(c.configView())
 }
 
-// showRPCConfig shows RPC configuration
-func (c *CLI) showRPCConfig() {
-	fmt.Printf("📡 RPC Configuration\n")
-	fmt.Printf("====================\n")
-	fmt.Printf("🔗 URL: %s\n", c.config.RPC.URL)
-	fmt.Printf("⏱️  Timeout: %d seconds\n", c.config.RPC.Timeout)
-	fmt.Printf("🔗 Max Connections: %d\n", c.config.RPC.MaxConnections)
-	fmt.Printf("🌐 WebSocket: %t\n", c.config.RPC.EnableWebSocket)
-	fmt.Printf("\n")
+// showRPCConfig renders the RPC section of the configuration.
+func (c *CLI) showRPCConfig() error {
+	return c.renderer.RenderObject(c.configView().RPC)
 }
 
-// showKeystoreConfig shows keystore configuration
-func (c *CLI) showKeystoreConfig() {
-	fmt.Printf("🔑 Keystore Configuration\n")
-	fmt.Printf("========================\n")
-	fmt.Printf("📁 Path: %s\n", c.config.Keystore.Path)
-	fmt.Printf("🔐 Encryption: %s\n", c.config.Keystore.Encryption)
-	fmt.Printf("\n")
+// showKeystoreConfig renders the keystore section of the configuration.
+func (c *CLI) showKeystoreConfig() error {
+	return c.renderer.RenderObject(c.configView().Keystore)
 }
 
-// showNodeConfig shows node configuration
-func (c *CLI) showNodeConfig() {
-	fmt.Printf("🖥️  Node Configuration\n")
-	fmt.Printf("=====================\n")
-	fmt.Printf("📁 Data Directory: %s\n", c.config.Node.DataDir)
-	fmt.Printf("📄 Genesis File: %s\n", c.config.Node.GenesisFile)
-	fmt.Printf("🌐 HTTP Port: %d\n", c.config.Node.HTTPPort)
-	fmt.Printf("🌐 WebSocket Port: %d\n", c.config.Node.WSPort)
-	fmt.Printf("🌐 P2P Port: %d\n", c.config.Node.P2PPort)
-	fmt.Printf("\n")
+// showNodeConfig renders the node section of the configuration.
+func (c *CLI) showNodeConfig() error {
+	return c.renderer.RenderObject(c.configView().Node)
 }
 
-// showValidatorConfig shows validator configuration
-func (c *CLI) showValidatorConfig() {
-	fmt.Printf("🏛️  Validator Configuration\n")
-	fmt.Printf("===========================\n")
-	fmt.Printf("✅ Enabled: %t\n", c.config.Validator.Enabled)
-	fmt.Printf("💰 Stake Amount: %s DIO\n", c.config.Validator.StakeAmount)
-	fmt.Printf("💸 Commission: %s%%\n", c.config.Validator.Commission)
-	fmt.Printf("🔑 Validator Key: %s\n", c.config.Validator.ValidatorKey)
-	fmt.Printf("⛽ Min Gas Price: %s Gwei\n", c.config.Validator.MinGasPrice)
-	fmt.Printf("\n")
+// showValidatorConfig renders the validator section of the configuration.
+func (c *CLI) showValidatorConfig() error {
+	return c.renderer.RenderObject(c.configView().Validator)
 }
 
-// showAPIConfig shows API configuration
-func (c *CLI) showAPIConfig() {
-	fmt.Printf("🌐 API Configuration\n")
-	fmt.Printf("=====================\n")
-	fmt.Printf("✅ Enabled: %t\n", c.config.API.Enabled)
-	fmt.Printf("🌐 Port: %d\n", c.config.API.Port)
-	fmt.Printf("🌐 Host: %s\n", c.config.API.Host)
-	fmt.Printf("🌐 CORS: %s\n", c.config.API.CORS)
-	fmt.Printf("\n")
+// showAPIConfig renders the API section of the configuration.
+func (c *CLI) showAPIConfig() error {
+	return c.renderer.RenderObject(c.configView().API)
 }
 
-// showLoggingConfig shows logging configuration
-func (c *CLI) showLoggingConfig() {
-	fmt.Printf("📝 Logging Configuration\n")
-	fmt.Printf("========================\n")
-	fmt.Printf("📊 Level: %s\n", c.config.Logging.Level)
-	fmt.Printf("📄 Format: %s\n", c.config.Logging.Format)
-	fmt.Printf("📤 Output: %s\n", c.config.Logging.Output)
-	fmt.Printf("📏 Max Size: %d MB\n", c.config.Logging.MaxSize)
-	fmt.Printf("📁 Max Backups: %d\n", c.config.Logging.MaxBackups)
-	fmt.Printf("📅 Max Age: %d days\n", c.config.Logging.MaxAge)
-	fmt.Printf("\n")
+// showLoggingConfig renders the logging section of the configuration.
+func (c *CLI) showLoggingConfig() error {
+	return c.renderer.RenderObject(c.configView().Logging)
 }
@@ -1,8 +1,21 @@
 package cli
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/DioraFund/diora-go-blockchain/internal/contractstore"
+	"github.com/DioraFund/diora-go-blockchain/internal/rpcclient"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/urfave/cli/v2"
 )
 
@@ -21,6 +34,91 @@ func (c *CLI) getContractCommand() *cli.Command {
 	}
 }
 
+// loadContractArtifact resolves file into its compiled bytecode and parsed
+// ABI: a .sol source is compiled via solc (honoring compiler/optimizer and
+// disambiguated by contractName if it defines more than one contract), a
+// .json artifact ({"abi": [...], "bytecode": "0x...", "deployedBytecode":
+// "0x..."}) is read and parsed directly.
+func loadContractArtifact(file, contractName, compiler, optimizer string) (*compiledContract, abi.ABI, error) {
+	switch filepath.Ext(file) {
+	case ".sol":
+		compiled, err := compileSolidity(file, compiler, optimizer, contractName)
+		if err != nil {
+			return nil, abi.ABI{}, err
+		}
+		parsed, err := abi.JSON(bytes.NewReader(compiled.ABI))
+		if err != nil {
+			return nil, abi.ABI{}, fmt.Errorf("failed to parse compiled ABI for %s: %w", file, err)
+		}
+		return compiled, parsed, nil
+	case ".json":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, abi.ABI{}, fmt.Errorf("failed to read artifact %s: %w", file, err)
+		}
+		var artifact struct {
+			ContractName     string          `json:"contractName"`
+			ABI              json.RawMessage `json:"abi"`
+			Bytecode         string          `json:"bytecode"`
+			DeployedBytecode string          `json:"deployedBytecode"`
+		}
+		if err := json.Unmarshal(data, &artifact); err != nil {
+			return nil, abi.ABI{}, fmt.Errorf("failed to parse artifact %s: %w", file, err)
+		}
+		parsed, err := abi.JSON(bytes.NewReader(artifact.ABI))
+		if err != nil {
+			return nil, abi.ABI{}, fmt.Errorf("failed to parse ABI in artifact %s: %w", file, err)
+		}
+		return &compiledContract{
+			Name:             artifact.ContractName,
+			ABI:              artifact.ABI,
+			Bytecode:         common.FromHex(artifact.Bytecode),
+			DeployedBytecode: common.FromHex(artifact.DeployedBytecode),
+		}, parsed, nil
+	default:
+		return nil, abi.ABI{}, fmt.Errorf("unsupported contract artifact %q (want a .sol source or a .json artifact)", file)
+	}
+}
+
+// resolveContractABI loads address's ABI from abiPath if given, otherwise
+// from deployer's local deployment index (as Add recorded it at deploy
+// time).
+func (c *CLI) resolveContractABI(abiPath string, deployer, address common.Address) (*abi.ABI, error) {
+	if abiPath != "" {
+		data, err := os.ReadFile(abiPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ABI file %s: %w", abiPath, err)
+		}
+		raw := json.RawMessage(data)
+		var artifact struct {
+			ABI json.RawMessage `json:"abi"`
+		}
+		if err := json.Unmarshal(data, &artifact); err == nil && len(artifact.ABI) > 0 {
+			raw = artifact.ABI
+		}
+		parsed, err := abi.JSON(bytes.NewReader(raw))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ABI file %s: %w", abiPath, err)
+		}
+		return &parsed, nil
+	}
+
+	records, err := c.contractStore().List(deployer)
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range records {
+		if rec.Address == address {
+			parsed, err := abi.JSON(bytes.NewReader(rec.ABI))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse stored ABI for %s: %w", address.Hex(), err)
+			}
+			return &parsed, nil
+		}
+	}
+	return nil, fmt.Errorf("no ABI known for %s: pass --abi, or deploy it through this CLI first so its ABI is recorded locally", address.Hex())
+}
+
 // getContractDeployCommand returns the contract deploy command
 func (c *CLI) getContractDeployCommand() *cli.Command {
 	return &cli.Command{
@@ -29,65 +127,153 @@ func (c *CLI) getContractDeployCommand() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "file",
+				Category: "Networking",
 				Aliases:  []string{"f"},
-				Usage:    "Contract file path",
+				Usage:    "Contract source (.sol) or compiled artifact (.json)",
 				Required: true,
 			},
+			&cli.StringFlag{
+				Name:     "contract",
+				Category: "Networking",
+				Usage:    "Contract name to deploy, if --file defines more than one",
+			},
 			&cli.StringFlag{
 				Name:     "from",
+				Category: "Networking",
 				Aliases:  []string{"a"},
 				Usage:    "From account address",
 				Required: true,
 			},
 			&cli.StringFlag{
 				Name:     "password",
+				Category: "Keystore",
 				Aliases:  []string{"p"},
 				Usage:    "Account password",
 				Required: true,
 			},
 			&cli.StringFlag{
-				Name:    "gas-price",
-				Aliases: []string{"g"},
-				Usage:   "Gas price in Gwei",
-				Value:   "20",
+				Name:     "gas-price",
+				Category: "Networking",
+				Aliases:  []string{"g"},
+				Usage:    "Gas price in Gwei",
+				Value:    "20",
+			},
+			&cli.StringFlag{
+				Name:     "gas-limit",
+				Category: "Networking",
+				Aliases:  []string{"l"},
+				Usage:    "Gas limit",
+				Value:    "3000000",
 			},
 			&cli.StringFlag{
-				Name:    "gas-limit",
-				Aliases: []string{"l"},
-				Usage:   "Gas limit",
-				Value:   "3000000",
+				Name:     "chain-id",
+				Category: "Networking",
+				Usage:    "Chain id to sign for; unset fetches it from the node",
 			},
 			&cli.StringFlag{
-				Name:    "args",
-				Aliases: []string{"a"},
-				Usage:   "Constructor arguments",
-				Value:   "",
+				Name:     "args",
+				Category: "Networking",
+				Usage:    "Constructor arguments, comma-separated",
+				Value:    "",
+			},
+			&cli.StringFlag{
+				Name:     "compiler",
+				Category: "Networking",
+				Aliases:  []string{"c"},
+				Usage:    "solc version to invoke, for --file .sol sources",
+				Value:    "0.8.26",
+			},
+			&cli.StringFlag{
+				Name:     "optimizer",
+				Category: "Networking",
+				Aliases:  []string{"o"},
+				Usage:    "Optimizer runs, for --file .sol sources",
+				Value:    "200",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
 			file := ctx.String("file")
-			from := ctx.String("from")
+			from := common.HexToAddress(ctx.String("from"))
 			password := ctx.String("password")
-			gasPrice := ctx.String("gas-price")
-			gasLimit := ctx.String("gas-limit")
-			args := ctx.String("args")
+			gasLimit, err := strconv.ParseUint(ctx.String("gas-limit"), 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid gas limit %q: %w", ctx.String("gas-limit"), err)
+			}
+			gasPrice, err := gweiToWei(ctx.String("gas-price"))
+			if err != nil {
+				return err
+			}
+
+			c.logger.Info("Deploying contract", "file", file, "from", from.Hex())
+
+			compiled, contractABI, err := loadContractArtifact(file, ctx.String("contract"), ctx.String("compiler"), ctx.String("optimizer"))
+			if err != nil {
+				return err
+			}
+			if len(compiled.Bytecode) == 0 {
+				return fmt.Errorf("%s has no creation bytecode to deploy", file)
+			}
+
+			ctorArgs, err := convertABIArgs(contractABI.Constructor.Inputs, splitArgs(ctx.String("args")))
+			if err != nil {
+				return fmt.Errorf("constructor arguments: %w", err)
+			}
+			packed, err := contractABI.Constructor.Inputs.Pack(ctorArgs...)
+			if err != nil {
+				return fmt.Errorf("failed to encode constructor arguments: %w", err)
+			}
+			data := append(append([]byte{}, compiled.Bytecode...), packed...)
+
+			ac := c.accountClient()
+			chainID, err := resolveChainID(ac, ctx)
+			if err != nil {
+				return err
+			}
+			nonce, err := ac.NextNonce(from)
+			if err != nil {
+				return fmt.Errorf("failed to fetch nonce for %s: %w", from.Hex(), err)
+			}
+
+			hash, err := rpcclient.LegacySigningHash(nonce, gasPrice, gasLimit, nil, big.NewInt(0), data, chainID)
+			if err != nil {
+				return fmt.Errorf("failed to compute signing hash: %w", err)
+			}
+			sig, err := c.walletManager(password).Sign(from, hash)
+			if err != nil {
+				return fmt.Errorf("failed to sign transaction: %w", err)
+			}
+			raw, err := rpcclient.AssembleLegacyTransaction(nonce, gasPrice, gasLimit, nil, big.NewInt(0), data, chainID, sig)
+			if err != nil {
+				return fmt.Errorf("failed to assemble signed transaction: %w", err)
+			}
+			txHash, err := ac.SendRawTransaction(raw)
+			if err != nil {
+				return fmt.Errorf("failed to broadcast deployment: %w", err)
+			}
 
-			c.logger.Info("Deploying contract", "file", file, "from", from)
+			contractAddr := crypto.CreateAddress(from, nonce)
+			if err := c.contractStore().Add(from, contractstore.Record{
+				Address:    contractAddr,
+				Name:       compiled.Name,
+				TxHash:     txHash,
+				Deployer:   from,
+				ABI:        compiled.ABI,
+				DeployedAt: time.Now(),
+			}); err != nil {
+				c.logger.Info("Failed to record deployment locally", "address", contractAddr.Hex(), "error", err)
+			}
 
-			// TODO: Implement contract deployment logic
 			fmt.Printf("🚀 Deploying Smart Contract\n")
 			fmt.Printf("============================\n")
 			fmt.Printf("📄 File: %s\n", file)
-			fmt.Printf("📤 From: %s\n", from)
-			fmt.Printf("🔐 Password: [hidden]\n")
-			fmt.Printf("⛽ Gas Price: %s Gwei\n", gasPrice)
-			fmt.Printf("⛽ Gas Limit: %s\n", gasLimit)
-			fmt.Printf("📝 Args: %s\n", args)
-			fmt.Printf("💸 Est. Gas Fee: ~0.060000 DIO\n")
+			fmt.Printf("📤 From: %s\n", from.Hex())
+			fmt.Printf("⛽ Gas Price: %s Gwei\n", ctx.String("gas-price"))
+			fmt.Printf("⛽ Gas Limit: %d\n", gasLimit)
+			fmt.Printf("🔢 Nonce: %d\n", nonce)
 
 			fmt.Printf("\n✅ Contract deployment submitted!\n")
-			fmt.Printf("📋 Transaction Hash: 0xabcdef1234567890...\n")
-			fmt.Printf("⏳ Waiting for confirmation...\n")
+			fmt.Printf("📋 Transaction Hash: %s\n", txHash.Hex())
+			fmt.Printf("📍 Contract Address: %s\n", contractAddr.Hex())
 
 			return nil
 		},
@@ -102,87 +288,168 @@ func (c *CLI) getContractCallCommand() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "address",
+				Category: "Networking",
 				Aliases:  []string{"addr", "a"},
 				Usage:    "Contract address",
 				Required: true,
 			},
+			&cli.StringFlag{
+				Name:     "abi",
+				Category: "Networking",
+				Usage:    "ABI file (bare array or artifact with an \"abi\" field); defaults to --from's locally recorded deployment of --address",
+			},
 			&cli.StringFlag{
 				Name:     "function",
+				Category: "Networking",
 				Aliases:  []string{"func", "f"},
 				Usage:    "Function name",
 				Required: true,
 			},
 			&cli.StringFlag{
-				Name:    "args",
-				Aliases: []string{"a"},
-				Usage:   "Function arguments",
-				Value:   "",
+				Name:     "args",
+				Category: "Networking",
+				Usage:    "Function arguments, comma-separated",
+				Value:    "",
 			},
 			&cli.StringFlag{
 				Name:     "from",
-				Aliases:  []string{"from", "fr"},
+				Category: "Networking",
+				Aliases:  []string{"fr"},
 				Usage:    "From account address",
 				Required: true,
 			},
 			&cli.StringFlag{
 				Name:     "password",
+				Category: "Keystore",
 				Aliases:  []string{"p"},
 				Usage:    "Account password",
 				Required: true,
 			},
 			&cli.StringFlag{
-				Name:    "gas-price",
-				Aliases: []string{"g"},
-				Usage:   "Gas price in Gwei",
-				Value:   "20",
+				Name:     "gas-price",
+				Category: "Networking",
+				Aliases:  []string{"g"},
+				Usage:    "Gas price in Gwei",
+				Value:    "20",
 			},
 			&cli.StringFlag{
-				Name:    "gas-limit",
-				Aliases: []string{"l"},
-				Usage:   "Gas limit",
-				Value:   "100000",
+				Name:     "gas-limit",
+				Category: "Networking",
+				Aliases:  []string{"l"},
+				Usage:    "Gas limit",
+				Value:    "100000",
+			},
+			&cli.StringFlag{
+				Name:     "chain-id",
+				Category: "Networking",
+				Usage:    "Chain id to sign for; unset fetches it from the node",
 			},
 			&cli.BoolFlag{
-				Name:    "view",
-				Aliases: []string{"v"},
-				Usage:   "View function (read-only)",
-				Value:   false,
+				Name:     "view",
+				Category: "Networking",
+				Aliases:  []string{"v"},
+				Usage:    "View function (read-only, via eth_call rather than a signed transaction)",
+				Value:    false,
 			},
 		},
 		Action: func(ctx *cli.Context) error {
-			address := ctx.String("address")
+			address := common.HexToAddress(ctx.String("address"))
 			function := ctx.String("function")
-			args := ctx.String("args")
-			from := ctx.String("from")
+			from := common.HexToAddress(ctx.String("from"))
 			password := ctx.String("password")
-			gasPrice := ctx.String("gas-price")
-			gasLimit := ctx.String("gas-limit")
 			view := ctx.Bool("view")
+			gasLimit, err := strconv.ParseUint(ctx.String("gas-limit"), 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid gas limit %q: %w", ctx.String("gas-limit"), err)
+			}
+			gasPrice, err := gweiToWei(ctx.String("gas-price"))
+			if err != nil {
+				return err
+			}
 
-			c.logger.Info("Calling contract function", "address", address, "function", function, "view", view)
+			c.logger.Info("Calling contract function", "address", address.Hex(), "function", function, "view", view)
+
+			contractABI, err := c.resolveContractABI(ctx.String("abi"), from, address)
+			if err != nil {
+				return err
+			}
+			method, ok := contractABI.Methods[function]
+			if !ok {
+				return fmt.Errorf("function %q not found in ABI", function)
+			}
+
+			callArgs, err := convertABIArgs(method.Inputs, splitArgs(ctx.String("args")))
+			if err != nil {
+				return fmt.Errorf("function arguments: %w", err)
+			}
+			data, err := contractABI.Pack(function, callArgs...)
+			if err != nil {
+				return fmt.Errorf("failed to encode call: %w", err)
+			}
+
+			ac := c.accountClient()
 
-			// TODO: Implement contract call logic
 			fmt.Printf("🔧 Calling Smart Contract Function\n")
 			fmt.Printf("===================================\n")
-			fmt.Printf("📍 Contract: %s\n", address)
+			fmt.Printf("📍 Contract: %s\n", address.Hex())
 			fmt.Printf("🔧 Function: %s\n", function)
-			fmt.Printf("📝 Args: %s\n", args)
-			fmt.Printf("📤 From: %s\n", from)
-			fmt.Printf("🔐 Password: [hidden]\n")
-			fmt.Printf("⛽ Gas Price: %s Gwei\n", gasPrice)
-			fmt.Printf("⛽ Gas Limit: %s\n", gasLimit)
-			fmt.Printf("👁️  View: %t\n", view)
 
 			if view {
+				out, err := ac.CallContract(rpcclient.CallMsg{From: from, To: address, Data: data})
+				if err != nil {
+					return fmt.Errorf("call failed: %w", err)
+				}
+				values, err := method.Outputs.Unpack(out)
+				if err != nil {
+					return fmt.Errorf("failed to decode result: %w", err)
+				}
+
 				fmt.Printf("\n📊 Function Result\n")
 				fmt.Printf("==================\n")
-				fmt.Printf("📤 Return: 123456789\n")
-				fmt.Printf("📊 Type: uint256\n")
-			} else {
-				fmt.Printf("💸 Est. Gas Fee: ~0.002000 DIO\n")
-				fmt.Printf("\n✅ Function call submitted!\n")
-				fmt.Printf("📋 Transaction Hash: 0xabcdef1234567890...\n")
+				if len(values) == 0 {
+					fmt.Printf("(no return value)\n")
+				}
+				for i, val := range values {
+					name := method.Outputs[i].Name
+					if name == "" {
+						name = fmt.Sprintf("ret%d", i)
+					}
+					fmt.Printf("📤 %s (%s): %v\n", name, method.Outputs[i].Type.String(), val)
+				}
+				return nil
+			}
+
+			chainID, err := resolveChainID(ac, ctx)
+			if err != nil {
+				return err
+			}
+			nonce, err := ac.NextNonce(from)
+			if err != nil {
+				return fmt.Errorf("failed to fetch nonce for %s: %w", from.Hex(), err)
+			}
+
+			hash, err := rpcclient.LegacySigningHash(nonce, gasPrice, gasLimit, &address, big.NewInt(0), data, chainID)
+			if err != nil {
+				return fmt.Errorf("failed to compute signing hash: %w", err)
+			}
+			sig, err := c.walletManager(password).Sign(from, hash)
+			if err != nil {
+				return fmt.Errorf("failed to sign transaction: %w", err)
+			}
+			raw, err := rpcclient.AssembleLegacyTransaction(nonce, gasPrice, gasLimit, &address, big.NewInt(0), data, chainID, sig)
+			if err != nil {
+				return fmt.Errorf("failed to assemble signed transaction: %w", err)
 			}
+			txHash, err := ac.SendRawTransaction(raw)
+			if err != nil {
+				return fmt.Errorf("failed to broadcast call: %w", err)
+			}
+
+			fmt.Printf("📤 From: %s\n", from.Hex())
+			fmt.Printf("⛽ Gas Price: %s Gwei\n", ctx.String("gas-price"))
+			fmt.Printf("⛽ Gas Limit: %d\n", gasLimit)
+			fmt.Printf("\n✅ Function call submitted!\n")
+			fmt.Printf("📋 Transaction Hash: %s\n", txHash.Hex())
 
 			return nil
 		},
@@ -197,45 +464,72 @@ func (c *CLI) getContractInfoCommand() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "address",
+				Category: "Networking",
 				Aliases:  []string{"addr", "a"},
 				Usage:    "Contract address",
 				Required: true,
 			},
 			&cli.BoolFlag{
-				Name:    "detailed",
-				Aliases: []string{"d"},
-				Usage:   "Show detailed information",
-				Value:   false,
+				Name:     "detailed",
+				Category: "Networking",
+				Aliases:  []string{"d"},
+				Usage:    "Show detailed information",
+				Value:    false,
 			},
 		},
 		Action: func(ctx *cli.Context) error {
-			address := ctx.String("address")
+			address := common.HexToAddress(ctx.String("address"))
 			detailed := ctx.Bool("detailed")
 
-			c.logger.Info("Getting contract info", "address", address, "detailed", detailed)
+			c.logger.Info("Getting contract info", "address", address.Hex(), "detailed", detailed)
+
+			ac := c.accountClient()
+			code, err := ac.Code(address)
+			if err != nil {
+				return fmt.Errorf("failed to fetch code for %s: %w", address.Hex(), err)
+			}
+			if len(code) == 0 {
+				return fmt.Errorf("%s has no code; it isn't a contract (or hasn't been deployed)", address.Hex())
+			}
+			codeHash := crypto.Keccak256Hash(code)
+			standard := detectStandard(ac, address)
 
-			// TODO: Implement contract info logic
 			fmt.Printf("📋 Contract Information\n")
 			fmt.Printf("=====================\n")
-			fmt.Printf("📍 Address: %s\n", address)
-			fmt.Printf("📝 Name: MyToken\n")
-			fmt.Printf("🔖 Symbol: MTK\n")
-			fmt.Printf("📊 Type: ERC20\n")
-			fmt.Printf("📦 Block: 1,234,567\n")
-			fmt.Printf("📅 Created: 2024-01-15 10:30:00\n")
-			fmt.Printf("👤 Creator: 0x1234567890123456789012345678901234567890\n")
+			fmt.Printf("📍 Address: %s\n", address.Hex())
+			fmt.Printf("📦 Code Size: %d bytes\n", len(code))
+			fmt.Printf("🔑 Bytecode Hash: %s\n", codeHash.Hex())
+			if standard != "" {
+				fmt.Printf("📊 Detected Standard: %s\n", standard)
+			}
+
+			if meta, err := ac.ContractInfo(address); err != nil {
+				c.logger.Info("Creator lookup unavailable", "address", address.Hex(), "error", err)
+			} else {
+				fmt.Printf("👤 Creator: %s\n", meta.Creator.Hex())
+				fmt.Printf("📦 Created At Block: %d\n", meta.Block)
+				fmt.Printf("🔗 Creation Tx: %s\n", meta.CreationTx.Hex())
+			}
 
 			if detailed {
 				fmt.Printf("\n📊 Detailed Information\n")
 				fmt.Printf("=====================\n")
-				fmt.Printf("🔖 Version: 1.0.0\n")
-				fmt.Printf("💰 Total Supply: 1,000,000,000 MTK\n")
-				fmt.Printf("🔢 Decimals: 18\n")
-				fmt.Printf("📤 Transactions: 1,234\n")
-				fmt.Printf("👥 Holders: 567\n")
-				fmt.Printf("📊 Verified: Yes\n")
-				fmt.Printf("🔗 Source: https://etherscan.io/address/...\n")
-				fmt.Printf("📝 ABI: Available\n")
+				if rec, err := c.contractStore().LoadVerification(address); err == nil && rec != nil {
+					fmt.Printf("✅ Verified: yes (compiler %s, optimizer %s runs)\n", rec.Compiler, rec.Optimizer)
+				} else {
+					fmt.Printf("❓ Verified: no\n")
+				}
+				if standard == "ERC20" {
+					if name, err := callERC20String(ac, address, selectorName); err == nil {
+						fmt.Printf("📝 Name: %s\n", name)
+					}
+					if symbol, err := callERC20String(ac, address, selectorSymbol); err == nil {
+						fmt.Printf("🔖 Symbol: %s\n", symbol)
+					}
+					if decimals, err := callERC20Uint8(ac, address, selectorDecimals); err == nil {
+						fmt.Printf("🔢 Decimals: %d\n", decimals)
+					}
+				}
 			}
 
 			return nil
@@ -251,33 +545,60 @@ func (c *CLI) getContractListCommand() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "address",
+				Category: "Networking",
 				Aliases:  []string{"addr", "a"},
-				Usage:    "Account address",
+				Usage:    "Deployer account address",
 				Required: true,
 			},
 			&cli.StringFlag{
-				Name:    "type",
-				Aliases: []string{"t"},
-				Usage:   "Contract type (ERC20, ERC721, custom)",
-				Value:   "",
+				Name:     "type",
+				Category: "Networking",
+				Aliases:  []string{"t"},
+				Usage:    "Contract type (ERC20, ERC721, custom)",
+				Value:    "",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
-			address := ctx.String("address")
+			address := common.HexToAddress(ctx.String("address"))
 			contractType := ctx.String("type")
 
-			c.logger.Info("Listing contracts", "address", address, "type", contractType)
+			c.logger.Info("Listing contracts", "address", address.Hex(), "type", contractType)
+
+			records, err := c.contractStore().List(address)
+			if err != nil {
+				return fmt.Errorf("failed to read contract index for %s: %w", address.Hex(), err)
+			}
+			if contractType != "" {
+				var filtered []contractstore.Record
+				for _, rec := range records {
+					if strings.EqualFold(rec.Type, contractType) {
+						filtered = append(filtered, rec)
+					}
+				}
+				records = filtered
+			}
 
-			// TODO: Implement contract listing logic
-			fmt.Printf("📋 Deployed Contracts for %s\n", address)
+			fmt.Printf("📋 Deployed Contracts for %s\n", address.Hex())
 			fmt.Printf("================================\n")
-			fmt.Printf("📊 Total Contracts: 3\n")
+			fmt.Printf("📊 Total Contracts: %d\n", len(records))
+
+			if len(records) == 0 {
+				return nil
+			}
 
 			fmt.Printf("\n📋 Contract List\n")
 			fmt.Printf("==============\n")
-			fmt.Printf("1. 📍 0xabc123... - MyToken - ERC20 - 2024-01-15 10:30:00\n")
-			fmt.Printf("2. 📍 0xdef456... - NFTCollection - ERC721 - 2024-01-14 15:20:00\n")
-			fmt.Printf("3. 📍 0xghi789... - CustomContract - Custom - 2024-01-13 09:15:00\n")
+			for i, rec := range records {
+				name := rec.Name
+				if name == "" {
+					name = "(unnamed)"
+				}
+				typ := rec.Type
+				if typ == "" {
+					typ = "custom"
+				}
+				fmt.Printf("%d. 📍 %s - %s - %s - %s\n", i+1, rec.Address.Hex(), name, typ, rec.DeployedAt.Format("2006-01-02 15:04:05"))
+			}
 
 			return nil
 		},
@@ -292,49 +613,96 @@ func (c *CLI) getContractVerifyCommand() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "address",
+				Category: "Networking",
 				Aliases:  []string{"addr", "a"},
 				Usage:    "Contract address",
 				Required: true,
 			},
 			&cli.StringFlag{
 				Name:     "file",
+				Category: "Networking",
 				Aliases:  []string{"f"},
 				Usage:    "Source code file",
 				Required: true,
 			},
 			&cli.StringFlag{
-				Name:    "compiler",
-				Aliases: []string{"c"},
-				Usage:   "Compiler version",
-				Value:   "0.8.26",
+				Name:     "contract",
+				Category: "Networking",
+				Usage:    "Contract name to verify, if --file defines more than one",
+			},
+			&cli.StringFlag{
+				Name:     "compiler",
+				Category: "Networking",
+				Aliases:  []string{"c"},
+				Usage:    "Compiler version",
+				Value:    "0.8.26",
+			},
+			&cli.StringFlag{
+				Name:     "optimizer",
+				Category: "Networking",
+				Aliases:  []string{"o"},
+				Usage:    "Optimizer runs",
+				Value:    "200",
 			},
 			&cli.StringFlag{
-				Name:    "optimizer",
-				Aliases: []string{"o"},
-				Usage:   "Optimizer runs",
-				Value:   "200",
+				Name:     "sourcify-url",
+				Category: "Networking",
+				Usage:    "Sourcify-compatible endpoint to also submit this verification to; unset skips it",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
-			address := ctx.String("address")
+			address := common.HexToAddress(ctx.String("address"))
 			file := ctx.String("file")
 			compiler := ctx.String("compiler")
 			optimizer := ctx.String("optimizer")
+			sourcifyURL := ctx.String("sourcify-url")
 
-			c.logger.Info("Verifying contract", "address", address, "file", file, "compiler", compiler)
+			c.logger.Info("Verifying contract", "address", address.Hex(), "file", file, "compiler", compiler)
+
+			onChainCode, err := c.accountClient().Code(address)
+			if err != nil {
+				return fmt.Errorf("failed to fetch deployed code for %s: %w", address.Hex(), err)
+			}
+			if len(onChainCode) == 0 {
+				return fmt.Errorf("%s has no code to verify", address.Hex())
+			}
+
+			compiled, err := compileSolidity(file, compiler, optimizer, ctx.String("contract"))
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(stripMetadata(onChainCode), stripMetadata(compiled.DeployedBytecode)) {
+				return fmt.Errorf("recompiled bytecode for %s does not match the code deployed at %s", file, address.Hex())
+			}
+
+			if err := c.contractStore().SaveVerification(contractstore.Verification{
+				Address:    address,
+				Source:     file,
+				Compiler:   compiler,
+				Optimizer:  optimizer,
+				ABI:        compiled.ABI,
+				VerifiedAt: time.Now(),
+			}); err != nil {
+				return fmt.Errorf("failed to save verification record: %w", err)
+			}
 
-			// TODO: Implement contract verification logic
 			fmt.Printf("🔍 Verifying Smart Contract\n")
 			fmt.Printf("==========================\n")
-			fmt.Printf("📍 Contract: %s\n", address)
+			fmt.Printf("📍 Contract: %s\n", address.Hex())
 			fmt.Printf("📄 Source File: %s\n", file)
 			fmt.Printf("🔧 Compiler: %s\n", compiler)
 			fmt.Printf("⚡ Optimizer: %s runs\n", optimizer)
-			fmt.Printf("⏱️  Est. Time: 2-5 minutes\n")
+			fmt.Printf("🔑 Deployed Bytecode Hash: %s\n", crypto.Keccak256Hash(onChainCode).Hex())
+
+			if sourcifyURL != "" {
+				if err := submitSourcifyVerification(sourcifyURL, address, file, compiled); err != nil {
+					fmt.Printf("⚠️  Sourcify submission failed: %v\n", err)
+				} else {
+					fmt.Printf("📡 Submitted to %s\n", sourcifyURL)
+				}
+			}
 
-			fmt.Printf("\n✅ Contract verification submitted!\n")
-			fmt.Printf("📋 Verification ID: 12345\n")
-			fmt.Printf("⏳ Checking verification status...\n")
+			fmt.Printf("\n✅ Contract verified: recompiled bytecode matches %s\n", file)
 
 			return nil
 		},
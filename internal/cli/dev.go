@@ -2,7 +2,10 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"runtime"
 
+	"github.com/DioraFund/diora-go-blockchain/conformance"
 	"github.com/urfave/cli/v2"
 )
 
@@ -16,6 +19,7 @@ func (c *CLI) getDevCommand() *cli.Command {
 			c.getDevNodeCommand(),
 			c.getDevFaucetCommand(),
 			c.getDevTestCommand(),
+			c.getDevXputCommand(),
 			c.getDevDebugCommand(),
 		},
 	}
@@ -28,22 +32,25 @@ func (c *CLI) getDevGenesisCommand() *cli.Command {
 		Usage: "Generate genesis file",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:    "output",
-				Aliases: []string{"o"},
-				Usage:   "Output file path",
-				Value:   "genesis.json",
+				Name:     "output",
+				Category: "Output",
+				Aliases:  []string{"o"},
+				Usage:    "Output file path",
+				Value:    "genesis.json",
 			},
 			&cli.StringFlag{
-				Name:    "network",
-				Aliases: []string{"n"},
-				Usage:   "Network type (mainnet, testnet, dev)",
-				Value:   "dev",
+				Name:     "network",
+				Category: "Networking",
+				Aliases:  []string{"n"},
+				Usage:    "Network type (mainnet, testnet, dev)",
+				Value:    "dev",
 			},
 			&cli.StringFlag{
-				Name:    "chain-id",
-				Aliases: []string{"c"},
-				Usage:   "Chain ID",
-				Value:   "1337",
+				Name:     "chain-id",
+				Category: "Networking",
+				Aliases:  []string{"c"},
+				Usage:    "Chain ID",
+				Value:    "1337",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -78,65 +85,53 @@ func (c *CLI) getDevNodeCommand() *cli.Command {
 		Usage: "Start development node",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:    "genesis",
-				Aliases: []string{"g"},
-				Usage:   "Genesis file path",
-				Value:   "genesis.json",
+				Name:     "genesis",
+				Category: "Networking",
+				Aliases:  []string{"g"},
+				Usage:    "Genesis file path",
+				Value:    "genesis.json",
 			},
 			&cli.StringFlag{
-				Name:    "data-dir",
-				Aliases: []string{"d"},
-				Usage:   "Data directory",
-				Value:   "./dev-data",
+				Name:     "data-dir",
+				Category: "Networking",
+				Aliases:  []string{"d"},
+				Usage:    "Data directory",
+				Value:    "./dev-data",
 			},
 			&cli.BoolFlag{
-				Name:    "mine",
-				Aliases: []string{"m"},
-				Usage:   "Enable mining",
-				Value:   true,
+				Name:     "mine",
+				Category: "Networking",
+				Aliases:  []string{"m"},
+				Usage:    "Enable mining",
+				Value:    true,
 			},
 			&cli.StringFlag{
-				Name:    "rpc-port",
-				Aliases: []string{"r"},
-				Usage:   "RPC port",
-				Value:   "8545",
+				Name:     "rpc-port",
+				Category: "Networking",
+				Aliases:  []string{"r"},
+				Usage:    "RPC port",
+				Value:    "8545",
 			},
 			&cli.StringFlag{
-				Name:    "ws-port",
-				Aliases: []string{"w"},
-				Usage:   "WebSocket port",
-				Value:   "8546",
+				Name:     "ws-port",
+				Category: "Networking",
+				Aliases:  []string{"w"},
+				Usage:    "WebSocket port",
+				Value:    "8546",
+			},
+			&cli.BoolFlag{
+				Name:     "light",
+				Category: "Networking",
+				Usage:    "Run as an on-demand-retrieval light client instead of syncing full state",
+				Value:    false,
 			},
 		},
-		Action: func(ctx *cli.Context) error {
-			genesis := ctx.String("genesis")
-			dataDir := ctx.String("data-dir")
-			mine := ctx.Bool("mine")
-			rpcPort := ctx.String("rpc-port")
-			wsPort := ctx.String("ws-port")
-
-			c.logger.Info("Starting dev node", "genesis", genesis, "data_dir", dataDir, "mine", mine)
-
-			// TODO: Implement dev node logic
-			fmt.Printf("🖥️  Development Node\n")
-			fmt.Printf("====================\n")
-			fmt.Printf("📄 Genesis: %s\n", genesis)
-			fmt.Printf("📁 Data Dir: %s\n", dataDir)
-			fmt.Printf("⛏️  Mining: %t\n", mine)
-			fmt.Printf("📡 RPC Port: %s\n", rpcPort)
-			fmt.Printf("🌐 WS Port: %s\n", wsPort)
-
-			fmt.Printf("\n🚀 Starting development node...\n")
-			fmt.Printf("📡 RPC: http://localhost:%s\n", rpcPort)
-			fmt.Printf("🌐 WebSocket: ws://localhost:%s\n", wsPort)
-			fmt.Printf("⛏️  Mining: %t\n", mine)
-
-			fmt.Printf("\n✅ Development node started!\n")
-			fmt.Printf("👀 Monitoring logs... (Ctrl+C to stop)\n")
-			// TODO: Implement dev node monitoring
-
-			return nil
+		Subcommands: []*cli.Command{
+			c.getDevNodeWorkersCommand(),
+			c.getDevNodeWorkerCommand(),
+			c.getDevNodeLogLevelCommand(),
 		},
+		Action: c.runDevNode,
 	}
 }
 
@@ -148,21 +143,24 @@ func (c *CLI) getDevFaucetCommand() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "address",
+				Category: "Networking",
 				Aliases:  []string{"addr", "a"},
 				Usage:    "Recipient address",
 				Required: true,
 			},
 			&cli.StringFlag{
-				Name:    "amount",
-				Aliases: []string{"amt"},
-				Usage:   "Amount to send",
-				Value:   "1000",
+				Name:     "amount",
+				Category: "Networking",
+				Aliases:  []string{"amt"},
+				Usage:    "Amount to send",
+				Value:    "1000",
 			},
 			&cli.StringFlag{
-				Name:    "token",
-				Aliases: []string{"t"},
-				Usage:   "Token contract address (empty for native)",
-				Value:   "",
+				Name:     "token",
+				Category: "Networking",
+				Aliases:  []string{"t"},
+				Usage:    "Token contract address (empty for native)",
+				Value:    "",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -195,32 +193,68 @@ func (c *CLI) getDevTestCommand() *cli.Command {
 		Usage: "Run development tests",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:    "type",
-				Aliases: []string{"t"},
-				Usage:   "Test type (unit, integration, e2e)",
-				Value:   "unit",
+				Name:     "type",
+				Category: "Networking",
+				Aliases:  []string{"t"},
+				Usage:    "Test type (unit, integration, e2e)",
+				Value:    "unit",
 			},
 			&cli.StringFlag{
-				Name:    "package",
-				Aliases: []string{"p"},
-				Usage:   "Package to test",
-				Value:   "./...",
+				Name:     "package",
+				Category: "Networking",
+				Aliases:  []string{"p"},
+				Usage:    "Package to test",
+				Value:    "./...",
 			},
 			&cli.BoolFlag{
-				Name:    "verbose",
-				Aliases: []string{"v"},
-				Usage:   "Verbose output",
-				Value:   false,
+				Name:     "verbose",
+				Category: "Networking",
+				Aliases:  []string{"v"},
+				Usage:    "Verbose output",
+				Value:    false,
 			},
 			&cli.BoolFlag{
-				Name:    "cover",
-				Aliases: []string{"c"},
-				Usage:   "Show coverage",
-				Value:   false,
+				Name:     "cover",
+				Category: "Networking",
+				Aliases:  []string{"c"},
+				Usage:    "Show coverage",
+				Value:    false,
+			},
+			&cli.StringFlag{
+				Name:     "vectors",
+				Category: "Networking",
+				Usage:    "Conformance test-vector corpus directory (--type=conformance only); expected to be a directory tree or a git submodule pinned to a known commit",
+				Value:    "./extern/test-vectors",
+			},
+			&cli.StringFlag{
+				Name:     "include",
+				Category: "Networking",
+				Usage:    "Only run vectors whose path (relative to --vectors) matches this glob (--type=conformance only)",
+			},
+			&cli.StringFlag{
+				Name:     "exclude",
+				Category: "Networking",
+				Usage:    "Skip vectors whose path (relative to --vectors) matches this glob (--type=conformance only)",
+			},
+			&cli.IntFlag{
+				Name:     "parallel",
+				Category: "Networking",
+				Usage:    "Number of vectors to run concurrently (--type=conformance only)",
+				Value:    runtime.NumCPU(),
+			},
+			&cli.StringFlag{
+				Name:     "report-file",
+				Category: "Networking",
+				Usage:    "Write a JUnit XML report here (--type=conformance only); default prints a human summary only",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
 			testType := ctx.String("type")
+
+			if testType == "conformance" {
+				return c.runDevConformanceTest(ctx)
+			}
+
 			pkg := ctx.String("package")
 			verbose := ctx.Bool("verbose")
 			cover := ctx.Bool("cover")
@@ -253,6 +287,77 @@ func (c *CLI) getDevTestCommand() *cli.Command {
 	}
 }
 
+// runDevConformanceTest runs `diora dev test --type=conformance`: every
+// vector under --vectors matching --include/--exclude against
+// conformance.RunCorpus, printing a human summary and, if --report-file is
+// set, a JUnit XML report CI can render next to the project's other test
+// results. SKIP_CONFORMANCE=1 skips the run entirely, mirroring the escape
+// hatch conformance's own `go test -run Conformance` respects, so a build
+// that can't afford the corpus's full runtime can still opt out in CI.
+func (c *CLI) runDevConformanceTest(ctx *cli.Context) error {
+	if os.Getenv("SKIP_CONFORMANCE") == "1" {
+		fmt.Println("⏭️  Skipping conformance tests (SKIP_CONFORMANCE=1)")
+		return nil
+	}
+
+	dir := ctx.String("vectors")
+	include := ctx.String("include")
+	exclude := ctx.String("exclude")
+	parallel := ctx.Int("parallel")
+	reportFile := ctx.String("report-file")
+
+	c.logger.Info("Running conformance vectors", "dir", dir, "include", include, "exclude", exclude, "parallel", parallel)
+
+	fmt.Printf("🧪 Conformance Test Vectors\n")
+	fmt.Printf("====================\n")
+	fmt.Printf("📁 Corpus: %s\n", dir)
+	fmt.Printf("🔢 Parallel: %d\n", parallel)
+
+	results, err := conformance.RunCorpus(dir, include, exclude, parallel)
+	if err != nil {
+		return fmt.Errorf("failed to run conformance corpus %s: %w", dir, err)
+	}
+	if len(results) == 0 {
+		return fmt.Errorf("no vectors under %s matched --include=%q --exclude=%q", dir, include, exclude)
+	}
+
+	if reportFile != "" {
+		if err := conformance.WriteJUnitReport(reportFile, results); err != nil {
+			return fmt.Errorf("failed to write JUnit report %s: %w", reportFile, err)
+		}
+		fmt.Printf("📄 JUnit report: %s\n", reportFile)
+	}
+
+	var failed int
+	fmt.Printf("\n🧪 Running %d vector(s)...\n", len(results))
+	for _, r := range results {
+		if r.Passed {
+			fmt.Printf("✅ PASS: %s\n", r.Vector)
+			continue
+		}
+		failed++
+		fmt.Printf("❌ FAIL: %s\n", r.Vector)
+		if r.Error != "" {
+			fmt.Printf("   error: %s\n", r.Error)
+			continue
+		}
+		for _, mismatch := range r.Mismatches {
+			fmt.Printf("   %s\n", mismatch)
+		}
+	}
+
+	fmt.Printf("\n📊 Test Results\n")
+	fmt.Printf("==============\n")
+	fmt.Printf("✅ Passed: %d\n", len(results)-failed)
+	fmt.Printf("❌ Failed: %d\n", failed)
+	fmt.Printf("📊 Total: %d\n", len(results))
+
+	if failed > 0 {
+		return fmt.Errorf("%d/%d conformance vector(s) failed", failed, len(results))
+	}
+	return nil
+}
+
 // getDevDebugCommand returns the dev debug command
 func (c *CLI) getDevDebugCommand() *cli.Command {
 	return &cli.Command{
@@ -260,22 +365,25 @@ func (c *CLI) getDevDebugCommand() *cli.Command {
 		Usage: "Debug blockchain state",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:    "component",
-				Aliases: []string{"c"},
-				Usage:   "Component to debug (state, storage, memory)",
-				Value:   "state",
+				Name:     "component",
+				Category: "Networking",
+				Aliases:  []string{"c"},
+				Usage:    "Component to debug (state, storage, memory)",
+				Value:    "state",
 			},
 			&cli.StringFlag{
-				Name:    "address",
-				Aliases: []string{"addr", "a"},
-				Usage:   "Address to debug",
-				Value:   "",
+				Name:     "address",
+				Category: "Networking",
+				Aliases:  []string{"addr", "a"},
+				Usage:    "Address to debug",
+				Value:    "",
 			},
 			&cli.StringFlag{
-				Name:    "block",
-				Aliases: []string{"b"},
-				Usage:   "Block number to debug",
-				Value:   "latest",
+				Name:     "block",
+				Category: "Networking",
+				Aliases:  []string{"b"},
+				Usage:    "Block number to debug",
+				Value:    "latest",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
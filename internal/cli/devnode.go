@@ -0,0 +1,345 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/DioraFund/diora-go-blockchain/internal/logger"
+	"github.com/DioraFund/diora-go-blockchain/internal/noderuntime"
+	"github.com/urfave/cli/v2"
+)
+
+// nodeControlSocket is the Unix socket a running `diora dev node` listens
+// on for `diora dev node workers`/`diora dev node worker restart <id>` to
+// connect to — the same "control plane over a Unix socket next to the
+// data dir" shape internal/walletrpc uses for wallet daemon, scoped here to
+// worker supervision instead of signing.
+func nodeControlSocket(dataDir string) string {
+	return filepath.Join(dataDir, "node.sock")
+}
+
+// loopWorker runs fn on a fixed interval until ctx is cancelled or Stop is
+// called, the shape every one of the dev node's simulated workers (miner,
+// RPC server, WS server, peer manager) shares. fn returning an error ends
+// the worker, which the Supervisor then restarts with backoff.
+type loopWorker struct {
+	id       string
+	interval time.Duration
+	fn       func() error
+	stop     chan struct{}
+}
+
+func newLoopWorker(id string, interval time.Duration, fn func() error) *loopWorker {
+	return &loopWorker{id: id, interval: interval, fn: fn, stop: make(chan struct{}, 1)}
+}
+
+func (w *loopWorker) ID() string { return w.id }
+
+func (w *loopWorker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.stop:
+			return nil
+		case <-ticker.C:
+			if err := w.fn(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (w *loopWorker) Stop() error {
+	select {
+	case w.stop <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// runDevNode builds the dev node's four supervised workers (miner, RPC
+// server, WS server, peer manager), starts them under a Supervisor so a
+// panic or error in one doesn't take the others down, serves the worker
+// control socket, and blocks until the process is interrupted.
+func (c *CLI) runDevNode(ctx *cli.Context) error {
+	genesis := ctx.String("genesis")
+	dataDir := ctx.String("data-dir")
+	mine := ctx.Bool("mine")
+	rpcPort := ctx.String("rpc-port")
+	wsPort := ctx.String("ws-port")
+	light := ctx.Bool("light")
+
+	if light && mine {
+		return fmt.Errorf("--light and --mine are mutually exclusive: a light client has no state to mine against")
+	}
+
+	c.logger.Info("Starting dev node", "genesis", genesis, "data_dir", dataDir, "mine", mine, "light", light)
+
+	fmt.Printf("🖥️  Development Node\n")
+	fmt.Printf("====================\n")
+	fmt.Printf("📄 Genesis: %s\n", genesis)
+	fmt.Printf("📁 Data Dir: %s\n", dataDir)
+	fmt.Printf("⛏️  Mining: %t\n", mine)
+	fmt.Printf("📡 RPC Port: %s\n", rpcPort)
+	fmt.Printf("🌐 WS Port: %s\n", wsPort)
+	if light {
+		// The dev node's workers are stubs with no embedded *core.Blockchain
+		// to back a light.LocalOdrBackend yet (see package light) — this
+		// flag is recorded and surfaced today, the same as the other worker
+		// stubs below, ahead of that wiring.
+		fmt.Printf("💡 Light client: enabled (ODR backend not yet wired to a running chain)\n")
+	}
+
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return fmt.Errorf("failed to create data dir: %w", err)
+	}
+	checkpoints, err := noderuntime.NewCheckpointStore(filepath.Join(dataDir, "checkpoints"))
+	if err != nil {
+		return err
+	}
+
+	// logs owns one sub-logger per worker, so log.modules.<worker>=<level>
+	// in config (or `diora dev node log-level <worker> <level>` at
+	// runtime, via the control socket below) can quiet a noisy one
+	// independently of the rest.
+	logs := logger.NewRegistry()
+	logs.Configure(c.config.Logging.Modules)
+
+	supervisor := noderuntime.NewSupervisor()
+	if mine {
+		// Sampled(5): a live miner would log once per block, which at
+		// mainnet block rates is far too often to keep at info level
+		// forever — every 5th tick is logged, each carrying how many
+		// ticks since the last one were skipped.
+		minerLog := logs.Get("miner").Sampled(5)
+		supervisor.Add(newLoopWorker("miner", 2*time.Second, func() error {
+			minerLog.Info("miner tick")
+			return checkpoints.Save("miner", []byte(fmt.Sprintf(`{"last_tick":%q}`, time.Now().Format(time.RFC3339))))
+		}), noderuntime.DefaultRestartPolicy)
+	}
+	supervisor.Add(newLoopWorker("rpc-server", 5*time.Second, func() error { return nil }), noderuntime.DefaultRestartPolicy)
+	supervisor.Add(newLoopWorker("ws-server", 5*time.Second, func() error { return nil }), noderuntime.DefaultRestartPolicy)
+	supervisor.Add(newLoopWorker("peer-manager", 5*time.Second, func() error { return nil }), noderuntime.DefaultRestartPolicy)
+
+	runCtx, cancel := signal.NotifyContext(c.ctx, os.Interrupt)
+	defer cancel()
+
+	supervisor.Start(runCtx)
+	defer supervisor.Stop()
+
+	control := newNodeControl(supervisor, logs)
+	socket := nodeControlSocket(dataDir)
+	listener, err := control.listen(socket)
+	if err != nil {
+		return fmt.Errorf("failed to start node control socket: %w", err)
+	}
+	defer listener.Close()
+	go http.Serve(listener, control)
+
+	fmt.Printf("\n🚀 Starting development node...\n")
+	fmt.Printf("📡 RPC: http://localhost:%s\n", rpcPort)
+	fmt.Printf("🌐 WebSocket: ws://localhost:%s\n", wsPort)
+	fmt.Printf("⛏️  Mining: %t\n", mine)
+
+	fmt.Printf("\n✅ Development node started!\n")
+	fmt.Printf("👀 Monitoring workers... (Ctrl+C to stop, `diora dev node workers` for status)\n")
+
+	<-runCtx.Done()
+	fmt.Printf("\n🛑 Shutting down development node...\n")
+	return nil
+}
+
+// nodeControl serves the worker control socket: ListWorkers and
+// RestartWorker, the two operations `diora dev node workers` and `diora
+// dev node worker restart <id>` need from a running node process, plus
+// SetLogLevel for `diora dev node log-level <module> <level>`.
+type nodeControl struct {
+	supervisor *noderuntime.Supervisor
+	logs       *logger.Registry
+}
+
+func newNodeControl(s *noderuntime.Supervisor, logs *logger.Registry) *nodeControl {
+	return &nodeControl{supervisor: s, logs: logs}
+}
+
+func (n *nodeControl) listen(socket string) (net.Listener, error) {
+	os.Remove(socket)
+	return net.Listen("unix", socket)
+}
+
+type nodeControlRequest struct {
+	Method string `json:"method"`
+	ID     string `json:"id,omitempty"`
+	Level  string `json:"level,omitempty"`
+}
+
+type nodeControlResponse struct {
+	Workers []noderuntime.Status `json:"workers,omitempty"`
+	Error   string               `json:"error,omitempty"`
+}
+
+func (n *nodeControl) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req nodeControlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		json.NewEncoder(w).Encode(nodeControlResponse{Error: "invalid request: " + err.Error()})
+		return
+	}
+
+	switch req.Method {
+	case "ListWorkers":
+		json.NewEncoder(w).Encode(nodeControlResponse{Workers: n.supervisor.Statuses()})
+	case "RestartWorker":
+		if err := n.supervisor.Restart(req.ID); err != nil {
+			json.NewEncoder(w).Encode(nodeControlResponse{Error: err.Error()})
+			return
+		}
+		json.NewEncoder(w).Encode(nodeControlResponse{})
+	case "SetLogLevel":
+		if err := n.logs.SetLevel(req.ID, req.Level); err != nil {
+			json.NewEncoder(w).Encode(nodeControlResponse{Error: err.Error()})
+			return
+		}
+		json.NewEncoder(w).Encode(nodeControlResponse{})
+	default:
+		json.NewEncoder(w).Encode(nodeControlResponse{Error: "unknown method " + req.Method})
+	}
+}
+
+// callNodeControl dials the control socket at socket and issues req,
+// returning an error if the socket doesn't exist (no node is running) or
+// the call otherwise fails.
+func callNodeControl(socket string, req nodeControlRequest) (*nodeControlResponse, error) {
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return net.Dial("unix", socket)
+			},
+		},
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Post("http://unix/", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("no dev node appears to be running at %s: %w", socket, err)
+	}
+	defer resp.Body.Close()
+
+	var out nodeControlResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	if out.Error != "" {
+		return nil, fmt.Errorf("%s", out.Error)
+	}
+	return &out, nil
+}
+
+// getDevNodeWorkersCommand returns the dev node workers command
+func (c *CLI) getDevNodeWorkersCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "workers",
+		Usage: "List the running dev node's supervised workers",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "data-dir",
+				Category: "Networking",
+				Aliases:  []string{"d"},
+				Usage:    "Data directory of the running dev node",
+				Value:    "./dev-data",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			resp, err := callNodeControl(nodeControlSocket(ctx.String("data-dir")), nodeControlRequest{Method: "ListWorkers"})
+			if err != nil {
+				return err
+			}
+
+			workers := resp.Workers
+			sort.Slice(workers, func(i, j int) bool { return workers[i].ID < workers[j].ID })
+
+			fmt.Printf("%-16s %-12s %-9s %s\n", "WORKER", "STATE", "RESTARTS", "LAST ERROR")
+			for _, w := range workers {
+				fmt.Printf("%-16s %-12s %-9d %s\n", w.ID, w.State, w.Restarts, w.LastError)
+			}
+			return nil
+		},
+	}
+}
+
+// getDevNodeWorkerCommand returns the dev node worker command group
+func (c *CLI) getDevNodeWorkerCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "worker",
+		Usage: "Manage a running dev node's individual workers",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "restart",
+				Usage: "Restart one worker immediately, skipping its backoff delay",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "data-dir",
+						Category: "Networking",
+						Aliases:  []string{"d"},
+						Usage:    "Data directory of the running dev node",
+						Value:    "./dev-data",
+					},
+				},
+				Action: func(ctx *cli.Context) error {
+					id := ctx.Args().First()
+					if id == "" {
+						return fmt.Errorf("usage: diora dev node worker restart <id>")
+					}
+					if _, err := callNodeControl(nodeControlSocket(ctx.String("data-dir")), nodeControlRequest{Method: "RestartWorker", ID: id}); err != nil {
+						return err
+					}
+					fmt.Printf("✅ Restart requested for worker %q\n", id)
+					return nil
+				},
+			},
+		},
+	}
+}
+
+// getDevNodeLogLevelCommand returns the dev node log-level command
+func (c *CLI) getDevNodeLogLevelCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "log-level",
+		Usage:     "Change a running dev node worker's log level at runtime",
+		ArgsUsage: "<module> <level>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "data-dir",
+				Category: "Networking",
+				Aliases:  []string{"d"},
+				Usage:    "Data directory of the running dev node",
+				Value:    "./dev-data",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			module, level := ctx.Args().Get(0), ctx.Args().Get(1)
+			if module == "" || level == "" {
+				return fmt.Errorf("usage: diora dev node log-level <module> <level>")
+			}
+			if _, err := callNodeControl(nodeControlSocket(ctx.String("data-dir")), nodeControlRequest{Method: "SetLogLevel", ID: module, Level: level}); err != nil {
+				return err
+			}
+			fmt.Printf("✅ %s log level set to %s\n", module, level)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,551 @@
+package cli
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/DioraFund/diora-go-blockchain/internal/keystore"
+	"github.com/DioraFund/diora-go-blockchain/internal/rpcclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+// xputEphemeralPassword encrypts every wallet xput generates for itself.
+// These keys never outlive the run (the keystore directory is a temp dir,
+// removed on exit) and are never shown to a user, so there's no password
+// strength to gate the way `wallet create` does.
+const xputEphemeralPassword = "xput-ephemeral-wallet"
+
+// xputFundTimeout bounds how long xput waits for its funding transactions
+// to be included before giving up and starting the load pattern anyway.
+const xputFundTimeout = 30 * time.Second
+
+// xputPollInterval is how often the inclusion-tracking goroutine polls
+// eth_getTransactionByHash for outstanding transactions and samples the
+// queue depth time series.
+const xputPollInterval = 250 * time.Millisecond
+
+// getDevXputCommand returns the dev xput command: a built-in load
+// generator that funds a pool of ephemeral wallets and drives
+// transactions against the configured node at a target rate, so an
+// operator can measure throughput and submit-to-inclusion latency without
+// standing up a separate benchmarking tool.
+func (c *CLI) getDevXputCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "xput",
+		Usage: "Generate transaction load and report achieved TPS and latency",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:     "count",
+				Category: "Networking",
+				Usage:    "Number of ephemeral wallets to generate and fund",
+				Value:    10,
+			},
+			&cli.StringFlag{
+				Name:     "funder",
+				Category: "Networking",
+				Usage:    "Address to fund ephemeral wallets from",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "funder-password",
+				Category: "Networking",
+				Usage:    "Password unlocking --funder",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "fund-amount",
+				Category: "Networking",
+				Usage:    "DIO sent to each ephemeral wallet before load starts",
+				Value:    "1",
+			},
+			&cli.StringFlag{
+				Name:     "transfer-amount",
+				Category: "Networking",
+				Usage:    "DIO sent by each load-generated transaction",
+				Value:    "0.0001",
+			},
+			&cli.StringFlag{
+				Name:     "pattern",
+				Category: "Networking",
+				Usage:    "Traffic pattern: pingpong, fanout, or random",
+				Value:    "random",
+			},
+			&cli.IntFlag{
+				Name:     "tps",
+				Category: "Networking",
+				Usage:    "Target transactions per second",
+				Value:    10,
+			},
+			&cli.StringFlag{
+				Name:     "duration",
+				Category: "Networking",
+				Usage:    "How long to generate load, e.g. 60s, 5m",
+				Value:    "60s",
+			},
+			&cli.StringFlag{
+				Name:     "tx-size",
+				Category: "Networking",
+				Usage:    "Transaction shape to submit; only native is accepted, erc20 and contract-call are rejected",
+				Value:    "native",
+			},
+			&cli.StringFlag{
+				Name:     "gas-price",
+				Category: "Networking",
+				Usage:    "Gas price in Gwei",
+				Value:    "20",
+			},
+			&cli.StringFlag{
+				Name:     "gas-limit",
+				Category: "Networking",
+				Usage:    "Gas limit per transaction",
+				Value:    "21000",
+			},
+			&cli.StringFlag{
+				Name:     "chain-id",
+				Category: "Networking",
+				Usage:    "Chain id to sign for (default: fetched from the node)",
+			},
+			&cli.StringFlag{
+				Name:     "metrics-out",
+				Category: "Networking",
+				Usage:    "Write a Prometheus text-format dump of this run's results to this file",
+			},
+		},
+		Action: c.runDevXput,
+	}
+}
+
+// xputWallet is one ephemeral wallet xput drives traffic through: its
+// address, and a locally-maintained next-nonce so submitting a
+// transaction never needs an eth_getTransactionCount round trip.
+type xputWallet struct {
+	address common.Address
+	nonce   uint64
+}
+
+// xputInclusion records one submitted transaction waiting to be mined:
+// submittedAt is used to compute its submit→inclusion latency once
+// xputPoller sees it land in a block.
+type xputInclusion struct {
+	hash        common.Hash
+	submittedAt time.Time
+}
+
+func (c *CLI) runDevXput(ctx *cli.Context) error {
+	count := ctx.Int("count")
+	if count < 2 {
+		return fmt.Errorf("--count must be at least 2, got %d", count)
+	}
+	pattern := ctx.String("pattern")
+	switch pattern {
+	case "pingpong", "fanout", "random":
+	default:
+		return fmt.Errorf("unknown --pattern %q (want pingpong, fanout, or random)", pattern)
+	}
+	txSize := ctx.String("tx-size")
+	if txSize != "native" {
+		return fmt.Errorf("--tx-size %s is not yet wired through the RPC client; only native is supported", txSize)
+	}
+	tps := ctx.Int("tps")
+	if tps < 1 {
+		return fmt.Errorf("--tps must be at least 1, got %d", tps)
+	}
+	duration, err := time.ParseDuration(ctx.String("duration"))
+	if err != nil {
+		return fmt.Errorf("invalid --duration %q: %w", ctx.String("duration"), err)
+	}
+	funder := common.HexToAddress(ctx.String("funder"))
+	fundAmount, err := dioToWei(ctx.String("fund-amount"))
+	if err != nil {
+		return fmt.Errorf("invalid --fund-amount: %w", err)
+	}
+	transferAmount, err := dioToWei(ctx.String("transfer-amount"))
+	if err != nil {
+		return fmt.Errorf("invalid --transfer-amount: %w", err)
+	}
+	gasPrice, err := gweiToWei(ctx.String("gas-price"))
+	if err != nil {
+		return fmt.Errorf("invalid --gas-price: %w", err)
+	}
+	gasLimit, err := strconv.ParseUint(ctx.String("gas-limit"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid --gas-limit %q: %w", ctx.String("gas-limit"), err)
+	}
+
+	ac := c.accountClient()
+	chainID := new(big.Int)
+	if ctx.IsSet("chain-id") {
+		var ok bool
+		chainID, ok = new(big.Int).SetString(ctx.String("chain-id"), 10)
+		if !ok {
+			return fmt.Errorf("invalid chain id %q", ctx.String("chain-id"))
+		}
+	} else if chainID, err = ac.ChainID(); err != nil {
+		return fmt.Errorf("failed to fetch chain id: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "diora-xput-*")
+	if err != nil {
+		return fmt.Errorf("failed to create ephemeral keystore dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	ks := keystore.NewKeystore(dir)
+	wallet := keystore.NewLocalWallet(ks, xputEphemeralPassword)
+
+	fmt.Printf("🚀 diora dev xput\n")
+	fmt.Printf("====================\n")
+	fmt.Printf("🧾 Pattern: %s | 🎯 TPS: %d | ⏱️  Duration: %s | 👛 Wallets: %d\n", pattern, tps, duration, count)
+
+	wallets := make([]*xputWallet, count)
+	for i := 0; i < count; i++ {
+		account, err := ks.NewAccount(fmt.Sprintf("xput-%d", i), xputEphemeralPassword)
+		if err != nil {
+			return fmt.Errorf("failed to generate ephemeral wallet %d: %w", i, err)
+		}
+		wallets[i] = &xputWallet{address: account.Address}
+	}
+
+	fmt.Printf("\n💧 Funding %d wallet(s) from %s...\n", count, funder.Hex())
+	funderNonce, err := ac.NextNonce(funder)
+	if err != nil {
+		return fmt.Errorf("failed to fetch nonce for funder %s: %w", funder.Hex(), err)
+	}
+	funderWallet := c.walletManager(ctx.String("funder-password"))
+	var lastFundHash common.Hash
+	for _, w := range wallets {
+		hash, err := submitTransfer(ac, funderWallet, funder, w.address, fundAmount, funderNonce, gasPrice, gasLimit, chainID)
+		if err != nil {
+			return fmt.Errorf("failed to fund %s: %w", w.address.Hex(), err)
+		}
+		lastFundHash = hash
+		funderNonce++
+	}
+	if err := waitForInclusion(ac, lastFundHash, xputFundTimeout); err != nil {
+		fmt.Printf("⚠️  %v; proceeding anyway\n", err)
+	}
+
+	for _, w := range wallets {
+		nonce, err := ac.NextNonce(w.address)
+		if err != nil {
+			return fmt.Errorf("failed to fetch nonce for %s: %w", w.address.Hex(), err)
+		}
+		w.nonce = nonce
+	}
+
+	poller := newXputPoller(ac)
+	stopPoller := poller.start()
+
+	fmt.Printf("\n📈 Generating load...\n")
+	ticker := time.NewTicker(time.Second / time.Duration(tps))
+	defer ticker.Stop()
+	deadline := time.Now().Add(duration)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	var submitted int
+	pairCursor := 0
+	fanoutCursor := 1
+	for time.Now().Before(deadline) {
+		<-ticker.C
+		src, dst := nextPair(pattern, wallets, rng, &pairCursor, &fanoutCursor)
+
+		hash, err := submitTransfer(ac, wallet, src.address, dst.address, transferAmount, src.nonce, gasPrice, gasLimit, chainID)
+		if err != nil {
+			c.logger.Warn("xput: submit failed", "from", src.address.Hex(), "to", dst.address.Hex(), "error", err)
+			continue
+		}
+		src.nonce++
+		submitted++
+		poller.track(hash)
+	}
+
+	// Give outstanding transactions a grace period to land before reporting,
+	// capped so a stalled node can't hang the command forever.
+	drain := duration / 2
+	if drain > 10*time.Second {
+		drain = 10 * time.Second
+	}
+	time.Sleep(drain)
+	report := stopPoller()
+
+	achievedTPS := float64(submitted) / duration.Seconds()
+	fmt.Printf("\n📊 Xput Report\n")
+	fmt.Printf("==============\n")
+	fmt.Printf("📤 Submitted: %d\n", submitted)
+	fmt.Printf("📥 Included: %d\n", report.included)
+	fmt.Printf("📈 Achieved TPS: %.2f\n", achievedTPS)
+	printLatencyPercentiles(report.latencies)
+	printQueueDepthSeries(report.queueDepthSamples)
+	printBlockInclusionCounts(report.perBlockInclusions)
+
+	if out := ctx.String("metrics-out"); out != "" {
+		if err := writeXputPromMetrics(out, achievedTPS, submitted, report); err != nil {
+			return fmt.Errorf("failed to write --metrics-out: %w", err)
+		}
+		fmt.Printf("\n📄 Metrics: %s\n", out)
+	}
+
+	return nil
+}
+
+// nextPair picks the (source, destination) wallet for the next load
+// transaction according to pattern: pingpong bounces each adjacent pair of
+// wallets back and forth, fanout always sends from wallets[0] to the rest
+// in turn, and random picks two distinct wallets uniformly.
+func nextPair(pattern string, wallets []*xputWallet, rng *rand.Rand, pairCursor, fanoutCursor *int) (*xputWallet, *xputWallet) {
+	switch pattern {
+	case "pingpong":
+		n := len(wallets) / 2 * 2
+		i := *pairCursor % n
+		*pairCursor++
+		a, b := wallets[i-i%2], wallets[i-i%2+1]
+		if i%2 == 0 {
+			return a, b
+		}
+		return b, a
+	case "fanout":
+		dst := wallets[*fanoutCursor%(len(wallets)-1)+1]
+		*fanoutCursor++
+		return wallets[0], dst
+	default: // random
+		i := rng.Intn(len(wallets))
+		j := rng.Intn(len(wallets) - 1)
+		if j >= i {
+			j++
+		}
+		return wallets[i], wallets[j]
+	}
+}
+
+// submitTransfer signs and broadcasts a single native-value legacy
+// transfer, the same LegacySigningHash/AssembleLegacyTransaction path
+// `tx send` uses, parameterized so xput's funding phase and load phase
+// share it.
+func submitTransfer(ac *rpcclient.AccountClient, wallet keystore.WalletManager, from, to common.Address, value *big.Int, nonce uint64, gasPrice *big.Int, gasLimit uint64, chainID *big.Int) (common.Hash, error) {
+	hash, err := rpcclient.LegacySigningHash(nonce, gasPrice, gasLimit, &to, value, nil, chainID)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to compute signing hash: %w", err)
+	}
+	sig, err := wallet.Sign(from, hash)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	raw, err := rpcclient.AssembleLegacyTransaction(nonce, gasPrice, gasLimit, &to, value, nil, chainID, sig)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to assemble signed transaction: %w", err)
+	}
+	return ac.SendRawTransaction(raw)
+}
+
+// waitForInclusion polls hash until the node reports a block number for it
+// or timeout elapses.
+func waitForInclusion(ac *rpcclient.AccountClient, hash common.Hash, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		info, err := ac.TransactionByHash(hash)
+		if err == nil && info != nil && info.BlockNumber != nil {
+			return nil
+		}
+		time.Sleep(xputPollInterval)
+	}
+	return fmt.Errorf("timed out waiting for funding transaction %s to be included", hash.Hex())
+}
+
+// xputQueueSample is one point of the queue-depth-over-time series: how
+// many of xput's own submitted transactions were still unconfirmed at t.
+// There is no RPC endpoint exposing the node's global mempool size, so
+// this tracks xput's own outstanding set as a proxy for pool pressure
+// under the load this run generated.
+type xputQueueSample struct {
+	t     time.Time
+	depth int
+}
+
+// xputReport is everything the poller accumulated over a run: per-tx
+// latency, queue depth over time, and how many of xput's transactions
+// landed in each block.
+type xputReport struct {
+	included           int
+	latencies          []time.Duration
+	queueDepthSamples  []xputQueueSample
+	perBlockInclusions map[uint64]int
+}
+
+// xputPoller tracks submitted transaction hashes and periodically checks
+// which have been included, recording submit→inclusion latency and a
+// queue-depth time series without the load-generation loop itself ever
+// blocking on an RPC round trip.
+type xputPoller struct {
+	ac      *rpcclient.AccountClient
+	mu      sync.Mutex
+	pending map[common.Hash]time.Time
+	report  xputReport
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+func newXputPoller(ac *rpcclient.AccountClient) *xputPoller {
+	return &xputPoller{
+		ac:      ac,
+		pending: make(map[common.Hash]time.Time),
+		report:  xputReport{perBlockInclusions: make(map[uint64]int)},
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+func (p *xputPoller) track(hash common.Hash) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending[hash] = time.Now()
+}
+
+func (p *xputPoller) start() func() xputReport {
+	go func() {
+		defer close(p.stopped)
+		ticker := time.NewTicker(xputPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.poll()
+			case <-p.done:
+				p.poll()
+				return
+			}
+		}
+	}()
+	return func() xputReport {
+		close(p.done)
+		<-p.stopped
+		return p.report
+	}
+}
+
+func (p *xputPoller) poll() {
+	p.mu.Lock()
+	hashes := make([]common.Hash, 0, len(p.pending))
+	for h := range p.pending {
+		hashes = append(hashes, h)
+	}
+	p.mu.Unlock()
+
+	for _, h := range hashes {
+		info, err := p.ac.TransactionByHash(h)
+		if err != nil || info == nil || info.BlockNumber == nil {
+			continue
+		}
+		p.mu.Lock()
+		submittedAt, ok := p.pending[h]
+		if ok {
+			delete(p.pending, h)
+			p.report.included++
+			p.report.latencies = append(p.report.latencies, time.Since(submittedAt))
+			p.report.perBlockInclusions[info.BlockNumber.Uint64()]++
+		}
+		p.mu.Unlock()
+	}
+
+	p.mu.Lock()
+	p.report.queueDepthSamples = append(p.report.queueDepthSamples, xputQueueSample{t: time.Now(), depth: len(p.pending)})
+	p.mu.Unlock()
+}
+
+// percentile returns the pth percentile (0-100) of sorted, which must
+// already be sorted ascending.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(p / 100 * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
+func printLatencyPercentiles(latencies []time.Duration) {
+	fmt.Printf("\n⏱️  Submit → Inclusion Latency\n")
+	if len(latencies) == 0 {
+		fmt.Printf("   no transactions were included before the report was generated\n")
+		return
+	}
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	fmt.Printf("   p50: %s | p95: %s | p99: %s\n", percentile(sorted, 50), percentile(sorted, 95), percentile(sorted, 99))
+}
+
+func printQueueDepthSeries(samples []xputQueueSample) {
+	fmt.Printf("\n📦 Mempool Queue Depth (xput's own outstanding transactions)\n")
+	if len(samples) == 0 {
+		fmt.Printf("   no samples collected\n")
+		return
+	}
+	maxDepth := 0
+	var sum int
+	for _, s := range samples {
+		if s.depth > maxDepth {
+			maxDepth = s.depth
+		}
+		sum += s.depth
+	}
+	fmt.Printf("   avg: %.1f | max: %d | samples: %d\n", float64(sum)/float64(len(samples)), maxDepth, len(samples))
+}
+
+func printBlockInclusionCounts(perBlock map[uint64]int) {
+	fmt.Printf("\n📦 Per-Block Inclusion Counts\n")
+	if len(perBlock) == 0 {
+		fmt.Printf("   no transactions were included before the report was generated\n")
+		return
+	}
+	blocks := make([]uint64, 0, len(perBlock))
+	for b := range perBlock {
+		blocks = append(blocks, b)
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i] < blocks[j] })
+	for _, b := range blocks {
+		fmt.Printf("   block %d: %d\n", b, perBlock[b])
+	}
+}
+
+// writeXputPromMetrics writes this run's results as Prometheus text
+// exposition format, so an operator can scrape or plot xput runs across
+// releases the same way they would a live /metrics endpoint.
+func writeXputPromMetrics(path string, achievedTPS float64, submitted int, report xputReport) error {
+	var out string
+	out += "# HELP diora_xput_submitted_total Transactions submitted during this run\n"
+	out += "# TYPE diora_xput_submitted_total counter\n"
+	out += fmt.Sprintf("diora_xput_submitted_total %d\n", submitted)
+
+	out += "# HELP diora_xput_included_total Transactions confirmed included during this run\n"
+	out += "# TYPE diora_xput_included_total counter\n"
+	out += fmt.Sprintf("diora_xput_included_total %d\n", report.included)
+
+	out += "# HELP diora_xput_achieved_tps Achieved transactions per second over the run's duration\n"
+	out += "# TYPE diora_xput_achieved_tps gauge\n"
+	out += fmt.Sprintf("diora_xput_achieved_tps %f\n", achievedTPS)
+
+	if len(report.latencies) > 0 {
+		sorted := append([]time.Duration(nil), report.latencies...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		out += "# HELP diora_xput_latency_seconds Submit-to-inclusion latency\n"
+		out += "# TYPE diora_xput_latency_seconds summary\n"
+		for _, q := range []float64{0.5, 0.95, 0.99} {
+			out += fmt.Sprintf("diora_xput_latency_seconds{quantile=\"%.2f\"} %f\n", q, percentile(sorted, q*100).Seconds())
+		}
+	}
+
+	if len(report.queueDepthSamples) > 0 {
+		out += "# HELP diora_xput_mempool_queue_depth Outstanding xput transactions not yet included, sampled over the run\n"
+		out += "# TYPE diora_xput_mempool_queue_depth gauge\n"
+		for _, s := range report.queueDepthSamples {
+			out += fmt.Sprintf("diora_xput_mempool_queue_depth %d %d\n", s.depth, s.t.UnixMilli())
+		}
+	}
+
+	return os.WriteFile(path, []byte(out), 0644)
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+// getGovernanceCommand returns the governance command
+func (c *CLI) getGovernanceCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "governance",
+		Usage: "Governance proposal operations",
+		Subcommands: []*cli.Command{
+			c.getGovernanceHaltBlockCommand(),
+			c.getGovernanceUnhaltCommand(),
+		},
+	}
+}
+
+// getGovernanceHaltBlockCommand returns the governance halt-block command
+func (c *CLI) getGovernanceHaltBlockCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "halt-block",
+		Usage: "Submit a ProposalTypeSetHaltBlock proposal scheduling a coordinated chain halt",
+		Flags: []cli.Flag{
+			&cli.Uint64Flag{
+				Name:     "height",
+				Usage:    "Block height the chain should halt at or before",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "title",
+				Category: "Validator",
+				Usage:    "Proposal title",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "proposer",
+				Category: "Validator",
+				Usage:    "Proposer address",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			height := ctx.Uint64("height")
+			title := ctx.String("title")
+			proposer := common.HexToAddress(ctx.String("proposer"))
+
+			c.logger.Info("Submitting set-halt-block proposal", "height", height, "title", title)
+
+			if err := c.accountClient().SubmitHaltBlockProposal(proposer, title, height); err != nil {
+				return fmt.Errorf("failed to submit proposal: %w", err)
+			}
+
+			fmt.Printf("Submitted halt-block proposal %q: chain will halt at block %d\n", title, height)
+			return nil
+		},
+	}
+}
+
+// getGovernanceUnhaltCommand returns the governance unhalt command
+func (c *CLI) getGovernanceUnhaltCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "unhalt",
+		Usage: "Submit a ProposalTypeUnhalt proposal clearing a pending halt height",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "title",
+				Category: "Validator",
+				Usage:    "Proposal title",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "proposer",
+				Category: "Validator",
+				Usage:    "Proposer address",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			title := ctx.String("title")
+			proposer := common.HexToAddress(ctx.String("proposer"))
+
+			c.logger.Info("Submitting unhalt proposal", "title", title)
+
+			if err := c.accountClient().SubmitUnhaltProposal(proposer, title); err != nil {
+				return fmt.Errorf("failed to submit proposal: %w", err)
+			}
+
+			fmt.Printf("Submitted unhalt proposal %q: chain may resume producing blocks\n", title)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/DioraFund/diora-go-blockchain/internal/rpcclient"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Raw 4-byte function selectors used to probe a contract's standard without
+// needing its ABI: keccak256("supportsInterface(bytes4)")[:4],
+// keccak256("name()")[:4], keccak256("symbol()")[:4],
+// keccak256("decimals()")[:4].
+var (
+	selectorSupportsInterface = [4]byte{0x01, 0xff, 0xc9, 0xa7}
+	selectorName              = [4]byte{0x06, 0xfd, 0xde, 0x03}
+	selectorSymbol            = [4]byte{0x95, 0xd8, 0x9b, 0x41}
+	selectorDecimals          = [4]byte{0x31, 0x3c, 0xe5, 0x67}
+)
+
+// erc721InterfaceID is the EIP-165 interface id ERC-721 registers:
+// XOR of its nine function selectors.
+var erc721InterfaceID = [4]byte{0x80, 0xac, 0x58, 0xcd}
+
+// supportsERC165Interface calls address's supportsInterface(bytes4) and
+// reports whether it claims interfaceID, tolerating contracts that don't
+// implement EIP-165 at all (the call reverts or returns garbage) by simply
+// reporting false rather than erroring.
+func supportsERC165Interface(ac *rpcclient.AccountClient, address common.Address, interfaceID [4]byte) bool {
+	data := append(append([]byte{}, selectorSupportsInterface[:]...), make([]byte, 32)...)
+	copy(data[4:8], interfaceID[:])
+	out, err := ac.CallContract(rpcclient.CallMsg{To: address, Data: data})
+	if err != nil || len(out) < 32 {
+		return false
+	}
+	return out[31] == 1
+}
+
+// detectStandard classifies address as "ERC721" (via its formal EIP-165
+// marker), "ERC20" (heuristically: both decimals() and symbol() answer
+// without reverting, since ERC-20 predates EIP-165 and has no formal
+// marker), or "" if neither probe succeeds.
+func detectStandard(ac *rpcclient.AccountClient, address common.Address) string {
+	if supportsERC165Interface(ac, address, erc721InterfaceID) {
+		return "ERC721"
+	}
+	if _, err := callERC20Uint8(ac, address, selectorDecimals); err == nil {
+		if _, err := callERC20String(ac, address, selectorSymbol); err == nil {
+			return "ERC20"
+		}
+	}
+	return ""
+}
+
+// stringType and uint8Type are the ad-hoc abi.Type values callERC20String
+// and callERC20Uint8 decode return data with; building them fresh avoids
+// needing a full ABI just to unpack a single well-known scalar.
+var stringType, uint8Type = mustABIType("string"), mustABIType("uint8")
+
+func mustABIType(name string) abi.Type {
+	t, err := abi.NewType(name, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// callERC20String calls address's zero-argument, string-returning selector
+// (name() or symbol()) and decodes the result.
+func callERC20String(ac *rpcclient.AccountClient, address common.Address, selector [4]byte) (string, error) {
+	out, err := ac.CallContract(rpcclient.CallMsg{To: address, Data: selector[:]})
+	if err != nil {
+		return "", err
+	}
+	values, err := (abi.Arguments{{Type: stringType}}).Unpack(out)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode string result: %w", err)
+	}
+	return values[0].(string), nil
+}
+
+// callERC20Uint8 calls address's zero-argument, uint8-returning selector
+// (decimals()) and decodes the result.
+func callERC20Uint8(ac *rpcclient.AccountClient, address common.Address, selector [4]byte) (uint8, error) {
+	out, err := ac.CallContract(rpcclient.CallMsg{To: address, Data: selector[:]})
+	if err != nil {
+		return 0, err
+	}
+	values, err := (abi.Arguments{{Type: uint8Type}}).Unpack(out)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode uint8 result: %w", err)
+	}
+	return values[0].(uint8), nil
+}
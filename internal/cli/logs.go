@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/DioraFund/diora-go-blockchain/internal/rpcclient"
+	"github.com/urfave/cli/v2"
+)
+
+// getLogsCommand returns the logs command
+func (c *CLI) getLogsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "logs",
+		Usage: "Event log operations",
+		Subcommands: []*cli.Command{
+			c.getLogsQueryCommand(),
+		},
+	}
+}
+
+// getLogsQueryCommand returns the logs query command
+func (c *CLI) getLogsQueryCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "query",
+		Usage: "Query event logs by block range, address, and topics",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "from",
+				Category: "Monitoring",
+				Usage:    "Start block (number, \"earliest\", or \"latest\")",
+				Value:    "earliest",
+			},
+			&cli.StringFlag{
+				Name:     "to",
+				Category: "Monitoring",
+				Usage:    "End block (number, \"earliest\", or \"latest\")",
+				Value:    "latest",
+			},
+			&cli.StringFlag{
+				Name:     "address",
+				Category: "Monitoring",
+				Usage:    "Contract address to filter on",
+			},
+			&cli.StringSliceFlag{
+				Name:     "topic",
+				Category: "Monitoring",
+				Usage:    "Topic to match at its position (repeatable, one per position; omit a position with \"\")",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			from := ctx.String("from")
+			to := ctx.String("to")
+			address := ctx.String("address")
+			topics := ctx.StringSlice("topic")
+
+			c.logger.Info("Querying logs", "from", from, "to", to, "address", address, "topics", topics)
+
+			logs, err := c.accountClient().FilterLogs(rpcclient.LogFilter{
+				FromBlock: from,
+				ToBlock:   to,
+				Address:   address,
+				Topics:    topics,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to query logs: %w", err)
+			}
+
+			fmt.Printf("📜 Event Logs\n")
+			fmt.Printf("=============\n")
+			if len(logs) == 0 {
+				fmt.Printf("No logs found\n")
+				return nil
+			}
+
+			for i, l := range logs {
+				fmt.Printf("%d. Block %d, tx %s, index %d\n", i+1, l.BlockNumber, l.TxHash.Hex(), l.Index)
+				fmt.Printf("   📍 Address: %s\n", l.Address.Hex())
+				for j, topic := range l.Topics {
+					fmt.Printf("   🏷️  Topic[%d]: %s\n", j, topic.Hex())
+				}
+				fmt.Printf("   📦 Data: 0x%x\n", l.Data)
+			}
+
+			return nil
+		},
+	}
+}
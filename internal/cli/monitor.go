@@ -1,11 +1,81 @@
 package cli
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-
+	"math/big"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/DioraFund/diora-go-blockchain/internal/monitor"
+	"github.com/DioraFund/diora-go-blockchain/internal/rpcclient"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 )
 
+// BlockView is monitor blocks's --output shape for a single block.
+type BlockView struct {
+	Number    uint64 `json:"number" yaml:"number"`
+	Hash      string `json:"hash" yaml:"hash"`
+	TxCount   int    `json:"tx_count" yaml:"tx_count"`
+	Timestamp string `json:"timestamp" yaml:"timestamp"`
+}
+
+func newBlockView(b *rpcclient.BlockSummary) BlockView {
+	return BlockView{
+		Number:    b.Number,
+		Hash:      b.Hash.Hex(),
+		TxCount:   b.TxCount,
+		Timestamp: time.Unix(int64(b.Timestamp), 0).Format("2006-01-02 15:04:05"),
+	}
+}
+
+// TxView is monitor transactions's --output shape for a single transaction.
+type TxView struct {
+	Hash  string `json:"hash" yaml:"hash"`
+	From  string `json:"from" yaml:"from"`
+	To    string `json:"to" yaml:"to"`
+	Value string `json:"value_dio" yaml:"value_dio"`
+}
+
+func newTxView(tx *rpcclient.TxInfo) TxView {
+	to := "(contract creation)"
+	if tx.To != nil {
+		to = tx.To.Hex()
+	}
+	return TxView{
+		Hash:  tx.Hash.Hex(),
+		From:  tx.From.Hex(),
+		To:    to,
+		Value: weiToDIO(tx.Value),
+	}
+}
+
+// NetworkStatsView is monitor network's --output shape.
+type NetworkStatsView struct {
+	BlockHeight      uint64 `json:"block_height" yaml:"block_height"`
+	TPS              string `json:"tps" yaml:"tps"`
+	GasPriceWei      string `json:"gas_price_wei" yaml:"gas_price_wei"`
+	ActiveValidators uint64 `json:"active_validators" yaml:"active_validators"`
+	PeerCount        int    `json:"peer_count" yaml:"peer_count"`
+}
+
+// GasView is monitor gas's --output shape: the current price plus its
+// history over the requested period.
+type GasView struct {
+	Period       string         `json:"period" yaml:"period"`
+	CurrentPrice string         `json:"current_price_wei" yaml:"current_price_wei"`
+	History      []GasPointView `json:"history" yaml:"history"`
+}
+
+// GasPointView is one sample of GasView.History.
+type GasPointView struct {
+	Timestamp string `json:"timestamp" yaml:"timestamp"`
+	PriceWei  string `json:"price_wei" yaml:"price_wei"`
+}
+
 // getMonitorCommand returns the monitor command
 func (c *CLI) getMonitorCommand() *cli.Command {
 	return &cli.Command{
@@ -17,10 +87,45 @@ func (c *CLI) getMonitorCommand() *cli.Command {
 			c.getMonitorValidatorsCommand(),
 			c.getMonitorNetworkCommand(),
 			c.getMonitorGasCommand(),
+			c.getMonitorMetricsCommand(),
 		},
 	}
 }
 
+// followContext derives the context a --follow loop runs under from c.ctx,
+// cancelled on Ctrl+C exactly like `dev node`'s run loop.
+func (c *CLI) followContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(c.ctx, os.Interrupt)
+}
+
+// followInterval parses --interval (e.g. "5s", "10s", "1m") for the polling
+// fallback a --follow session uses when WS is disabled in config. An
+// enabled but unreachable WS endpoint is an error, not a fallback.
+func followInterval(ctx *cli.Context) (time.Duration, error) {
+	interval, err := time.ParseDuration(ctx.String("interval"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid --interval %q: %w", ctx.String("interval"), err)
+	}
+	return interval, nil
+}
+
+// streamFollow runs produce (a --follow loop that pushes items onto items
+// and closes it on exit) concurrently with c.renderer.RenderStream, so
+// ndjson/json/yaml modes flush each item as it arrives instead of
+// buffering the whole --follow session.
+func (c *CLI) streamFollow(produce func(items chan<- interface{}) error) error {
+	items := make(chan interface{})
+	done := make(chan error, 1)
+	go func() {
+		defer close(items)
+		done <- produce(items)
+	}()
+	if err := c.renderer.RenderStream(items); err != nil {
+		return err
+	}
+	return <-done
+}
+
 // getMonitorBlocksCommand returns the monitor blocks command
 func (c *CLI) getMonitorBlocksCommand() *cli.Command {
 	return &cli.Command{
@@ -28,16 +133,24 @@ func (c *CLI) getMonitorBlocksCommand() *cli.Command {
 		Usage: "Monitor new blocks",
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
-				Name:    "follow",
-				Aliases: []string{"f"},
-				Usage:   "Follow new blocks in real-time",
-				Value:   false,
+				Name:     "follow",
+				Category: "Monitoring",
+				Aliases:  []string{"f"},
+				Usage:    "Follow new blocks in real-time",
+				Value:    false,
 			},
 			&cli.IntFlag{
-				Name:    "limit",
-				Aliases: []string{"l"},
-				Usage:   "Number of blocks to show",
-				Value:   10,
+				Name:     "limit",
+				Category: "Monitoring",
+				Aliases:  []string{"l"},
+				Usage:    "Number of blocks to show",
+				Value:    10,
+			},
+			&cli.StringFlag{
+				Name:     "interval",
+				Category: "Monitoring",
+				Usage:    "Polling interval used when --follow runs without a WS subscription",
+				Value:    "5s",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -46,28 +159,94 @@ func (c *CLI) getMonitorBlocksCommand() *cli.Command {
 
 			c.logger.Info("Monitoring blocks", "follow", follow, "limit", limit)
 
-			// TODO: Implement block monitoring logic
-			fmt.Printf("📦 Block Monitor\n")
-			fmt.Printf("================\n")
-			fmt.Printf("👁️  Follow: %t\n", follow)
-			fmt.Printf("📊 Limit: %d\n", limit)
+			ac := c.accountClient()
+			blocks, err := ac.RecentBlocks(limit)
+			if err != nil {
+				return fmt.Errorf("failed to fetch recent blocks: %w", err)
+			}
 
-			fmt.Printf("\n📦 Recent Blocks\n")
-			fmt.Printf("==============\n")
-			for i := 1; i <= limit; i++ {
-				fmt.Printf("%d. 📦 #%d - 0xabc... - 42 txs - 6s - 2024-01-15 10:%02d:00\n", i, 1234567-i+1, 30-i+1)
+			views := make([]BlockView, len(blocks))
+			for i, b := range blocks {
+				views[i] = newBlockView(b)
+			}
+			if err := c.renderer.RenderList(views); err != nil {
+				return err
 			}
 
-			if follow {
-				fmt.Printf("\n👀 Following new blocks... (Ctrl+C to stop)\n")
-				// TODO: Implement real-time block following
+			if !follow {
+				return nil
 			}
 
-			return nil
+			runCtx, cancel := c.followContext()
+			defer cancel()
+			return c.streamFollow(func(items chan<- interface{}) error {
+				return c.followBlocks(runCtx, ctx, ac, items)
+			})
 		},
 	}
 }
 
+// followBlocks streams new block heads via WS when config.RPC.EnableWebSocket
+// is on, otherwise falls back to polling ac.BlockNumber at --interval.
+func (c *CLI) followBlocks(runCtx context.Context, ctx *cli.Context, ac *rpcclient.AccountClient, items chan<- interface{}) error {
+	if c.config.RPC.EnableWebSocket {
+		return c.followBlocksWS(runCtx, ac, items)
+	}
+	interval, err := followInterval(ctx)
+	if err != nil {
+		return err
+	}
+	last := uint64(0)
+	return monitor.Poll(runCtx, interval, func() error {
+		head, err := ac.BlockNumber()
+		if err != nil {
+			return fmt.Errorf("failed to poll block number: %w", err)
+		}
+		if last == 0 {
+			last = head
+			return nil
+		}
+		for n := last + 1; n <= head; n++ {
+			block, err := ac.BlockByNumber(n)
+			if err != nil {
+				return fmt.Errorf("failed to fetch block %d: %w", n, err)
+			}
+			if block != nil {
+				items <- newBlockView(block)
+			}
+		}
+		last = head
+		return nil
+	})
+}
+
+func (c *CLI) followBlocksWS(runCtx context.Context, ac *rpcclient.AccountClient, items chan<- interface{}) error {
+	sub, err := monitor.Dial(c.config.RPC.URL)
+	if err != nil {
+		return fmt.Errorf("failed to open block subscription, falling back requires --interval polling instead: %w", err)
+	}
+	defer sub.Close()
+
+	payloads, err := sub.Subscribe(runCtx, "newHeads")
+	if err != nil {
+		return err
+	}
+	for payload := range payloads {
+		var head struct {
+			Number uint64 `json:"number"`
+		}
+		if err := json.Unmarshal(payload, &head); err != nil {
+			continue
+		}
+		block, err := ac.BlockByNumber(head.Number)
+		if err != nil || block == nil {
+			continue
+		}
+		items <- newBlockView(block)
+	}
+	return nil
+}
+
 // getMonitorTransactionsCommand returns the monitor transactions command
 func (c *CLI) getMonitorTransactionsCommand() *cli.Command {
 	return &cli.Command{
@@ -75,22 +254,31 @@ func (c *CLI) getMonitorTransactionsCommand() *cli.Command {
 		Usage: "Monitor new transactions",
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
-				Name:    "follow",
-				Aliases: []string{"f"},
-				Usage:   "Follow new transactions in real-time",
-				Value:   false,
+				Name:     "follow",
+				Category: "Monitoring",
+				Aliases:  []string{"f"},
+				Usage:    "Follow new transactions in real-time",
+				Value:    false,
 			},
 			&cli.IntFlag{
-				Name:    "limit",
-				Aliases: []string{"l"},
-				Usage:   "Number of transactions to show",
-				Value:   20,
+				Name:     "limit",
+				Category: "Monitoring",
+				Aliases:  []string{"l"},
+				Usage:    "Number of transactions to show",
+				Value:    20,
 			},
 			&cli.StringFlag{
-				Name:    "type",
-				Aliases: []string{"t"},
-				Usage:   "Transaction type (send, receive, contract)",
-				Value:   "",
+				Name:     "type",
+				Category: "Monitoring",
+				Aliases:  []string{"t"},
+				Usage:    "Transaction type (send, receive, contract); only contract narrows the list, send and receive show every transaction",
+				Value:    "",
+			},
+			&cli.StringFlag{
+				Name:     "interval",
+				Category: "Monitoring",
+				Usage:    "Polling interval used when --follow runs without a WS subscription",
+				Value:    "5s",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -100,29 +288,97 @@ func (c *CLI) getMonitorTransactionsCommand() *cli.Command {
 
 			c.logger.Info("Monitoring transactions", "follow", follow, "limit", limit, "type", txType)
 
-			// TODO: Implement transaction monitoring logic
-			fmt.Printf("💸 Transaction Monitor\n")
-			fmt.Printf("====================\n")
-			fmt.Printf("👁️  Follow: %t\n", follow)
-			fmt.Printf("📊 Limit: %d\n", limit)
-			fmt.Printf("📝 Type: %s\n", txType)
-
-			fmt.Printf("\n💸 Recent Transactions\n")
-			fmt.Printf("====================\n")
-			for i := 1; i <= limit; i++ {
-				fmt.Printf("%d. 💸 0xabc... - 0xdef... - 100.000000 DIO - 0.000420 DIO - 2024-01-15 10:%02d:00\n", i, 30-i+1)
+			ac := c.accountClient()
+			txs, err := ac.RecentTransactions(limit)
+			if err != nil {
+				return fmt.Errorf("failed to fetch recent transactions: %w", err)
 			}
 
-			if follow {
-				fmt.Printf("\n👀 Following new transactions... (Ctrl+C to stop)\n")
-				// TODO: Implement real-time transaction following
+			var views []TxView
+			for _, tx := range txs {
+				if !matchesTxType(tx, txType) {
+					continue
+				}
+				views = append(views, newTxView(tx))
+			}
+			if err := c.renderer.RenderList(views); err != nil {
+				return err
 			}
 
-			return nil
+			if !follow {
+				return nil
+			}
+
+			runCtx, cancel := c.followContext()
+			defer cancel()
+			return c.streamFollow(func(items chan<- interface{}) error {
+				return c.followTransactions(runCtx, ctx, ac, txType, items)
+			})
 		},
 	}
 }
 
+func matchesTxType(tx *rpcclient.TxInfo, txType string) bool {
+	switch txType {
+	case "", "send":
+		return true
+	case "contract":
+		return tx.To == nil
+	default:
+		return true
+	}
+}
+
+func (c *CLI) followTransactions(runCtx context.Context, ctx *cli.Context, ac *rpcclient.AccountClient, txType string, items chan<- interface{}) error {
+	if c.config.RPC.EnableWebSocket {
+		return c.followTransactionsWS(runCtx, ac, txType, items)
+	}
+	interval, err := followInterval(ctx)
+	if err != nil {
+		return err
+	}
+	seen := make(map[common.Hash]bool)
+	return monitor.Poll(runCtx, interval, func() error {
+		txs, err := ac.RecentTransactions(ctx.Int("limit"))
+		if err != nil {
+			return fmt.Errorf("failed to poll recent transactions: %w", err)
+		}
+		for _, tx := range txs {
+			if seen[tx.Hash] || !matchesTxType(tx, txType) {
+				continue
+			}
+			seen[tx.Hash] = true
+			items <- newTxView(tx)
+		}
+		return nil
+	})
+}
+
+func (c *CLI) followTransactionsWS(runCtx context.Context, ac *rpcclient.AccountClient, txType string, items chan<- interface{}) error {
+	sub, err := monitor.Dial(c.config.RPC.URL)
+	if err != nil {
+		return fmt.Errorf("failed to open transaction subscription: %w", err)
+	}
+	defer sub.Close()
+
+	payloads, err := sub.Subscribe(runCtx, "pendingTransactions")
+	if err != nil {
+		return err
+	}
+	for payload := range payloads {
+		var hexHash string
+		if err := json.Unmarshal(payload, &hexHash); err != nil {
+			continue
+		}
+		tx, err := ac.TransactionByHash(common.HexToHash(hexHash))
+		if err != nil || tx == nil || !matchesTxType(tx, txType) {
+			continue
+		}
+		items <- newTxView(tx)
+	}
+	return nil
+}
+
 // getMonitorValidatorsCommand returns the monitor validators command
 func (c *CLI) getMonitorValidatorsCommand() *cli.Command {
 	return &cli.Command{
@@ -130,42 +386,67 @@ func (c *CLI) getMonitorValidatorsCommand() *cli.Command {
 		Usage: "Monitor validator activity",
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
-				Name:    "follow",
-				Aliases: []string{"f"},
-				Usage:   "Follow validator activity in real-time",
-				Value:   false,
+				Name:     "follow",
+				Category: "Monitoring",
+				Aliases:  []string{"f"},
+				Usage:    "Follow validator activity in real-time",
+				Value:    false,
 			},
 			&cli.StringFlag{
-				Name:    "sort",
-				Aliases: []string{"s"},
-				Usage:   "Sort by (blocks, rewards, uptime)",
-				Value:   "blocks",
+				Name:     "sort",
+				Category: "Monitoring",
+				Aliases:  []string{"s"},
+				Usage:    "Sort by (stake, commission)",
+				Value:    "stake",
+			},
+			&cli.StringFlag{
+				Name:     "interval",
+				Category: "Monitoring",
+				Usage:    "Polling interval for --follow (validators has no WS push channel yet)",
+				Value:    "10s",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
 			follow := ctx.Bool("follow")
-			sort := ctx.String("sort")
+			sortBy := ctx.String("sort")
 
-			c.logger.Info("Monitoring validators", "follow", follow, "sort", sort)
+			c.logger.Info("Monitoring validators", "follow", follow, "sort", sortBy)
 
-			// TODO: Implement validator monitoring logic
-			fmt.Printf("🏛️  Validator Monitor\n")
-			fmt.Printf("====================\n")
-			fmt.Printf("👁️  Follow: %t\n", follow)
-			fmt.Printf("📊 Sort: %s\n", sort)
+			vc := c.validatorClient()
+			fetchValidators := func() ([]rpcclient.Validator, error) {
+				return vc.List(true, sortBy)
+			}
 
-			fmt.Printf("\n🏛️  Validator Activity\n")
-			fmt.Printf("====================\n")
-			fmt.Printf("1. 🏛️  Validator1 - 144 blocks - 1,234.567890 DIO - 99.98% uptime\n")
-			fmt.Printf("2. 🏛️  Validator2 - 143 blocks - 1,234.567890 DIO - 99.95% uptime\n")
-			fmt.Printf("3. 🏛️  Validator3 - 142 blocks - 1,234.567890 DIO - 99.99% uptime\n")
+			validators, err := fetchValidators()
+			if err != nil {
+				return fmt.Errorf("failed to fetch validators: %w", err)
+			}
+			if err := c.renderer.RenderList(validators); err != nil {
+				return err
+			}
 
-			if follow {
-				fmt.Printf("\n👀 Following validator activity... (Ctrl+C to stop)\n")
-				// TODO: Implement real-time validator monitoring
+			if !follow {
+				return nil
 			}
 
-			return nil
+			interval, err := followInterval(ctx)
+			if err != nil {
+				return err
+			}
+			runCtx, cancel := c.followContext()
+			defer cancel()
+			return c.streamFollow(func(items chan<- interface{}) error {
+				return monitor.Poll(runCtx, interval, func() error {
+					validators, err := fetchValidators()
+					if err != nil {
+						return fmt.Errorf("failed to poll validators: %w", err)
+					}
+					for _, v := range validators {
+						items <- v
+					}
+					return nil
+				})
+			})
 		},
 	}
 }
@@ -177,16 +458,18 @@ func (c *CLI) getMonitorNetworkCommand() *cli.Command {
 		Usage: "Monitor network statistics",
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
-				Name:    "follow",
-				Aliases: []string{"f"},
-				Usage:   "Follow network stats in real-time",
-				Value:   false,
+				Name:     "follow",
+				Category: "Monitoring",
+				Aliases:  []string{"f"},
+				Usage:    "Follow network stats in real-time",
+				Value:    false,
 			},
 			&cli.StringFlag{
-				Name:    "interval",
-				Aliases: []string{"i"},
-				Usage:   "Update interval (5s, 10s, 30s, 1m)",
-				Value:   "10s",
+				Name:     "interval",
+				Category: "Monitoring",
+				Aliases:  []string{"i"},
+				Usage:    "Update interval (5s, 10s, 30s, 1m); network has no WS push channel yet",
+				Value:    "10s",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -195,28 +478,54 @@ func (c *CLI) getMonitorNetworkCommand() *cli.Command {
 
 			c.logger.Info("Monitoring network", "follow", follow, "interval", interval)
 
-			// TODO: Implement network monitoring logic
-			fmt.Printf("🌐 Network Monitor\n")
-			fmt.Printf("==================\n")
-			fmt.Printf("👁️  Follow: %t\n", follow)
-			fmt.Printf("⏱️  Interval: %s\n", interval)
-
-			fmt.Printf("\n🌐 Network Statistics\n")
-			fmt.Printf("====================\n")
-			fmt.Printf("📊 Block Height: 1,234,567\n")
-			fmt.Printf("💸 TPS: 856\n")
-			fmt.Printf("⛽ Gas Price: 20 Gwei\n")
-			fmt.Printf("👥 Active Accounts: 12,345\n")
-			fmt.Printf("🏛️  Active Validators: 42\n")
-			fmt.Printf("📡 Peers: 25 connected\n")
-			fmt.Printf("💰 Total Value: 45,678,901 DIO\n")
-
-			if follow {
-				fmt.Printf("\n👀 Following network stats... (Ctrl+C to stop)\n")
-				// TODO: Implement real-time network monitoring
+			ac := c.accountClient()
+			ad := c.adminClient()
+			fetchStats := func() (NetworkStatsView, error) {
+				stats, err := ac.NetworkStats()
+				if err != nil {
+					return NetworkStatsView{}, fmt.Errorf("failed to fetch network stats: %w", err)
+				}
+				peers, err := ad.Peers()
+				if err != nil {
+					return NetworkStatsView{}, fmt.Errorf("failed to fetch peers: %w", err)
+				}
+				return NetworkStatsView{
+					BlockHeight:      stats.BlockNumber,
+					TPS:              fmt.Sprintf("%.2f", stats.TPS),
+					GasPriceWei:      stats.GasPrice.String(),
+					ActiveValidators: stats.ActiveValidators,
+					PeerCount:        len(peers),
+				}, nil
 			}
 
-			return nil
+			view, err := fetchStats()
+			if err != nil {
+				return err
+			}
+			if err := c.renderer.RenderObject(view); err != nil {
+				return err
+			}
+
+			if !follow {
+				return nil
+			}
+
+			parsedInterval, err := followInterval(ctx)
+			if err != nil {
+				return err
+			}
+			runCtx, cancel := c.followContext()
+			defer cancel()
+			return c.streamFollow(func(items chan<- interface{}) error {
+				return monitor.Poll(runCtx, parsedInterval, func() error {
+					view, err := fetchStats()
+					if err != nil {
+						return err
+					}
+					items <- view
+					return nil
+				})
+			})
 		},
 	}
 }
@@ -228,16 +537,24 @@ func (c *CLI) getMonitorGasCommand() *cli.Command {
 		Usage: "Monitor gas prices and usage",
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
-				Name:    "follow",
-				Aliases: []string{"f"},
-				Usage:   "Follow gas prices in real-time",
-				Value:   false,
+				Name:     "follow",
+				Category: "Monitoring",
+				Aliases:  []string{"f"},
+				Usage:    "Follow gas prices in real-time",
+				Value:    false,
+			},
+			&cli.StringFlag{
+				Name:     "period",
+				Category: "Monitoring",
+				Aliases:  []string{"p"},
+				Usage:    "Time period (1h, 6h, 24h, 7d)",
+				Value:    "24h",
 			},
 			&cli.StringFlag{
-				Name:    "period",
-				Aliases: []string{"p"},
-				Usage:   "Time period (1h, 6h, 24h, 7d)",
-				Value:   "24h",
+				Name:     "interval",
+				Category: "Monitoring",
+				Usage:    "Polling interval for --follow (gas has no WS push channel yet)",
+				Value:    "10s",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -246,36 +563,69 @@ func (c *CLI) getMonitorGasCommand() *cli.Command {
 
 			c.logger.Info("Monitoring gas", "follow", follow, "period", period)
 
-			// TODO: Implement gas monitoring logic
-			fmt.Printf("⛽ Gas Monitor\n")
-			fmt.Printf("==============\n")
-			fmt.Printf("👁️  Follow: %t\n", follow)
-			fmt.Printf("⏱️  Period: %s\n", period)
-
-			fmt.Printf("\n⛽ Gas Statistics (%s)\n", period)
-			fmt.Printf("========================\n")
-			fmt.Printf("📊 Current Price: 20 Gwei\n")
-			fmt.Printf("📈 Average Price: 22 Gwei\n")
-			fmt.Printf("📉 Min Price: 15 Gwei\n")
-			fmt.Printf("📈 Max Price: 35 Gwei\n")
-			fmt.Printf("💸 Total Gas Used: 1,234,567,890\n")
-			fmt.Printf("📊 Gas Limit: 15,000,000\n")
-			fmt.Printf("📈 Utilization: 82.3%\n")
-
-			fmt.Printf("\n⛽ Gas Price History\n")
-			fmt.Printf("===================\n")
-			fmt.Printf("📊 1h ago: 18 Gwei\n")
-			fmt.Printf("📊 2h ago: 19 Gwei\n")
-			fmt.Printf("📊 3h ago: 21 Gwei\n")
-			fmt.Printf("📊 4h ago: 23 Gwei\n")
-			fmt.Printf("📊 5h ago: 20 Gwei\n")
-
-			if follow {
-				fmt.Printf("\n👀 Following gas prices... (Ctrl+C to stop)\n")
-				// TODO: Implement real-time gas monitoring
+			ac := c.accountClient()
+			fetchGas := func() (GasView, error) {
+				price, err := ac.GasPrice()
+				if err != nil {
+					return GasView{}, fmt.Errorf("failed to fetch gas price: %w", err)
+				}
+				history, err := ac.GasPriceHistory(period)
+				if err != nil {
+					return GasView{}, fmt.Errorf("failed to fetch gas price history: %w", err)
+				}
+				return GasView{
+					Period:       period,
+					CurrentPrice: price.String(),
+					History:      newGasPointViews(history),
+				}, nil
 			}
 
-			return nil
+			view, err := fetchGas()
+			if err != nil {
+				return err
+			}
+			if err := c.renderer.RenderObject(view); err != nil {
+				return err
+			}
+
+			if !follow {
+				return nil
+			}
+
+			interval, err := followInterval(ctx)
+			if err != nil {
+				return err
+			}
+			runCtx, cancel := c.followContext()
+			defer cancel()
+			return c.streamFollow(func(items chan<- interface{}) error {
+				return monitor.Poll(runCtx, interval, func() error {
+					view, err := fetchGas()
+					if err != nil {
+						return err
+					}
+					items <- view
+					return nil
+				})
+			})
 		},
 	}
 }
+
+func newGasPointViews(history []rpcclient.GasPricePoint) []GasPointView {
+	views := make([]GasPointView, len(history))
+	for i, point := range history {
+		views[i] = GasPointView{
+			Timestamp: time.Unix(point.Timestamp, 0).Format("2006-01-02 15:04:05"),
+			PriceWei:  priceString(point.Price),
+		}
+	}
+	return views
+}
+
+func priceString(price *big.Int) string {
+	if price == nil {
+		return "0"
+	}
+	return price.String()
+}
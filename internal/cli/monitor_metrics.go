@@ -0,0 +1,322 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DioraFund/diora-go-blockchain/internal/monitor"
+	"github.com/DioraFund/diora-go-blockchain/internal/rpcclient"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/push"
+	"github.com/urfave/cli/v2"
+)
+
+// metricsExporter holds the Prometheus gauges `monitor metrics` exposes, in
+// its own prometheus.Registry scoped to --namespace rather than the
+// package-level default registry internal/logger/metrics.go registers
+// logsEmittedTotal on — a process only ever runs one `monitor metrics`
+// exporter per invocation, and its namespace is a runtime flag, not a
+// compile-time constant, so it can't reuse that package's init()-time
+// MustRegister pattern.
+type metricsExporter struct {
+	registry        *prometheus.Registry
+	blockHeight     prometheus.Gauge
+	tps             prometheus.Gauge
+	gasPriceGwei    prometheus.Gauge
+	gasUtilization  prometheus.Gauge
+	peersConnected  prometheus.Gauge
+	validatorUptime *prometheus.GaugeVec
+}
+
+// newMetricsExporter builds the gauges monitor metrics's body describes
+// (diora_block_height, diora_tps, diora_gas_price_gwei,
+// diora_peers_connected, diora_validator_uptime{validator=...},
+// diora_gas_utilization), all named under namespace.
+func newMetricsExporter(namespace string) *metricsExporter {
+	e := &metricsExporter{registry: prometheus.NewRegistry()}
+	e.blockHeight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "block_height",
+		Help:      "Latest block number observed.",
+	})
+	e.tps = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "tps",
+		Help:      "Transactions per second, as reported by diora_networkStats.",
+	})
+	e.gasPriceGwei = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "gas_price_gwei",
+		Help:      "Current suggested gas price, in Gwei.",
+	})
+	e.gasUtilization = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "gas_utilization",
+		Help:      "Most recently observed block's gasUsed/gasLimit ratio.",
+	})
+	e.peersConnected = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "peers_connected",
+		Help:      "Number of peers currently connected.",
+	})
+	e.validatorUptime = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "validator_uptime",
+		Help:      "Per-validator uptime percentage.",
+	}, []string{"validator"})
+
+	e.registry.MustRegister(e.blockHeight, e.tps, e.gasPriceGwei, e.gasUtilization, e.peersConnected, e.validatorUptime)
+	return e
+}
+
+// observeBlock updates diora_block_height/diora_gas_utilization from b, the
+// per-newHeads update path followMetricsBlocks drives.
+func (e *metricsExporter) observeBlock(b *rpcclient.BlockSummary) {
+	e.blockHeight.Set(float64(b.Number))
+	if b.GasLimit > 0 {
+		e.gasUtilization.Set(float64(b.GasUsed) / float64(b.GasLimit))
+	}
+}
+
+// observeNetwork updates diora_tps/diora_gas_price_gwei/diora_peers_connected
+// from a diora_networkStats + admin_peers poll.
+func (e *metricsExporter) observeNetwork(stats *rpcclient.NetworkStats, peerCount int) {
+	e.tps.Set(stats.TPS)
+	if stats.GasPrice != nil {
+		gwei, _ := new(big.Float).Quo(new(big.Float).SetInt(stats.GasPrice), big.NewFloat(1e9)).Float64()
+		e.gasPriceGwei.Set(gwei)
+	}
+	e.peersConnected.Set(float64(peerCount))
+}
+
+// observeValidatorUptime updates diora_validator_uptime{validator=addr} for
+// one validator's validator_info response.
+func (e *metricsExporter) observeValidatorUptime(info *rpcclient.ValidatorInfo) {
+	pct, err := strconv.ParseFloat(strings.TrimSuffix(info.Uptime, "%"), 64)
+	if err != nil {
+		return
+	}
+	e.validatorUptime.WithLabelValues(info.Address.Hex()).Set(pct)
+}
+
+// Handler serves the registry in Prometheus text exposition format.
+func (e *metricsExporter) Handler() http.Handler {
+	return promhttp.HandlerFor(e.registry, promhttp.HandlerOpts{})
+}
+
+// push gathers the registry and pushes it to gatewayURL under job "diora",
+// the one-shot counterpart to Handler for operators who scrape via
+// Pushgateway instead of pulling straight from --listen.
+func (e *metricsExporter) push(gatewayURL string) error {
+	return push.New(gatewayURL, "diora").Gatherer(e.registry).Push()
+}
+
+// getMonitorMetricsCommand returns the monitor metrics command: a
+// Prometheus exporter fed by the same WS/poll plumbing --follow sessions
+// use, so its gauges update on every new head rather than on a fixed
+// scrape-only poll.
+func (c *CLI) getMonitorMetricsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "metrics",
+		Usage: "Run a Prometheus metrics exporter for chain activity",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "listen",
+				Category: "Monitoring",
+				Usage:    "Address the metrics HTTP server listens on",
+				Value:    ":9109",
+			},
+			&cli.StringFlag{
+				Name:     "path",
+				Category: "Monitoring",
+				Usage:    "HTTP path metrics are served on",
+				Value:    "/metrics",
+			},
+			&cli.StringFlag{
+				Name:     "namespace",
+				Category: "Monitoring",
+				Usage:    "Prometheus metric name prefix",
+				Value:    "diora",
+			},
+			&cli.StringFlag{
+				Name:     "push-gateway",
+				Category: "Monitoring",
+				Usage:    "Pushgateway URL to also push metrics to; the --listen server still runs",
+				Value:    "",
+			},
+			&cli.StringFlag{
+				Name:     "interval",
+				Category: "Monitoring",
+				Usage:    "Refresh interval for metrics with no WS push channel (validators, peers, gas) and for --push-gateway pushes",
+				Value:    "10s",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			namespace := ctx.String("namespace")
+			interval, err := followInterval(ctx)
+			if err != nil {
+				return err
+			}
+
+			exp := newMetricsExporter(namespace)
+			runCtx, cancel := c.followContext()
+			defer cancel()
+
+			ac := c.accountClient()
+			ad := c.adminClient()
+			vc := c.validatorClient()
+
+			errCh := make(chan error, 4)
+			go func() { errCh <- c.followMetricsBlocks(runCtx, ctx, ac, exp) }()
+			go func() {
+				errCh <- monitor.Poll(runCtx, interval, func() error {
+					return pollNetworkMetrics(ac, ad, exp)
+				})
+			}()
+			go func() {
+				errCh <- monitor.Poll(runCtx, interval, func() error {
+					return pollValidatorMetrics(vc, exp)
+				})
+			}()
+
+			if gateway := ctx.String("push-gateway"); gateway != "" {
+				c.logger.Info("Pushing metrics", "gateway", gateway, "interval", interval)
+				go func() {
+					errCh <- monitor.Poll(runCtx, interval, func() error {
+						if err := exp.push(gateway); err != nil {
+							return fmt.Errorf("failed to push metrics to %s: %w", gateway, err)
+						}
+						return nil
+					})
+				}()
+			}
+
+			path := ctx.String("path")
+			mux := http.NewServeMux()
+			mux.Handle(path, exp.Handler())
+			srv := &http.Server{Addr: ctx.String("listen"), Handler: mux}
+
+			go func() {
+				<-runCtx.Done()
+				shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer shutdownCancel()
+				srv.Shutdown(shutdownCtx)
+			}()
+
+			c.logger.Info("Serving metrics", "listen", ctx.String("listen"), "path", path)
+			if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				return fmt.Errorf("metrics server failed: %w", err)
+			}
+
+			for i := 0; i < cap(errCh); i++ {
+				select {
+				case err := <-errCh:
+					if err != nil {
+						return err
+					}
+				default:
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// followMetricsBlocks keeps diora_block_height/diora_gas_utilization
+// current via the same WS-with-polling-fallback plumbing `monitor blocks
+// --follow` uses, updating exp directly instead of rendering each block.
+func (c *CLI) followMetricsBlocks(runCtx context.Context, ctx *cli.Context, ac *rpcclient.AccountClient, exp *metricsExporter) error {
+	if c.config.RPC.EnableWebSocket {
+		return c.followMetricsBlocksWS(runCtx, ac, exp)
+	}
+	interval, err := followInterval(ctx)
+	if err != nil {
+		return err
+	}
+	last := uint64(0)
+	return monitor.Poll(runCtx, interval, func() error {
+		head, err := ac.BlockNumber()
+		if err != nil {
+			return fmt.Errorf("failed to poll block number: %w", err)
+		}
+		if last == 0 {
+			last = head
+		}
+		for n := last + 1; n <= head; n++ {
+			block, err := ac.BlockByNumber(n)
+			if err != nil {
+				return fmt.Errorf("failed to fetch block %d: %w", n, err)
+			}
+			if block != nil {
+				exp.observeBlock(block)
+			}
+		}
+		last = head
+		return nil
+	})
+}
+
+func (c *CLI) followMetricsBlocksWS(runCtx context.Context, ac *rpcclient.AccountClient, exp *metricsExporter) error {
+	sub, err := monitor.Dial(c.config.RPC.URL)
+	if err != nil {
+		return fmt.Errorf("failed to open block subscription for metrics: %w", err)
+	}
+	defer sub.Close()
+
+	payloads, err := sub.Subscribe(runCtx, "newHeads")
+	if err != nil {
+		return err
+	}
+	for payload := range payloads {
+		var head struct {
+			Number uint64 `json:"number"`
+		}
+		if err := json.Unmarshal(payload, &head); err != nil {
+			continue
+		}
+		block, err := ac.BlockByNumber(head.Number)
+		if err != nil || block == nil {
+			continue
+		}
+		exp.observeBlock(block)
+	}
+	return nil
+}
+
+// pollNetworkMetrics refreshes diora_tps/diora_gas_price_gwei/diora_peers_connected.
+func pollNetworkMetrics(ac *rpcclient.AccountClient, ad *rpcclient.AdminClient, exp *metricsExporter) error {
+	stats, err := ac.NetworkStats()
+	if err != nil {
+		return fmt.Errorf("failed to fetch network stats: %w", err)
+	}
+	peers, err := ad.Peers()
+	if err != nil {
+		return fmt.Errorf("failed to fetch peers: %w", err)
+	}
+	exp.observeNetwork(stats, len(peers))
+	return nil
+}
+
+// pollValidatorMetrics refreshes diora_validator_uptime for every active
+// validator.
+func pollValidatorMetrics(vc *rpcclient.ValidatorClient, exp *metricsExporter) error {
+	validators, err := vc.List(true, "stake")
+	if err != nil {
+		return fmt.Errorf("failed to fetch validators: %w", err)
+	}
+	for _, v := range validators {
+		info, err := vc.Info(v.Address)
+		if err != nil {
+			continue
+		}
+		exp.observeValidatorUptime(info)
+	}
+	return nil
+}
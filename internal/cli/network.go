@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/urfave/cli/v2"
 )
@@ -27,10 +28,11 @@ func (c *CLI) getNetworkStatusCommand() *cli.Command {
 		Usage: "Get network status",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:    "rpc-url",
-				Aliases: []string{"r"},
-				Usage:   "RPC endpoint URL",
-				Value:   c.config.RPC.URL,
+				Name:     "rpc-url",
+				Category: "Networking",
+				Aliases:  []string{"r"},
+				Usage:    "RPC endpoint URL",
+				Value:    c.config.RPC.URL,
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -38,6 +40,15 @@ func (c *CLI) getNetworkStatusCommand() *cli.Command {
 
 			c.logger.Info("Getting network status", "rpc_url", rpcURL)
 
+			haltHeight, halted, err := c.accountClient().HaltHeight()
+			if err != nil {
+				return fmt.Errorf("failed to fetch pending halt height: %w", err)
+			}
+			pendingHalt := "none"
+			if halted {
+				pendingHalt = strconv.FormatUint(haltHeight, 10)
+			}
+
 			// TODO: Implement network status logic
 			fmt.Printf("🌐 Network Status\n")
 			fmt.Printf("================\n")
@@ -49,6 +60,7 @@ func (c *CLI) getNetworkStatusCommand() *cli.Command {
 			fmt.Printf("📈 Network: Mainnet\n")
 			fmt.Printf("⏱️  Block Time: 6 seconds\n")
 			fmt.Printf("👥 Active Validators: 42\n")
+			fmt.Printf("🛑 Pending Halt Height: %s\n", pendingHalt)
 
 			return nil
 		},
@@ -62,10 +74,11 @@ func (c *CLI) getNetworkPeersCommand() *cli.Command {
 		Usage: "Get network peers information",
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
-				Name:    "detailed",
-				Aliases: []string{"d"},
-				Usage:   "Show detailed peer information",
-				Value:   false,
+				Name:     "detailed",
+				Category: "Networking",
+				Aliases:  []string{"d"},
+				Usage:    "Show detailed peer information",
+				Value:    false,
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -102,10 +115,11 @@ func (c *CLI) getNetworkStatsCommand() *cli.Command {
 		Usage: "Get network statistics",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:    "period",
-				Aliases: []string{"p"},
-				Usage:   "Time period (1h, 24h, 7d)",
-				Value:   "24h",
+				Name:     "period",
+				Category: "Networking",
+				Aliases:  []string{"p"},
+				Usage:    "Time period (1h, 24h, 7d)",
+				Value:    "24h",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -137,10 +151,11 @@ func (c *CLI) getNetworkSyncCommand() *cli.Command {
 		Usage: "Network synchronization information",
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
-				Name:    "watch",
-				Aliases: []string{"w"},
-				Usage:   "Watch sync progress in real-time",
-				Value:   false,
+				Name:     "watch",
+				Category: "Networking",
+				Aliases:  []string{"w"},
+				Usage:    "Watch sync progress in real-time",
+				Value:    false,
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/DioraFund/diora-go-blockchain/internal/rpcclient"
+	"github.com/urfave/cli/v2"
+)
+
+// adminClient builds an AdminClient against the configured node.
+func (c *CLI) adminClient() *rpcclient.AdminClient {
+	return rpcclient.NewAdminClient(c.config.RPC.URL, rpcTimeout)
+}
+
+// getNodeCommand returns the node command
+func (c *CLI) getNodeCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "node",
+		Usage: "Node identity and peer management",
+		Subcommands: []*cli.Command{
+			c.getNodeInfoCommand(),
+			c.getNodePeersCommand(),
+			c.getNodeConnectCommand(),
+		},
+	}
+}
+
+// getNodeInfoCommand returns the node info command
+func (c *CLI) getNodeInfoCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "info",
+		Usage: "Show this node's identity and chain head (admin_nodeInfo)",
+		Flags: []cli.Flag{
+			outputFlag(),
+		},
+		Action: func(ctx *cli.Context) error {
+			c.logger.Info("Getting node info")
+
+			info, err := c.adminClient().NodeInfo()
+			if err != nil {
+				return fmt.Errorf("failed to get node info: %w", err)
+			}
+
+			if ctx.String("output") == "json" {
+				return printJSON(info)
+			}
+
+			fmt.Printf("🖥️  Node Info\n")
+			fmt.Printf("===========\n")
+			fmt.Printf("🆔 ID: %s\n", info.ID)
+			fmt.Printf("🔗 Enode: %s\n", info.Enode)
+			fmt.Printf("📡 Listen Address: %s\n", info.ListenAddr)
+			fmt.Printf("📛 Name: %s\n", info.Name)
+			fmt.Printf("🧩 Protocols: %v\n", info.Protocols)
+			fmt.Printf("⛓️  Chain ID: %s\n", info.ChainID)
+			fmt.Printf("🌐 Network ID: %s\n", info.NetworkID)
+			fmt.Printf("📦 Head Block: %s (%s)\n", info.HeadBlock, info.HeadBlockHash)
+
+			return nil
+		},
+	}
+}
+
+// getNodePeersCommand returns the node peers command
+func (c *CLI) getNodePeersCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "peers",
+		Usage: "List this node's registered peers (admin_peers)",
+		Flags: []cli.Flag{
+			outputFlag(),
+		},
+		Action: func(ctx *cli.Context) error {
+			c.logger.Info("Getting node peers")
+
+			peers, err := c.adminClient().Peers()
+			if err != nil {
+				return fmt.Errorf("failed to get node peers: %w", err)
+			}
+
+			if ctx.String("output") == "json" {
+				return printJSON(peers)
+			}
+
+			fmt.Printf("👥 Node Peers\n")
+			fmt.Printf("============\n")
+			fmt.Printf("📊 Total: %d\n\n", len(peers))
+			for i, p := range peers {
+				trusted := ""
+				if p.Trusted {
+					trusted = " (trusted)"
+				}
+				fmt.Printf("%d. %s%s\n", i+1, p.Enode, trusted)
+			}
+
+			return nil
+		},
+	}
+}
+
+// getNodeConnectCommand returns the node connect command
+func (c *CLI) getNodeConnectCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "connect",
+		Usage:     "Register a peer by its enode URL (admin_addPeer)",
+		ArgsUsage: "<enode>",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:     "trusted",
+				Category: "Networking",
+				Aliases:  []string{"t"},
+				Usage:    "Register the peer as trusted (admin_addTrustedPeer)",
+				Value:    false,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			enode := ctx.Args().First()
+			if enode == "" {
+				return fmt.Errorf("usage: diora node connect <enode>")
+			}
+
+			c.logger.Info("Connecting to peer", "enode", enode)
+
+			client := c.adminClient()
+			if ctx.Bool("trusted") {
+				if err := client.AddTrustedPeer(enode); err != nil {
+					return fmt.Errorf("failed to add trusted peer: %w", err)
+				}
+			} else if err := client.AddPeer(enode); err != nil {
+				return fmt.Errorf("failed to add peer: %w", err)
+			}
+
+			fmt.Printf("✅ Registered peer: %s\n", enode)
+			return nil
+		},
+	}
+}
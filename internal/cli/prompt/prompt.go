@@ -0,0 +1,104 @@
+// Package prompt provides TTY-safe interactive prompts for destructive or
+// secret-bearing CLI commands (config reset, and any future wallet
+// delete/account remove): a confirmation, a masked password read, and a
+// numbered selection, each refusing to block on a stdin no one can answer
+// instead of silently treating EOF as an answer.
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// AssumeYesEnv is the environment variable that, set to a truthy value,
+// answers every Confirm prompt "yes" without touching stdin - the
+// non-flag escape hatch for pipelines that can't thread a --yes through
+// (Makefiles, CI step configs).
+const AssumeYesEnv = "DIORA_ASSUME_YES"
+
+// AssumeYes reports whether DIORA_ASSUME_YES is set to a truthy value.
+func AssumeYes() bool {
+	v, ok := os.LookupEnv(AssumeYesEnv)
+	if !ok {
+		return false
+	}
+	b, err := strconv.ParseBool(v)
+	return err == nil && b
+}
+
+// Confirm asks msg as a yes/no question. assumeYes (typically a command's
+// own --yes/--assume-yes flag, OR'd with AssumeYes() by the caller)
+// answers true without reading stdin. Otherwise, if stdin isn't a
+// terminal, Confirm refuses to prompt and returns an error rather than
+// letting a CI pipeline's closed stdin read as EOF and silently proceed
+// (or silently abort) a destructive command.
+func Confirm(msg string, assumeYes bool) (bool, error) {
+	if assumeYes {
+		return true, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, fmt.Errorf("prompt: refusing to prompt on a non-interactive stdin; pass --yes or set %s=1", AssumeYesEnv)
+	}
+	return readConfirm(os.Stdin, os.Stdout, msg)
+}
+
+func readConfirm(in io.Reader, out io.Writer, msg string) (bool, error) {
+	fmt.Fprintf(out, "%s [y/N]: ", msg)
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes", nil
+}
+
+// ReadPassword prompts msg and reads a line from stdin without echoing it.
+// Returns an error if stdin isn't a terminal, the same TTY-safety rule
+// Confirm applies.
+func ReadPassword(msg string) (string, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("prompt: refusing to read a password from a non-interactive stdin")
+	}
+	fmt.Fprintf(os.Stdout, "%s: ", msg)
+	b, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stdout)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+	return string(b), nil
+}
+
+// Select asks the user to choose one of options by number and returns the
+// chosen option, for disambiguating between several matches (e.g. more
+// than one keystore account matching a partial address) instead of
+// failing outright. Refuses to prompt on a non-interactive stdin.
+func Select(msg string, options []string) (string, error) {
+	if len(options) == 0 {
+		return "", fmt.Errorf("prompt: %s: no options to choose from", msg)
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("prompt: refusing to prompt on a non-interactive stdin; pass a more specific value instead")
+	}
+
+	fmt.Println(msg)
+	for i, opt := range options {
+		fmt.Printf("  %d) %s\n", i+1, opt)
+	}
+	fmt.Print("Enter a number: ")
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil || n < 1 || n > len(options) {
+		return "", fmt.Errorf("prompt: invalid selection %q", strings.TrimSpace(line))
+	}
+	return options[n-1], nil
+}
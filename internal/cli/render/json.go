@@ -0,0 +1,73 @@
+package render
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+)
+
+type jsonRenderer struct {
+	w io.Writer
+}
+
+func (r *jsonRenderer) encode(v interface{}) error {
+	enc := json.NewEncoder(r.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func (r *jsonRenderer) RenderObject(v interface{}) error {
+	return r.encode(v)
+}
+
+func (r *jsonRenderer) RenderList(items interface{}) error {
+	return r.encode(items)
+}
+
+func (r *jsonRenderer) RenderStream(items <-chan interface{}) error {
+	for item := range items {
+		if err := r.encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ndjsonRenderer is RenderStream's natural format: one compact JSON object
+// per line rather than one indented document for the whole run, so a
+// `monitor blocks --follow -o ndjson` session can be piped into jq/grep
+// line by line as each block arrives.
+type ndjsonRenderer struct {
+	w io.Writer
+}
+
+func (r *ndjsonRenderer) encodeLine(v interface{}) error {
+	enc := json.NewEncoder(r.w)
+	return enc.Encode(v)
+}
+
+func (r *ndjsonRenderer) RenderObject(v interface{}) error {
+	return r.encodeLine(v)
+}
+
+func (r *ndjsonRenderer) RenderList(items interface{}) error {
+	val := reflect.ValueOf(items)
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return r.encodeLine(items)
+	}
+	for i := 0; i < val.Len(); i++ {
+		if err := r.encodeLine(val.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ndjsonRenderer) RenderStream(items <-chan interface{}) error {
+	for item := range items {
+		if err := r.encodeLine(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Package render gives the CLI one place to decide how a command's result
+// gets printed, instead of every Action hand-rolling its own fmt.Printf
+// block: a Renderer picks table (a plain "Field: value" human view; see
+// tableRenderer), json, yaml, or ndjson (one JSON object per line, for
+// `monitor --follow` and other streaming output) based on the global
+// --output flag.
+package render
+
+import (
+	"fmt"
+	"io"
+)
+
+// Format is a --output value this package knows how to render.
+type Format string
+
+const (
+	FormatTable  Format = "table"
+	FormatJSON   Format = "json"
+	FormatYAML   Format = "yaml"
+	FormatNDJSON Format = "ndjson"
+)
+
+// ParseFormat validates a --output flag value, defaulting an empty string
+// to FormatTable so callers don't need their own zero-value check.
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case "", FormatTable:
+		return FormatTable, nil
+	case FormatJSON, FormatYAML, FormatNDJSON:
+		return Format(s), nil
+	default:
+		return "", fmt.Errorf("invalid --output %q (want table, json, yaml, or ndjson)", s)
+	}
+}
+
+// Renderer prints a command's result in one Format. RenderObject is for a
+// single result (`node info`, `config show --section rpc`); RenderList for
+// a slice of them (`validator list`); RenderStream for a live sequence a
+// --follow session produces, rendering (and flushing) each item as it
+// arrives rather than buffering the whole run.
+type Renderer interface {
+	RenderObject(v interface{}) error
+	RenderList(items interface{}) error
+	RenderStream(items <-chan interface{}) error
+}
+
+// New returns the Renderer for format, writing to w. quiet suppresses
+// table mode's blank-line separators between list entries; it has no
+// effect on json/yaml/ndjson, which are already as terse as the data
+// allows.
+func New(format Format, w io.Writer, quiet bool) Renderer {
+	switch format {
+	case FormatJSON:
+		return &jsonRenderer{w: w}
+	case FormatYAML:
+		return &yamlRenderer{w: w}
+	case FormatNDJSON:
+		return &ndjsonRenderer{w: w}
+	default:
+		return &tableRenderer{w: w, quiet: quiet}
+	}
+}
@@ -0,0 +1,109 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// tableRenderer is the --output table default: a plain "Field: value" dump
+// of a result's exported fields, reflected rather than hand-printed per
+// command. It deliberately doesn't reproduce the emoji-decorated headers
+// individual commands used to print by hand — those were one-off per
+// field and can't be derived generically from a struct; table mode here
+// is the structured renderer's plain fallback, not a replacement for
+// every command's existing human-facing formatting.
+type tableRenderer struct {
+	w     io.Writer
+	quiet bool
+}
+
+func (t *tableRenderer) RenderObject(v interface{}) error {
+	writeFields(t.w, "", reflect.ValueOf(v))
+	return nil
+}
+
+func (t *tableRenderer) RenderList(items interface{}) error {
+	val := reflect.ValueOf(items)
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return fmt.Errorf("render: RenderList needs a slice, got %T", items)
+	}
+	for i := 0; i < val.Len(); i++ {
+		if i > 0 && !t.quiet {
+			fmt.Fprintln(t.w)
+		}
+		writeFields(t.w, "", val.Index(i))
+	}
+	return nil
+}
+
+func (t *tableRenderer) RenderStream(items <-chan interface{}) error {
+	first := true
+	for item := range items {
+		if !first && !t.quiet {
+			fmt.Fprintln(t.w)
+		}
+		first = false
+		writeFields(t.w, "", reflect.ValueOf(item))
+	}
+	return nil
+}
+
+// stringer returns v's fmt.Stringer implementation, if it has one — the
+// escape hatch that lets *big.Int, common.Hash, and common.Address (all
+// structs or pointers-to-struct with unexported fields) render as their
+// usual string form instead of writeFields trying to reflect into them.
+func stringer(v reflect.Value) (fmt.Stringer, bool) {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil, false
+	}
+	s, ok := v.Interface().(fmt.Stringer)
+	return s, ok
+}
+
+// writeFields writes v (a struct, or anything nested inside one) as
+// "Field: value" lines, indented by indent and recursing one level deeper
+// per nested struct.
+func writeFields(w io.Writer, indent string, v reflect.Value) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			fmt.Fprintf(w, "%s<none>\n", indent)
+			return
+		}
+		v = v.Elem()
+	}
+	if s, ok := stringer(v); ok {
+		fmt.Fprintf(w, "%s%s\n", indent, s.String())
+		return
+	}
+	if v.Kind() != reflect.Struct {
+		fmt.Fprintf(w, "%s%v\n", indent, v.Interface())
+		return
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		fv := v.Field(i)
+
+		if s, ok := stringer(fv); ok {
+			fmt.Fprintf(w, "%s%s: %s\n", indent, field.Name, s.String())
+			continue
+		}
+
+		deref := fv
+		for deref.Kind() == reflect.Ptr && !deref.IsNil() {
+			deref = deref.Elem()
+		}
+		if deref.Kind() == reflect.Struct {
+			fmt.Fprintf(w, "%s%s:\n", indent, field.Name)
+			writeFields(w, indent+"  ", fv)
+			continue
+		}
+
+		fmt.Fprintf(w, "%s%s: %v\n", indent, field.Name, fv.Interface())
+	}
+}
@@ -0,0 +1,39 @@
+package render
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+type yamlRenderer struct {
+	w io.Writer
+}
+
+func (r *yamlRenderer) RenderObject(v interface{}) error {
+	enc := yaml.NewEncoder(r.w)
+	defer enc.Close()
+	return enc.Encode(v)
+}
+
+func (r *yamlRenderer) RenderList(items interface{}) error {
+	return r.RenderObject(items)
+}
+
+func (r *yamlRenderer) RenderStream(items <-chan interface{}) error {
+	// A --follow session's length isn't known up front, so each item is
+	// its own "---"-separated document, encoded as it arrives, rather
+	// than buffering the whole run into one list.
+	for item := range items {
+		if _, err := io.WriteString(r.w, "---\n"); err != nil {
+			return err
+		}
+		enc := yaml.NewEncoder(r.w)
+		if err := enc.Encode(item); err != nil {
+			enc.Close()
+			return err
+		}
+		enc.Close()
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// compiledContract is one contract solc produced from a source file: its
+// ABI plus creation bytecode (constructor + init code) and runtime bytecode
+// (what ends up stored at the deployed address), both hex-decoded.
+type compiledContract struct {
+	Name             string
+	ABI              json.RawMessage
+	Bytecode         []byte
+	DeployedBytecode []byte
+}
+
+// solcBinary resolves which solc binary to invoke for compiler, a version
+// string like "0.8.26". It prefers a version-pinned "solc-<compiler>" on
+// PATH (the naming solc-select installs under) and falls back to a bare
+// "solc", so a host with only one compiler installed still works without
+// --compiler needing to match it exactly.
+func solcBinary(compiler string) string {
+	if compiler != "" {
+		if path, err := exec.LookPath("solc-" + compiler); err == nil {
+			return path
+		}
+	}
+	return "solc"
+}
+
+// compiledContractEntry is one contract within solc --combined-json's
+// top-level "contracts" map, keyed "path/to/file.sol:ContractName".
+type compiledContractEntry struct {
+	ABI        json.RawMessage `json:"abi"`
+	Bin        string          `json:"bin"`
+	BinRuntime string          `json:"bin-runtime"`
+}
+
+// compileSolidity invokes solc on path, honoring compiler (which binary to
+// run) and optimizerRuns (a --optimize-runs count; "0" or unparsable skips
+// optimization). contractName disambiguates which contract to return when
+// path defines more than one; it may be empty if path defines exactly one.
+func compileSolidity(path, compiler, optimizerRuns, contractName string) (*compiledContract, error) {
+	args := []string{"--combined-json", "abi,bin,bin-runtime"}
+	if runs, err := strconv.Atoi(optimizerRuns); err == nil && runs > 0 {
+		args = append(args, "--optimize", "--optimize-runs", strconv.Itoa(runs))
+	}
+	args = append(args, path)
+
+	cmd := exec.Command(solcBinary(compiler), args...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("solc compilation of %s failed: %w\n%s", path, err, out)
+	}
+
+	var parsed struct {
+		Contracts map[string]compiledContractEntry `json:"contracts"`
+	}
+	if err := json.Unmarshal(out, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse solc output for %s: %w", path, err)
+	}
+
+	key, entry, err := selectCompiledContract(parsed.Contracts, contractName)
+	if err != nil {
+		return nil, err
+	}
+
+	name := key
+	if i := strings.LastIndex(key, ":"); i >= 0 {
+		name = key[i+1:]
+	}
+	return &compiledContract{
+		Name:             name,
+		ABI:              entry.ABI,
+		Bytecode:         common.FromHex(entry.Bin),
+		DeployedBytecode: common.FromHex(entry.BinRuntime),
+	}, nil
+}
+
+// selectCompiledContract picks contractName's entry out of contracts
+// ("path/to/file.sol:ContractName" keys), or the sole entry if contractName
+// is empty and contracts defines exactly one.
+func selectCompiledContract(contracts map[string]compiledContractEntry, contractName string) (string, compiledContractEntry, error) {
+	if contractName != "" {
+		for key, entry := range contracts {
+			if key == contractName || strings.HasSuffix(key, ":"+contractName) {
+				return key, entry, nil
+			}
+		}
+		return "", compiledContractEntry{}, fmt.Errorf("no contract named %q in solc output", contractName)
+	}
+	if len(contracts) == 1 {
+		for key, entry := range contracts {
+			return key, entry, nil
+		}
+	}
+	names := make([]string, 0, len(contracts))
+	for key := range contracts {
+		names = append(names, key)
+	}
+	return "", compiledContractEntry{}, fmt.Errorf("source defines %d contracts %v, specify one with --contract", len(contracts), names)
+}
+
+// stripMetadata trims the CBOR-encoded compiler-metadata suffix solc
+// appends to every contract's runtime bytecode since 0.5.9 (the CBOR data
+// followed by a 2-byte big-endian length of that data, not counting the 2
+// length bytes themselves, at the very end of the code). Comparing bytecode after stripping lets `contract verify`
+// match a recompile against what the node actually has on chain even when
+// the embedded IPFS/metadata hash differs (e.g. a different solc build, or
+// metadata stripped at deploy time).
+func stripMetadata(code []byte) []byte {
+	if len(code) < 2 {
+		return code
+	}
+	n := int(code[len(code)-2])<<8 | int(code[len(code)-1])
+	if n+2 > len(code) {
+		return code
+	}
+	return code[:len(code)-n-2]
+}
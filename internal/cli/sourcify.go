@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// submitSourcifyVerification POSTs address's source and ABI to a
+// Sourcify-compatible endpoint's /verify route as multipart form data, the
+// same shape Sourcify's own "check by address" upload form submits.
+func submitSourcifyVerification(endpointURL string, address common.Address, sourcePath string, compiled *compiledContract) error {
+	source, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to read source %s: %w", sourcePath, err)
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if err := w.WriteField("address", address.Hex()); err != nil {
+		return err
+	}
+	sourceField, err := w.CreateFormFile("files", filepath.Base(sourcePath))
+	if err != nil {
+		return err
+	}
+	if _, err := sourceField.Write(source); err != nil {
+		return err
+	}
+	abiField, err := w.CreateFormFile("files", "metadata.json")
+	if err != nil {
+		return err
+	}
+	if _, err := abiField.Write(compiled.ABI); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpointURL+"/verify", &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", endpointURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s responded with status %s", endpointURL, resp.Status)
+	}
+	return nil
+}
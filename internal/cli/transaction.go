@@ -1,11 +1,82 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"math/big"
+	"os"
+	"strconv"
 
+	"github.com/DioraFund/diora-go-blockchain/internal/rpcclient"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 )
 
+// gweiToWei converts a decimal Gwei string (as --gas-price/--max-priority-fee
+// take it) to the wei big.Int a transaction's GasPrice field is stored in.
+func gweiToWei(gwei string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(gwei, 10)
+	if !ok || n.Sign() < 0 {
+		return nil, fmt.Errorf("invalid gwei amount %q", gwei)
+	}
+	return n.Mul(n, big.NewInt(1_000_000_000)), nil
+}
+
+// dioToWei converts a decimal DIO amount string (as --amount takes it, the
+// same unit weiToDIO renders for display elsewhere in the CLI) to the wei
+// big.Int a transaction's Value field is stored in.
+func dioToWei(dio string) (*big.Int, error) {
+	f, ok := new(big.Float).SetPrec(256).SetString(dio)
+	if !ok || f.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount %q", dio)
+	}
+	wei, _ := new(big.Float).Mul(f, big.NewFloat(1e18)).Int(nil)
+	return wei, nil
+}
+
+// cliAccessTuple is one entry of the JSON file --access-list reads:
+// [{address, storageKeys[]}], mirroring core.AccessTuple's two fields in
+// the plain string form a JSON file (rather than an RLP-decoded struct)
+// carries them in.
+type cliAccessTuple struct {
+	Address     string   `json:"address"`
+	StorageKeys []string `json:"storageKeys"`
+}
+
+// readAccessList parses path as a JSON array of cliAccessTuple. An empty
+// path (the flag's default) returns a nil list rather than an error.
+func readAccessList(path string) ([]cliAccessTuple, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading access list file: %w", err)
+	}
+	var list []cliAccessTuple
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("parsing access list file: %w", err)
+	}
+	return list, nil
+}
+
+// resolveChainID returns --chain-id parsed as a decimal integer if the
+// caller set it, otherwise fetches the node's own chain id via ac.
+func resolveChainID(ac *rpcclient.AccountClient, ctx *cli.Context) (*big.Int, error) {
+	if !ctx.IsSet("chain-id") {
+		chainID, err := ac.ChainID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch chain id: %w", err)
+		}
+		return chainID, nil
+	}
+	chainID, ok := new(big.Int).SetString(ctx.String("chain-id"), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid chain id %q", ctx.String("chain-id"))
+	}
+	return chainID, nil
+}
+
 // getTransactionCommand returns the transaction command
 func (c *CLI) getTransactionCommand() *cli.Command {
 	return &cli.Command{
@@ -29,65 +100,145 @@ func (c *CLI) getTransactionSendCommand() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "to",
+				Category: "Networking",
 				Aliases:  []string{"t"},
 				Usage:    "Recipient address",
 				Required: true,
 			},
 			&cli.StringFlag{
 				Name:     "amount",
+				Category: "Networking",
 				Aliases:  []string{"a"},
 				Usage:    "Amount to send",
 				Required: true,
 			},
 			&cli.StringFlag{
 				Name:     "from",
+				Category: "Networking",
 				Aliases:  []string{"f"},
 				Usage:    "From account address",
 				Required: true,
 			},
 			&cli.StringFlag{
 				Name:     "password",
+				Category: "Keystore",
 				Aliases:  []string{"p"},
 				Usage:    "Account password",
 				Required: true,
 			},
 			&cli.StringFlag{
-				Name:    "gas-price",
-				Aliases: []string{"g"},
-				Usage:   "Gas price in Gwei",
-				Value:   "20",
+				Name:     "gas-price",
+				Category: "Networking",
+				Aliases:  []string{"g"},
+				Usage:    "Gas price in Gwei",
+				Value:    "20",
 			},
 			&cli.StringFlag{
-				Name:    "gas-limit",
-				Aliases: []string{"l"},
-				Usage:   "Gas limit",
-				Value:   "21000",
+				Name:     "gas-limit",
+				Category: "Networking",
+				Aliases:  []string{"l"},
+				Usage:    "Gas limit",
+				Value:    "21000",
+			},
+			&cli.StringFlag{
+				Name:     "chain-id",
+				Category: "Networking",
+				Usage:    "Chain id to sign for; unset fetches it from the node",
+			},
+			&cli.StringFlag{
+				Name:     "type",
+				Category: "Networking",
+				Usage:    "Transaction type; only legacy is accepted, accesslist and dynamic are rejected",
+				Value:    "legacy",
+			},
+			&cli.StringFlag{
+				Name:     "max-priority-fee",
+				Category: "Networking",
+				Usage:    "Max priority fee per gas in Gwei, for --type dynamic",
+			},
+			&cli.StringFlag{
+				Name:     "access-list",
+				Category: "Networking",
+				Usage:    "JSON file of [{address, storageKeys[]}], for --type accesslist/dynamic",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
-			to := ctx.String("to")
+			to := common.HexToAddress(ctx.String("to"))
 			amount := ctx.String("amount")
-			from := ctx.String("from")
+			from := common.HexToAddress(ctx.String("from"))
 			password := ctx.String("password")
-			gasPrice := ctx.String("gas-price")
-			gasLimit := ctx.String("gas-limit")
+			gasLimit, err := strconv.ParseUint(ctx.String("gas-limit"), 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid gas limit %q: %w", ctx.String("gas-limit"), err)
+			}
+			txType := ctx.String("type")
+
+			switch txType {
+			case "legacy":
+			case "accesslist", "dynamic":
+				return fmt.Errorf("--type %s is not yet wired through the RPC client; only legacy is supported", txType)
+			default:
+				return fmt.Errorf("unknown transaction type %q (want legacy, accesslist, or dynamic)", txType)
+			}
 
-			c.logger.Info("Sending transaction", "to", to, "amount", amount, "from", from)
+			value, err := dioToWei(amount)
+			if err != nil {
+				return err
+			}
+			gasPrice, err := gweiToWei(ctx.String("gas-price"))
+			if err != nil {
+				return err
+			}
+			accessList, err := readAccessList(ctx.String("access-list"))
+			if err != nil {
+				return err
+			}
+			if len(accessList) > 0 {
+				return fmt.Errorf("--access-list is only meaningful for --type accesslist/dynamic, which are not yet wired through the RPC client")
+			}
+
+			c.logger.Info("Sending transaction", "to", to.Hex(), "amount", amount, "from", from.Hex(), "type", txType)
+
+			wallet := c.walletManager(password)
+			ac := c.accountClient()
+			chainID, err := resolveChainID(ac, ctx)
+			if err != nil {
+				return err
+			}
+			nonce, err := ac.NextNonce(from)
+			if err != nil {
+				return fmt.Errorf("failed to fetch nonce for %s: %w", from.Hex(), err)
+			}
+
+			hash, err := rpcclient.LegacySigningHash(nonce, gasPrice, gasLimit, &to, value, nil, chainID)
+			if err != nil {
+				return fmt.Errorf("failed to compute signing hash: %w", err)
+			}
+			sig, err := wallet.Sign(from, hash)
+			if err != nil {
+				return fmt.Errorf("failed to sign transaction: %w", err)
+			}
+			raw, err := rpcclient.AssembleLegacyTransaction(nonce, gasPrice, gasLimit, &to, value, nil, chainID, sig)
+			if err != nil {
+				return fmt.Errorf("failed to assemble signed transaction: %w", err)
+			}
+			txHash, err := ac.SendRawTransaction(raw)
+			if err != nil {
+				return fmt.Errorf("failed to broadcast transaction: %w", err)
+			}
 
-			// TODO: Implement transaction sending logic
 			fmt.Printf("💸 Sending DIO Tokens\n")
 			fmt.Printf("====================\n")
-			fmt.Printf("📤 From: %s\n", from)
-			fmt.Printf("📥 To: %s\n", to)
+			fmt.Printf("📤 From: %s\n", from.Hex())
+			fmt.Printf("📥 To: %s\n", to.Hex())
 			fmt.Printf("💰 Amount: %s DIO\n", amount)
-			fmt.Printf("⛽ Gas Price: %s Gwei\n", gasPrice)
-			fmt.Printf("⛽ Gas Limit: %s\n", gasLimit)
-			fmt.Printf("💸 Est. Gas Fee: 0.000420 DIO\n")
-			fmt.Printf("🔐 Password: [hidden]\n")
+			fmt.Printf("🧾 Type: %s (chain id %s)\n", txType, chainID.String())
+			fmt.Printf("⛽ Gas Price: %s Gwei\n", ctx.String("gas-price"))
+			fmt.Printf("⛽ Gas Limit: %d\n", gasLimit)
+			fmt.Printf("🔢 Nonce: %d\n", nonce)
 
 			fmt.Printf("\n✅ Transaction submitted!\n")
-			fmt.Printf("📋 Transaction Hash: 0xabcdef1234567890...\n")
-			fmt.Printf("⏳ Waiting for confirmation...\n")
+			fmt.Printf("📋 Transaction Hash: %s\n", txHash.Hex())
 
 			return nil
 		},
@@ -102,15 +253,17 @@ func (c *CLI) getTransactionInfoCommand() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "hash",
+				Category: "Networking",
 				Aliases:  []string{"h"},
 				Usage:    "Transaction hash",
 				Required: true,
 			},
 			&cli.BoolFlag{
-				Name:    "detailed",
-				Aliases: []string{"d"},
-				Usage:   "Show detailed information",
-				Value:   false,
+				Name:     "detailed",
+				Category: "Networking",
+				Aliases:  []string{"d"},
+				Usage:    "Show detailed information",
+				Value:    false,
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -161,6 +314,7 @@ func (c *CLI) getTransactionStatusCommand() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "hash",
+				Category: "Networking",
 				Aliases:  []string{"h"},
 				Usage:    "Transaction hash",
 				Required: true,
@@ -171,16 +325,29 @@ func (c *CLI) getTransactionStatusCommand() *cli.Command {
 
 			c.logger.Info("Getting transaction status", "hash", hash)
 
-			// TODO: Implement transaction status logic
+			info, err := c.accountClient().TransactionByHash(common.HexToHash(hash))
+			if err != nil {
+				return fmt.Errorf("failed to fetch transaction %s: %w", hash, err)
+			}
+			if info == nil {
+				fmt.Printf("❓ Transaction %s is unknown to this node\n", hash)
+				return nil
+			}
+
 			fmt.Printf("📊 Transaction Status\n")
 			fmt.Printf("====================\n")
 			fmt.Printf("🔗 Hash: %s\n", hash)
-			fmt.Printf("✅ Status: Confirmed\n")
-			fmt.Printf("📦 Block: 1,234,567\n")
-			fmt.Printf("⏱️  Confirmations: 42\n")
-			fmt.Printf("⏳ Time: 6 seconds\n")
-			fmt.Printf("💸 Gas Used: 21,000 / 21,000\n")
-			fmt.Printf("📊 Success: Yes\n")
+			fmt.Printf("📊 Status: %s\n", info.Status)
+			if info.From != (common.Address{}) {
+				fmt.Printf("📤 From: %s\n", info.From.Hex())
+			}
+			if info.To != nil {
+				fmt.Printf("📥 To: %s\n", info.To.Hex())
+			}
+			if info.Status == "confirmed" {
+				fmt.Printf("📦 Block: %s\n", info.BlockNumber.String())
+				fmt.Printf("💸 Gas Used: %d\n", info.GasUsed)
+			}
 
 			return nil
 		},
@@ -195,21 +362,24 @@ func (c *CLI) getTransactionHistoryCommand() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "address",
+				Category: "Networking",
 				Aliases:  []string{"addr", "a"},
 				Usage:    "Account address",
 				Required: true,
 			},
 			&cli.IntFlag{
-				Name:    "limit",
-				Aliases: []string{"l"},
-				Usage:   "Number of transactions to show",
-				Value:   10,
+				Name:     "limit",
+				Category: "Networking",
+				Aliases:  []string{"l"},
+				Usage:    "Number of transactions to show",
+				Value:    10,
 			},
 			&cli.StringFlag{
-				Name:    "type",
-				Aliases: []string{"t"},
-				Usage:   "Transaction type (send, receive, contract)",
-				Value:   "",
+				Name:     "type",
+				Category: "Networking",
+				Aliases:  []string{"t"},
+				Usage:    "Transaction type (send, receive, contract)",
+				Value:    "",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -219,13 +389,25 @@ func (c *CLI) getTransactionHistoryCommand() *cli.Command {
 
 			c.logger.Info("Getting transaction history", "address", address, "limit", limit, "type", txType)
 
-			// TODO: Implement transaction history logic
+			addr := common.HexToAddress(address)
+			txs, err := c.accountClient().TransactionsByAddress(addr, limit)
+			if err != nil {
+				return fmt.Errorf("failed to fetch transaction history for %s: %w", address, err)
+			}
+
 			fmt.Printf("📋 Transaction History for %s\n", address)
 			fmt.Printf("================================\n")
-			fmt.Printf("Showing %d recent transactions\n\n", limit)
+			if len(txs) == 0 {
+				fmt.Printf("No transactions found\n")
+				return nil
+			}
 
-			for i := 1; i <= limit; i++ {
-				fmt.Printf("%d. 📤 Send - 0xabcdef... - 10.000000 DIO - 2024-01-%02d 10:30:00 - Confirmed\n", i, 15-i+1)
+			for i, tx := range txs {
+				direction := "📥 Receive"
+				if tx.From == addr {
+					direction = "📤 Send"
+				}
+				fmt.Printf("%d. %s - %s - %s\n", i+1, direction, tx.Hash.Hex(), tx.Status)
 			}
 
 			return nil
@@ -237,52 +419,104 @@ func (c *CLI) getTransactionHistoryCommand() *cli.Command {
 func (c *CLI) getTransactionEstimateCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "estimate",
-		Usage: "Estimate gas for transaction",
+		Usage: "Estimate gas for a transaction or contract call",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "to",
+				Category: "Networking",
 				Aliases:  []string{"t"},
 				Usage:    "Recipient address",
 				Required: true,
 			},
 			&cli.StringFlag{
 				Name:     "amount",
+				Category: "Networking",
 				Aliases:  []string{"a"},
 				Usage:    "Amount to send",
 				Required: true,
 			},
 			&cli.StringFlag{
 				Name:     "from",
+				Category: "Networking",
 				Aliases:  []string{"f"},
 				Usage:    "From account address",
 				Required: true,
 			},
 			&cli.StringFlag{
-				Name:    "gas-price",
-				Aliases: []string{"g"},
-				Usage:   "Gas price in Gwei",
-				Value:   "20",
+				Name:     "gas-price",
+				Category: "Networking",
+				Aliases:  []string{"g"},
+				Usage:    "Gas price in Gwei",
+				Value:    "20",
+			},
+			&cli.StringFlag{
+				Name:     "data",
+				Category: "Networking",
+				Usage:    "Contract call data, hex-encoded",
+			},
+			&cli.StringFlag{
+				Name:     "access-list",
+				Category: "Networking",
+				Usage:    "JSON file of [{address, storageKeys[]}]",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
-			to := ctx.String("to")
+			to := common.HexToAddress(ctx.String("to"))
 			amount := ctx.String("amount")
-			from := ctx.String("from")
+			from := common.HexToAddress(ctx.String("from"))
 			gasPrice := ctx.String("gas-price")
+			data := common.FromHex(ctx.String("data"))
+
+			c.logger.Info("Estimating transaction gas", "to", to.Hex(), "amount", amount, "from", from.Hex(), "gas_price", gasPrice)
+
+			value, err := dioToWei(amount)
+			if err != nil {
+				return err
+			}
+			weiGasPrice, err := gweiToWei(gasPrice)
+			if err != nil {
+				return err
+			}
+			cliList, err := readAccessList(ctx.String("access-list"))
+			if err != nil {
+				return err
+			}
+			accessList := make([]rpcclient.AccessTuple, len(cliList))
+			for i, tuple := range cliList {
+				keys := make([]common.Hash, len(tuple.StorageKeys))
+				for j, k := range tuple.StorageKeys {
+					keys[j] = common.HexToHash(k)
+				}
+				accessList[i] = rpcclient.AccessTuple{
+					Address:     common.HexToAddress(tuple.Address),
+					StorageKeys: keys,
+				}
+			}
+
+			estimate, err := c.accountClient().EstimateGas(rpcclient.CallMsg{
+				From:       from,
+				To:         to,
+				Value:      value,
+				Data:       data,
+				GasPrice:   weiGasPrice,
+				AccessList: accessList,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to estimate gas: %w", err)
+			}
 
-			c.logger.Info("Estimating transaction gas", "to", to, "amount", amount, "from", from, "gas_price", gasPrice)
+			fee := new(big.Int).Mul(weiGasPrice, new(big.Int).SetUint64(estimate.GasLimit))
 
-			// TODO: Implement gas estimation logic
 			fmt.Printf("⛽ Gas Estimation\n")
 			fmt.Printf("==================\n")
-			fmt.Printf("📤 From: %s\n", from)
-			fmt.Printf("📥 To: %s\n", to)
+			fmt.Printf("📤 From: %s\n", from.Hex())
+			fmt.Printf("📥 To: %s\n", to.Hex())
 			fmt.Printf("💰 Amount: %s DIO\n", amount)
 			fmt.Printf("⛽ Gas Price: %s Gwei\n", gasPrice)
-			fmt.Printf("⛽ Estimated Gas: 21,000\n")
-			fmt.Printf("💸 Est. Gas Fee: 0.000420 DIO\n")
-			fmt.Printf("📊 Gas Limit: 21,000\n")
-			fmt.Printf("⏱️  Est. Time: 15 seconds\n")
+			fmt.Printf("🧮 Intrinsic Gas: %d\n", estimate.IntrinsicGas)
+			fmt.Printf("⚙️  Execution Gas: %d\n", estimate.ExecutionGas)
+			fmt.Printf("📊 Suggested Gas Limit: %d\n", estimate.GasLimit)
+			fmt.Printf("💸 Est. Gas Fee: %s wei\n", fee.String())
 
 			return nil
 		},
@@ -1,11 +1,77 @@
 package cli
 
 import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/DioraFund/diora-go-blockchain/internal/keystore"
+	"github.com/DioraFund/diora-go-blockchain/internal/rpcclient"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 )
 
+// rpcTimeout bounds every validator_*/commission_* call this file makes
+// against the configured node.
+const rpcTimeout = 15 * time.Second
+
+// receiptTimeout bounds how long stake/commission-set wait for the
+// broadcast transaction to be mined before giving up on the wait (the
+// transaction itself is still pending, not failed).
+const receiptTimeout = 30 * time.Second
+
+// outputFlag is shared by the validator subcommands that print a result
+// (all but halt) and by the node subcommands, so results can be
+// consumed by scripts ("-o json") as well as read by a human ("-o table",
+// the default).
+func outputFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:     "output",
+		Category: "Output",
+		Aliases:  []string{"o"},
+		Usage:    "Output format (table, json)",
+		Value:    "table",
+	}
+}
+
+// printJSON marshals data as indented JSON, used whenever --output=json.
+func printJSON(data interface{}) error {
+	out, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+// validatorClient builds a ValidatorClient against the configured node.
+func (c *CLI) validatorClient() *rpcclient.ValidatorClient {
+	return rpcclient.NewValidatorClient(c.config.RPC.URL, rpcTimeout)
+}
+
+// resolveSigningKey finds the keystore key file whose address matches
+// address and decrypts it with password, the same ListKeyFiles +
+// ReadKeyFileAddress + DecryptKeyFile lookup cmd/diora-wallet/main.go's
+// signer uses to resolve an address to a key.
+func resolveSigningKey(ks *keystore.Keystore, address common.Address, password string) (*ecdsa.PrivateKey, error) {
+	paths, err := ks.ListKeyFiles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keystore key files: %w", err)
+	}
+	for _, path := range paths {
+		addr, err := ks.ReadKeyFileAddress(path)
+		if err != nil || addr != address {
+			continue
+		}
+		return ks.DecryptKeyFile(path, password)
+	}
+	return nil, fmt.Errorf("no keystore account found for %s", address.Hex())
+}
+
 // getValidatorCommand returns the validator command
 func (c *CLI) getValidatorCommand() *cli.Command {
 	return &cli.Command{
@@ -17,6 +83,74 @@ func (c *CLI) getValidatorCommand() *cli.Command {
 			c.getValidatorStakeCommand(),
 			c.getValidatorRewardsCommand(),
 			c.getValidatorCommissionCommand(),
+			c.getValidatorHaltCommand(),
+		},
+	}
+}
+
+// haltVoteHash hashes height the same way consensus.haltVoteHash does, so a
+// signature produced here verifies against consensus.SubmitHaltVote.
+func haltVoteHash(height uint64) [32]byte {
+	var heightBytes [8]byte
+	binary.BigEndian.PutUint64(heightBytes[:], height)
+	return sha256.Sum256(heightBytes[:])
+}
+
+// getValidatorHaltCommand returns the validator halt command, which submits
+// a vote to halt the chain at a given height (Minter's SetHaltBlock
+// mechanism) signed with the validator's consensus key — the same key
+// SignBlock uses, unlocked from the keystore the way commission set unlocks
+// the validator's own account.
+func (c *CLI) getValidatorHaltCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "halt",
+		Usage: "Vote to halt the chain at a future block height",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "address",
+				Category: "Validator",
+				Aliases:  []string{"addr", "a"},
+				Usage:    "Validator address casting the vote",
+				Required: true,
+			},
+			&cli.Uint64Flag{
+				Name:     "height",
+				Usage:    "Block height to halt at",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "password",
+				Category: "Keystore",
+				Aliases:  []string{"p"},
+				Usage:    "Password unlocking the validator's consensus key in the keystore",
+				Required: true,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			address := common.HexToAddress(ctx.String("address"))
+			height := ctx.Uint64("height")
+			password := ctx.String("password")
+
+			c.logger.Info("Submitting halt vote", "address", address.Hex(), "height", height)
+
+			ks := c.newKeystore(c.config.Keystore.Path)
+			key, err := resolveSigningKey(ks, address, password)
+			if err != nil {
+				return fmt.Errorf("failed to unlock %s: %w", address.Hex(), err)
+			}
+
+			hash := haltVoteHash(height)
+			sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+			if err != nil {
+				return fmt.Errorf("failed to sign halt vote: %w", err)
+			}
+
+			if err := c.validatorClient().HaltVote(address, height, sig); err != nil {
+				return fmt.Errorf("failed to submit halt vote: %w", err)
+			}
+
+			fmt.Printf("✅ Halt vote submitted for height %d\n", height)
+			return nil
 		},
 	}
 }
@@ -28,17 +162,20 @@ func (c *CLI) getValidatorListCommand() *cli.Command {
 		Usage: "List all validators",
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
-				Name:    "active",
-				Aliases: []string{"a"},
-				Usage:   "Show only active validators",
-				Value:   false,
+				Name:     "active",
+				Category: "Validator",
+				Aliases:  []string{"a"},
+				Usage:    "Show only active validators",
+				Value:    false,
 			},
 			&cli.StringFlag{
-				Name:    "sort",
-				Aliases: []string{"s"},
-				Usage:   "Sort by (stake, commission, rewards)",
-				Value:   "stake",
+				Name:     "sort",
+				Category: "Validator",
+				Aliases:  []string{"s"},
+				Usage:    "Sort by (stake, commission, rewards)",
+				Value:    "stake",
 			},
+			outputFlag(),
 		},
 		Action: func(ctx *cli.Context) error {
 			active := ctx.Bool("active")
@@ -46,18 +183,32 @@ func (c *CLI) getValidatorListCommand() *cli.Command {
 
 			c.logger.Info("Listing validators", "active", active, "sort", sort)
 
-			// TODO: Implement validator listing logic
+			validators, err := c.validatorClient().List(active, sort)
+			if err != nil {
+				return fmt.Errorf("failed to list validators: %w", err)
+			}
+
+			if ctx.String("output") == "json" {
+				return printJSON(validators)
+			}
+
+			activeCount := 0
+			for _, v := range validators {
+				if v.Active {
+					activeCount++
+				}
+			}
 			fmt.Printf("🏛️  Validators List\n")
 			fmt.Printf("==================\n")
-			fmt.Printf("📊 Total Validators: 42\n")
-			fmt.Printf("🟢 Active Validators: 42\n")
-			fmt.Printf("🔴 Inactive Validators: 0\n")
+			fmt.Printf("📊 Total Validators: %d\n", len(validators))
+			fmt.Printf("🟢 Active Validators: %d\n", activeCount)
+			fmt.Printf("🔴 Inactive Validators: %d\n", len(validators)-activeCount)
 
-			fmt.Printf("\n📋 Top Validators (sorted by %s)\n", sort)
+			fmt.Printf("\n📋 Validators (sorted by %s)\n", sort)
 			fmt.Printf("=====================================\n")
-			fmt.Printf("1. 🏛️  Validator1 - 0x1234... - 10,000,000 DIO stake - 5% commission\n")
-			fmt.Printf("2. 🏛️  Validator2 - 0x5678... - 8,500,000 DIO stake - 7% commission\n")
-			fmt.Printf("3. 🏛️  Validator3 - 0x9abc... - 7,200,000 DIO stake - 3% commission\n")
+			for i, v := range validators {
+				fmt.Printf("%d. 🏛️  %s - %s DIO stake - %s%% commission\n", i+1, v.Address.Hex(), v.Stake, v.Commission)
+			}
 
 			return nil
 		},
@@ -67,50 +218,60 @@ func (c *CLI) getValidatorListCommand() *cli.Command {
 // getValidatorInfoCommand returns the validator info command
 func (c *CLI) getValidatorInfoCommand() *cli.Command {
 	return &cli.Command{
-		Name:  "info",
-		Usage: "Get validator information",
+		Name:         "info",
+		Usage:        "Get validator information",
+		BashComplete: c.completeValidatorIDs,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "address",
+				Category: "Validator",
 				Aliases:  []string{"addr", "a"},
 				Usage:    "Validator address",
 				Required: true,
 			},
 			&cli.BoolFlag{
-				Name:    "detailed",
-				Aliases: []string{"d"},
-				Usage:   "Show detailed information",
-				Value:   false,
+				Name:     "detailed",
+				Category: "Validator",
+				Aliases:  []string{"d"},
+				Usage:    "Show detailed information",
+				Value:    false,
 			},
+			outputFlag(),
 		},
 		Action: func(ctx *cli.Context) error {
-			address := ctx.String("address")
+			address := common.HexToAddress(ctx.String("address"))
 			detailed := ctx.Bool("detailed")
 
-			c.logger.Info("Getting validator info", "address", address, "detailed", detailed)
+			c.logger.Info("Getting validator info", "address", address.Hex(), "detailed", detailed)
+
+			info, err := c.validatorClient().Info(address)
+			if err != nil {
+				return fmt.Errorf("failed to get validator info: %w", err)
+			}
+
+			if ctx.String("output") == "json" {
+				return printJSON(info)
+			}
 
-			// TODO: Implement validator info logic
 			fmt.Printf("🏛️  Validator Information\n")
 			fmt.Printf("========================\n")
-			fmt.Printf("📍 Address: %s\n", address)
-			fmt.Printf("📝 Name: Validator1\n")
-			fmt.Printf("🔢 Rank: #1\n")
-			fmt.Printf("💰 Stake: 10,000,000 DIO\n")
-			fmt.Printf("📊 Commission: 5%\n")
-			fmt.Printf("🎯 Status: Active\n")
-			fmt.Printf("⏱️  Uptime: 99.98%\n")
-			fmt.Printf("📈 Performance: 100%\n")
+			fmt.Printf("📍 Address: %s\n", info.Address.Hex())
+			fmt.Printf("🔢 Rank: #%d\n", info.Rank)
+			fmt.Printf("💰 Stake: %s DIO\n", info.Stake)
+			fmt.Printf("📊 Commission: %s%%\n", info.Commission)
+			if info.Active {
+				fmt.Printf("🎯 Status: Active\n")
+			} else {
+				fmt.Printf("🎯 Status: Inactive\n")
+			}
+			fmt.Printf("⏱️  Uptime: %s\n", info.Uptime)
 
 			if detailed {
 				fmt.Printf("\n📊 Detailed Information\n")
 				fmt.Printf("=====================\n")
-				fmt.Printf("🔑 Public Key: 0xabcdef...\n")
-				fmt.Printf("📅 Created: 2024-01-01 12:00:00\n")
-				fmt.Printf("🏆 Total Rewards: 1,234,567 DIO\n")
-				fmt.Printf("📤 Delegators: 1,234\n")
-				fmt.Printf("💸 Total Delegated: 45,678,901 DIO\n")
-				fmt.Printf("🔄 Last Block: 1,234,567\n")
-				fmt.Printf("⚡ Block Production: 1,440/day\n")
+				fmt.Printf("📤 Delegators: %d\n", info.Delegators)
+				fmt.Printf("💸 Total Delegated: %s DIO\n", info.TotalDelegated)
+				fmt.Printf("🔄 Last Block: %d\n", info.LastBlock)
 			}
 
 			return nil
@@ -126,51 +287,72 @@ func (c *CLI) getValidatorStakeCommand() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "validator",
+				Category: "Validator",
 				Aliases:  []string{"v"},
 				Usage:    "Validator address",
 				Required: true,
 			},
 			&cli.StringFlag{
 				Name:     "amount",
+				Category: "Validator",
 				Aliases:  []string{"a"},
 				Usage:    "Amount to stake",
 				Required: true,
 			},
 			&cli.StringFlag{
 				Name:     "from",
+				Category: "Validator",
 				Aliases:  []string{"f"},
 				Usage:    "From account address",
 				Required: true,
 			},
 			&cli.StringFlag{
 				Name:     "password",
+				Category: "Keystore",
 				Aliases:  []string{"p"},
-				Usage:    "Account password",
+				Usage:    "Password unlocking the --from account in the keystore",
 				Required: true,
 			},
+			outputFlag(),
 		},
 		Action: func(ctx *cli.Context) error {
-			validator := ctx.String("validator")
+			validator := common.HexToAddress(ctx.String("validator"))
 			amount := ctx.String("amount")
-			from := ctx.String("from")
+			from := common.HexToAddress(ctx.String("from"))
 			password := ctx.String("password")
 
-			c.logger.Info("Staking to validator", "validator", validator, "amount", amount, "from", from)
+			c.logger.Info("Staking to validator", "validator", validator.Hex(), "amount", amount, "from", from.Hex())
 
-			// TODO: Implement validator staking logic
-			fmt.Printf("🏛️  Staking to Validator\n")
-			fmt.Printf("========================\n")
-			fmt.Printf("📍 Validator: %s\n", validator)
-			fmt.Printf("💰 Amount: %s DIO\n", amount)
-			fmt.Printf("📤 From: %s\n", from)
-			fmt.Printf("🔐 Password: [hidden]\n")
-			fmt.Printf("⏱️  Est. Time: 15 seconds\n")
-			fmt.Printf("💸 Gas Fee: ~0.001 DIO\n")
-
-			fmt.Printf("\n✅ Staking transaction submitted!\n")
-			fmt.Printf("📋 Transaction Hash: 0xabcdef1234567890...\n")
+			ks := c.newKeystore(c.config.Keystore.Path)
+			key, err := resolveSigningKey(ks, from, password)
+			if err != nil {
+				return fmt.Errorf("failed to unlock %s: %w", from.Hex(), err)
+			}
+
+			vc := c.validatorClient()
+			nonce, err := vc.NextNonce(from)
+			if err != nil {
+				return fmt.Errorf("failed to fetch nonce for %s: %w", from.Hex(), err)
+			}
+
+			txHash, err := vc.Stake(from, validator, amount, nonce, key)
+			if err != nil {
+				return fmt.Errorf("failed to broadcast stake transaction: %w", err)
+			}
+
+			fmt.Printf("✅ Staking transaction submitted!\n")
+			fmt.Printf("📋 Transaction Hash: %s\n", txHash.Hex())
 			fmt.Printf("⏳ Waiting for confirmation...\n")
 
+			receipt, err := vc.WaitForReceipt(txHash, receiptTimeout)
+			if err != nil {
+				return fmt.Errorf("transaction %s broadcast, but: %w", txHash.Hex(), err)
+			}
+
+			if ctx.String("output") == "json" {
+				return printJSON(receipt)
+			}
+			fmt.Printf("📦 Mined in block %d (status: %v)\n", receipt.Block, receipt.Status)
 			return nil
 		},
 	}
@@ -184,33 +366,42 @@ func (c *CLI) getValidatorRewardsCommand() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "address",
+				Category: "Validator",
 				Aliases:  []string{"addr", "a"},
 				Usage:    "Validator address",
 				Required: true,
 			},
 			&cli.StringFlag{
-				Name:    "period",
-				Aliases: []string{"p"},
-				Usage:   "Time period (1h, 24h, 7d, 30d)",
-				Value:   "24h",
+				Name:     "period",
+				Category: "Validator",
+				Aliases:  []string{"p"},
+				Usage:    "Time period (1h, 24h, 7d, 30d)",
+				Value:    "24h",
 			},
+			outputFlag(),
 		},
 		Action: func(ctx *cli.Context) error {
-			address := ctx.String("address")
+			address := common.HexToAddress(ctx.String("address"))
 			period := ctx.String("period")
 
-			c.logger.Info("Getting validator rewards", "address", address, "period", period)
+			c.logger.Info("Getting validator rewards", "address", address.Hex(), "period", period)
+
+			rewards, err := c.validatorClient().Rewards(address, period)
+			if err != nil {
+				return fmt.Errorf("failed to get validator rewards: %w", err)
+			}
+
+			if ctx.String("output") == "json" {
+				return printJSON(rewards)
+			}
 
-			// TODO: Implement validator rewards logic
 			fmt.Printf("💰 Validator Rewards (%s)\n", period)
 			fmt.Printf("==========================\n")
-			fmt.Printf("📍 Validator: %s\n", address)
-			fmt.Printf("💸 Total Rewards: 12,345.678901 DIO\n")
-			fmt.Printf("📦 Block Rewards: 10,000.000000 DIO\n")
-			fmt.Printf("🔥 Gas Fees: 2,345.678901 DIO\n")
-			fmt.Printf("🏆 Commission: 0.000000 DIO\n")
-			fmt.Printf("📈 APY: 8.5%\n")
-			fmt.Printf("📊 Rewards Share: 2.34%\n")
+			fmt.Printf("📍 Validator: %s\n", rewards.Address.Hex())
+			fmt.Printf("💸 Total Rewards: %s DIO\n", rewards.Total)
+			for _, entry := range rewards.Entries {
+				fmt.Printf("   📦 Block %d: %s DIO (%s)\n", entry.Block, entry.Amount, entry.Timestamp.Format("2006-01-02 15:04:05"))
+			}
 
 			return nil
 		},
@@ -229,24 +420,34 @@ func (c *CLI) getValidatorCommissionCommand() *cli.Command {
 				Flags: []cli.Flag{
 					&cli.StringFlag{
 						Name:     "address",
+						Category: "Validator",
 						Aliases:  []string{"addr", "a"},
 						Usage:    "Validator address",
 						Required: true,
 					},
+					outputFlag(),
 				},
 				Action: func(ctx *cli.Context) error {
-					address := ctx.String("address")
+					address := common.HexToAddress(ctx.String("address"))
+
+					c.logger.Info("Getting validator commission", "address", address.Hex())
 
-					c.logger.Info("Getting validator commission", "address", address)
+					commission, err := c.validatorClient().CommissionOf(address)
+					if err != nil {
+						return fmt.Errorf("failed to get validator commission: %w", err)
+					}
+
+					if ctx.String("output") == "json" {
+						return printJSON(commission)
+					}
 
-					// TODO: Implement commission get logic
 					fmt.Printf("💸 Validator Commission\n")
 					fmt.Printf("=====================\n")
-					fmt.Printf("📍 Validator: %s\n", address)
-					fmt.Printf("📊 Current Rate: 5%\n")
-					fmt.Printf("💰 Total Earned: 1,234,567.890123 DIO\n")
-					fmt.Printf("📅 Last Updated: 2024-01-15 10:30:00\n")
-					fmt.Printf("🔄 Update Cooldown: 7 days remaining\n")
+					fmt.Printf("📍 Validator: %s\n", commission.Address.Hex())
+					fmt.Printf("📊 Current Rate: %s%%\n", commission.Rate)
+					fmt.Printf("💰 Total Earned: %s DIO\n", commission.TotalEarned)
+					fmt.Printf("📅 Last Updated: %s\n", commission.LastUpdated.Format("2006-01-02 15:04:05"))
+					fmt.Printf("🔄 Update Cooldown: %s remaining\n", commission.CooldownRemaining)
 
 					return nil
 				},
@@ -257,42 +458,63 @@ func (c *CLI) getValidatorCommissionCommand() *cli.Command {
 				Flags: []cli.Flag{
 					&cli.StringFlag{
 						Name:     "address",
+						Category: "Validator",
 						Aliases:  []string{"addr", "a"},
 						Usage:    "Validator address",
 						Required: true,
 					},
 					&cli.StringFlag{
 						Name:     "rate",
+						Category: "Validator",
 						Aliases:  []string{"r"},
 						Usage:    "Commission rate (0-100)",
 						Required: true,
 					},
 					&cli.StringFlag{
 						Name:     "password",
+						Category: "Keystore",
 						Aliases:  []string{"p"},
-						Usage:    "Validator password",
+						Usage:    "Password unlocking the validator's own account in the keystore",
 						Required: true,
 					},
+					outputFlag(),
 				},
 				Action: func(ctx *cli.Context) error {
-					address := ctx.String("address")
+					address := common.HexToAddress(ctx.String("address"))
 					rate := ctx.String("rate")
 					password := ctx.String("password")
 
-					c.logger.Info("Setting validator commission", "address", address, "rate", rate)
+					c.logger.Info("Setting validator commission", "address", address.Hex(), "rate", rate)
+
+					ks := c.newKeystore(c.config.Keystore.Path)
+					key, err := resolveSigningKey(ks, address, password)
+					if err != nil {
+						return fmt.Errorf("failed to unlock %s: %w", address.Hex(), err)
+					}
+
+					vc := c.validatorClient()
+					nonce, err := vc.NextNonce(address)
+					if err != nil {
+						return fmt.Errorf("failed to fetch nonce for %s: %w", address.Hex(), err)
+					}
+
+					txHash, err := vc.SetCommission(address, address, rate, nonce, key)
+					if err != nil {
+						return fmt.Errorf("failed to broadcast commission update: %w", err)
+					}
 
-					// TODO: Implement commission set logic
-					fmt.Printf("💸 Setting Validator Commission\n")
-					fmt.Printf("==============================\n")
-					fmt.Printf("📍 Validator: %s\n", address)
-					fmt.Printf("📊 New Rate: %s%%\n", rate)
-					fmt.Printf("🔐 Password: [hidden]\n")
-					fmt.Printf("⏱️  Est. Time: 15 seconds\n")
-					fmt.Printf("💸 Gas Fee: ~0.001 DIO\n")
+					fmt.Printf("✅ Commission update transaction submitted!\n")
+					fmt.Printf("📋 Transaction Hash: %s\n", txHash.Hex())
 
-					fmt.Printf("\n✅ Commission update transaction submitted!\n")
-					fmt.Printf("📋 Transaction Hash: 0xabcdef1234567890...\n")
+					receipt, err := vc.WaitForReceipt(txHash, receiptTimeout)
+					if err != nil {
+						return fmt.Errorf("transaction %s broadcast, but: %w", txHash.Hex(), err)
+					}
 
+					if ctx.String("output") == "json" {
+						return printJSON(receipt)
+					}
+					fmt.Printf("📦 Mined in block %d (status: %v)\n", receipt.Block, receipt.Status)
 					return nil
 				},
 			},
@@ -1,12 +1,49 @@
 package cli
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"math/big"
+	"os"
+	"strconv"
 
 	"github.com/DioraFund/diora-go-blockchain/internal/keystore"
+	"github.com/DioraFund/diora-go-blockchain/internal/password"
+	"github.com/DioraFund/diora-go-blockchain/internal/rpcclient"
+	"github.com/DioraFund/diora-go-blockchain/internal/walletrpc"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 )
 
+// depositContractAddress is the reserved system address core.
+// DepositContractAddress designates for EIP-6110-style validator deposits;
+// it's duplicated here rather than imported because the CLI only ever
+// talks to a node over RPC, never by linking core in-process.
+var depositContractAddress = common.HexToAddress("0x0000000000000000000000000000000000f100")
+
+// encodeDepositCalldata packs a deposit's fields into the same
+// length-prefixed layout core.decodeDepositLog expects from a Deposit
+// event's log data, minus the trailing index: the deposit contract (once
+// it exists) assigns that when it emits the log, the same way the real
+// EIP-6110 deposit contract does.
+func encodeDepositCalldata(pubkey, withdrawalCreds []byte, amount uint64, signature []byte) []byte {
+	var buf []byte
+	appendBytes := func(b []byte) {
+		length := make([]byte, 4)
+		binary.BigEndian.PutUint32(length, uint32(len(b)))
+		buf = append(buf, length...)
+		buf = append(buf, b...)
+	}
+	appendBytes(pubkey)
+	appendBytes(withdrawalCreds)
+	amt := make([]byte, 8)
+	binary.BigEndian.PutUint64(amt, amount)
+	buf = append(buf, amt...)
+	appendBytes(signature)
+	return buf
+}
+
 // getWalletCommand returns the wallet command
 func (c *CLI) getWalletCommand() *cli.Command {
 	return &cli.Command{
@@ -14,11 +51,104 @@ func (c *CLI) getWalletCommand() *cli.Command {
 		Usage: "Manage Diora wallets and accounts",
 		Subcommands: []*cli.Command{
 			c.getWalletCreateCommand(),
+			c.getWalletDepositCommand(),
 			c.getWalletImportCommand(),
 			c.getWalletListCommand(),
 			c.getWalletBalanceCommand(),
 			c.getWalletExportCommand(),
 			c.getWalletDeleteCommand(),
+			c.getWalletHDCreateCommand(),
+			c.getWalletHDDeriveCommand(),
+			c.getWalletHDExportMnemonicCommand(),
+			c.getWalletMnemonicCommand(),
+			c.getWalletDaemonCommand(),
+		},
+	}
+}
+
+// getWalletDaemonCommand returns the wallet daemon command: it unlocks
+// every account in a keystore directory once, up front, and then signs on
+// this process's behalf over a Unix socket and, optionally, a bearer-
+// token-protected TCP listener — letting `--wallet-url` point other `diora`
+// invocations at it instead of having each one decrypt keys itself.
+func (c *CLI) getWalletDaemonCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "daemon",
+		Usage: "Host a keystore directory behind a WalletList/WalletNew/WalletSign/WalletSignTx/WalletDelete/WalletHas JSON-RPC API",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "path",
+				Category: "Keystore",
+				Aliases:  []string{"d"},
+				Usage:    "Keystore directory path",
+				Value:    c.config.Keystore.Path,
+			},
+			&cli.StringFlag{
+				Name:     "password",
+				Category: "Keystore",
+				Aliases:  []string{"p"},
+				Usage:    "Password unlocking every account in the keystore",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "socket",
+				Category: "Keystore",
+				Usage:    "Unix socket path to listen on",
+				Value:    "$HOME/.diora/wallet.sock",
+			},
+			&cli.StringFlag{
+				Name:     "listen",
+				Category: "Keystore",
+				Usage:    "Optional TCP address to also listen on (requires --token)",
+			},
+			&cli.StringFlag{
+				Name:     "token",
+				Category: "Keystore",
+				Usage:    "Bearer token --listen callers must present",
+			},
+			&cli.StringFlag{
+				Name:     "policy-file",
+				Category: "Keystore",
+				Usage:    "JSON file of per-account signing policies (allow/deny destination, allowed tx types)",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			path := ctx.String("path")
+			socket := ctx.String("socket")
+			listen := ctx.String("listen")
+			token := ctx.String("token")
+
+			if listen != "" && token == "" {
+				return fmt.Errorf("--listen requires --token, so the TCP endpoint isn't left unauthenticated")
+			}
+
+			policies, err := walletrpc.LoadPolicies(ctx.String("policy-file"))
+			if err != nil {
+				return err
+			}
+
+			ks := c.newKeystore(path)
+			wallet := keystore.NewLocalWallet(ks, ctx.String("password"))
+			addrs, err := wallet.List()
+			if err != nil {
+				return fmt.Errorf("failed to list keystore accounts: %w", err)
+			}
+
+			daemon := walletrpc.NewDaemon(wallet, token, policies)
+			c.logger.Info("Starting wallet daemon", "path", path, "accounts", len(addrs), "socket", socket, "listen", listen)
+
+			fmt.Printf("🔏 diora wallet daemon hosting %d account(s) from %s\n", len(addrs), path)
+			fmt.Printf("📡 Unix socket: %s\n", socket)
+			if listen != "" {
+				fmt.Printf("📡 TCP: %s (bearer token required)\n", listen)
+			}
+
+			errCh := make(chan error, 2)
+			go func() { errCh <- daemon.ServeUnix(socket) }()
+			if listen != "" {
+				go func() { errCh <- daemon.ServeTCP(listen) }()
+			}
+			return <-errCh
 		},
 	}
 }
@@ -31,32 +161,56 @@ func (c *CLI) getWalletCreateCommand() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "name",
+				Category: "Keystore",
 				Aliases:  []string{"n"},
 				Usage:    "Wallet name",
 				Required: true,
 			},
 			&cli.StringFlag{
 				Name:     "password",
+				Category: "Keystore",
 				Aliases:  []string{"p"},
 				Usage:    "Wallet password",
 				Required: true,
 			},
 			&cli.StringFlag{
-				Name:    "path",
-				Aliases: []string{"d"},
-				Usage:   "Wallet directory path",
-				Value:   c.config.Keystore.Path,
+				Name:     "path",
+				Category: "Keystore",
+				Aliases:  []string{"d"},
+				Usage:    "Wallet directory path",
+				Value:    c.config.Keystore.Path,
+			},
+			&cli.IntFlag{
+				Name:     "min-password-score",
+				Category: "Keystore",
+				Usage:    "Minimum zxcvbn-style password strength score (0-4) required to create a wallet",
+				Value:    password.DefaultMinScore,
 			},
 		},
 		Action: func(ctx *cli.Context) error {
 			name := ctx.String("name")
-			password := ctx.String("password")
+			pw := ctx.String("password")
 			path := ctx.String("path")
+			minScore := ctx.Int("min-password-score")
+
+			if len(name) > password.MaxLength {
+				return fmt.Errorf("wallet name exceeds maximum length of %d bytes", password.MaxLength)
+			}
+			if err := password.Check(pw, minScore); err != nil {
+				var weak *password.WeakPasswordError
+				if errors.As(err, &weak) {
+					fmt.Printf("⚠️  Password strength: %d/%d\n", weak.Score, weak.MinScore)
+					for _, f := range weak.Feedback {
+						fmt.Printf("   - %s\n", f)
+					}
+				}
+				return fmt.Errorf("refusing to create wallet: %w", err)
+			}
 
 			c.logger.Info("Creating wallet", "name", name, "path", path)
 
-			ks := keystore.NewKeystore(path)
-			account, err := ks.NewAccount(name, password)
+			ks := c.newKeystore(path)
+			account, err := ks.NewAccount(name, pw)
 			if err != nil {
 				return fmt.Errorf("failed to create wallet: %w", err)
 			}
@@ -72,49 +226,231 @@ func (c *CLI) getWalletCreateCommand() *cli.Command {
 	}
 }
 
+// getWalletDepositCommand returns the wallet deposit command: it builds and
+// sends an EIP-6110-style validator deposit transaction to
+// depositContractAddress, the execution-layer counterpart to `wallet
+// hd-create` for onboarding a validator instead of an account.
+func (c *CLI) getWalletDepositCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "deposit",
+		Usage: "Send a validator deposit transaction",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "from",
+				Category: "Keystore",
+				Aliases:  []string{"f"},
+				Usage:    "From account address",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "password",
+				Category: "Keystore",
+				Aliases:  []string{"p"},
+				Usage:    "Account password",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "pubkey",
+				Category: "Keystore",
+				Usage:    "Validator public key, hex-encoded",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "withdrawal-credentials",
+				Category: "Keystore",
+				Usage:    "Withdrawal credentials, hex-encoded",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "signature",
+				Category: "Keystore",
+				Usage:    "Proof-of-possession signature, hex-encoded",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "amount",
+				Category: "Keystore",
+				Aliases:  []string{"a"},
+				Usage:    "Deposit amount in DIO",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "gas-price",
+				Category: "Networking",
+				Aliases:  []string{"g"},
+				Usage:    "Gas price in Gwei",
+				Value:    "20",
+			},
+			&cli.StringFlag{
+				Name:     "gas-limit",
+				Category: "Networking",
+				Aliases:  []string{"l"},
+				Usage:    "Gas limit",
+				Value:    "200000",
+			},
+			&cli.StringFlag{
+				Name:     "chain-id",
+				Category: "Networking",
+				Usage:    "Chain id to sign for",
+				Value:    "1337",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			from := common.HexToAddress(ctx.String("from"))
+			pw := ctx.String("password")
+			pubkey := common.FromHex(ctx.String("pubkey"))
+			withdrawalCreds := common.FromHex(ctx.String("withdrawal-credentials"))
+			signature := common.FromHex(ctx.String("signature"))
+
+			value, err := dioToWei(ctx.String("amount"))
+			if err != nil {
+				return err
+			}
+			gasLimit, err := strconv.ParseUint(ctx.String("gas-limit"), 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid gas limit %q: %w", ctx.String("gas-limit"), err)
+			}
+			gasPrice, err := gweiToWei(ctx.String("gas-price"))
+			if err != nil {
+				return err
+			}
+
+			data := encodeDepositCalldata(pubkey, withdrawalCreds, value.Uint64(), signature)
+
+			c.logger.Info("Sending deposit transaction", "from", from.Hex(), "amount", ctx.String("amount"))
+
+			wallet := c.walletManager(pw)
+			ac := c.accountClient()
+			chainID := new(big.Int)
+			if ctx.IsSet("chain-id") {
+				var ok bool
+				chainID, ok = new(big.Int).SetString(ctx.String("chain-id"), 10)
+				if !ok {
+					return fmt.Errorf("invalid chain id %q", ctx.String("chain-id"))
+				}
+			} else if chainID, err = ac.ChainID(); err != nil {
+				return fmt.Errorf("failed to fetch chain id: %w", err)
+			}
+			nonce, err := ac.NextNonce(from)
+			if err != nil {
+				return fmt.Errorf("failed to fetch nonce for %s: %w", from.Hex(), err)
+			}
+
+			hash, err := rpcclient.LegacySigningHash(nonce, gasPrice, gasLimit, &depositContractAddress, value, data, chainID)
+			if err != nil {
+				return fmt.Errorf("failed to compute signing hash: %w", err)
+			}
+			sig, err := wallet.Sign(from, hash)
+			if err != nil {
+				return fmt.Errorf("failed to sign transaction: %w", err)
+			}
+			raw, err := rpcclient.AssembleLegacyTransaction(nonce, gasPrice, gasLimit, &depositContractAddress, value, data, chainID, sig)
+			if err != nil {
+				return fmt.Errorf("failed to assemble signed transaction: %w", err)
+			}
+			txHash, err := ac.SendRawTransaction(raw)
+			if err != nil {
+				return fmt.Errorf("failed to broadcast transaction: %w", err)
+			}
+
+			fmt.Printf("🥩 Sending Validator Deposit\n")
+			fmt.Printf("====================\n")
+			fmt.Printf("📤 From: %s\n", from.Hex())
+			fmt.Printf("📥 Deposit Contract: %s\n", depositContractAddress.Hex())
+			fmt.Printf("💰 Amount: %s DIO\n", ctx.String("amount"))
+			fmt.Printf("🔢 Nonce: %d\n", nonce)
+
+			fmt.Printf("\n✅ Deposit transaction submitted!\n")
+			fmt.Printf("📋 Transaction Hash: %s\n", txHash.Hex())
+
+			return nil
+		},
+	}
+}
+
 // getWalletImportCommand returns the wallet import command
 func (c *CLI) getWalletImportCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "import",
-		Usage: "Import wallet from private key",
+		Usage: "Import wallet from a private key or a Web3 Secret Storage v3 keystore file",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "private-key",
+				Category: "Keystore",
 				Aliases:  []string{"key", "k"},
-				Usage:    "Private key to import",
-				Required: true,
+				Usage:    "Private key to import (--format raw, the default)",
 			},
 			&cli.StringFlag{
 				Name:     "name",
+				Category: "Keystore",
 				Aliases:  []string{"n"},
 				Usage:    "Wallet name",
 				Required: true,
 			},
 			&cli.StringFlag{
 				Name:     "password",
+				Category: "Keystore",
 				Aliases:  []string{"p"},
 				Usage:    "Wallet password",
 				Required: true,
 			},
 			&cli.StringFlag{
-				Name:    "path",
-				Aliases: []string{"d"},
-				Usage:   "Wallet directory path",
-				Value:   c.config.Keystore.Path,
+				Name:     "path",
+				Category: "Keystore",
+				Aliases:  []string{"d"},
+				Usage:    "Wallet directory path",
+				Value:    c.config.Keystore.Path,
+			},
+			&cli.StringFlag{
+				Name:     "format",
+				Category: "Output",
+				Usage:    "Import format: raw (hex private key) or v3 (Web3 Secret Storage v3 keystore file)",
+				Value:    "raw",
+			},
+			&cli.StringFlag{
+				Name:     "file",
+				Category: "Keystore",
+				Usage:    "Path to a v3 keystore file (required for --format v3)",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
-			privateKey := ctx.String("private-key")
 			name := ctx.String("name")
 			password := ctx.String("password")
 			path := ctx.String("path")
+			format := ctx.String("format")
 
-			c.logger.Info("Importing wallet", "name", name, "path", path)
+			c.logger.Info("Importing wallet", "name", name, "path", path, "format", format)
 
-			ks := keystore.NewKeystore(path)
-			account, err := ks.ImportPrivateKey(privateKey, name, password)
-			if err != nil {
-				return fmt.Errorf("failed to import wallet: %w", err)
+			ks := c.newKeystore(path)
+
+			var account *keystore.Account
+			switch format {
+			case "raw", "":
+				privateKey := ctx.String("private-key")
+				if privateKey == "" {
+					return fmt.Errorf("--private-key is required for --format raw")
+				}
+				imported, err := ks.ImportPrivateKey(privateKey, name, password)
+				if err != nil {
+					return fmt.Errorf("failed to import wallet: %w", err)
+				}
+				account = imported
+			case "v3":
+				file := ctx.String("file")
+				if file == "" {
+					return fmt.Errorf("--file is required for --format v3")
+				}
+				data, err := os.ReadFile(file)
+				if err != nil {
+					return fmt.Errorf("failed to read keystore file: %w", err)
+				}
+				imported, err := ks.ImportV3(data, password, name)
+				if err != nil {
+					return fmt.Errorf("failed to import wallet: %w", err)
+				}
+				account = imported
+			default:
+				return fmt.Errorf("unknown import format %q (want raw or v3)", format)
 			}
 
 			fmt.Printf("✅ Wallet imported successfully!\n")
@@ -135,10 +471,11 @@ func (c *CLI) getWalletListCommand() *cli.Command {
 		Usage: "List all wallets",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:    "path",
-				Aliases: []string{"d"},
-				Usage:   "Wallet directory path",
-				Value:   c.config.Keystore.Path,
+				Name:     "path",
+				Category: "Keystore",
+				Aliases:  []string{"d"},
+				Usage:    "Wallet directory path",
+				Value:    c.config.Keystore.Path,
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -146,7 +483,7 @@ func (c *CLI) getWalletListCommand() *cli.Command {
 
 			c.logger.Info("Listing wallets", "path", path)
 
-			ks := keystore.NewKeystore(path)
+			ks := c.newKeystore(path)
 			accounts, err := ks.ListAccounts()
 			if err != nil {
 				return fmt.Errorf("failed to list wallets: %w", err)
@@ -179,15 +516,17 @@ func (c *CLI) getWalletBalanceCommand() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "address",
+				Category: "Keystore",
 				Aliases:  []string{"addr", "a"},
 				Usage:    "Wallet address",
 				Required: true,
 			},
 			&cli.StringFlag{
-				Name:    "token",
-				Aliases: []string{"t"},
-				Usage:   "Token contract address (default: native DIO)",
-				Value:   "",
+				Name:     "token",
+				Category: "Keystore",
+				Aliases:  []string{"t"},
+				Usage:    "Token contract address (default: native DIO)",
+				Value:    "",
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -213,43 +552,85 @@ func (c *CLI) getWalletBalanceCommand() *cli.Command {
 func (c *CLI) getWalletExportCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "export",
-		Usage: "Export wallet private key",
+		Usage: "Export a wallet as a raw private key or a Web3 Secret Storage v3 keystore file",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "name",
+				Category: "Keystore",
 				Aliases:  []string{"n"},
 				Usage:    "Wallet name",
 				Required: true,
 			},
 			&cli.StringFlag{
 				Name:     "password",
+				Category: "Keystore",
 				Aliases:  []string{"p"},
 				Usage:    "Wallet password",
 				Required: true,
 			},
 			&cli.StringFlag{
-				Name:    "path",
-				Aliases: []string{"d"},
-				Usage:   "Wallet directory path",
-				Value:   c.config.Keystore.Path,
+				Name:     "path",
+				Category: "Keystore",
+				Aliases:  []string{"d"},
+				Usage:    "Wallet directory path",
+				Value:    c.config.Keystore.Path,
+			},
+			&cli.StringFlag{
+				Name:     "format",
+				Category: "Output",
+				Usage:    "Export format: raw (hex private key, unsafe to share) or v3 (Web3 Secret Storage v3 keystore file)",
+				Value:    "raw",
+			},
+			&cli.StringFlag{
+				Name:     "out",
+				Category: "Keystore",
+				Usage:    "Output path for the v3 keystore file (required for --format v3)",
+			},
+			&cli.StringFlag{
+				Name:     "kdf",
+				Category: "Keystore",
+				Usage:    "KDF for --format v3: scrypt (default) or pbkdf2",
+				Value:    keystore.KDFScrypt,
 			},
 		},
 		Action: func(ctx *cli.Context) error {
 			name := ctx.String("name")
 			password := ctx.String("password")
 			path := ctx.String("path")
+			format := ctx.String("format")
 
-			c.logger.Info("Exporting wallet", "name", name, "path", path)
+			c.logger.Info("Exporting wallet", "name", name, "path", path, "format", format)
 
-			ks := keystore.NewKeystore(path)
-			privateKey, err := ks.ExportPrivateKey(name, password)
-			if err != nil {
-				return fmt.Errorf("failed to export wallet: %w", err)
-			}
+			ks := c.newKeystore(path)
 
-			fmt.Printf("⚠️  WARNING: Keep your private key secure!\n")
-			fmt.Printf("📝 Wallet: %s\n", name)
-			fmt.Printf("🔑 Private Key: %s\n", privateKey)
+			switch format {
+			case "raw", "":
+				privateKey, err := ks.ExportPrivateKey(name, password)
+				if err != nil {
+					return fmt.Errorf("failed to export wallet: %w", err)
+				}
+
+				fmt.Printf("⚠️  WARNING: Keep your private key secure!\n")
+				fmt.Printf("📝 Wallet: %s\n", name)
+				fmt.Printf("🔑 Private Key: %s\n", privateKey)
+			case "v3":
+				out := ctx.String("out")
+				if out == "" {
+					return fmt.Errorf("--out is required for --format v3")
+				}
+				data, err := ks.ExportV3(name, password, keystore.V3Options{KDF: ctx.String("kdf")})
+				if err != nil {
+					return fmt.Errorf("failed to export wallet: %w", err)
+				}
+				if err := os.WriteFile(out, data, 0600); err != nil {
+					return fmt.Errorf("failed to write keystore file: %w", err)
+				}
+
+				fmt.Printf("✅ Exported wallet %q as a Web3 Secret Storage v3 keystore\n", name)
+				fmt.Printf("📂 File: %s\n", out)
+			default:
+				return fmt.Errorf("unknown export format %q (want raw or v3)", format)
+			}
 
 			return nil
 		},
@@ -264,21 +645,24 @@ func (c *CLI) getWalletDeleteCommand() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "name",
+				Category: "Keystore",
 				Aliases:  []string{"n"},
 				Usage:    "Wallet name",
 				Required: true,
 			},
 			&cli.BoolFlag{
-				Name:    "force",
-				Aliases: []string{"f"},
-				Usage:   "Force delete without confirmation",
-				Value:   false,
+				Name:     "force",
+				Category: "Keystore",
+				Aliases:  []string{"f"},
+				Usage:    "Force delete without confirmation",
+				Value:    false,
 			},
 			&cli.StringFlag{
-				Name:    "path",
-				Aliases: []string{"d"},
-				Usage:   "Wallet directory path",
-				Value:   c.config.Keystore.Path,
+				Name:     "path",
+				Category: "Keystore",
+				Aliases:  []string{"d"},
+				Usage:    "Wallet directory path",
+				Value:    c.config.Keystore.Path,
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -299,7 +683,7 @@ func (c *CLI) getWalletDeleteCommand() *cli.Command {
 				}
 			}
 
-			ks := keystore.NewKeystore(path)
+			ks := c.newKeystore(path)
 			err := ks.DeleteAccount(name)
 			if err != nil {
 				return fmt.Errorf("failed to delete wallet: %w", err)
@@ -311,3 +695,269 @@ func (c *CLI) getWalletDeleteCommand() *cli.Command {
 		},
 	}
 }
+
+// getWalletHDCreateCommand returns the wallet hd-create command
+func (c *CLI) getWalletHDCreateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "hd-create",
+		Usage: "Create or import a BIP-39/BIP-32 HD wallet",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Category: "Keystore",
+				Aliases:  []string{"n"},
+				Usage:    "Wallet name",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "password",
+				Category: "Keystore",
+				Aliases:  []string{"p"},
+				Usage:    "Wallet password",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "mnemonic",
+				Category: "Keystore",
+				Usage:    "Mnemonic to import (leave empty to generate a new 12-word mnemonic)",
+			},
+			&cli.StringFlag{
+				Name:     "passphrase",
+				Category: "Keystore",
+				Usage:    "Optional BIP-39 passphrase",
+			},
+			&cli.StringFlag{
+				Name:     "path",
+				Category: "Keystore",
+				Usage:    "HD derivation path",
+				Value:    keystore.DefaultHDPath,
+			},
+			&cli.StringFlag{
+				Name:     "dir",
+				Category: "Keystore",
+				Aliases:  []string{"d"},
+				Usage:    "Wallet directory path",
+				Value:    c.config.Keystore.Path,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			name := ctx.String("name")
+			password := ctx.String("password")
+			mnemonic := ctx.String("mnemonic")
+			passphrase := ctx.String("passphrase")
+			path := ctx.String("path")
+			dir := ctx.String("dir")
+
+			c.logger.Info("Creating HD wallet", "name", name, "path", dir)
+
+			ks := c.newKeystore(dir)
+			account, err := ks.NewHDAccount(name, password, mnemonic, passphrase, path, 0)
+			if err != nil {
+				return fmt.Errorf("failed to create HD wallet: %w", err)
+			}
+
+			fmt.Printf("✅ HD wallet created successfully!\n")
+			fmt.Printf("📝 Name: %s\n", account.Name)
+			fmt.Printf("📍 Address: %s\n", account.Address.Hex())
+			fmt.Printf("🧭 Path: %s\n", account.Path)
+			if mnemonic == "" {
+				generated, err := ks.ExportMnemonic(name, password)
+				if err != nil {
+					return fmt.Errorf("wallet created but failed to read back its mnemonic: %w", err)
+				}
+				fmt.Printf("⚠️  Save this mnemonic somewhere safe, it will not be shown again:\n")
+				fmt.Printf("🔑 %s\n", generated)
+			}
+
+			return nil
+		},
+	}
+}
+
+// getWalletHDDeriveCommand returns the wallet hd-derive command
+func (c *CLI) getWalletHDDeriveCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "hd-derive",
+		Aliases: []string{"derive"},
+		Usage:   "Derive the next (or, with --index, an explicit) account of an HD wallet",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Category: "Keystore",
+				Aliases:  []string{"n"},
+				Usage:    "HD wallet name",
+				Required: true,
+			},
+			&cli.IntFlag{
+				Name:     "index",
+				Category: "Keystore",
+				Usage:    "Derive this explicit child index instead of the next unused one",
+				Value:    -1,
+			},
+			&cli.StringFlag{
+				Name:     "dir",
+				Category: "Keystore",
+				Aliases:  []string{"d"},
+				Usage:    "Wallet directory path",
+				Value:    c.config.Keystore.Path,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			name := ctx.String("name")
+			index := ctx.Int("index")
+			dir := ctx.String("dir")
+
+			c.logger.Info("Deriving HD account", "name", name, "path", dir)
+
+			ks := c.newKeystore(dir)
+			var account *keystore.Account
+			var err error
+			if index >= 0 {
+				account, err = ks.DeriveAt(name, uint32(index))
+			} else {
+				account, err = ks.DeriveNext(name)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to derive account: %w", err)
+			}
+
+			fmt.Printf("✅ Account derived successfully!\n")
+			fmt.Printf("📝 Name: %s\n", account.Name)
+			fmt.Printf("📍 Address: %s\n", account.Address.Hex())
+			fmt.Printf("🧭 Path: %s\n", account.Path)
+
+			return nil
+		},
+	}
+}
+
+// getWalletHDExportMnemonicCommand returns the wallet hd-export-mnemonic command
+func (c *CLI) getWalletHDExportMnemonicCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "hd-export-mnemonic",
+		Usage: "Export the mnemonic phrase backing an HD wallet",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Category: "Keystore",
+				Aliases:  []string{"n"},
+				Usage:    "HD wallet name",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "password",
+				Category: "Keystore",
+				Aliases:  []string{"p"},
+				Usage:    "Wallet password",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "dir",
+				Category: "Keystore",
+				Aliases:  []string{"d"},
+				Usage:    "Wallet directory path",
+				Value:    c.config.Keystore.Path,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			name := ctx.String("name")
+			password := ctx.String("password")
+			dir := ctx.String("dir")
+
+			c.logger.Info("Exporting HD mnemonic", "name", name, "path", dir)
+
+			ks := c.newKeystore(dir)
+			mnemonic, err := ks.ExportMnemonic(name, password)
+			if err != nil {
+				return fmt.Errorf("failed to export mnemonic: %w", err)
+			}
+
+			fmt.Printf("⚠️  WARNING: Keep your mnemonic secure!\n")
+			fmt.Printf("📝 Wallet: %s\n", name)
+			fmt.Printf("🔑 Mnemonic: %s\n", mnemonic)
+
+			return nil
+		},
+	}
+}
+
+// getWalletMnemonicCommand returns the wallet mnemonic command group:
+// standalone BIP-39 generation and validation, independent of any keystore
+// account, for a caller that wants a phrase to write down (or check) before
+// ever handing it to `hd-create --mnemonic`.
+func (c *CLI) getWalletMnemonicCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "mnemonic",
+		Usage: "Generate or validate a BIP-39 mnemonic",
+		Subcommands: []*cli.Command{
+			c.getWalletMnemonicNewCommand(),
+			c.getWalletMnemonicImportCommand(),
+		},
+	}
+}
+
+// getWalletMnemonicNewCommand returns the wallet mnemonic new command
+func (c *CLI) getWalletMnemonicNewCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "new",
+		Usage: "Generate a new BIP-39 mnemonic",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:     "words",
+				Category: "Keystore",
+				Usage:    "Mnemonic word count (12 or 24)",
+				Value:    12,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			var bits int
+			switch words := ctx.Int("words"); words {
+			case 12:
+				bits = 128
+			case 24:
+				bits = 256
+			default:
+				return fmt.Errorf("unsupported mnemonic word count %d (want 12 or 24)", words)
+			}
+
+			mnemonic, err := keystore.NewMnemonic(bits)
+			if err != nil {
+				return fmt.Errorf("failed to generate mnemonic: %w", err)
+			}
+
+			fmt.Printf("⚠️  Save this mnemonic somewhere safe, it will not be shown again:\n")
+			fmt.Printf("🔑 %s\n", mnemonic)
+
+			return nil
+		},
+	}
+}
+
+// getWalletMnemonicImportCommand returns the wallet mnemonic import command:
+// it checks that a phrase is a well-formed BIP-39 mnemonic (every word
+// known, checksum bits matching) before a caller trusts it enough to pass
+// to `hd-create --mnemonic`.
+func (c *CLI) getWalletMnemonicImportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "import",
+		Usage: "Validate an existing BIP-39 mnemonic",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "words",
+				Category: "Keystore",
+				Usage:    "Mnemonic phrase to validate",
+				Required: true,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			mnemonic := ctx.String("words")
+
+			if !keystore.ValidateMnemonic(mnemonic) {
+				return fmt.Errorf("invalid mnemonic")
+			}
+
+			fmt.Printf("✅ Mnemonic is valid\n")
+			return nil
+		},
+	}
+}
@@ -11,78 +11,113 @@ import (
 // Config represents the application configuration
 type Config struct {
 	// General settings
-	ConfigPath string `mapstructure:"config_path"`
-	LogLevel   string `mapstructure:"log_level"`
-	Network    string `mapstructure:"network"`
+	ConfigPath string `mapstructure:"config_path" yaml:"config_path"`
+	LogLevel   string `mapstructure:"log_level" yaml:"log_level"`
+	Network    string `mapstructure:"network" yaml:"network"`
 
 	// RPC settings
-	RPC RPCConfig `mapstructure:"rpc"`
+	RPC RPCConfig `mapstructure:"rpc" yaml:"rpc"`
 
 	// Keystore settings
-	Keystore KeystoreConfig `mapstructure:"keystore"`
+	Keystore KeystoreConfig `mapstructure:"keystore" yaml:"keystore"`
+
+	// Wallet settings
+	Wallet WalletConfig `mapstructure:"wallet" yaml:"wallet"`
 
 	// Node settings
-	Node NodeConfig `mapstructure:"node"`
+	Node NodeConfig `mapstructure:"node" yaml:"node"`
 
 	// Validator settings
-	Validator ValidatorConfig `mapstructure:"validator"`
+	Validator ValidatorConfig `mapstructure:"validator" yaml:"validator"`
 
 	// API settings
-	API APIConfig `mapstructure:"api"`
+	API APIConfig `mapstructure:"api" yaml:"api"`
 
 	// Logging settings
-	Logging LoggingConfig `mapstructure:"logging"`
+	Logging LoggingConfig `mapstructure:"logging" yaml:"logging"`
+
+	// watch holds the state Watch needs across reload's swap (registered
+	// validators/listeners and their guarding mutex). It's unexported and
+	// untagged, so viper's Unmarshal never touches it and a staged Config
+	// always starts with it nil; reload copies the old one across, while
+	// Reload's *c = *newConfig replaces it with that nil.
+	watch *watchState
 }
 
 // RPCConfig contains RPC configuration
 type RPCConfig struct {
-	URL             string `mapstructure:"url"`
-	Timeout         int    `mapstructure:"timeout"`
-	MaxConnections  int    `mapstructure:"max_connections"`
-	EnableWebSocket bool   `mapstructure:"enable_websocket"`
+	URL             string `mapstructure:"url" yaml:"url"`
+	Timeout         int    `mapstructure:"timeout" yaml:"timeout"`
+	MaxConnections  int    `mapstructure:"max_connections" yaml:"max_connections"`
+	EnableWebSocket bool   `mapstructure:"enable_websocket" yaml:"enable_websocket"`
+
+	// APIs lists the RPC namespaces this CLI expects its configured node to
+	// serve (the client-side counterpart of the node's own --http.api/
+	// --ws.api whitelist) — informational today, since every rpcclient
+	// call just surfaces whatever error the node's own namespace gate
+	// returns if a command needs a namespace the node hasn't enabled.
+	APIs []string `mapstructure:"apis" yaml:"apis"`
 }
 
 // KeystoreConfig contains keystore configuration
 type KeystoreConfig struct {
-	Path       string `mapstructure:"path"`
-	Password   string `mapstructure:"password"`
-	Encryption string `mapstructure:"encryption"`
+	Path       string `mapstructure:"path" yaml:"path"`
+	Password   string `mapstructure:"password" yaml:"password"`
+	Encryption string `mapstructure:"encryption" yaml:"encryption"`
+}
+
+// WalletConfig contains the CLI's remote-wallet-daemon settings. URL is
+// empty by default, meaning signing commands unlock the local Keystore
+// directly instead of delegating to a `diora wallet daemon`.
+type WalletConfig struct {
+	URL   string `mapstructure:"url" yaml:"url"`
+	Token string `mapstructure:"token" yaml:"token"`
 }
 
 // NodeConfig contains node configuration
 type NodeConfig struct {
-	DataDir     string `mapstructure:"data_dir"`
-	GenesisFile string `mapstructure:"genesis_file"`
-	HTTPPort    int    `mapstructure:"http_port"`
-	WSPort      int    `mapstructure:"ws_port"`
-	P2PPort     int    `mapstructure:"p2p_port"`
+	DataDir     string `mapstructure:"data_dir" yaml:"data_dir"`
+	GenesisFile string `mapstructure:"genesis_file" yaml:"genesis_file"`
+	HTTPPort    int    `mapstructure:"http_port" yaml:"http_port"`
+	WSPort      int    `mapstructure:"ws_port" yaml:"ws_port"`
+	P2PPort     int    `mapstructure:"p2p_port" yaml:"p2p_port"`
+
+	// Light, when true, starts the node as an on-demand-retrieval light
+	// client (see package light) instead of syncing full state.
+	Light bool `mapstructure:"light" yaml:"light"`
 }
 
 // ValidatorConfig contains validator configuration
 type ValidatorConfig struct {
-	Enabled      bool   `mapstructure:"enabled"`
-	StakeAmount  string `mapstructure:"stake_amount"`
-	Commission   string `mapstructure:"commission"`
-	ValidatorKey string `mapstructure:"validator_key"`
-	MinGasPrice  string `mapstructure:"min_gas_price"`
+	Enabled      bool   `mapstructure:"enabled" yaml:"enabled"`
+	StakeAmount  string `mapstructure:"stake_amount" yaml:"stake_amount"`
+	Commission   string `mapstructure:"commission" yaml:"commission"`
+	ValidatorKey string `mapstructure:"validator_key" yaml:"validator_key"`
+	MinGasPrice  string `mapstructure:"min_gas_price" yaml:"min_gas_price"`
 }
 
 // APIConfig contains API configuration
 type APIConfig struct {
-	Enabled bool   `mapstructure:"enabled"`
-	Port    int    `mapstructure:"port"`
-	Host    string `mapstructure:"host"`
-	CORS    string `mapstructure:"cors"`
+	Enabled bool   `mapstructure:"enabled" yaml:"enabled"`
+	Port    int    `mapstructure:"port" yaml:"port"`
+	Host    string `mapstructure:"host" yaml:"host"`
+	CORS    string `mapstructure:"cors" yaml:"cors"`
 }
 
 // LoggingConfig contains logging configuration
 type LoggingConfig struct {
-	Level      string `mapstructure:"level"`
-	Format     string `mapstructure:"format"`
-	Output     string `mapstructure:"output"`
-	MaxSize    int    `mapstructure:"max_size"`
-	MaxBackups int    `mapstructure:"max_backups"`
-	MaxAge     int    `mapstructure:"max_age"`
+	Level      string `mapstructure:"level" yaml:"level"`
+	Format     string `mapstructure:"format" yaml:"format"`
+	Output     string `mapstructure:"output" yaml:"output"`
+	MaxSize    int    `mapstructure:"max_size" yaml:"max_size"`
+	MaxBackups int    `mapstructure:"max_backups" yaml:"max_backups"`
+	MaxAge     int    `mapstructure:"max_age" yaml:"max_age"`
+
+	// Modules sets a per-subsystem log level (e.g. "core", "p2p", "miner"),
+	// overriding Level for that module only. Keyed and consumed the way
+	// logger.Registry.Configure expects: log.modules.core=debug,
+	// log.modules.p2p=info.
+	Modules map[string]string `mapstructure:"modules" yaml:"modules,omitempty"`
 }
 
 // LoadConfig loads configuration from file and environment
@@ -160,48 +195,59 @@ func (c *Config) Save() error {
 	return nil
 }
 
-// setDefaults sets default configuration values
+// setDefaults seeds viper's defaults from DefaultConfig, so LoadConfig and
+// `config reset`/`config init` (which regenerate straight from
+// DefaultConfig) can never drift apart.
 func setDefaults() {
+	d := DefaultConfig()
+
 	// General defaults
-	viper.SetDefault("log_level", "info")
-	viper.SetDefault("network", "mainnet")
+	viper.SetDefault("log_level", d.LogLevel)
+	viper.SetDefault("network", d.Network)
 
 	// RPC defaults
-	viper.SetDefault("rpc.url", "http://localhost:8545")
-	viper.SetDefault("rpc.timeout", 30)
-	viper.SetDefault("rpc.max_connections", 100)
-	viper.SetDefault("rpc.enable_websocket", true)
+	viper.SetDefault("rpc.url", d.RPC.URL)
+	viper.SetDefault("rpc.timeout", d.RPC.Timeout)
+	viper.SetDefault("rpc.max_connections", d.RPC.MaxConnections)
+	viper.SetDefault("rpc.enable_websocket", d.RPC.EnableWebSocket)
+	viper.SetDefault("rpc.apis", d.RPC.APIs)
 
 	// Keystore defaults
-	viper.SetDefault("keystore.path", "$HOME/.diora/keystore")
-	viper.SetDefault("keystore.encryption", "aes256")
+	viper.SetDefault("keystore.path", d.Keystore.Path)
+	viper.SetDefault("keystore.encryption", d.Keystore.Encryption)
+
+	// Wallet daemon defaults (empty URL means sign against the local
+	// keystore directly)
+	viper.SetDefault("wallet.url", d.Wallet.URL)
+	viper.SetDefault("wallet.token", d.Wallet.Token)
 
 	// Node defaults
-	viper.SetDefault("node.data_dir", "$HOME/.diora/data")
-	viper.SetDefault("node.genesis_file", "genesis.json")
-	viper.SetDefault("node.http_port", 8545)
-	viper.SetDefault("node.ws_port", 8546)
-	viper.SetDefault("node.p2p_port", 30303)
+	viper.SetDefault("node.data_dir", d.Node.DataDir)
+	viper.SetDefault("node.genesis_file", d.Node.GenesisFile)
+	viper.SetDefault("node.http_port", d.Node.HTTPPort)
+	viper.SetDefault("node.ws_port", d.Node.WSPort)
+	viper.SetDefault("node.p2p_port", d.Node.P2PPort)
+	viper.SetDefault("node.light", d.Node.Light)
 
 	// Validator defaults
-	viper.SetDefault("validator.enabled", false)
-	viper.SetDefault("validator.stake_amount", "1000000000000000000") // 1 DIO
-	viper.SetDefault("validator.commission", "0.1")                   // 10%
-	viper.SetDefault("validator.min_gas_price", "1000000000")         // 1 Gwei
+	viper.SetDefault("validator.enabled", d.Validator.Enabled)
+	viper.SetDefault("validator.stake_amount", d.Validator.StakeAmount) // 1 DIO
+	viper.SetDefault("validator.commission", d.Validator.Commission)    // 10%
+	viper.SetDefault("validator.min_gas_price", d.Validator.MinGasPrice)
 
 	// API defaults
-	viper.SetDefault("api.enabled", true)
-	viper.SetDefault("api.port", 8080)
-	viper.SetDefault("api.host", "0.0.0.0")
-	viper.SetDefault("api.cors", "*")
+	viper.SetDefault("api.enabled", d.API.Enabled)
+	viper.SetDefault("api.port", d.API.Port)
+	viper.SetDefault("api.host", d.API.Host)
+	viper.SetDefault("api.cors", d.API.CORS)
 
 	// Logging defaults
-	viper.SetDefault("logging.level", "info")
-	viper.SetDefault("logging.format", "json")
-	viper.SetDefault("logging.output", "stdout")
-	viper.SetDefault("logging.max_size", 100)
-	viper.SetDefault("logging.max_backups", 3)
-	viper.SetDefault("logging.max_age", 28)
+	viper.SetDefault("logging.level", d.Logging.Level)
+	viper.SetDefault("logging.format", d.Logging.Format)
+	viper.SetDefault("logging.output", d.Logging.Output)
+	viper.SetDefault("logging.max_size", d.Logging.MaxSize)
+	viper.SetDefault("logging.max_backups", d.Logging.MaxBackups)
+	viper.SetDefault("logging.max_age", d.Logging.MaxAge)
 }
 
 // getConfigPath returns the configuration file path
@@ -0,0 +1,50 @@
+package config
+
+// DefaultConfig returns the hard-coded defaults setDefaults seeds viper
+// with, as a literal *Config. `config reset`/`config init`/Unset regenerate
+// straight from this rather than round-tripping through viper, since
+// neither command runs against a live viper instance the way LoadConfig
+// does.
+func DefaultConfig() *Config {
+	return &Config{
+		LogLevel: "info",
+		Network:  "mainnet",
+		RPC: RPCConfig{
+			URL:             "http://localhost:8545",
+			Timeout:         30,
+			MaxConnections:  100,
+			EnableWebSocket: true,
+			APIs:            []string{"eth", "net", "web3", "governance"},
+		},
+		Keystore: KeystoreConfig{
+			Path:       "$HOME/.diora/keystore",
+			Encryption: "scrypt",
+		},
+		Node: NodeConfig{
+			DataDir:     "$HOME/.diora/data",
+			GenesisFile: "genesis.json",
+			HTTPPort:    8545,
+			WSPort:      8546,
+			P2PPort:     30303,
+		},
+		Validator: ValidatorConfig{
+			StakeAmount: "1000000000000000000", // 1 DIO
+			Commission:  "10",                  // 10%
+			MinGasPrice: "1000000000",          // 1 Gwei
+		},
+		API: APIConfig{
+			Enabled: true,
+			Port:    8080,
+			Host:    "0.0.0.0",
+			CORS:    "*",
+		},
+		Logging: LoggingConfig{
+			Level:      "info",
+			Format:     "json",
+			Output:     "stdout",
+			MaxSize:    100,
+			MaxBackups: 3,
+			MaxAge:     28,
+		},
+	}
+}
@@ -0,0 +1,254 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fieldByPath navigates v (a reflect.Value of a Config, addressable) along
+// path's dot-separated mapstructure segments (e.g. "rpc.timeout"), the same
+// tag diffConfig already walks to build ConfigChange.Path, returning the
+// leaf field's reflect.Value.
+func fieldByPath(v reflect.Value, path string) (reflect.Value, error) {
+	cur := v
+	var walked []string
+	for _, seg := range strings.Split(path, ".") {
+		if cur.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("config: %q is not a section", strings.Join(walked, "."))
+		}
+		field, ok := fieldByTag(cur, seg)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("config: unknown key %q", path)
+		}
+		cur = field
+		walked = append(walked, seg)
+	}
+	return cur, nil
+}
+
+// fieldByTag finds v's field whose mapstructure tag (falling back to its
+// lowercased Go name) equals tag, mirroring diffConfig's own tag lookup.
+func fieldByTag(v reflect.Value, tag string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		name := f.Tag.Get("mapstructure")
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		if name == tag {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// Get returns the value stored at dotted path (e.g. "rpc.url").
+func (c *Config) Get(path string) (any, error) {
+	field, err := fieldByPath(reflect.ValueOf(c).Elem(), path)
+	if err != nil {
+		return nil, err
+	}
+	if field.Kind() == reflect.Struct {
+		return nil, fmt.Errorf("config: %q is a section, not a value", path)
+	}
+	return field.Interface(), nil
+}
+
+// Set parses raw (a --value string) into path's field type, validating it
+// with path's fieldValidators entry if one exists, and stores the result on
+// c. It returns the field's prior value so callers (config set --dry-run,
+// Watch's ConfigChange) can report a diff; it does not persist c to disk.
+func (c *Config) Set(path, raw string) (old any, err error) {
+	field, err := fieldByPath(reflect.ValueOf(c).Elem(), path)
+	if err != nil {
+		return nil, err
+	}
+	if field.Kind() == reflect.Struct {
+		return nil, fmt.Errorf("config: %q is a section, not a settable key", path)
+	}
+	old = field.Interface()
+
+	value, err := coerce(path, field.Type(), raw)
+	if err != nil {
+		return nil, err
+	}
+	field.Set(reflect.ValueOf(value))
+	return old, nil
+}
+
+// Unset resets path's field to DefaultConfig's value at the same path,
+// returning the field's prior value.
+func (c *Config) Unset(path string) (old any, err error) {
+	field, err := fieldByPath(reflect.ValueOf(c).Elem(), path)
+	if err != nil {
+		return nil, err
+	}
+	if field.Kind() == reflect.Struct {
+		return nil, fmt.Errorf("config: %q is a section, not a settable key", path)
+	}
+	old = field.Interface()
+
+	defaultField, err := fieldByPath(reflect.ValueOf(DefaultConfig()).Elem(), path)
+	if err != nil {
+		return nil, err
+	}
+	field.Set(defaultField)
+	return old, nil
+}
+
+// ResetSection restores path (a section such as "rpc", or "" for the whole
+// config) to DefaultConfig's values, returning every changed leaf as a
+// ConfigChange in the same shape Watch reports to its listeners. A full
+// reset preserves ConfigPath and the watch state across the swap, the same
+// way reload does, since neither is a user-facing setting DefaultConfig
+// models.
+func (c *Config) ResetSection(path string) ([]ConfigChange, error) {
+	def := DefaultConfig()
+
+	if path == "" {
+		before := *c
+		configPath, watch := c.ConfigPath, c.watch
+		*c = *def
+		c.ConfigPath, c.watch = configPath, watch
+		return diffConfig("", reflect.ValueOf(before), reflect.ValueOf(*c)), nil
+	}
+
+	cur, err := fieldByPath(reflect.ValueOf(c).Elem(), path)
+	if err != nil {
+		return nil, err
+	}
+	defVal, err := fieldByPath(reflect.ValueOf(def).Elem(), path)
+	if err != nil {
+		return nil, err
+	}
+	if cur.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config: %q is not a section", path)
+	}
+
+	before := reflect.New(cur.Type()).Elem()
+	before.Set(cur)
+	cur.Set(defVal)
+	return diffConfig(path, before, cur), nil
+}
+
+// fieldValidator coerces and validates a --value string for one dotted
+// path, returning the Go value Set stores (already the field's concrete
+// type, so field.Set(reflect.ValueOf(result)) never panics).
+type fieldValidator func(raw string) (any, error)
+
+// fieldValidators covers every key with a constraint tighter than "parses
+// as the field's Go type" — ports, URLs, percentages and the
+// keystore.encryption enum. Keys with no entry here fall back to coerce's
+// plain string/bool/int conversion.
+var fieldValidators = map[string]fieldValidator{
+	"rpc.url":             validateURL,
+	"rpc.timeout":         validateDurationSeconds,
+	"rpc.max_connections": validateNonNegativeInt,
+	"node.http_port":      validatePort,
+	"node.ws_port":        validatePort,
+	"node.p2p_port":       validatePort,
+	"api.port":            validatePort,
+	"validator.commission": func(raw string) (any, error) {
+		return validatePercentage("validator.commission", raw)
+	},
+	"keystore.encryption": validateKeystoreEncryption,
+}
+
+// coerce converts raw into fieldType, routing through path's fieldValidators
+// entry when one exists and otherwise applying the plain conversion its
+// reflect.Kind implies (string passes through as-is, bool via
+// strconv.ParseBool, int via strconv.Atoi).
+func coerce(path string, fieldType reflect.Type, raw string) (any, error) {
+	if validate, ok := fieldValidators[path]; ok {
+		return validate(raw)
+	}
+
+	switch fieldType.Kind() {
+	case reflect.String:
+		return raw, nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("config: %q: invalid bool %q", path, raw)
+		}
+		return b, nil
+	case reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("config: %q: invalid integer %q", path, raw)
+		}
+		return n, nil
+	default:
+		return nil, fmt.Errorf("config: %q: unsupported field type %s", path, fieldType)
+	}
+}
+
+func validateURL(raw string) (any, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("config: invalid URL %q", raw)
+	}
+	return raw, nil
+}
+
+// validateDurationSeconds accepts either a plain integer (seconds, the
+// field's own wire format) or a Go duration string ("30s", "1m"), so
+// `config set rpc.timeout 1m` and `config set rpc.timeout 60` both work.
+func validateDurationSeconds(raw string) (any, error) {
+	if seconds, err := strconv.Atoi(raw); err == nil {
+		return seconds, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return nil, fmt.Errorf("config: invalid duration %q", raw)
+	}
+	return int(d.Seconds()), nil
+}
+
+func validatePort(raw string) (any, error) {
+	port, err := strconv.Atoi(raw)
+	if err != nil || port < 1 || port > 65535 {
+		return nil, fmt.Errorf("config: invalid port %q: must be 1-65535", raw)
+	}
+	return port, nil
+}
+
+func validateNonNegativeInt(raw string) (any, error) {
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("config: invalid non-negative integer %q", raw)
+	}
+	return n, nil
+}
+
+// validatePercentage validates raw as a 0-100 percentage, returning it
+// unchanged as the string the field stores it as (e.g.
+// ValidatorConfig.Commission).
+func validatePercentage(path, raw string) (any, error) {
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil || v < 0 || v > 100 {
+		return nil, fmt.Errorf("config: %q: invalid percentage %q: must be 0-100", path, raw)
+	}
+	return raw, nil
+}
+
+// validateKeystoreEncryption restricts keystore.encryption to the KDFs
+// internal/cli's newKeystore actually switches on (keystore.KDFScrypt,
+// keystore.KDFPBKDF2); the string literals are duplicated rather than
+// imported to keep this package independent of internal/keystore.
+func validateKeystoreEncryption(raw string) (any, error) {
+	switch raw {
+	case "scrypt", "pbkdf2":
+		return raw, nil
+	default:
+		return nil, fmt.Errorf("config: invalid keystore.encryption %q: must be %q or %q", raw, "scrypt", "pbkdf2")
+	}
+}
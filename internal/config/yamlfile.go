@@ -0,0 +1,170 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WriteFile atomically writes c to path (ConfigPath's default when path is
+// empty): marshaled to YAML via the yaml tags config.go's structs carry
+// alongside their mapstructure ones, written to a temp file created
+// alongside path, fsynced, then renamed over path so a reader never
+// observes a partially written file. `config set`/`config unset`/`config
+// reset` all persist through this; it does not preserve a hand-edited
+// file's comments — `config init`'s Template/WriteTemplate is the only
+// path that writes comments, since DefaultConfig alone carries none to
+// round-trip.
+func WriteFile(c *Config, path string) error {
+	if path == "" {
+		path = getDefaultConfigPath()
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+	return atomicWrite(path, data)
+}
+
+// WriteTemplate atomically writes Template()'s fully-commented YAML to
+// path (ConfigPath's default when path is empty), the file `config init`
+// produces.
+func WriteTemplate(path string) error {
+	if path == "" {
+		path = getDefaultConfigPath()
+	}
+	return atomicWrite(path, Template())
+}
+
+// atomicWrite writes data to path via a temp file in path's own directory,
+// fsynced before the rename so the write is durable before it becomes
+// visible, and renamed over path so a concurrent reader always sees either
+// the whole old file or the whole new one, never a partial write.
+func atomicWrite(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".diora-config-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp config file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temp config file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to fsync temp config file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temp config file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		return fmt.Errorf("failed to set temp config file permissions: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to rename temp config file into place: %w", err)
+	}
+	return nil
+}
+
+// Template renders DefaultConfig as a fully-commented YAML document: every
+// section and leaf key gets a one-line comment explaining what it
+// controls, for `config init` to write instead of an uncommented
+// yaml.Marshal dump.
+func Template() []byte {
+	doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{templateRoot()}}
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		// templateRoot is built entirely from static strings and
+		// DefaultConfig's own fields below; an error here is a bug in this
+		// function, not bad input.
+		panic(fmt.Sprintf("config: template marshal: %v", err))
+	}
+	return data
+}
+
+func templateRoot() *yaml.Node {
+	d := DefaultConfig()
+	return mapping(
+		entry("log_level", d.LogLevel, "Minimum log level: trace, debug, info, warn, error."),
+		entry("network", d.Network, "Network name this CLI/node identifies as (mainnet, testnet, devnet, ...)."),
+		section("rpc", "JSON-RPC client settings used by every RPC-backed command.", mapping(
+			entry("url", d.RPC.URL, "JSON-RPC endpoint URL; overridden per-invocation by --rpc-url."),
+			entry("timeout", d.RPC.Timeout, "Request timeout, in seconds."),
+			entry("max_connections", d.RPC.MaxConnections, "Max concurrent RPC connections the client pool allows."),
+			entry("enable_websocket", d.RPC.EnableWebSocket, "Use a WS subscription for `monitor --follow` instead of polling."),
+			entry("apis", d.RPC.APIs, "RPC namespaces this CLI expects its configured node to serve."),
+		)),
+		section("keystore", "Local account keystore settings.", mapping(
+			entry("path", d.Keystore.Path, "Keystore directory."),
+			entry("encryption", d.Keystore.Encryption, "KDF used to encrypt new keys: scrypt or pbkdf2."),
+		)),
+		section("wallet", "Remote wallet daemon settings; leave url empty to sign against the local keystore.", mapping(
+			entry("url", d.Wallet.URL, "diora wallet daemon address (unix:///path/to/socket or http://host:port)."),
+			entry("token", d.Wallet.Token, "Bearer token authenticating to a TCP wallet-url."),
+		)),
+		section("node", "Local node settings used by `diora node`/`diora dev node`.", mapping(
+			entry("data_dir", d.Node.DataDir, "Node data directory."),
+			entry("genesis_file", d.Node.GenesisFile, "Genesis file path."),
+			entry("http_port", d.Node.HTTPPort, "HTTP JSON-RPC port."),
+			entry("ws_port", d.Node.WSPort, "WebSocket JSON-RPC port."),
+			entry("p2p_port", d.Node.P2PPort, "P2P listen port."),
+			entry("light", d.Node.Light, "Run as an on-demand-retrieval light client instead of syncing full state."),
+		)),
+		section("validator", "Validator settings used by `diora validator`/`diora dev node --mine`.", mapping(
+			entry("enabled", d.Validator.Enabled, "Run this node as a validator."),
+			entry("stake_amount", d.Validator.StakeAmount, "Stake amount, in wei."),
+			entry("commission", d.Validator.Commission, "Commission rate, as a percentage (0-100)."),
+			entry("validator_key", d.Validator.ValidatorKey, "Keystore address of the validator's consensus key."),
+			entry("min_gas_price", d.Validator.MinGasPrice, "Minimum gas price this validator accepts, in wei."),
+		)),
+		section("api", "Embedded REST/WS API server settings.", mapping(
+			entry("enabled", d.API.Enabled, "Serve the REST/WS API."),
+			entry("port", d.API.Port, "API listen port."),
+			entry("host", d.API.Host, "API listen address."),
+			entry("cors", d.API.CORS, "Access-Control-Allow-Origin value."),
+		)),
+		section("logging", "Structured logging settings.", mapping(
+			entry("level", d.Logging.Level, "Default log level."),
+			entry("format", d.Logging.Format, "Log encoding: json or text."),
+			entry("output", d.Logging.Output, "Log destination: stdout or a file path."),
+			entry("max_size", d.Logging.MaxSize, "Max log file size, in MB, before rotation."),
+			entry("max_backups", d.Logging.MaxBackups, "Rotated log files to keep."),
+			entry("max_age", d.Logging.MaxAge, "Days to keep rotated log files."),
+		)),
+	)
+}
+
+// mapping builds a YAML mapping node out of key/value node pairs, in order.
+func mapping(pairs ...[2]*yaml.Node) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, pair := range pairs {
+		node.Content = append(node.Content, pair[0], pair[1])
+	}
+	return node
+}
+
+// entry encodes value as key's YAML value node, with comment attached as
+// the key's HeadComment (what yaml.v3 renders as a line above "key: ...").
+func entry(key string, value any, comment string) [2]*yaml.Node {
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: key, HeadComment: comment}
+	valueNode := &yaml.Node{}
+	if err := valueNode.Encode(value); err != nil {
+		panic(fmt.Sprintf("config: template encode %s: %v", key, err))
+	}
+	return [2]*yaml.Node{keyNode, valueNode}
+}
+
+// section is entry's counterpart for a nested mapping (an already-built
+// section node) instead of a scalar/sequence leaf.
+func section(key, comment string, value *yaml.Node) [2]*yaml.Node {
+	return [2]*yaml.Node{{Kind: yaml.ScalarNode, Value: key, HeadComment: comment}, value}
+}
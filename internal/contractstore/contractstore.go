@@ -0,0 +1,145 @@
+// Package contractstore persists what the CLI's contract subcommands learn
+// about a deployment locally — one JSON file per deployer account listing
+// the contracts it has deployed, plus one JSON file per address recording
+// a successful source-verification — so commands like `contract list` and
+// `contract verify` have real state to work from across invocations instead
+// of re-deriving it from the chain every time.
+package contractstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Record is one contract a deployer account has deployed, as appended to
+// that account's index by Add.
+type Record struct {
+	Address    common.Address  `json:"address"`
+	Name       string          `json:"name,omitempty"`
+	Type       string          `json:"type,omitempty"`
+	TxHash     common.Hash     `json:"tx_hash"`
+	Deployer   common.Address  `json:"deployer"`
+	ABI        json.RawMessage `json:"abi,omitempty"`
+	DeployedAt time.Time       `json:"deployed_at"`
+}
+
+// Verification is a successful `contract verify` outcome for one address,
+// as written to <dir>/verified/<address>.json.
+type Verification struct {
+	Address    common.Address  `json:"address"`
+	Source     string          `json:"source"`
+	Compiler   string          `json:"compiler"`
+	Optimizer  string          `json:"optimizer"`
+	ABI        json.RawMessage `json:"abi"`
+	VerifiedAt time.Time       `json:"verified_at"`
+}
+
+// Store roots a deployed-contracts index and a verification record store
+// at dir, creating it on first write rather than at construction — the
+// same lazy-MkdirAll the keystore package's own save paths use.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a Store rooted at dir.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+func (s *Store) indexPath(account common.Address) string {
+	return filepath.Join(s.dir, account.Hex()+".json")
+}
+
+func (s *Store) verificationPath(address common.Address) string {
+	return filepath.Join(s.dir, "verified", address.Hex()+".json")
+}
+
+// Add appends rec to account's deployed-contracts index, creating it if
+// this is account's first deployment.
+func (s *Store) Add(account common.Address, rec Record) error {
+	records, err := s.List(account)
+	if err != nil {
+		return err
+	}
+	records = append(records, rec)
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create contract store directory: %w", err)
+	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal contract index for %s: %w", account.Hex(), err)
+	}
+	path := s.indexPath(account)
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write contract index for %s: %w", account.Hex(), err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to commit contract index for %s: %w", account.Hex(), err)
+	}
+	return nil
+}
+
+// List returns account's deployed-contracts index, oldest first. A missing
+// index (an account that has never deployed through this CLI) returns an
+// empty slice rather than an error.
+func (s *Store) List(account common.Address) ([]Record, error) {
+	data, err := ioutil.ReadFile(s.indexPath(account))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contract index for %s: %w", account.Hex(), err)
+	}
+	var records []Record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse contract index for %s: %w", account.Hex(), err)
+	}
+	return records, nil
+}
+
+// SaveVerification writes rec as address's verification record, replacing
+// any prior one.
+func (s *Store) SaveVerification(rec Verification) error {
+	dir := filepath.Join(s.dir, "verified")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create verification directory: %w", err)
+	}
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal verification record for %s: %w", rec.Address.Hex(), err)
+	}
+	path := s.verificationPath(rec.Address)
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write verification record for %s: %w", rec.Address.Hex(), err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to commit verification record for %s: %w", rec.Address.Hex(), err)
+	}
+	return nil
+}
+
+// LoadVerification reads address's verification record, or (nil, nil) if
+// it has never been verified through this CLI.
+func (s *Store) LoadVerification(address common.Address) (*Verification, error) {
+	data, err := ioutil.ReadFile(s.verificationPath(address))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read verification record for %s: %w", address.Hex(), err)
+	}
+	var rec Verification
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("failed to parse verification record for %s: %w", address.Hex(), err)
+	}
+	return &rec, nil
+}
@@ -0,0 +1,218 @@
+package keystore
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CacheEvent is broadcast to accountCache subscribers whenever an
+// encrypted key file appears or disappears from the keystore directory —
+// accounts.KeystoreBackend forwards these as WalletArrived/WalletDropped
+// events so the Manager's feed reflects keys dropped in or removed by an
+// external tool, not just ones this process itself wrote.
+type CacheEvent struct {
+	Address common.Address
+	Path    string
+	Arrived bool // false means the key file was removed
+}
+
+// accountCache is the in-memory index ListAccounts, GetAccount, and
+// findKeystoreFile serve their reads from: a directory watcher (watch.go,
+// or watch_fallback.go's poller where fsnotify isn't available) keeps it
+// current, turning what used to be an O(files) directory scan per call
+// into an O(log n) map lookup.
+type accountCache struct {
+	dir string
+	fc  *fileCache
+
+	mu        sync.RWMutex
+	byName    map[string]*Account       // "<name>.json" stem -> account metadata
+	byAddress map[common.Address]string // address -> its "UTC--..." key file path
+
+	watcher *watcher
+
+	subMu sync.Mutex
+	subs  map[int]chan<- CacheEvent
+	next  int
+}
+
+func newAccountCache(dir string) *accountCache {
+	ac := &accountCache{
+		dir:       dir,
+		fc:        newFileCache(),
+		byName:    make(map[string]*Account),
+		byAddress: make(map[common.Address]string),
+		subs:      make(map[int]chan<- CacheEvent),
+	}
+	ac.scan()
+	ac.watcher = newWatcher(ac)
+	ac.watcher.start()
+	return ac
+}
+
+// accounts returns every cached account, sorted by name for a stable,
+// deterministic ListAccounts order.
+func (ac *accountCache) accounts() []*Account {
+	ac.mu.RLock()
+	defer ac.mu.RUnlock()
+
+	out := make([]*Account, 0, len(ac.byName))
+	for _, a := range ac.byName {
+		cp := *a
+		out = append(out, &cp)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func (ac *accountCache) account(name string) (*Account, bool) {
+	ac.mu.RLock()
+	defer ac.mu.RUnlock()
+	a, ok := ac.byName[name]
+	if !ok {
+		return nil, false
+	}
+	cp := *a
+	return &cp, true
+}
+
+func (ac *accountCache) keyFile(address common.Address) (string, bool) {
+	ac.mu.RLock()
+	defer ac.mu.RUnlock()
+	path, ok := ac.byAddress[address]
+	return path, ok
+}
+
+func (ac *accountCache) keyFiles() []string {
+	ac.mu.RLock()
+	defer ac.mu.RUnlock()
+	out := make([]string, 0, len(ac.byAddress))
+	for _, path := range ac.byAddress {
+		out = append(out, path)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// scan diffs the keystore directory against the cache's last known state
+// and applies whatever changed: account metadata files update byName
+// silently, but key files update byAddress and emit a CacheEvent, since
+// those are what a Backend needs to know about to add or drop a Wallet.
+func (ac *accountCache) scan() {
+	created, updated, deleted, err := ac.fc.scan(ac.dir)
+	if err != nil {
+		return
+	}
+
+	for _, path := range append(created, updated...) {
+		ac.applyFile(path)
+	}
+	for _, path := range deleted {
+		ac.applyRemoval(path)
+	}
+}
+
+func (ac *accountCache) applyFile(path string) {
+	name := filepath.Base(path)
+
+	switch {
+	case strings.HasPrefix(name, "UTC--"):
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return
+		}
+		var enc EncryptedKeystore
+		if err := json.Unmarshal(data, &enc); err != nil {
+			return
+		}
+		address := common.HexToAddress(enc.Address)
+
+		ac.mu.Lock()
+		ac.byAddress[address] = path
+		ac.mu.Unlock()
+		ac.notify(CacheEvent{Address: address, Path: path, Arrived: true})
+
+	case strings.HasSuffix(name, hdIndexSuffix), !strings.HasSuffix(name, ".json"):
+		// HD-wallet index bookkeeping and the encrypted mnemonic
+		// ("HD--<name>") files are neither an account nor a key file.
+
+	default:
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return
+		}
+		var account Account
+		if err := json.Unmarshal(data, &account); err != nil {
+			return
+		}
+
+		ac.mu.Lock()
+		ac.byName[strings.TrimSuffix(name, ".json")] = &account
+		ac.mu.Unlock()
+	}
+}
+
+func (ac *accountCache) applyRemoval(path string) {
+	name := filepath.Base(path)
+
+	switch {
+	case strings.HasPrefix(name, "UTC--"):
+		ac.mu.Lock()
+		var removed common.Address
+		var found bool
+		for addr, p := range ac.byAddress {
+			if p == path {
+				removed, found = addr, true
+				delete(ac.byAddress, addr)
+				break
+			}
+		}
+		ac.mu.Unlock()
+		if found {
+			ac.notify(CacheEvent{Address: removed, Path: path, Arrived: false})
+		}
+
+	case strings.HasSuffix(name, ".json") && !strings.HasSuffix(name, hdIndexSuffix):
+		ac.mu.Lock()
+		delete(ac.byName, strings.TrimSuffix(name, ".json"))
+		ac.mu.Unlock()
+	}
+}
+
+// subscribe registers sink to receive this cache's CacheEvents. The
+// returned function unsubscribes it.
+func (ac *accountCache) subscribe(sink chan<- CacheEvent) func() {
+	ac.subMu.Lock()
+	defer ac.subMu.Unlock()
+	id := ac.next
+	ac.next++
+	ac.subs[id] = sink
+	return func() {
+		ac.subMu.Lock()
+		defer ac.subMu.Unlock()
+		delete(ac.subs, id)
+	}
+}
+
+func (ac *accountCache) notify(event CacheEvent) {
+	ac.subMu.Lock()
+	defer ac.subMu.Unlock()
+	for _, sink := range ac.subs {
+		select {
+		case sink <- event:
+		default:
+		}
+	}
+}
+
+func (ac *accountCache) close() {
+	if ac.watcher != nil {
+		ac.watcher.close()
+	}
+}
@@ -0,0 +1,205 @@
+package keystore
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultHDPath is the BIP-44 path Ethereum wallets (geth, MetaMask,
+// ledger) derive accounts under, stopping one level short of the address
+// index so child(i) can fill it in.
+const DefaultHDPath = "m/44'/60'/0'/0"
+
+// hardenedOffset is added to a path component's index to mark it
+// hardened, per BIP-32.
+const hardenedOffset = 0x80000000
+
+// extendedKey is a single node of a BIP-32 HD tree. Key is nil for a
+// neutered (public-only) key, in which case only non-hardened children can
+// be derived — exactly the property DeriveNext relies on to hand out new
+// addresses without the keystore password.
+type extendedKey struct {
+	Key       []byte // 32 bytes, nil if neutered
+	PubKey    []byte // 33-byte compressed public key
+	ChainCode []byte // 32 bytes
+	Depth     byte
+	ChildNum  uint32
+}
+
+// newMasterKey derives the root extended key from a BIP-39 seed via
+// HMAC-SHA512 keyed with the literal "Bitcoin seed", the same construction
+// every BIP-32 wallet uses regardless of the coin it then derives for.
+func newMasterKey(seed []byte) (*extendedKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key := &extendedKey{Key: sum[:32], ChainCode: sum[32:]}
+	if err := key.validate(); err != nil {
+		return nil, err
+	}
+	key.PubKey = compress(key.Key)
+	return key, nil
+}
+
+func (k *extendedKey) validate() error {
+	if new(big.Int).SetBytes(k.Key).Sign() == 0 {
+		return fmt.Errorf("derived an invalid zero private key")
+	}
+	if new(big.Int).SetBytes(k.Key).Cmp(crypto.S256().Params().N) >= 0 {
+		return fmt.Errorf("derived a private key outside the curve order")
+	}
+	return nil
+}
+
+func compress(priv []byte) []byte {
+	x, y := crypto.S256().ScalarBaseMult(priv)
+	return compressPoint(x, y)
+}
+
+func compressPoint(x, y *big.Int) []byte {
+	out := make([]byte, 33)
+	if y.Bit(0) == 0 {
+		out[0] = 0x02
+	} else {
+		out[0] = 0x03
+	}
+	x.FillBytes(out[1:])
+	return out
+}
+
+// neuter drops the private component, leaving a key that can still derive
+// non-hardened children (enough to hand out new receive addresses) but
+// can no longer sign or derive hardened ones.
+func (k *extendedKey) neuter() *extendedKey {
+	return &extendedKey{PubKey: k.PubKey, ChainCode: k.ChainCode, Depth: k.Depth, ChildNum: k.ChildNum}
+}
+
+// child derives the index'th child of k. Indices >= hardenedOffset are
+// hardened and require k.Key; smaller indices derive from the public key
+// alone, so they also work on a neutered key.
+func (k *extendedKey) child(index uint32) (*extendedKey, error) {
+	var data []byte
+	if index >= hardenedOffset {
+		if k.Key == nil {
+			return nil, fmt.Errorf("cannot derive hardened child %d from a public-only key", index-hardenedOffset)
+		}
+		data = append([]byte{0x00}, k.Key...)
+	} else {
+		data = k.PubKey
+	}
+
+	var idxBytes [4]byte
+	binary.BigEndian.PutUint32(idxBytes[:], index)
+	data = append(data, idxBytes[:]...)
+
+	mac := hmac.New(sha512.New, k.ChainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	il, chainCode := sum[:32], sum[32:]
+
+	ilNum := new(big.Int).SetBytes(il)
+	if ilNum.Cmp(crypto.S256().Params().N) >= 0 {
+		return nil, fmt.Errorf("derived IL outside the curve order, reroll index %d", index)
+	}
+
+	child := &extendedKey{ChainCode: chainCode, Depth: k.Depth + 1, ChildNum: index}
+
+	if k.Key != nil {
+		childNum := new(big.Int).Add(ilNum, new(big.Int).SetBytes(k.Key))
+		childNum.Mod(childNum, crypto.S256().Params().N)
+		if childNum.Sign() == 0 {
+			return nil, fmt.Errorf("derived an invalid zero private key, reroll index %d", index)
+		}
+		keyBytes := make([]byte, 32)
+		childNum.FillBytes(keyBytes)
+		child.Key = keyBytes
+		child.PubKey = compress(child.Key)
+		return child, nil
+	}
+
+	parentX, parentY := decompress(k.PubKey)
+	ilX, ilY := crypto.S256().ScalarBaseMult(il)
+	childX, childY := crypto.S256().Add(parentX, parentY, ilX, ilY)
+	if childX.Sign() == 0 && childY.Sign() == 0 {
+		return nil, fmt.Errorf("derived the point at infinity, reroll index %d", index)
+	}
+	child.PubKey = compressPoint(childX, childY)
+	return child, nil
+}
+
+func decompress(compressed []byte) (*big.Int, *big.Int) {
+	pub, err := crypto.DecompressPubkey(compressed)
+	if err != nil {
+		// compress/child only ever feed this valid points derived from the
+		// curve itself, so a decompress failure means an internal bug.
+		panic(fmt.Sprintf("bip32: invalid compressed point: %v", err))
+	}
+	return pub.X, pub.Y
+}
+
+// ecdsaPrivateKey returns k's private key in the form the rest of the
+// keystore/accounts code already signs with. It fails on a neutered key.
+func (k *extendedKey) ecdsaPrivateKey() (*ecdsa.PrivateKey, error) {
+	if k.Key == nil {
+		return nil, fmt.Errorf("key is public-only, no private key available")
+	}
+	return crypto.ToECDSA(k.Key)
+}
+
+// address derives the Ethereum address for k's public key, valid for both
+// full and neutered keys.
+func (k *extendedKey) address() (common.Address, error) {
+	pub, err := crypto.DecompressPubkey(k.PubKey)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid public key: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// derivePath walks master down path (e.g. "m/44'/60'/0'/0/3"), applying
+// child() once per component. A trailing "'" or "H" on a component marks
+// it hardened.
+func derivePath(master *extendedKey, path string) (*extendedKey, error) {
+	components := strings.Split(path, "/")
+	if len(components) == 0 || components[0] != "m" {
+		return nil, fmt.Errorf("derivation path %q must start with \"m\"", path)
+	}
+
+	key := master
+	for _, c := range components[1:] {
+		index, err := parsePathComponent(c)
+		if err != nil {
+			return nil, fmt.Errorf("derivation path %q: %w", path, err)
+		}
+		key, err = key.child(index)
+		if err != nil {
+			return nil, fmt.Errorf("derivation path %q: %w", path, err)
+		}
+	}
+	return key, nil
+}
+
+func parsePathComponent(c string) (uint32, error) {
+	hardened := strings.HasSuffix(c, "'") || strings.HasSuffix(c, "H")
+	if hardened {
+		c = c[:len(c)-1]
+	}
+	n, err := strconv.ParseUint(c, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid path component %q: %w", c, err)
+	}
+	if hardened {
+		return uint32(n) + hardenedOffset, nil
+	}
+	return uint32(n), nil
+}
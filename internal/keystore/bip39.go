@@ -0,0 +1,159 @@
+package keystore
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// wordlistIndex maps each BIP-39 word back to its position in
+// englishWordlist, built once so ValidateMnemonic doesn't linear-scan 2048
+// strings per word.
+var wordlistIndex = func() map[string]int {
+	idx := make(map[string]int, len(englishWordlist))
+	for i, w := range englishWordlist {
+		idx[w] = i
+	}
+	return idx
+}()
+
+// NewMnemonic generates a BIP-39 mnemonic of the requested entropy size:
+// 128 bits for 12 words, 256 bits for 24 words. Any other bit count is
+// rejected rather than silently rounded, since it would produce a
+// wordlist-incompatible checksum length.
+func NewMnemonic(entropyBits int) (string, error) {
+	if entropyBits != 128 && entropyBits != 256 {
+		return "", fmt.Errorf("unsupported mnemonic entropy size %d bits (want 128 or 256)", entropyBits)
+	}
+
+	entropy := make([]byte, entropyBits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", fmt.Errorf("failed to generate entropy: %w", err)
+	}
+
+	return entropyToMnemonic(entropy)
+}
+
+// wordsToBits converts a BIP-39 word count (12 or 24, the two lengths this
+// package generates) to the entropy size NewMnemonic expects. 0 defaults to
+// 12 words, the length NewHDAccount always generated before it took a word
+// count.
+func wordsToBits(words int) (int, error) {
+	switch words {
+	case 0, 12:
+		return 128, nil
+	case 24:
+		return 256, nil
+	default:
+		return 0, fmt.Errorf("unsupported mnemonic word count %d (want 12 or 24)", words)
+	}
+}
+
+// entropyToMnemonic appends the checksum bits (the first entropyLen/32
+// bits of SHA-256(entropy)) to entropy and splits the result into 11-bit
+// word indices, exactly as the BIP-39 spec defines.
+func entropyToMnemonic(entropy []byte) (string, error) {
+	checksumBits := len(entropy) * 8 / 32
+	hash := sha256.Sum256(entropy)
+
+	bits := append(bytesToBits(entropy), bytesToBits(hash[:])[:checksumBits]...)
+
+	words := make([]string, 0, len(bits)/11)
+	for i := 0; i+11 <= len(bits); i += 11 {
+		words = append(words, englishWordlist[bitsToInt(bits[i:i+11])])
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+// ValidateMnemonic reports whether mnemonic is a well-formed BIP-39 phrase:
+// every word known, word count one of the standard sizes, and the trailing
+// checksum bits matching SHA-256 of the decoded entropy.
+func ValidateMnemonic(mnemonic string) bool {
+	words := strings.Fields(mnemonic)
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return false
+	}
+
+	bits := make([]bool, 0, len(words)*11)
+	for _, w := range words {
+		i, ok := wordlistIndex[w]
+		if !ok {
+			return false
+		}
+		bits = append(bits, intToBits(i, 11)...)
+	}
+
+	checksumBits := len(bits) / 33
+	entropyBits := len(bits) - checksumBits
+	entropy := bitsToBytes(bits[:entropyBits])
+
+	hash := sha256.Sum256(entropy)
+	want := bytesToBits(hash[:])[:checksumBits]
+	got := bits[entropyBits:]
+	for i := range want {
+		if want[i] != got[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// SeedFromMnemonic derives the 64-byte BIP-32 master seed from mnemonic and
+// an optional passphrase, per BIP-39: PBKDF2-HMAC-SHA512 over the mnemonic
+// with salt "mnemonic"+passphrase, 2048 rounds. Both strings are used as
+// given; no NFKD normalization is applied. The mnemonic is not
+// required to be one this package generated — any valid phrase, including
+// one imported from MetaMask/geth, derives the same seed.
+func SeedFromMnemonic(mnemonic, passphrase string) []byte {
+	return pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"+passphrase), 2048, 64, sha512.New)
+}
+
+func bytesToBits(b []byte) []bool {
+	bits := make([]bool, len(b)*8)
+	for i, c := range b {
+		for j := 0; j < 8; j++ {
+			bits[i*8+j] = c&(1<<(7-j)) != 0
+		}
+	}
+	return bits
+}
+
+func bitsToBytes(bits []bool) []byte {
+	out := make([]byte, len(bits)/8)
+	for i := range out {
+		var c byte
+		for j := 0; j < 8; j++ {
+			if bits[i*8+j] {
+				c |= 1 << (7 - j)
+			}
+		}
+		out[i] = c
+	}
+	return out
+}
+
+func bitsToInt(bits []bool) int {
+	n := 0
+	for _, b := range bits {
+		n <<= 1
+		if b {
+			n |= 1
+		}
+	}
+	return n
+}
+
+func intToBits(n, width int) []bool {
+	bits := make([]bool, width)
+	for i := 0; i < width; i++ {
+		bits[width-1-i] = n&(1<<i) != 0
+	}
+	return bits
+}
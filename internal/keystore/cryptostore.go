@@ -0,0 +1,374 @@
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+)
+
+// KDF names a keystore's key-derivation function, matching the Ethereum Web3
+// Secret Storage v3 spec's "kdf" field exactly so files produced here decode
+// in geth/MetaMask and vice versa.
+const (
+	KDFScrypt = "scrypt"
+	KDFPBKDF2 = "pbkdf2"
+)
+
+// Scrypt parameters geth's accounts/keystore uses for interactively-unlocked
+// accounts. Higher than these (e.g. geth's "light" 1<<12 variant used for
+// throwaway test keys) trades interoperability confidence for speed; callers
+// wanting that tradeoff should derive their own keystore.
+const (
+	scryptN     = 1 << 18 // 262144
+	scryptR     = 8
+	scryptP     = 1
+	scryptDKLen = 32
+
+	pbkdf2C     = 262144
+	pbkdf2DKLen = 32
+	pbkdf2PRF   = "hmac-sha256"
+
+	cipherAES128CTR = "aes-128-ctr"
+)
+
+// ErrInvalidPassword is returned by decryptV3 when the recomputed MAC
+// doesn't match the one stored in the keystore file — the one error that
+// covers both "wrong password" and "corrupted file", the same ambiguity
+// geth's keystore accepts since distinguishing them would leak information
+// about which is more likely.
+var ErrInvalidPassword = fmt.Errorf("invalid password")
+
+// V3Options customizes EncryptV3's KDF. The zero value encrypts with scrypt
+// at this package's interoperability defaults (N=262144, r=8, p=1) — the
+// same parameters geth uses for interactively-unlocked accounts.
+type V3Options struct {
+	// KDF selects KDFScrypt or KDFPBKDF2; empty defaults to KDFScrypt.
+	KDF string
+	// N, R, P override scrypt's cost parameters when KDF is KDFScrypt; a
+	// zero field falls back to this package's scryptN/scryptR/scryptP
+	// defaults. Ignored for KDFPBKDF2.
+	N, R, P int
+}
+
+// encryptV3 encrypts privateKeyBytes per the Web3 Secret Storage v3 spec:
+// derive a 32-byte key from password via kdf, split it into encKey (used for
+// AES-128-CTR) and macKey (used for the MAC), then store everything needed
+// to reverse it in a Crypto struct. kdf must be KDFScrypt or KDFPBKDF2.
+func encryptV3(privateKeyBytes []byte, password, kdf string) (*Crypto, error) {
+	return encryptV3Opts(privateKeyBytes, password, V3Options{KDF: kdf})
+}
+
+// encryptV3Opts is encryptV3 with scrypt's cost parameters configurable via
+// opts, the common path EncryptV3 and encryptV3 both build on.
+func encryptV3Opts(privateKeyBytes []byte, password string, opts V3Options) (*Crypto, error) {
+	kdf := opts.KDF
+	if kdf == "" {
+		kdf = KDFScrypt
+	}
+
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	derivedKey, kdfParams, err := deriveKeyOpts(password, salt, kdf, opts)
+	if err != nil {
+		return nil, err
+	}
+	encKey, macKey := derivedKey[:16], derivedKey[16:32]
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, fmt.Errorf("failed to generate IV: %w", err)
+	}
+
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	cipherText := make([]byte, len(privateKeyBytes))
+	cipher.NewCTR(block, iv).XORKeyStream(cipherText, privateKeyBytes)
+
+	mac := keccak256Mac(macKey, cipherText)
+
+	return &Crypto{
+		KDF:        kdf,
+		KDFParams:  kdfParams,
+		Cipher:     cipherAES128CTR,
+		CipherText: hex.EncodeToString(cipherText),
+		CipherParams: CipherParamsV3{
+			IV: hex.EncodeToString(iv),
+		},
+		MAC: hex.EncodeToString(mac),
+	}, nil
+}
+
+// decryptV3 reverses encryptV3: it re-derives the key from password and the
+// file's stored KDF parameters, rejects on a MAC mismatch before touching
+// the ciphertext at all, and only then runs AES-128-CTR decryption.
+func decryptV3(c *Crypto, password string) ([]byte, error) {
+	if c.Cipher != cipherAES128CTR {
+		return nil, fmt.Errorf("unsupported cipher %q", c.Cipher)
+	}
+
+	salt, derivedParams, err := parseKDFParams(c.KDF, c.KDFParams)
+	if err != nil {
+		return nil, err
+	}
+	derivedKey, _, err := deriveKeyWithParams(password, salt, c.KDF, derivedParams)
+	if err != nil {
+		return nil, err
+	}
+	encKey, macKey := derivedKey[:16], derivedKey[16:32]
+
+	cipherText, err := hex.DecodeString(c.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ciphertext: %w", err)
+	}
+
+	wantMAC, err := hex.DecodeString(c.MAC)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mac: %w", err)
+	}
+	gotMAC := keccak256Mac(macKey, cipherText)
+	if subtle.ConstantTimeCompare(wantMAC, gotMAC) != 1 {
+		return nil, ErrInvalidPassword
+	}
+
+	iv, err := hex.DecodeString(cipherParamsIV(c.CipherParams))
+	if err != nil {
+		return nil, fmt.Errorf("invalid iv: %w", err)
+	}
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	privateKeyBytes := make([]byte, len(cipherText))
+	cipher.NewCTR(block, iv).XORKeyStream(privateKeyBytes, cipherText)
+	return privateKeyBytes, nil
+}
+
+// keccak256Mac computes keccak256(macKey || cipherText), the MAC the v3 spec
+// specifies — not SHA-256, which only protects against accidental
+// corruption, not a tampered ciphertext paired with a recomputed SHA-256 MAC.
+func keccak256Mac(macKey, cipherText []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(macKey)
+	h.Write(cipherText)
+	return h.Sum(nil)
+}
+
+// ScryptParams is the "kdfparams" object of a scrypt-derived v3 keystore.
+type ScryptParams struct {
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+}
+
+// PBKDF2Params is the "kdfparams" object of a PBKDF2-derived v3 keystore.
+type PBKDF2Params struct {
+	C     int    `json:"c"`
+	PRF   string `json:"prf"`
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+}
+
+// CipherParamsV3 is the "cipherparams" object of a v3 keystore; aes-128-ctr
+// is the only cipher the spec defines, so IV is the only field it needs.
+type CipherParamsV3 struct {
+	IV string `json:"iv"`
+}
+
+// deriveKey picks fresh KDF parameters for kdf (scrypt's defaults or
+// PBKDF2's) and derives a key from password and salt, returning the params
+// alongside so encryptV3 can store them in the keystore file.
+func deriveKey(password string, salt []byte, kdf string) ([]byte, interface{}, error) {
+	switch kdf {
+	case KDFScrypt:
+		dk, err := scrypt.Key([]byte(password), salt, scryptN, scryptR, scryptP, scryptDKLen)
+		if err != nil {
+			return nil, nil, fmt.Errorf("scrypt key derivation failed: %w", err)
+		}
+		return dk, ScryptParams{N: scryptN, R: scryptR, P: scryptP, DKLen: scryptDKLen, Salt: hex.EncodeToString(salt)}, nil
+	case KDFPBKDF2:
+		dk := pbkdf2.Key([]byte(password), salt, pbkdf2C, pbkdf2DKLen, sha256.New)
+		return dk, PBKDF2Params{C: pbkdf2C, PRF: pbkdf2PRF, DKLen: pbkdf2DKLen, Salt: hex.EncodeToString(salt)}, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported kdf %q", kdf)
+	}
+}
+
+// deriveKeyOpts is deriveKey with scrypt's N/r/p overridable via opts,
+// falling back to the package defaults for any zero field.
+func deriveKeyOpts(password string, salt []byte, kdf string, opts V3Options) ([]byte, interface{}, error) {
+	if kdf != KDFScrypt {
+		return deriveKey(password, salt, kdf)
+	}
+
+	n, r, p := opts.N, opts.R, opts.P
+	if n == 0 {
+		n = scryptN
+	}
+	if r == 0 {
+		r = scryptR
+	}
+	if p == 0 {
+		p = scryptP
+	}
+
+	dk, err := scrypt.Key([]byte(password), salt, n, r, p, scryptDKLen)
+	if err != nil {
+		return nil, nil, fmt.Errorf("scrypt key derivation failed: %w", err)
+	}
+	return dk, ScryptParams{N: n, R: r, P: p, DKLen: scryptDKLen, Salt: hex.EncodeToString(salt)}, nil
+}
+
+// EncryptV3 encrypts key (a raw ECDSA private key, as crypto.FromECDSA
+// returns) into a complete, standalone Web3 Secret Storage v3 keystore file
+// — the format `wallet export --format v3` writes and geth/MetaMask/ethers
+// can import directly, unlike this package's on-disk "UTC--..." files which
+// also carry a Diora-specific Name field. opts customizes the KDF; its zero
+// value matches encryptV3's defaults.
+func EncryptV3(key []byte, password string, opts V3Options) ([]byte, error) {
+	privateKey, err := crypto.ToECDSA(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	cryptoStruct, err := encryptV3Opts(key, password, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ks := EncryptedKeystore{
+		Address:   crypto.PubkeyToAddress(privateKey.PublicKey).Hex(),
+		PublicKey: hex.EncodeToString(crypto.FromECDSAPub(&privateKey.PublicKey)),
+		Crypto:    *cryptoStruct,
+		CreatedAt: time.Now(),
+		Version:   3,
+	}
+	return json.MarshalIndent(ks, "", "  ")
+}
+
+// DecryptV3 reverses EncryptV3, extracting the raw private key from a
+// standalone v3 keystore file's JSON bytes.
+func DecryptV3(keystoreJSON []byte, password string) ([]byte, error) {
+	var ks EncryptedKeystore
+	if err := json.Unmarshal(keystoreJSON, &ks); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal v3 keystore: %w", err)
+	}
+	return decryptV3(&ks.Crypto, password)
+}
+
+// derivedKDFParams is what parseKDFParams extracts from a decoded keystore
+// file: enough to re-run the same derivation deriveKey used to create it.
+type derivedKDFParams struct {
+	n, r, p, c int
+}
+
+// parseKDFParams tolerantly reads a keystore file's "kdfparams" object,
+// which arrives as map[string]interface{} after JSON decoding into the
+// Crypto.KDFParams interface{} field, and returns its salt plus whichever
+// of n/r/p (scrypt) or c (PBKDF2) apply.
+func parseKDFParams(kdf string, raw interface{}) ([]byte, derivedKDFParams, error) {
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, derivedKDFParams{}, fmt.Errorf("malformed kdfparams")
+	}
+
+	saltHex, _ := m["salt"].(string)
+	salt, err := hex.DecodeString(saltHex)
+	if err != nil {
+		return nil, derivedKDFParams{}, fmt.Errorf("invalid salt: %w", err)
+	}
+
+	var p derivedKDFParams
+	switch kdf {
+	case KDFScrypt:
+		p.n, p.r, p.p = int(asFloat(m["n"])), int(asFloat(m["r"])), int(asFloat(m["p"]))
+		if p.n == 0 || p.r == 0 || p.p == 0 {
+			return nil, derivedKDFParams{}, fmt.Errorf("incomplete scrypt kdfparams")
+		}
+	case KDFPBKDF2:
+		p.c = int(asFloat(m["c"]))
+		if p.c == 0 {
+			return nil, derivedKDFParams{}, fmt.Errorf("incomplete pbkdf2 kdfparams")
+		}
+	default:
+		return nil, derivedKDFParams{}, fmt.Errorf("unsupported kdf %q", kdf)
+	}
+	return salt, p, nil
+}
+
+// deriveKeyWithParams re-derives a key using parameters read back out of a
+// keystore file, the decrypt-side counterpart to deriveKey.
+func deriveKeyWithParams(password string, salt []byte, kdf string, p derivedKDFParams) ([]byte, interface{}, error) {
+	switch kdf {
+	case KDFScrypt:
+		dk, err := scrypt.Key([]byte(password), salt, p.n, p.r, p.p, scryptDKLen)
+		if err != nil {
+			return nil, nil, fmt.Errorf("scrypt key derivation failed: %w", err)
+		}
+		return dk, nil, nil
+	case KDFPBKDF2:
+		return pbkdf2.Key([]byte(password), salt, p.c, pbkdf2DKLen, sha256.New), nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported kdf %q", kdf)
+	}
+}
+
+// asFloat reads a JSON-decoded number out of an interface{} (always
+// float64, since encoding/json decodes all numbers that way absent a
+// concrete target type) tolerating a missing/wrong-typed field as zero.
+func asFloat(v interface{}) float64 {
+	f, _ := v.(float64)
+	return f
+}
+
+// cipherParamsIV reads the "iv" field out of a keystore's cipherparams,
+// which like kdfparams arrives as map[string]interface{} once decoded.
+func cipherParamsIV(raw interface{}) string {
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	iv, _ := m["iv"].(string)
+	return iv
+}
+
+// UnmarshalJSON decodes a keystore file tolerant of the address field being
+// spelled "address" (geth, MetaMask) or "Address" (some older exporters),
+// so files produced by either still import cleanly.
+func (ks *EncryptedKeystore) UnmarshalJSON(data []byte) error {
+	type alias EncryptedKeystore
+	var a alias
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	if a.Address == "" {
+		var caseFallback struct {
+			Address string `json:"Address"`
+		}
+		if err := json.Unmarshal(data, &caseFallback); err == nil {
+			a.Address = caseFallback.Address
+		}
+	}
+
+	*ks = EncryptedKeystore(a)
+	return nil
+}
@@ -0,0 +1,66 @@
+package keystore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// fileStamp is the (size, mtime) pair fileCache uses to tell whether a
+// file changed without reading its contents.
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+// fileCache tracks every file in a keystore directory by fileStamp so
+// accountCache can tell, on each scan, exactly which files are new,
+// changed, or gone — instead of re-reading and re-parsing the whole
+// directory every time.
+type fileCache struct {
+	files map[string]fileStamp // path -> stamp, as of the last scan
+}
+
+func newFileCache() *fileCache {
+	return &fileCache{files: make(map[string]fileStamp)}
+}
+
+// scan reads dir and returns the paths that are new, changed, or deleted
+// since the last scan (or everything, as "created", on the first one),
+// updating the cache's own state to match what it just saw.
+func (fc *fileCache) scan(dir string) (created, updated, deleted []string, err error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, nil, nil, err
+	}
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	seen := make(map[string]fileStamp, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		stamp := fileStamp{size: entry.Size(), modTime: entry.ModTime()}
+		seen[path] = stamp
+
+		if old, known := fc.files[path]; !known {
+			created = append(created, path)
+		} else if old != stamp {
+			updated = append(updated, path)
+		}
+	}
+
+	for path := range fc.files {
+		if _, ok := seen[path]; !ok {
+			deleted = append(deleted, path)
+		}
+	}
+
+	fc.files = seen
+	return created, updated, deleted, nil
+}
@@ -0,0 +1,349 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// hdIndexSuffix marks the small per-wallet bookkeeping file DeriveNext
+// reads and advances. ListAccounts skips anything ending in it, the same
+// way it already skips "UTC--" key files, so it never shows up disguised
+// as an account.
+const hdIndexSuffix = ".hd.json"
+
+// HDWalletInfo is the JSON index NewHDAccount creates and DeriveNext
+// advances for one HD wallet: the path new accounts are derived under, the
+// neutered (public-only) extended key at that path, and the next unused
+// child index. BasePubKey/ChainCode are not secret — storing them lets
+// DeriveNext hand out new receive addresses without ever asking for the
+// keystore password, the same public-derivation trick MetaMask/geth use.
+type HDWalletInfo struct {
+	Name       string    `json:"name"`
+	BasePath   string    `json:"path"`
+	BasePubKey string    `json:"base_pubkey"`
+	ChainCode  string    `json:"chain_code"`
+	NextIndex  uint32    `json:"next_index"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewHDAccount creates (or imports) a BIP-39/BIP-32 HD wallet named name: it
+// derives a BIP-32 master key from mnemonic (generating a fresh one of the
+// requested word count if mnemonic is empty) and passphrase, derives the
+// first child account at path+"/0" (path defaults to DefaultHDPath), and
+// persists three things: the new account's own encrypted v3 keystore file
+// (so it signs like any other account), the mnemonic itself encrypted
+// under password, and the HDWalletInfo index DeriveNext needs to keep
+// handing out children.
+//
+// words selects the generated mnemonic's length (12 or 24; 0 defaults to
+// 12) and is ignored when mnemonic is already set.
+func (ks *Keystore) NewHDAccount(name, password, mnemonic, passphrase, path string, words int) (*Account, error) {
+	if path == "" {
+		path = DefaultHDPath
+	}
+
+	if mnemonic == "" {
+		bits, err := wordsToBits(words)
+		if err != nil {
+			return nil, err
+		}
+		generated, err := NewMnemonic(bits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate mnemonic: %w", err)
+		}
+		mnemonic = generated
+	} else if !ValidateMnemonic(mnemonic) {
+		return nil, fmt.Errorf("invalid mnemonic")
+	}
+
+	seed := SeedFromMnemonic(mnemonic, passphrase)
+	master, err := newMasterKey(seed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive master key: %w", err)
+	}
+
+	base, err := derivePath(master, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive base key: %w", err)
+	}
+
+	account, err := ks.deriveAndSaveChild(name, password, base, path, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ks.saveMnemonic(name, mnemonic, password); err != nil {
+		return nil, fmt.Errorf("failed to save mnemonic: %w", err)
+	}
+
+	neutered := base.neuter()
+	info := &HDWalletInfo{
+		Name:       name,
+		BasePath:   path,
+		BasePubKey: hex.EncodeToString(neutered.PubKey),
+		ChainCode:  hex.EncodeToString(neutered.ChainCode),
+		NextIndex:  1,
+		CreatedAt:  time.Now(),
+	}
+	if err := ks.saveHDIndex(info); err != nil {
+		return nil, fmt.Errorf("failed to save HD index: %w", err)
+	}
+
+	return account, nil
+}
+
+// DeriveNext derives and persists the next unused child account of the HD
+// wallet name, advancing its stored index. It needs no password: the
+// wallet's base path ends in the standard non-hardened "change/index"
+// levels, so the child's address is computable from the stored extended
+// public key alone. Signing that new account still requires unlocking it
+// with the keystore password the same as any other account.
+func (ks *Keystore) DeriveNext(name string) (*Account, error) {
+	info, err := ks.loadHDIndex(name)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := ks.deriveChildAccount(name, info, info.NextIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	info.NextIndex = info.NextIndex + 1
+	if err := ks.saveHDIndex(info); err != nil {
+		return nil, fmt.Errorf("failed to update HD index: %w", err)
+	}
+
+	return account, nil
+}
+
+// DeriveAt derives and persists the HD wallet name's child account at the
+// explicit index (rather than the next unused one DeriveNext would pick),
+// so a caller that already knows which address it wants — recovering one
+// shown by another wallet, say — doesn't have to call DeriveNext index+1
+// times to reach it. The wallet's stored next-index bookkeeping only moves
+// forward, so DeriveNext still skips whatever index was handed out here.
+func (ks *Keystore) DeriveAt(name string, index uint32) (*Account, error) {
+	info, err := ks.loadHDIndex(name)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := ks.deriveChildAccount(name, info, index)
+	if err != nil {
+		return nil, err
+	}
+
+	if index >= info.NextIndex {
+		info.NextIndex = index + 1
+		if err := ks.saveHDIndex(info); err != nil {
+			return nil, fmt.Errorf("failed to update HD index: %w", err)
+		}
+	}
+
+	return account, nil
+}
+
+// deriveChildAccount derives and persists the HD wallet info's child
+// account at index from its stored neutered extended key, the computation
+// DeriveNext and DeriveAt share.
+func (ks *Keystore) deriveChildAccount(name string, info *HDWalletInfo, index uint32) (*Account, error) {
+	pubKey, err := hex.DecodeString(info.BasePubKey)
+	if err != nil {
+		return nil, fmt.Errorf("corrupt HD index for %q: %w", name, err)
+	}
+	chainCode, err := hex.DecodeString(info.ChainCode)
+	if err != nil {
+		return nil, fmt.Errorf("corrupt HD index for %q: %w", name, err)
+	}
+
+	base := &extendedKey{PubKey: pubKey, ChainCode: chainCode}
+	child, err := base.child(index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive child %d: %w", index, err)
+	}
+
+	address, err := child.address()
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive address: %w", err)
+	}
+	pub, err := publicKeyHex(child.PubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	account := &Account{
+		Name:      fmt.Sprintf("%s-%d", name, index),
+		Address:   address,
+		PublicKey: pub,
+		Path:      fmt.Sprintf("%s/%d", info.BasePath, index),
+		CreatedAt: time.Now(),
+	}
+	if err := ks.saveAccount(account); err != nil {
+		return nil, fmt.Errorf("failed to save account: %w", err)
+	}
+
+	return account, nil
+}
+
+// ExportMnemonic decrypts and returns the mnemonic phrase backing the HD
+// wallet name, the one secret DeriveNext never needs and NewHDAccount
+// requires password to reveal again.
+func (ks *Keystore) ExportMnemonic(name, password string) (string, error) {
+	path := ks.mnemonicPath(name)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("HD wallet not found: %w", err)
+	}
+
+	var enc EncryptedKeystore
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return "", fmt.Errorf("failed to unmarshal mnemonic file: %w", err)
+	}
+
+	mnemonic, err := decryptV3(&enc.Crypto, password)
+	if err != nil {
+		return "", err
+	}
+	return string(mnemonic), nil
+}
+
+// deriveAndSaveChild derives the account at basePath+"/"+index from base,
+// saves its private key as an ordinary v3 keystore file, and records the
+// BIP-32 path it was derived from on the account metadata.
+func (ks *Keystore) deriveAndSaveChild(name, password string, base *extendedKey, basePath string, index uint32) (*Account, error) {
+	child, err := base.child(index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive child %d: %w", index, err)
+	}
+
+	privateKey, err := child.ecdsaPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	address, err := child.address()
+	if err != nil {
+		return nil, err
+	}
+	pub, err := publicKeyHex(child.PubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	account := &Account{
+		Name:      name,
+		Address:   address,
+		PublicKey: pub,
+		Path:      fmt.Sprintf("%s/%d", basePath, index),
+		CreatedAt: time.Now(),
+	}
+
+	if err := ks.savePrivateKey(privateKey, account, password); err != nil {
+		return nil, fmt.Errorf("failed to save private key: %w", err)
+	}
+	if err := ks.saveAccount(account); err != nil {
+		return nil, fmt.Errorf("failed to save account: %w", err)
+	}
+
+	return account, nil
+}
+
+// ChildIndexFromPath extracts the trailing index from path (e.g.
+// "m/44'/60'/0'/0/7"), requiring its parent to match the HD wallet name's
+// stored base path exactly. DeriveAt can only walk one more non-hardened
+// level below the neutered key NewHDAccount stored, so a path that departs
+// from that base anywhere else is rejected rather than silently ignored.
+func (ks *Keystore) ChildIndexFromPath(name, path string) (uint32, error) {
+	info, err := ks.loadHDIndex(name)
+	if err != nil {
+		return 0, err
+	}
+
+	prefix := info.BasePath + "/"
+	if !strings.HasPrefix(path, prefix) {
+		return 0, fmt.Errorf("path %q does not extend %q's base path %q", path, name, info.BasePath)
+	}
+
+	index, err := parsePathComponent(strings.TrimPrefix(path, prefix))
+	if err != nil {
+		return 0, fmt.Errorf("invalid derivation path %q: %w", path, err)
+	}
+	if index >= hardenedOffset {
+		return 0, fmt.Errorf("path %q: hardened child derivation requires the mnemonic, use DeriveAt on an unlocked wallet instead", path)
+	}
+	return index, nil
+}
+
+func (ks *Keystore) mnemonicPath(name string) string {
+	return filepath.Join(ks.path, "HD--"+name)
+}
+
+// saveMnemonic encrypts mnemonic under password using the keystore's
+// configured KDF and writes it next to the account's own key file, reusing
+// the same v3 Crypto struct keystore files already use.
+func (ks *Keystore) saveMnemonic(name, mnemonic, password string) error {
+	if err := os.MkdirAll(ks.path, 0700); err != nil {
+		return fmt.Errorf("failed to create keystore directory: %w", err)
+	}
+
+	cryptoStruct, err := encryptV3([]byte(mnemonic), password, ks.kdf)
+	if err != nil {
+		return err
+	}
+
+	enc := &EncryptedKeystore{
+		Name:      name,
+		Crypto:    *cryptoStruct,
+		CreatedAt: time.Now(),
+		Version:   3,
+	}
+	data, err := json.MarshalIndent(enc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal mnemonic: %w", err)
+	}
+
+	return ioutil.WriteFile(ks.mnemonicPath(name), data, 0600)
+}
+
+func (ks *Keystore) hdIndexPath(name string) string {
+	return filepath.Join(ks.path, name+hdIndexSuffix)
+}
+
+func (ks *Keystore) saveHDIndex(info *HDWalletInfo) error {
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal HD index: %w", err)
+	}
+	return ioutil.WriteFile(ks.hdIndexPath(info.Name), data, 0644)
+}
+
+func (ks *Keystore) loadHDIndex(name string) (*HDWalletInfo, error) {
+	data, err := ioutil.ReadFile(ks.hdIndexPath(name))
+	if err != nil {
+		return nil, fmt.Errorf("HD wallet not found: %w", err)
+	}
+	var info HDWalletInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal HD index: %w", err)
+	}
+	return &info, nil
+}
+
+// publicKeyHex decompresses compressed and hex-encodes it in the same
+// uncompressed form Account.PublicKey already uses elsewhere in this
+// package.
+func publicKeyHex(compressed []byte) (string, error) {
+	pub, err := crypto.DecompressPubkey(compressed)
+	if err != nil {
+		return "", fmt.Errorf("invalid public key: %w", err)
+	}
+	return hex.EncodeToString(crypto.FromECDSAPub(pub)), nil
+}
@@ -3,18 +3,17 @@ package keystore
 import (
 	"crypto/ecdsa"
 	"crypto/rand"
-	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
-	"golang.org/x/crypto/pbkdf2"
 )
 
 // Account represents a wallet account
@@ -23,6 +22,10 @@ type Account struct {
 	Address   common.Address `json:"address"`
 	PublicKey string         `json:"public_key"`
 	CreatedAt time.Time      `json:"created_at"`
+	// Path is the BIP-32 derivation path this account was derived from,
+	// e.g. "m/44'/60'/0'/0/3". Empty for accounts created by NewAccount or
+	// ImportPrivateKey rather than NewHDAccount/DeriveNext.
+	Path string `json:"path,omitempty"`
 }
 
 // EncryptedKeystore represents an encrypted keystore file
@@ -35,7 +38,12 @@ type EncryptedKeystore struct {
 	Version   int       `json:"version"`
 }
 
-// Crypto represents the crypto section of keystore
+// Crypto represents the crypto section of keystore, the Web3 Secret Storage
+// v3 format's "crypto" object. KDFParams/CipherParams are interface{}
+// because their shape depends on KDF (ScryptParams or PBKDF2Params on
+// encrypt; a map[string]interface{} once decoded from JSON, read back out
+// by parseKDFParams/cipherParamsIV) and Cipher is always aes-128-ctr, the
+// only cipher the spec defines.
 type Crypto struct {
 	KDF          string      `json:"kdf"`
 	KDFParams    interface{} `json:"kdfparams"`
@@ -48,15 +56,49 @@ type Crypto struct {
 // Keystore manages wallet accounts
 type Keystore struct {
 	path string
+	kdf  string
+
+	cacheOnce sync.Once
+	cache     *accountCache
 }
 
-// NewKeystore creates a new keystore instance
+// NewKeystore creates a new keystore instance, encrypting new accounts with
+// scrypt — the KDF geth and MetaMask default to for interactively-created
+// keys.
 func NewKeystore(path string) *Keystore {
+	return NewKeystoreWithKDF(path, KDFScrypt)
+}
+
+// NewKeystoreWithKDF creates a keystore instance that encrypts new accounts
+// with kdf (KDFScrypt or KDFPBKDF2) instead of the scrypt default; existing
+// keystore files are decrypted using whichever KDF their own file declares,
+// regardless of this setting.
+func NewKeystoreWithKDF(path, kdf string) *Keystore {
 	return &Keystore{
 		path: path,
+		kdf:  kdf,
 	}
 }
 
+// accountCache returns this keystore's directory index, starting its
+// watcher on first use rather than in NewKeystore so constructing a
+// Keystore stays a pure, I/O-free call.
+func (ks *Keystore) accountCache() *accountCache {
+	ks.cacheOnce.Do(func() {
+		ks.cache = newAccountCache(ks.path)
+	})
+	return ks.cache
+}
+
+// Subscribe registers sink to receive a CacheEvent whenever an encrypted
+// key file appears in or disappears from this keystore's directory —
+// accounts.KeystoreBackend uses this to turn external changes (a key file
+// dropped in or deleted by another tool) into WalletArrived/WalletDropped
+// events on the Manager's feed.
+func (ks *Keystore) Subscribe(sink chan<- CacheEvent) (unsubscribe func()) {
+	return ks.accountCache().subscribe(sink)
+}
+
 // NewAccount creates a new account
 func (ks *Keystore) NewAccount(name, password string) (*Account, error) {
 	// Generate private key
@@ -115,63 +157,19 @@ func (ks *Keystore) ImportPrivateKey(privateKeyStr, name, password string) (*Acc
 	return account, nil
 }
 
-// ListAccounts returns all accounts
+// ListAccounts returns all accounts, served from accountCache rather than
+// rescanning the keystore directory on every call.
 func (ks *Keystore) ListAccounts() ([]*Account, error) {
-	var accounts []*Account
-
-	// Ensure keystore directory exists
-	if err := os.MkdirAll(ks.path, 0700); err != nil {
-		return nil, fmt.Errorf("failed to create keystore directory: %w", err)
-	}
-
-	// Read account files
-	files, err := ioutil.ReadDir(ks.path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read keystore directory: %w", err)
-	}
-
-	for _, file := range files {
-		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
-			continue
-		}
-
-		// Skip keystore files (private keys)
-		if file.Name()[:8] == "UTC--" {
-			continue
-		}
-
-		// Read account file
-		accountPath := filepath.Join(ks.path, file.Name())
-		data, err := ioutil.ReadFile(accountPath)
-		if err != nil {
-			continue
-		}
-
-		var account Account
-		if err := json.Unmarshal(data, &account); err != nil {
-			continue
-		}
-
-		accounts = append(accounts, &account)
-	}
-
-	return accounts, nil
+	return ks.accountCache().accounts(), nil
 }
 
-// GetAccount returns an account by name
+// GetAccount returns an account by name, served from accountCache.
 func (ks *Keystore) GetAccount(name string) (*Account, error) {
-	accountPath := filepath.Join(ks.path, name+".json")
-	data, err := ioutil.ReadFile(accountPath)
-	if err != nil {
-		return nil, fmt.Errorf("account not found: %w", err)
-	}
-
-	var account Account
-	if err := json.Unmarshal(data, &account); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal account: %w", err)
+	account, ok := ks.accountCache().account(name)
+	if !ok {
+		return nil, fmt.Errorf("account not found: %s", name)
 	}
-
-	return &account, nil
+	return account, nil
 }
 
 // ExportPrivateKey exports the private key
@@ -207,6 +205,52 @@ func (ks *Keystore) ExportPrivateKey(name, password string) (string, error) {
 	return hex.EncodeToString(crypto.FromECDSA(privateKey)), nil
 }
 
+// ExportV3 exports name's private key as a standalone Web3 Secret Storage
+// v3 keystore file, re-encrypted under opts rather than reusing whichever
+// KDF parameters the on-disk key file already has — e.g. a caller handing
+// the key to MetaMask/geth can still ask for a different scrypt cost or
+// PBKDF2 instead of whatever this keystore was created with.
+func (ks *Keystore) ExportV3(name, password string, opts V3Options) ([]byte, error) {
+	account, err := ks.GetAccount(name)
+	if err != nil {
+		return nil, err
+	}
+
+	keystoreFile, err := ks.findKeystoreFile(account.Address)
+	if err != nil {
+		return nil, fmt.Errorf("keystore file not found: %w", err)
+	}
+
+	data, err := ioutil.ReadFile(keystoreFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore file: %w", err)
+	}
+
+	var keystore EncryptedKeystore
+	if err := json.Unmarshal(data, &keystore); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal keystore: %w", err)
+	}
+
+	privateKey, err := ks.decryptPrivateKey(&keystore, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt private key: %w", err)
+	}
+
+	return EncryptV3(crypto.FromECDSA(privateKey), password, opts)
+}
+
+// ImportV3 imports a standalone Web3 Secret Storage v3 keystore file (the
+// format EncryptV3/ExportV3 produce, and geth/MetaMask/ethers export by
+// default) under name, re-encrypting it into this keystore the same way
+// ImportPrivateKey does.
+func (ks *Keystore) ImportV3(keystoreJSON []byte, password, name string) (*Account, error) {
+	privateKeyBytes, err := DecryptV3(keystoreJSON, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt v3 keystore: %w", err)
+	}
+	return ks.ImportPrivateKey(hex.EncodeToString(privateKeyBytes), name, password)
+}
+
 // DeleteAccount deletes an account
 func (ks *Keystore) DeleteAccount(name string) error {
 	account, err := ks.GetAccount(name)
@@ -230,6 +274,10 @@ func (ks *Keystore) DeleteAccount(name string) error {
 		return fmt.Errorf("failed to delete keystore file: %w", err)
 	}
 
+	// Update the cache immediately rather than waiting for the watcher to
+	// notice — the caller may ListAccounts again before that fires.
+	ks.accountCache().scan()
+
 	return nil
 }
 
@@ -261,6 +309,10 @@ func (ks *Keystore) savePrivateKey(privateKey *ecdsa.PrivateKey, account *Accoun
 		return fmt.Errorf("failed to write keystore file: %w", err)
 	}
 
+	// Update the cache immediately rather than waiting for the watcher to
+	// notice — the caller may list/sign against this key before it fires.
+	ks.accountCache().scan()
+
 	return nil
 }
 
@@ -272,78 +324,42 @@ func (ks *Keystore) saveAccount(account *Account) error {
 		return fmt.Errorf("failed to marshal account: %w", err)
 	}
 
-	return ioutil.WriteFile(accountPath, data, 0644)
-}
-
-// encryptPrivateKey encrypts private key
-func (ks *Keystore) encryptPrivateKey(privateKey *ecdsa.PrivateKey, account *Account, password string) (*EncryptedKeystore, error) {
-	// Generate salt
-	salt := make([]byte, 32)
-	if _, err := rand.Read(salt); err != nil {
-		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	if err := ioutil.WriteFile(accountPath, data, 0644); err != nil {
+		return err
 	}
 
-	// Derive key using PBKDF2
-	derivedKey := pbkdf2.Key([]byte(password), salt, 100000, 32, sha256.New)
+	ks.accountCache().scan()
+	return nil
+}
 
-	// Encrypt private key (simplified - in production use proper encryption)
+// encryptPrivateKey encrypts private key into a Web3 Secret Storage v3
+// keystore, using whichever KDF this Keystore was constructed with.
+func (ks *Keystore) encryptPrivateKey(privateKey *ecdsa.PrivateKey, account *Account, password string) (*EncryptedKeystore, error) {
 	privateKeyBytes := crypto.FromECDSA(privateKey)
-	cipherText := make([]byte, len(privateKeyBytes))
-	for i, b := range privateKeyBytes {
-		cipherText[i] = b ^ derivedKey[i%len(derivedKey)]
+	cryptoStruct, err := encryptV3(privateKeyBytes, password, ks.kdf)
+	if err != nil {
+		return nil, err
 	}
 
-	// Generate MAC
-	mac := sha256.Sum256(append(derivedKey[16:32], cipherText...))
-
 	return &EncryptedKeystore{
 		Address:   account.Address.Hex(),
 		PublicKey: account.PublicKey,
-		Crypto: Crypto{
-			KDF: "pbkdf2",
-			KDFParams: map[string]interface{}{
-				"dklen": 32,
-				"c":     100000,
-				"salt":  hex.EncodeToString(salt),
-				"prf":   "hmac-sha256",
-			},
-			Cipher:     "aes-128-ctr",
-			CipherText: hex.EncodeToString(cipherText),
-			CipherParams: map[string]interface{}{
-				"iv": hex.EncodeToString(make([]byte, 16)),
-			},
-			MAC: hex.EncodeToString(mac[:]),
-		},
+		Crypto:    *cryptoStruct,
 		Name:      account.Name,
 		CreatedAt: account.CreatedAt,
 		Version:   3,
 	}, nil
 }
 
-// decryptPrivateKey decrypts private key
+// decryptPrivateKey decrypts private key out of a Web3 Secret Storage v3
+// keystore. The file's own "kdf" field decides which KDF is re-run, not
+// ks.kdf — a keystore created elsewhere with the other KDF still decrypts.
 func (ks *Keystore) decryptPrivateKey(keystore *EncryptedKeystore, password string) (*ecdsa.PrivateKey, error) {
-	// Extract parameters
-	kdfParams := keystore.Crypto.KDFParams.(map[string]interface{})
-	salt, err := hex.DecodeString(kdfParams["salt"].(string))
-	if err != nil {
-		return nil, fmt.Errorf("invalid salt: %w", err)
-	}
-
-	// Derive key
-	derivedKey := pbkdf2.Key([]byte(password), salt, 100000, 32, sha256.New)
-
-	// Decrypt private key (simplified - in production use proper decryption)
-	cipherText, err := hex.DecodeString(keystore.Crypto.CipherText)
+	privateKeyBytes, err := decryptV3(&keystore.Crypto, password)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ciphertext: %w", err)
+		return nil, err
 	}
 
-	privateKeyBytes := make([]byte, len(cipherText))
-	for i, b := range cipherText {
-		privateKeyBytes[i] = b ^ derivedKey[i%len(derivedKey)]
-	}
-
-	// Convert to private key
 	privateKey, err := crypto.ToECDSA(privateKeyBytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert to private key: %w", err)
@@ -352,34 +368,52 @@ func (ks *Keystore) decryptPrivateKey(keystore *EncryptedKeystore, password stri
 	return privateKey, nil
 }
 
-// findKeystoreFile finds keystore file by address
+// findKeystoreFile finds keystore file by address, served from
+// accountCache.
 func (ks *Keystore) findKeystoreFile(address common.Address) (string, error) {
-	files, err := ioutil.ReadDir(ks.path)
+	path, ok := ks.accountCache().keyFile(address)
+	if !ok {
+		return "", fmt.Errorf("keystore file not found for address %s", address.Hex())
+	}
+	return path, nil
+}
+
+// ListKeyFiles returns the paths of every encrypted key file in the
+// keystore directory, one per account, regardless of whether that
+// account's "<name>.json" metadata file still exists alongside it. This is
+// what accounts.KeystoreBackend scans to build one Wallet per file.
+func (ks *Keystore) ListKeyFiles() ([]string, error) {
+	return ks.accountCache().keyFiles(), nil
+}
+
+// ReadKeyFileAddress returns the address a key file at path was created
+// for, without decrypting it.
+func (ks *Keystore) ReadKeyFileAddress(path string) (common.Address, error) {
+	data, err := ioutil.ReadFile(path)
 	if err != nil {
-		return "", fmt.Errorf("failed to read keystore directory: %w", err)
+		return common.Address{}, fmt.Errorf("failed to read keystore file: %w", err)
 	}
 
-	for _, file := range files {
-		if file.IsDir() || file.Name()[:8] != "UTC--" {
-			continue
-		}
+	var keystore EncryptedKeystore
+	if err := json.Unmarshal(data, &keystore); err != nil {
+		return common.Address{}, fmt.Errorf("failed to unmarshal keystore: %w", err)
+	}
 
-		// Read keystore file
-		keystorePath := filepath.Join(ks.path, file.Name())
-		data, err := ioutil.ReadFile(keystorePath)
-		if err != nil {
-			continue
-		}
+	return common.HexToAddress(keystore.Address), nil
+}
 
-		var keystore EncryptedKeystore
-		if err := json.Unmarshal(data, &keystore); err != nil {
-			continue
-		}
+// DecryptKeyFile decrypts the key file at path directly, without going
+// through the "<name>.json" account metadata ExportPrivateKey requires.
+func (ks *Keystore) DecryptKeyFile(path, password string) (*ecdsa.PrivateKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore file: %w", err)
+	}
 
-		if keystore.Address == address.Hex() {
-			return keystorePath, nil
-		}
+	var keystore EncryptedKeystore
+	if err := json.Unmarshal(data, &keystore); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal keystore: %w", err)
 	}
 
-	return "", fmt.Errorf("keystore file not found for address %s", address.Hex())
+	return ks.decryptPrivateKey(&keystore, password)
 }
@@ -0,0 +1,106 @@
+package keystore
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// WalletManager is the narrow signing surface both an in-process keystore
+// (LocalWallet) and a remote signing daemon (RemoteWallet, in
+// internal/walletrpc) satisfy, so a CLI command that needs a signature
+// doesn't care whether the key lives in this process or behind a `diora
+// wallet daemon` on hardened hardware.
+type WalletManager interface {
+	// List returns the addresses this manager can sign for.
+	List() ([]common.Address, error)
+	// New creates a fresh account named name and returns its address.
+	New(name string) (common.Address, error)
+	// Has reports whether addr is one this manager can sign for.
+	Has(addr common.Address) (bool, error)
+	// Delete removes the named account.
+	Delete(name string) error
+	// Sign returns a 65-byte secp256k1 signature ([R || S || V]) over hash,
+	// produced by addr's private key.
+	Sign(addr common.Address, hash common.Hash) ([]byte, error)
+}
+
+// LocalWallet is the WalletManager a CLI invocation uses by default: every
+// New/Sign/Delete call unlocks ks's on-disk keystore with password, the
+// same per-call unlock resolveSigningKey has always done, just reached
+// through the WalletManager interface instead of directly.
+type LocalWallet struct {
+	ks       *Keystore
+	password string
+}
+
+// NewLocalWallet returns a WalletManager backed by ks, unlocking accounts
+// with password.
+func NewLocalWallet(ks *Keystore, password string) *LocalWallet {
+	return &LocalWallet{ks: ks, password: password}
+}
+
+// List implements WalletManager.
+func (w *LocalWallet) List() ([]common.Address, error) {
+	accounts, err := w.ks.ListAccounts()
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]common.Address, len(accounts))
+	for i, account := range accounts {
+		addrs[i] = account.Address
+	}
+	return addrs, nil
+}
+
+// New implements WalletManager.
+func (w *LocalWallet) New(name string) (common.Address, error) {
+	account, err := w.ks.NewAccount(name, w.password)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return account.Address, nil
+}
+
+// Has implements WalletManager.
+func (w *LocalWallet) Has(addr common.Address) (bool, error) {
+	_, err := w.findKeyFile(addr)
+	if err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// Delete implements WalletManager.
+func (w *LocalWallet) Delete(name string) error {
+	return w.ks.DeleteAccount(name)
+}
+
+// Sign implements WalletManager.
+func (w *LocalWallet) Sign(addr common.Address, hash common.Hash) ([]byte, error) {
+	path, err := w.findKeyFile(addr)
+	if err != nil {
+		return nil, err
+	}
+	key, err := w.ks.DecryptKeyFile(path, w.password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unlock %s: %w", addr.Hex(), err)
+	}
+	return crypto.Sign(hash.Bytes(), key)
+}
+
+// findKeyFile is resolveSigningKey's lookup half, shared by Has and Sign.
+func (w *LocalWallet) findKeyFile(addr common.Address) (string, error) {
+	paths, err := w.ks.ListKeyFiles()
+	if err != nil {
+		return "", fmt.Errorf("failed to list keystore key files: %w", err)
+	}
+	for _, path := range paths {
+		a, err := w.ks.ReadKeyFileAddress(path)
+		if err == nil && a == addr {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no keystore account found for %s", addr.Hex())
+}
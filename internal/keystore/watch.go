@@ -0,0 +1,81 @@
+//go:build !plan9
+
+package keystore
+
+import (
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watcher drives accountCache.scan() off real filesystem events via
+// fsnotify, rescanning whenever the keystore directory itself changes
+// instead of polling it. If fsnotify can't start (no inotify/kqueue/
+// ReadDirectoryChangesW support, or the directory can't be watched for
+// some other reason), it falls back to the same polling loop
+// watch_fallback.go uses on platforms fsnotify doesn't support at all.
+type watcher struct {
+	ac   *accountCache
+	fsw  *fsnotify.Watcher
+	quit chan struct{}
+}
+
+func newWatcher(ac *accountCache) *watcher {
+	return &watcher{ac: ac, quit: make(chan struct{})}
+}
+
+func (w *watcher) start() {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		go w.poll()
+		return
+	}
+	if err := fsw.Add(w.ac.dir); err != nil {
+		fsw.Close()
+		go w.poll()
+		return
+	}
+
+	w.fsw = fsw
+	go w.loop()
+}
+
+func (w *watcher) loop() {
+	for {
+		select {
+		case <-w.quit:
+			return
+		case _, ok := <-w.fsw.Events:
+			if !ok {
+				return
+			}
+			w.ac.scan()
+		case _, ok := <-w.fsw.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// poll is the fallback used when fsnotify itself failed to start.
+func (w *watcher) poll() {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.quit:
+			return
+		case <-ticker.C:
+			w.ac.scan()
+		}
+	}
+}
+
+func (w *watcher) close() {
+	close(w.quit)
+	if w.fsw != nil {
+		w.fsw.Close()
+	}
+}
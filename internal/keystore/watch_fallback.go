@@ -0,0 +1,38 @@
+//go:build plan9
+
+package keystore
+
+import "time"
+
+// watcher polls the keystore directory every 2 seconds instead of using
+// fsnotify, which has no backend on plan9.
+type watcher struct {
+	ac   *accountCache
+	quit chan struct{}
+}
+
+func newWatcher(ac *accountCache) *watcher {
+	return &watcher{ac: ac, quit: make(chan struct{})}
+}
+
+func (w *watcher) start() {
+	go w.poll()
+}
+
+func (w *watcher) poll() {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.quit:
+			return
+		case <-ticker.C:
+			w.ac.scan()
+		}
+	}
+}
+
+func (w *watcher) close() {
+	close(w.quit)
+}
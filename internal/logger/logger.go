@@ -9,9 +9,14 @@ import (
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-// Logger represents the application logger
+// Logger represents the application logger. It embeds *logrus.Entry rather
+// than *logrus.Logger so WithField/WithFields/WithError/WithBlock/WithTx/
+// WithPeer can return a new Logger carrying accumulated fields without
+// mutating the root logger every other Logger sharing it also logs
+// through; SetLevel/SetOutput/SetFormatter reach the shared root via
+// Entry.Logger.
 type Logger struct {
-	*logrus.Logger
+	*logrus.Entry
 	name string
 }
 
@@ -25,10 +30,11 @@ func NewLogger(name string) *Logger {
 	})
 	log.SetLevel(logrus.InfoLevel)
 	log.SetOutput(os.Stdout)
+	log.AddHook(&metricsHook{module: name})
 
 	return &Logger{
-		Logger: log,
-		name:   name,
+		Entry: logrus.NewEntry(log),
+		name:  name,
 	}
 }
 
@@ -93,40 +99,41 @@ func NewLoggerWithConfig(name string, level string, format string, output string
 	default:
 		log.SetOutput(os.Stdout)
 	}
+	log.AddHook(&metricsHook{module: name})
 
 	return &Logger{
-		Logger: log,
-		name:   name,
+		Entry: logrus.NewEntry(log),
+		name:  name,
 	}
 }
 
 // WithField adds a field to the logger
 func (l *Logger) WithField(key string, value interface{}) *Logger {
 	return &Logger{
-		Logger: l.Logger.WithField(key, value),
-		name:   l.name,
+		Entry: l.Entry.WithField(key, value),
+		name:  l.name,
 	}
 }
 
 // WithFields adds multiple fields to the logger
 func (l *Logger) WithFields(fields logrus.Fields) *Logger {
 	return &Logger{
-		Logger: l.Logger.WithFields(fields),
-		name:   l.name,
+		Entry: l.Entry.WithFields(fields),
+		name:  l.name,
 	}
 }
 
 // WithError adds an error field to the logger
 func (l *Logger) WithError(err error) *Logger {
 	return &Logger{
-		Logger: l.Logger.WithError(err),
-		name:   l.name,
+		Entry: l.Entry.WithError(err),
+		name:  l.name,
 	}
 }
 
 // GetWriter returns the logger's writer
 func (l *Logger) GetWriter() io.Writer {
-	return l.Logger.Out
+	return l.Entry.Logger.Out
 }
 
 // SetLevel sets the log level
@@ -135,21 +142,27 @@ func (l *Logger) SetLevel(level string) error {
 	if err != nil {
 		return err
 	}
-	l.Logger.SetLevel(logLevel)
+	l.Entry.Logger.SetLevel(logLevel)
 	return nil
 }
 
 // GetLevel returns the current log level
 func (l *Logger) GetLevel() logrus.Level {
-	return l.Logger.GetLevel()
+	return l.Entry.Logger.GetLevel()
 }
 
 // SetOutput sets the output destination
 func (l *Logger) SetOutput(w io.Writer) {
-	l.Logger.SetOutput(w)
+	l.Entry.Logger.SetOutput(w)
 }
 
 // SetFormatter sets the log formatter
 func (l *Logger) SetFormatter(formatter logrus.Formatter) {
-	l.Logger.SetFormatter(formatter)
+	l.Entry.Logger.SetFormatter(formatter)
+}
+
+// Name returns the name this Logger (or the root Logger it was derived
+// from via With*) was created with — the module name Registry keys on.
+func (l *Logger) Name() string {
+	return l.name
 }
@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Registry owns one named sub-logger per subsystem (e.g. "core", "p2p",
+// "miner"), so a single log.modules.<name>=<level> config section can set
+// each one's level, and SetLevel can change it at runtime without
+// restarting — the action a control-plane method like admin_setLogLevel
+// exposes.
+type Registry struct {
+	mu      sync.RWMutex
+	loggers map[string]*Logger
+	levels  map[string]string
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		loggers: make(map[string]*Logger),
+		levels:  make(map[string]string),
+	}
+}
+
+// Configure applies a module->level map (e.g. the log.modules config
+// section) to every already-created logger and remembers it for loggers
+// Get creates afterward.
+func (r *Registry) Configure(levels map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for module, level := range levels {
+		r.levels[module] = level
+		if l, ok := r.loggers[module]; ok {
+			l.SetLevel(level)
+		}
+	}
+}
+
+// Get returns module's sub-logger, creating it at its configured level
+// (or the Logger default if unconfigured) on first use.
+func (r *Registry) Get(module string) *Logger {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if l, ok := r.loggers[module]; ok {
+		return l
+	}
+
+	l := NewLogger(module)
+	if level, ok := r.levels[module]; ok {
+		l.SetLevel(level)
+	}
+	r.loggers[module] = l
+	return l
+}
+
+// SetLevel changes module's level at runtime, creating its logger first if
+// it doesn't exist yet so the level takes effect as soon as Get is called.
+func (r *Registry) SetLevel(module, level string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if l, ok := r.loggers[module]; ok {
+		if err := l.SetLevel(level); err != nil {
+			return fmt.Errorf("invalid log level %q: %w", level, err)
+		}
+		r.levels[module] = level
+		return nil
+	}
+
+	l := NewLogger(module)
+	if err := l.SetLevel(level); err != nil {
+		return fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+	r.loggers[module] = l
+	r.levels[module] = level
+	return nil
+}
+
+// Modules returns every module name the registry currently knows about,
+// whether because it has a configured level or a logger has been created
+// for it.
+func (r *Registry) Modules() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	for module := range r.loggers {
+		seen[module] = true
+	}
+	for module := range r.levels {
+		seen[module] = true
+	}
+
+	out := make([]string, 0, len(seen))
+	for module := range seen {
+		out = append(out, module)
+	}
+	return out
+}
@@ -0,0 +1,46 @@
+package logger
+
+import "testing"
+
+func TestRegistryConfigureAppliesToExistingLogger(t *testing.T) {
+	r := NewRegistry()
+	l := r.Get("core")
+	r.Configure(map[string]string{"core": "debug"})
+
+	if l.GetLevel().String() != "debug" {
+		t.Errorf("level = %s, want debug", l.GetLevel())
+	}
+}
+
+func TestRegistrySetLevelCreatesLoggerIfMissing(t *testing.T) {
+	r := NewRegistry()
+	if err := r.SetLevel("p2p", "warn"); err != nil {
+		t.Fatalf("SetLevel: %v", err)
+	}
+
+	l := r.Get("p2p")
+	if l.GetLevel().String() != "warning" {
+		t.Errorf("level = %s, want warning", l.GetLevel())
+	}
+}
+
+func TestRegistrySetLevelRejectsInvalidLevel(t *testing.T) {
+	r := NewRegistry()
+	if err := r.SetLevel("core", "not-a-level"); err == nil {
+		t.Error("expected an error for an invalid level, got nil")
+	}
+}
+
+func TestSampledLoggerEmitsEveryNth(t *testing.T) {
+	s := NewLogger("test").Sampled(3)
+
+	emitted := 0
+	for i := 0; i < 9; i++ {
+		if emit, _ := s.shouldEmit(); emit {
+			emitted++
+		}
+	}
+	if emitted != 3 {
+		t.Errorf("emitted = %d, want 3", emitted)
+	}
+}
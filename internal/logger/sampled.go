@@ -0,0 +1,63 @@
+package logger
+
+import "sync/atomic"
+
+// SampledLogger wraps a Logger so only every Nth call to Info/Warn/Error/
+// Debug is actually emitted, each carrying a "skipped" field counting how
+// many calls were dropped since the last one emitted — a busy validator
+// logging one line per transaction would otherwise drown its disk.
+type SampledLogger struct {
+	*Logger
+	every   int64
+	counter int64
+}
+
+// Sampled returns a SampledLogger that emits every nth call to one of its
+// logging methods. n<1 is treated as 1 (no sampling, every call emitted).
+func (l *Logger) Sampled(n int) *SampledLogger {
+	if n < 1 {
+		n = 1
+	}
+	return &SampledLogger{Logger: l, every: int64(n)}
+}
+
+// shouldEmit reports whether the current call should actually be logged,
+// and if so how many prior calls were skipped since the last emitted one.
+func (s *SampledLogger) shouldEmit() (emit bool, skipped int64) {
+	n := atomic.AddInt64(&s.counter, 1)
+	if n < s.every {
+		return false, 0
+	}
+	atomic.AddInt64(&s.counter, -s.every)
+	return true, s.every - 1
+}
+
+// Info emits args through the underlying Logger's Info once every s.every
+// calls, tagging the emitted line with how many calls since the last one
+// were skipped.
+func (s *SampledLogger) Info(args ...interface{}) {
+	if emit, skipped := s.shouldEmit(); emit {
+		s.Logger.WithField("skipped", skipped).Info(args...)
+	}
+}
+
+// Warn is Info's counterpart for warning-level lines.
+func (s *SampledLogger) Warn(args ...interface{}) {
+	if emit, skipped := s.shouldEmit(); emit {
+		s.Logger.WithField("skipped", skipped).Warn(args...)
+	}
+}
+
+// Error is Info's counterpart for error-level lines.
+func (s *SampledLogger) Error(args ...interface{}) {
+	if emit, skipped := s.shouldEmit(); emit {
+		s.Logger.WithField("skipped", skipped).Error(args...)
+	}
+}
+
+// Debug is Info's counterpart for debug-level lines.
+func (s *SampledLogger) Debug(args ...interface{}) {
+	if emit, skipped := s.shouldEmit(); emit {
+		s.Logger.WithField("skipped", skipped).Debug(args...)
+	}
+}
@@ -0,0 +1,103 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// mockSubscribeServer speaks just enough of api/subscriptions.go's protocol
+// to exercise Subscribe: ack the first "subscribe" request, then push
+// payloads as notifications for that subscription id.
+func mockSubscribeServer(t *testing.T, payloads []string) *httptest.Server {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var req subscribeRequest
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+		if err := conn.WriteJSON(subscribeAck{Subscription: "0xdeadbeefcafebabe01"}); err != nil {
+			return
+		}
+		for _, p := range payloads {
+			params, err := json.Marshal([]json.RawMessage{
+				json.RawMessage(`"0xdeadbeefcafebabe01"`),
+				json.RawMessage(p),
+			})
+			if err != nil {
+				return
+			}
+			note := notification{Method: "notification", Params: params}
+			if err := conn.WriteJSON(note); err != nil {
+				return
+			}
+		}
+	}))
+}
+
+func TestSubscribeStreamsNotifications(t *testing.T) {
+	srv := mockSubscribeServer(t, []string{`{"number":42}`, `{"number":43}`})
+	defer srv.Close()
+
+	wsAddr := "ws" + srv.URL[len("http"):]
+	conn, _, err := websocket.DefaultDialer.Dial(wsAddr, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	sub := &Subscriber{conn: conn}
+	defer sub.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	payloads, err := sub.Subscribe(ctx, "newHeads")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	first, ok := <-payloads
+	if !ok {
+		t.Fatal("expected a first payload, channel closed")
+	}
+	if string(first) != `{"number":42}` {
+		t.Errorf("first payload = %s, want {\"number\":42}", first)
+	}
+	second := <-payloads
+	if string(second) != `{"number":43}` {
+		t.Errorf("second payload = %s, want {\"number\":43}", second)
+	}
+}
+
+func TestPollStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	done := make(chan error, 1)
+	go func() {
+		done <- Poll(ctx, 10*time.Millisecond, func() error {
+			calls++
+			return nil
+		})
+	}()
+
+	time.Sleep(35 * time.Millisecond)
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Fatalf("Poll returned error: %v", err)
+	}
+	if calls < 2 {
+		t.Errorf("expected Poll to call fn at least twice before cancel, got %d", calls)
+	}
+}
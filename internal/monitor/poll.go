@@ -0,0 +1,29 @@
+package monitor
+
+import (
+	"context"
+	"time"
+)
+
+// Poll calls fn immediately, then again every interval, until ctx is
+// cancelled or fn returns an error. It's what every `monitor --follow`
+// subcommand falls back to when config.RPC.EnableWebSocket is off, and
+// what validators/network/gas always use regardless (the node has no WS
+// push channel for any of those yet, only newHeads/pendingTransactions).
+func Poll(ctx context.Context, interval time.Duration, fn func() error) error {
+	if err := fn(); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := fn(); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsURL derives a node's WS subscription endpoint from its HTTP(S)
+// JSON-RPC URL (config.RPC.URL / --rpc-url): same host and port, ws(s)
+// scheme, and the /ws route api/server.go mounts its subscribe protocol on.
+func wsURL(rpcURL string) string {
+	url := strings.Replace(rpcURL, "https://", "wss://", 1)
+	url = strings.Replace(url, "http://", "ws://", 1)
+	return strings.TrimRight(url, "/") + "/ws"
+}
+
+// subscribeRequest/notification/subscribeAck mirror the wire shapes
+// api/subscriptions.go speaks: a client sends
+// {"method":"subscribe","params":["newHeads"]}, the server acks with
+// {"subscription":"<id>"} and then repeatedly pushes
+// {"method":"notification","params":["<id>", payload]}.
+type subscribeRequest struct {
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+type subscribeAck struct {
+	Subscription string `json:"subscription"`
+}
+
+type notification struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// Subscriber is a single WS connection to a node's /ws endpoint, open for
+// exactly one subscription at a time (one per `monitor --follow` session).
+type Subscriber struct {
+	conn *websocket.Conn
+}
+
+// Dial opens a WS connection to rpcURL's /ws endpoint.
+func Dial(rpcURL string) (*Subscriber, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL(rpcURL), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", wsURL(rpcURL), err)
+	}
+	return &Subscriber{conn: conn}, nil
+}
+
+// Close closes the underlying WS connection.
+func (s *Subscriber) Close() error {
+	return s.conn.Close()
+}
+
+// Subscribe opens channel ("newHeads" or "pendingTransactions") with any
+// extra subscribe params, then streams each notification's payload onto the
+// returned channel until ctx is cancelled or the connection errors. The
+// channel is closed when streaming stops; callers should range over it.
+func (s *Subscriber) Subscribe(ctx context.Context, channel string, params ...interface{}) (<-chan json.RawMessage, error) {
+	req := subscribeRequest{Method: "subscribe", Params: append([]interface{}{channel}, params...)}
+	if err := s.conn.WriteJSON(req); err != nil {
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", channel, err)
+	}
+
+	var ack subscribeAck
+	if err := s.conn.ReadJSON(&ack); err != nil {
+		return nil, fmt.Errorf("failed to read %s subscription ack: %w", channel, err)
+	}
+
+	out := make(chan json.RawMessage)
+	go func() {
+		<-ctx.Done()
+		s.conn.Close()
+	}()
+	go func() {
+		defer close(out)
+		for {
+			var note notification
+			if err := s.conn.ReadJSON(&note); err != nil {
+				return
+			}
+			if note.Method != "notification" {
+				continue
+			}
+			var params []json.RawMessage
+			if err := json.Unmarshal(note.Params, &params); err != nil || len(params) != 2 {
+				continue
+			}
+			select {
+			case out <- params[1]:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,59 @@
+package noderuntime
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// CheckpointStore persists a Worker's in-flight task state to disk across
+// restarts, so a worker that resumes after a crash can pick its queue back
+// up instead of dropping whatever it was working on. One file per worker
+// ID, written whole each time — the checkpoints this package expects
+// (pending tx batches, in-progress block templates) are small enough that
+// atomic replace is cheaper to reason about than an append log.
+type CheckpointStore struct {
+	dir string
+}
+
+// NewCheckpointStore returns a CheckpointStore rooted at dir, creating it
+// if it doesn't already exist.
+func NewCheckpointStore(dir string) (*CheckpointStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint dir: %w", err)
+	}
+	return &CheckpointStore{dir: dir}, nil
+}
+
+// Save writes data as the checkpoint for worker id, replacing any prior
+// checkpoint. It writes to a temp file and renames over the target so a
+// crash mid-write can't leave a partially-written checkpoint behind.
+func (c *CheckpointStore) Save(id string, data []byte) error {
+	path := c.path(id)
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write checkpoint for %s: %w", id, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to commit checkpoint for %s: %w", id, err)
+	}
+	return nil
+}
+
+// Load returns the last checkpoint saved for worker id, or (nil, nil) if
+// none exists yet.
+func (c *CheckpointStore) Load(id string) ([]byte, error) {
+	data, err := ioutil.ReadFile(c.path(id))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint for %s: %w", id, err)
+	}
+	return data, nil
+}
+
+func (c *CheckpointStore) path(id string) string {
+	return filepath.Join(c.dir, id+".checkpoint.json")
+}
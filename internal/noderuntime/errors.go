@@ -0,0 +1,7 @@
+package noderuntime
+
+import "errors"
+
+// ErrUnknownWorker is returned by Supervisor.Restart for an id that was
+// never registered with Add.
+var ErrUnknownWorker = errors.New("noderuntime: unknown worker id")
@@ -0,0 +1,187 @@
+package noderuntime
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// supervisedWorker pairs a Worker with its own cancellation and the state
+// the Supervisor mutates as it starts, restarts, and stops it.
+type supervisedWorker struct {
+	worker  Worker
+	policy  RestartPolicy
+	cancel  context.CancelFunc
+	restart chan struct{}
+
+	mu     sync.Mutex
+	status Status
+}
+
+// Supervisor runs a fixed set of Workers, restarting any that return an
+// error with exponential backoff and jitter, and reports their status on
+// demand for `diora dev node workers` and `diora dev node worker restart`.
+type Supervisor struct {
+	mu      sync.Mutex
+	workers map[string]*supervisedWorker
+	wg      sync.WaitGroup
+}
+
+// NewSupervisor returns a Supervisor with no workers yet; call Add before
+// Start.
+func NewSupervisor() *Supervisor {
+	return &Supervisor{workers: make(map[string]*supervisedWorker)}
+}
+
+// Add registers w to run under policy once Start is called. Add must not
+// be called after Start.
+func (s *Supervisor) Add(w Worker, policy RestartPolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.workers[w.ID()] = &supervisedWorker{
+		worker:  w,
+		policy:  policy,
+		restart: make(chan struct{}, 1),
+		status:  Status{ID: w.ID(), State: StateStopped},
+	}
+}
+
+// Start launches every registered worker in its own restart loop, each
+// tied to a child of ctx so Stop (or ctx's own cancellation) brings all of
+// them down together.
+func (s *Supervisor) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sw := range s.workers {
+		workerCtx, cancel := context.WithCancel(ctx)
+		sw.cancel = cancel
+		s.wg.Add(1)
+		go s.run(workerCtx, sw)
+	}
+}
+
+// Stop cancels every worker and waits for its restart loop to exit.
+func (s *Supervisor) Stop() {
+	s.mu.Lock()
+	for _, sw := range s.workers {
+		sw.worker.Stop()
+		sw.cancel()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+// Statuses returns a snapshot of every worker's current state, sorted by
+// registration order is not guaranteed; callers that want a stable
+// ordering should sort by ID themselves.
+func (s *Supervisor) Statuses() []Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Status, 0, len(s.workers))
+	for _, sw := range s.workers {
+		sw.mu.Lock()
+		out = append(out, sw.status)
+		sw.mu.Unlock()
+	}
+	return out
+}
+
+// Restart asks the worker with the given id to stop its current Start
+// call and restart immediately, skipping the backoff delay it would
+// otherwise wait out after a failure. It reports ErrUnknownWorker if id
+// doesn't match a registered worker.
+func (s *Supervisor) Restart(id string) error {
+	s.mu.Lock()
+	sw, ok := s.workers[id]
+	s.mu.Unlock()
+	if !ok {
+		return ErrUnknownWorker
+	}
+	sw.worker.Stop()
+	select {
+	case sw.restart <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// run is the per-worker restart loop: call Start, and on any non-nil,
+// non-cancellation error, back off and try again, until ctx is done.
+func (s *Supervisor) run(ctx context.Context, sw *supervisedWorker) {
+	defer s.wg.Done()
+
+	attempt := 0
+	for {
+		sw.setState(StateStarting, "")
+		sw.setStartedAt(time.Now())
+		sw.setState(StateRunning, "")
+
+		err := sw.worker.Start(ctx)
+
+		if ctx.Err() != nil {
+			sw.setState(StateStopped, "")
+			return
+		}
+
+		attempt++
+		sw.incRestarts()
+		sw.setState(StateFailed, errString(err))
+
+		delay := backoff(sw.policy, attempt)
+		sw.setState(StateRestarting, errString(err))
+
+		select {
+		case <-ctx.Done():
+			sw.setState(StateStopped, "")
+			return
+		case <-sw.restart:
+			attempt = 0
+		case <-time.After(delay):
+		}
+	}
+}
+
+// backoff computes the delay before the given restart attempt (1-indexed):
+// policy.Min doubled attempt-1 times, capped at policy.Max, with up to 50%
+// jitter added so concurrently-crashing workers don't restart in lockstep.
+func backoff(policy RestartPolicy, attempt int) time.Duration {
+	delay := policy.Min
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= policy.Max {
+			delay = policy.Max
+			break
+		}
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	return delay + jitter
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func (sw *supervisedWorker) setState(state WorkerState, lastErr string) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	sw.status.State = state
+	if lastErr != "" {
+		sw.status.LastError = lastErr
+	}
+}
+
+func (sw *supervisedWorker) setStartedAt(t time.Time) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	sw.status.StartedAt = t
+}
+
+func (sw *supervisedWorker) incRestarts() {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	sw.status.Restarts++
+}
@@ -0,0 +1,57 @@
+// Package noderuntime supervises the long-lived goroutines a `diora dev
+// node` process runs — miner, RPC server, WS server, peer manager — as
+// independently restartable Workers, so one panicking goroutine (a miner
+// choking on a bad block, say) doesn't take the RPC server down with it.
+package noderuntime
+
+import (
+	"context"
+	"time"
+)
+
+// WorkerState is a Worker's lifecycle state as tracked by the Supervisor.
+type WorkerState string
+
+const (
+	StateStarting   WorkerState = "starting"
+	StateRunning    WorkerState = "running"
+	StateFailed     WorkerState = "failed"
+	StateRestarting WorkerState = "restarting"
+	StateStopped    WorkerState = "stopped"
+)
+
+// Worker is one independently-supervised unit of node work. Start must
+// block until ctx is cancelled or the worker can no longer make progress,
+// returning the error that ended it (nil on a clean Stop-triggered exit).
+// Stop asks a running Start call to return; the Supervisor always cancels
+// the worker's context too, so Stop only needs to do cleanup Start's own
+// ctx.Done handling can't, such as releasing a resource Start acquired
+// before ctx existed.
+type Worker interface {
+	ID() string
+	Start(ctx context.Context) error
+	Stop() error
+}
+
+// RestartPolicy bounds the exponential backoff the Supervisor applies
+// between a Worker's failed Start calls. Delay doubles from Min towards
+// Max, with up to 50% jitter added so a crash loop across several workers
+// doesn't restart them all in lockstep.
+type RestartPolicy struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// DefaultRestartPolicy is the 100ms-to-30s backoff the dev node's own
+// workers use unless a caller overrides it.
+var DefaultRestartPolicy = RestartPolicy{Min: 100 * time.Millisecond, Max: 30 * time.Second}
+
+// Status is a point-in-time snapshot of one supervised Worker, the shape
+// `diora dev node workers` prints one row of per worker.
+type Status struct {
+	ID        string
+	State     WorkerState
+	Restarts  int
+	LastError string
+	StartedAt time.Time
+}
@@ -0,0 +1,67 @@
+package password
+
+import "testing"
+
+func TestPasswordScoreCommonPassword(t *testing.T) {
+	score, feedback := PasswordScore("password")
+	if score != 0 {
+		t.Errorf("score = %d, want 0", score)
+	}
+	if len(feedback) == 0 {
+		t.Error("expected feedback for a common password, got none")
+	}
+}
+
+func TestPasswordScoreRepeatedCharacters(t *testing.T) {
+	score, _ := PasswordScore("aaaaaaaaaaaa")
+	if score > 1 {
+		t.Errorf("score = %d, want <= 1 for a repeated-character password", score)
+	}
+}
+
+func TestPasswordScoreSequence(t *testing.T) {
+	score, _ := PasswordScore("abcdefgh")
+	if score > 1 {
+		t.Errorf("score = %d, want <= 1 for a sequential password", score)
+	}
+}
+
+func TestPasswordScoreStrongPassphrase(t *testing.T) {
+	score, feedback := PasswordScore("correct-horse-battery-staple-9Q!")
+	if score < 3 {
+		t.Errorf("score = %d, want >= 3 for a long random passphrase", score)
+	}
+	if len(feedback) != 0 {
+		t.Errorf("feedback = %v, want none for a strong passphrase", feedback)
+	}
+}
+
+func TestCheckRejectsBelowMinScore(t *testing.T) {
+	err := Check("password", DefaultMinScore)
+	if err == nil {
+		t.Fatal("expected an error for a weak password")
+	}
+	weak, ok := err.(*WeakPasswordError)
+	if !ok {
+		t.Fatalf("err = %T, want *WeakPasswordError", err)
+	}
+	if weak.MinScore != DefaultMinScore {
+		t.Errorf("MinScore = %d, want %d", weak.MinScore, DefaultMinScore)
+	}
+}
+
+func TestCheckAcceptsStrongPassphrase(t *testing.T) {
+	if err := Check("correct-horse-battery-staple-9Q!", DefaultMinScore); err != nil {
+		t.Errorf("Check returned an error for a strong passphrase: %v", err)
+	}
+}
+
+func TestCheckRejectsOverlongPassword(t *testing.T) {
+	overlong := make([]byte, MaxLength+1)
+	for i := range overlong {
+		overlong[i] = 'a'
+	}
+	if err := Check(string(overlong), DefaultMinScore); err == nil {
+		t.Fatal("expected an error for a password over MaxLength")
+	}
+}
@@ -0,0 +1,78 @@
+package rpcclient
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AccountClient is a Client scoped to the node's eth_* balance/nonce/raw-tx/
+// gas-estimation JSON-RPC methods, the counterpart to the account/tx
+// subcommands in internal/cli/account.go and internal/cli/transaction.go.
+type AccountClient struct {
+	*Client
+}
+
+// NewAccountClient returns an AccountClient talking to url.
+func NewAccountClient(url string, timeout time.Duration) *AccountClient {
+	return &AccountClient{Client: NewClient(url, timeout)}
+}
+
+// Balance fetches address's native DIO balance, in wei.
+func (c *AccountClient) Balance(address common.Address) (*big.Int, error) {
+	var result string
+	if err := c.Call("eth_getBalance", []interface{}{address.Hex(), "latest"}, &result); err != nil {
+		return nil, err
+	}
+	return parseHexBig(result)
+}
+
+// NextNonce fetches address's next usable nonce.
+func (c *AccountClient) NextNonce(address common.Address) (uint64, error) {
+	var result string
+	if err := c.Call("eth_getTransactionCount", []interface{}{address.Hex(), "latest"}, &result); err != nil {
+		return 0, err
+	}
+	nonce, ok := new(big.Int).SetString(trimHexPrefix(result), 16)
+	if !ok {
+		return 0, fmt.Errorf("invalid nonce %q", result)
+	}
+	return nonce.Uint64(), nil
+}
+
+// ChainID fetches the chain id a transaction must be EIP-155-signed for to
+// validate against this node.
+func (c *AccountClient) ChainID() (*big.Int, error) {
+	var result string
+	if err := c.Call("eth_chainId", nil, &result); err != nil {
+		return nil, err
+	}
+	return parseHexBig(result)
+}
+
+// SendRawTransaction broadcasts raw — a MarshalBinary-encoded signed
+// transaction — and returns its hash.
+func (c *AccountClient) SendRawTransaction(raw []byte) (common.Hash, error) {
+	var result string
+	if err := c.Call("eth_sendRawTransaction", []interface{}{"0x" + common.Bytes2Hex(raw)}, &result); err != nil {
+		return common.Hash{}, err
+	}
+	return common.HexToHash(result), nil
+}
+
+func trimHexPrefix(s string) string {
+	if len(s) >= 2 && s[0:2] == "0x" {
+		return s[2:]
+	}
+	return s
+}
+
+func parseHexBig(s string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(trimHexPrefix(s), 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid hex quantity %q", s)
+	}
+	return n, nil
+}
@@ -0,0 +1,71 @@
+package rpcclient
+
+import "time"
+
+// AdminClient is a Client scoped to the node's admin_* JSON-RPC namespace,
+// the counterpart to the node subcommands in internal/cli/node.go. The
+// admin namespace is opt-in on the node side (see --http.api), so every
+// call here can fail with "the admin namespace is not enabled" against a
+// node that hasn't been started with it.
+type AdminClient struct {
+	*Client
+}
+
+// NewAdminClient returns an AdminClient talking to url.
+func NewAdminClient(url string, timeout time.Duration) *AdminClient {
+	return &AdminClient{Client: NewClient(url, timeout)}
+}
+
+// NodeInfo is the response shape for admin_nodeInfo.
+type NodeInfo struct {
+	ID            string   `json:"id"`
+	Enode         string   `json:"enode"`
+	ListenAddr    string   `json:"listenAddr"`
+	Name          string   `json:"name"`
+	Protocols     []string `json:"protocols"`
+	ChainID       string   `json:"chainId"`
+	NetworkID     string   `json:"networkId"`
+	HeadBlock     string   `json:"headBlock"`
+	HeadBlockHash string   `json:"headBlockHash"`
+}
+
+// Peer is one entry of admin_peers' response.
+type Peer struct {
+	ID      string    `json:"id"`
+	Enode   string    `json:"enode"`
+	Trusted bool      `json:"trusted"`
+	AddedAt time.Time `json:"addedAt"`
+}
+
+// NodeInfo fetches this node's own identity and chain head.
+func (c *AdminClient) NodeInfo() (*NodeInfo, error) {
+	var out NodeInfo
+	if err := c.Call("admin_nodeInfo", []interface{}{}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Peers fetches every peer registered via AddPeer/AddTrustedPeer.
+func (c *AdminClient) Peers() ([]Peer, error) {
+	var out []Peer
+	if err := c.Call("admin_peers", []interface{}{}, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AddPeer registers enode as a known peer.
+func (c *AdminClient) AddPeer(enode string) error {
+	return c.Call("admin_addPeer", []interface{}{enode}, nil)
+}
+
+// AddTrustedPeer registers enode as a known, trusted peer.
+func (c *AdminClient) AddTrustedPeer(enode string) error {
+	return c.Call("admin_addTrustedPeer", []interface{}{enode}, nil)
+}
+
+// RemovePeer drops enode from the known-peer table.
+func (c *AdminClient) RemovePeer(enode string) error {
+	return c.Call("admin_removePeer", []interface{}{enode}, nil)
+}
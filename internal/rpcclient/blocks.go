@@ -0,0 +1,106 @@
+package rpcclient
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BlockSummary is the subset of api.BlockResponse the monitor subcommands
+// need: enough to list or follow blocks without decoding every transaction
+// in them.
+type BlockSummary struct {
+	Number     uint64
+	Hash       common.Hash
+	ParentHash common.Hash
+	Timestamp  uint64
+	TxCount    int
+	GasUsed    uint64
+	GasLimit   uint64
+	Miner      common.Address
+}
+
+// blockWire is the raw JSON shape eth_getBlockByNumber/eth_getBlockByHash
+// return (api.BlockResponse), trimmed to the fields BlockSummary decodes.
+type blockWire struct {
+	Number       uint64          `json:"number"`
+	Hash         string          `json:"hash"`
+	ParentHash   string          `json:"parentHash"`
+	Timestamp    uint64          `json:"timestamp"`
+	Transactions []transactionID `json:"transactions"`
+	GasUsed      uint64          `json:"gasUsed"`
+	GasLimit     uint64          `json:"gasLimit"`
+	Miner        string          `json:"miner"`
+}
+
+// transactionID decodes just enough of api.TransactionResp to count a
+// block's transactions without pulling in every field.
+type transactionID struct {
+	Hash string `json:"hash"`
+}
+
+func (w blockWire) decode() *BlockSummary {
+	return &BlockSummary{
+		Number:     w.Number,
+		Hash:       common.HexToHash(w.Hash),
+		ParentHash: common.HexToHash(w.ParentHash),
+		Timestamp:  w.Timestamp,
+		TxCount:    len(w.Transactions),
+		GasUsed:    w.GasUsed,
+		GasLimit:   w.GasLimit,
+		Miner:      common.HexToAddress(w.Miner),
+	}
+}
+
+// BlockNumber fetches the node's current chain head height.
+func (c *AccountClient) BlockNumber() (uint64, error) {
+	var result string
+	if err := c.Call("eth_blockNumber", nil, &result); err != nil {
+		return 0, err
+	}
+	n, ok := new(big.Int).SetString(trimHexPrefix(result), 16)
+	if !ok {
+		return 0, fmt.Errorf("invalid block number %q", result)
+	}
+	return n.Uint64(), nil
+}
+
+// BlockByNumber fetches the block at height number, without its full
+// transaction bodies.
+func (c *AccountClient) BlockByNumber(number uint64) (*BlockSummary, error) {
+	var wire *blockWire
+	hexNumber := fmt.Sprintf("0x%x", number)
+	if err := c.Call("eth_getBlockByNumber", []interface{}{hexNumber, false}, &wire); err != nil {
+		return nil, err
+	}
+	if wire == nil {
+		return nil, nil
+	}
+	return wire.decode(), nil
+}
+
+// RecentBlocks fetches the latest limit blocks, newest first.
+func (c *AccountClient) RecentBlocks(limit int) ([]*BlockSummary, error) {
+	head, err := c.BlockNumber()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch chain head: %w", err)
+	}
+
+	blocks := make([]*BlockSummary, 0, limit)
+	for i := 0; i < limit; i++ {
+		if int(head) < i {
+			break
+		}
+		number := head - uint64(i)
+		block, err := c.BlockByNumber(number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch block %d: %w", number, err)
+		}
+		if block == nil {
+			break
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
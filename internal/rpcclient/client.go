@@ -0,0 +1,83 @@
+// Package rpcclient is a thin JSON-RPC 2.0 client for talking to a diora
+// node's HTTP endpoint from the CLI — the client-side half of the same
+// request/response shape api/jsonrpc.go serves and accounts.externalRPCClient
+// already speaks against an external signer.
+package rpcclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// Client is a minimal JSON-RPC 2.0 HTTP client, one per configured node URL.
+type Client struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewClient returns a Client that POSTs requests to url, aborting any call
+// that takes longer than timeout.
+func NewClient(url string, timeout time.Duration) *Client {
+	return &Client{
+		url:        url,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+type rpcRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+	ID      int         `json:"id"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int             `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// Call invokes method with params and unmarshals the result into out. out
+// may be nil if the caller doesn't need the result.
+func (c *Client) Call(method string, params interface{}, out interface{}) error {
+	body, err := json.Marshal(rpcRequest{JSONRPC: "2.0", Method: method, Params: params, ID: 1})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", method, err)
+	}
+
+	resp, err := c.httpClient.Post(c.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", c.url, err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response from %s: %w", c.url, err)
+	}
+
+	var rpcResp rpcResponse
+	if err := json.Unmarshal(raw, &rpcResp); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", c.url, err)
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("%s: %s (code %d)", method, rpcResp.Error.Message, rpcResp.Error.Code)
+	}
+	if out == nil || len(rpcResp.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(rpcResp.Result, out); err != nil {
+		return fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package rpcclient
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Code fetches the bytecode stored at address: the deployed runtime code
+// for a contract account, or an empty slice for an externally-owned one.
+func (c *AccountClient) Code(address common.Address) ([]byte, error) {
+	var result string
+	if err := c.Call("eth_getCode", []interface{}{address.Hex(), "latest"}, &result); err != nil {
+		return nil, err
+	}
+	return common.FromHex(result), nil
+}
+
+// CallContract runs msg against the node's current state without creating a
+// transaction and returns its return data, the RPC counterpart to
+// core.Blockchain.CallContract. Named CallContract rather than Call so it
+// doesn't shadow the embedded Client.Call this and every other AccountClient
+// method are built on.
+func (c *AccountClient) CallContract(msg CallMsg) ([]byte, error) {
+	var result string
+	if err := c.Call("eth_call", []interface{}{msg.rpcParams()}, &result); err != nil {
+		return nil, err
+	}
+	return common.FromHex(result), nil
+}
+
+// ContractMeta is contract_info's response: who deployed address and how,
+// fields neither Code nor TransactionsByAddress can answer since a
+// contract's creation transaction is sent TO no one (TransactionsByAddress
+// only walks transactions FROM an address).
+type ContractMeta struct {
+	Creator    common.Address `json:"creator"`
+	CreationTx common.Hash    `json:"creation_tx"`
+	Block      uint64         `json:"block"`
+}
+
+// ContractInfo fetches address's deployment metadata.
+func (c *AccountClient) ContractInfo(address common.Address) (*ContractMeta, error) {
+	var wire struct {
+		Creator    string `json:"creator"`
+		CreationTx string `json:"creation_tx"`
+		Block      uint64 `json:"block"`
+	}
+	if err := c.Call("contract_info", []interface{}{address.Hex()}, &wire); err != nil {
+		return nil, err
+	}
+	return &ContractMeta{
+		Creator:    common.HexToAddress(wire.Creator),
+		CreationTx: common.HexToHash(wire.CreationTx),
+		Block:      wire.Block,
+	}, nil
+}
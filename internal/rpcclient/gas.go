@@ -0,0 +1,170 @@
+package rpcclient
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AccessTuple is one entry of an EIP-2930 access list, the same shape
+// core.AccessTuple carries server-side.
+type AccessTuple struct {
+	Address     common.Address
+	StorageKeys []common.Hash
+}
+
+// CallMsg describes a message eth_estimateGas runs against the node's
+// current state without creating a transaction, mirroring core.CallMsg.
+type CallMsg struct {
+	From       common.Address
+	To         common.Address
+	Value      *big.Int
+	Data       []byte
+	GasPrice   *big.Int
+	AccessList []AccessTuple
+}
+
+// GasEstimate decomposes eth_estimateGas's suggested gas limit into the
+// intrinsic gas msg owes before the EVM runs a single instruction (computed
+// locally via intrinsicGas, the same pricing core.IntrinsicGas applies
+// server-side) and the extra execution gas the node's binary search found
+// msg needed on top.
+type GasEstimate struct {
+	IntrinsicGas uint64
+	ExecutionGas uint64
+	GasLimit     uint64
+}
+
+// EstimateGas calls eth_estimateGas for msg and splits its suggested gas
+// limit into intrinsic vs execution gas.
+func (c *AccountClient) EstimateGas(msg CallMsg) (*GasEstimate, error) {
+	intrinsic, err := intrinsicGas(msg.Data, msg.AccessList)
+	if err != nil {
+		return nil, err
+	}
+
+	var result string
+	if err := c.Call("eth_estimateGas", []interface{}{msg.rpcParams()}, &result); err != nil {
+		return nil, err
+	}
+	limit, ok := new(big.Int).SetString(trimHexPrefix(result), 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid gas estimate %q", result)
+	}
+	gasLimit := limit.Uint64()
+	if gasLimit < intrinsic {
+		return nil, fmt.Errorf("node returned gas estimate %d below intrinsic floor %d", gasLimit, intrinsic)
+	}
+
+	return &GasEstimate{
+		IntrinsicGas: intrinsic,
+		ExecutionGas: gasLimit - intrinsic,
+		GasLimit:     gasLimit,
+	}, nil
+}
+
+// GasPrice fetches the node's current suggested gas price, in wei.
+func (c *AccountClient) GasPrice() (*big.Int, error) {
+	var result string
+	if err := c.Call("eth_gasPrice", nil, &result); err != nil {
+		return nil, err
+	}
+	return parseHexBig(result)
+}
+
+// GasPricePoint is one sample of GasPriceHistory, oldest first.
+type GasPricePoint struct {
+	Timestamp int64    `json:"timestamp"`
+	Price     *big.Int `json:"price"`
+}
+
+type gasPricePointWire struct {
+	Timestamp int64  `json:"timestamp"`
+	Price     string `json:"price"`
+}
+
+// GasPriceHistory fetches sampled gas prices over period (e.g. "1h", "24h",
+// "7d") via diora_gasPriceHistory. This method has no server-side handler
+// yet (see rpcMethods in api/jsonrpc.go) — it's wired up client-side ahead
+// of that work, the same way ContractInfo/eth_getCode were added before
+// the node implemented them, so `monitor gas` has somewhere real to call
+// into once it exists.
+func (c *AccountClient) GasPriceHistory(period string) ([]GasPricePoint, error) {
+	var wire []gasPricePointWire
+	if err := c.Call("diora_gasPriceHistory", []interface{}{period}, &wire); err != nil {
+		return nil, err
+	}
+	points := make([]GasPricePoint, 0, len(wire))
+	for _, w := range wire {
+		price, err := parseHexBig(w.Price)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, GasPricePoint{Timestamp: w.Timestamp, Price: price})
+	}
+	return points, nil
+}
+
+// rpcParams renders msg into the "from"/"to"/"data"/"value"/"gasPrice"/
+// "accessList" map eth_estimateGas, eth_call, and eth_sendTransaction-shaped
+// methods all take as their single positional argument.
+func (msg CallMsg) rpcParams() map[string]interface{} {
+	params := map[string]interface{}{
+		"from": msg.From.Hex(),
+		"to":   msg.To.Hex(),
+		"data": "0x" + common.Bytes2Hex(msg.Data),
+	}
+	if msg.Value != nil {
+		params["value"] = "0x" + msg.Value.Text(16)
+	}
+	if msg.GasPrice != nil {
+		params["gasPrice"] = "0x" + msg.GasPrice.Text(16)
+	}
+	if len(msg.AccessList) > 0 {
+		accessList := make([]map[string]interface{}, len(msg.AccessList))
+		for i, tuple := range msg.AccessList {
+			keys := make([]string, len(tuple.StorageKeys))
+			for j, k := range tuple.StorageKeys {
+				keys[j] = k.Hex()
+			}
+			accessList[i] = map[string]interface{}{
+				"address":     tuple.Address.Hex(),
+				"storageKeys": keys,
+			}
+		}
+		params["accessList"] = accessList
+	}
+	return params
+}
+
+// Intrinsic gas constants, duplicated from core.IntrinsicGas's pricing
+// (core/intrinsicgas.go) rather than imported — this client deliberately
+// doesn't depend on core, the same tradeoff LegacySigningHash makes to
+// sign a transaction without it.
+const (
+	txGas                     uint64 = 21000
+	txDataZeroGas             uint64 = 4
+	txDataNonZeroGasEIP2028   uint64 = 68
+	txAccessListAddressGas    uint64 = 2400
+	txAccessListStorageKeyGas uint64 = 1900
+)
+
+// intrinsicGas mirrors core.IntrinsicGas: TxGas plus 68/4 gas per
+// nonzero/zero calldata byte, plus 2400/1900 gas per access list
+// address/storage key.
+func intrinsicGas(data []byte, accessList []AccessTuple) (uint64, error) {
+	gas := txGas
+	for _, b := range data {
+		if b == 0 {
+			gas += txDataZeroGas
+		} else {
+			gas += txDataNonZeroGasEIP2028
+		}
+	}
+	for _, tuple := range accessList {
+		gas += txAccessListAddressGas
+		gas += uint64(len(tuple.StorageKeys)) * txAccessListStorageKeyGas
+	}
+	return gas, nil
+}
@@ -0,0 +1,57 @@
+package rpcclient
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// HaltHeight fetches the block height a governance proposal has scheduled
+// the chain to halt at, if any. The second return is false once no
+// set-halt-block proposal is pending.
+func (c *AccountClient) HaltHeight() (uint64, bool, error) {
+	var result *string
+	if err := c.Call("governance_haltHeight", nil, &result); err != nil {
+		return 0, false, err
+	}
+	if result == nil {
+		return 0, false, nil
+	}
+	height, err := parseHexBig(*result)
+	if err != nil {
+		return 0, false, err
+	}
+	return height.Uint64(), true, nil
+}
+
+// SubmitHaltBlockProposal schedules the chain to halt at height, submitted
+// by proposer. Proposal voting isn't implemented yet, so this takes effect
+// immediately rather than waiting on a vote.
+func (c *AccountClient) SubmitHaltBlockProposal(proposer common.Address, title string, height uint64) error {
+	return c.Call("governance_submitProposal", []interface{}{map[string]interface{}{
+		"type":     "set-halt-block",
+		"title":    title,
+		"proposer": proposer.Hex(),
+		"payload":  "0x" + common.Bytes2Hex(EncodeHaltHeightPayload(height)),
+	}}, nil)
+}
+
+// SubmitUnhaltProposal clears a pending halt height a prior
+// SubmitHaltBlockProposal scheduled.
+func (c *AccountClient) SubmitUnhaltProposal(proposer common.Address, title string) error {
+	return c.Call("governance_submitProposal", []interface{}{map[string]interface{}{
+		"type":     "unhalt",
+		"title":    title,
+		"proposer": proposer.Hex(),
+	}}, nil)
+}
+
+// EncodeHaltHeightPayload mirrors core.EncodeHaltHeightPayload: internal/cli
+// never links core (it only talks to a node over JSON-RPC), so the halt
+// height's wire encoding is duplicated here the same way wallet.go duplicates
+// DepositContractAddress.
+func EncodeHaltHeightPayload(height uint64) []byte {
+	payload := make([]byte, 8)
+	binary.BigEndian.PutUint64(payload, height)
+	return payload
+}
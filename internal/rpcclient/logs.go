@@ -0,0 +1,81 @@
+package rpcclient
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Log mirrors core.Log's fields in the common.Address/common.Hash types the
+// rest of this package decodes into, the counterpart to the logs subcommand
+// in internal/cli/logs.go.
+type Log struct {
+	Address     common.Address
+	Topics      []common.Hash
+	Data        []byte
+	BlockNumber uint64
+	TxHash      common.Hash
+	TxIndex     uint32
+	Index       uint32
+	Removed     bool
+}
+
+// logWire is the raw JSON shape eth_getLogs returns: core.Log has no custom
+// MarshalJSON, so its Address/Hash fields reflect as plain byte arrays rather
+// than the hex strings the rest of this API uses.
+type logWire struct {
+	Address     [20]byte
+	Topics      [][32]byte
+	Data        []byte
+	BlockNumber uint64
+	TxHash      [32]byte
+	TxIndex     uint32
+	Index       uint32
+	Removed     bool
+}
+
+func (w logWire) decode() *Log {
+	topics := make([]common.Hash, len(w.Topics))
+	for i, t := range w.Topics {
+		topics[i] = common.BytesToHash(t[:])
+	}
+	return &Log{
+		Address:     common.BytesToAddress(w.Address[:]),
+		Topics:      topics,
+		Data:        w.Data,
+		BlockNumber: w.BlockNumber,
+		TxHash:      common.BytesToHash(w.TxHash[:]),
+		TxIndex:     w.TxIndex,
+		Index:       w.Index,
+		Removed:     w.Removed,
+	}
+}
+
+// LogFilter is eth_getLogs's query: logs from fromBlock through toBlock
+// (either a decimal height or a tag like "latest"), optionally narrowed to
+// one contract address and position-matched topics (an empty entry is a
+// wildcard for that position).
+type LogFilter struct {
+	FromBlock string
+	ToBlock   string
+	Address   string
+	Topics    []string
+}
+
+// FilterLogs fetches the logs matching filter via eth_getLogs, the RPC
+// counterpart to core.Blockchain.FilterLogs.
+func (c *AccountClient) FilterLogs(filter LogFilter) ([]*Log, error) {
+	params := map[string]interface{}{
+		"fromBlock": filter.FromBlock,
+		"toBlock":   filter.ToBlock,
+		"address":   filter.Address,
+		"topics":    filter.Topics,
+	}
+	var wire []logWire
+	if err := c.Call("eth_getLogs", []interface{}{params}, &wire); err != nil {
+		return nil, err
+	}
+	logs := make([]*Log, len(wire))
+	for i, w := range wire {
+		logs[i] = w.decode()
+	}
+	return logs, nil
+}
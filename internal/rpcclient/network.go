@@ -0,0 +1,54 @@
+package rpcclient
+
+import "math/big"
+
+// NetworkStats mirrors api.NetworkStats's fields in the types the rest of
+// this package decodes into, the counterpart to the network/monitor
+// subcommands that surface chain-wide activity rather than a single
+// account or validator.
+type NetworkStats struct {
+	ChainID          *big.Int
+	BlockNumber      uint64
+	GasPrice         *big.Int
+	ActiveValidators uint64
+	PeerCount        uint64
+	TPS              float64
+}
+
+type networkStatsWire struct {
+	ChainID          string  `json:"chainId"`
+	BlockNumber      uint64  `json:"blockNumber"`
+	GasPrice         string  `json:"gasPrice"`
+	ActiveValidators uint64  `json:"activeValidators"`
+	PeerCount        uint64  `json:"peerCount"`
+	TPS              float64 `json:"tps"`
+}
+
+// NetworkStats fetches chain-wide activity stats via diora_networkStats.
+// Like GasPriceHistory, this has no server-side handler yet — api/server.go
+// already defines an equivalent NetworkStats shape for its REST route, but
+// nothing in api/jsonrpc.go's rpcMethods table serves it yet over JSON-RPC.
+// `monitor network`'s polling fallback calls it anyway, ready for when the
+// node grows the handler.
+func (c *AccountClient) NetworkStats() (*NetworkStats, error) {
+	var wire networkStatsWire
+	if err := c.Call("diora_networkStats", nil, &wire); err != nil {
+		return nil, err
+	}
+	chainID, err := parseHexBig(wire.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice, err := parseHexBig(wire.GasPrice)
+	if err != nil {
+		return nil, err
+	}
+	return &NetworkStats{
+		ChainID:          chainID,
+		BlockNumber:      wire.BlockNumber,
+		GasPrice:         gasPrice,
+		ActiveValidators: wire.ActiveValidators,
+		PeerCount:        wire.PeerCount,
+		TPS:              wire.TPS,
+	}, nil
+}
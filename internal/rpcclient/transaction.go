@@ -0,0 +1,89 @@
+package rpcclient
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// legacyTx is the signed shape of a LegacyTxType transaction, the same
+// seven-field-plus-V/R/S tuple core.Transaction's MarshalBinary encodes a
+// LegacyTxType transaction as — so a raw transaction signed here round-trips
+// through core.DecodeRawTransaction unchanged. Like stakeTx/commissionTx
+// above, this deliberately doesn't import core/accounts: it defines just
+// enough of the encoding to sign and broadcast one.
+type legacyTx struct {
+	Nonce    uint64
+	GasPrice *big.Int
+	GasLimit uint64
+	To       *common.Address `rlp:"nil"`
+	Value    *big.Int
+	Data     []byte
+	V, R, S  *big.Int
+}
+
+// LegacySigningHash recomputes core.SigningHash's EIP-155 tuple — (nonce,
+// gasPrice, gasLimit, to, value, data, chainID, 0, 0), RLP-encoded and
+// keccak256'd — locally, so this client can sign a legacy transaction
+// without importing core. Exported so a caller whose signing key lives
+// outside this process (see keystore.WalletManager) can get the exact hash
+// a signer needs to produce a signature over.
+func LegacySigningHash(nonce uint64, gasPrice *big.Int, gasLimit uint64, to *common.Address, value *big.Int, data []byte, chainID *big.Int) (common.Hash, error) {
+	addr := to
+	if addr == nil {
+		addr = &common.Address{}
+	}
+	encoded, err := rlp.EncodeToBytes([]interface{}{
+		nonce, gasPrice, gasLimit, *addr, value, data, chainID, uint(0), uint(0),
+	})
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// AssembleLegacyTransaction combines a 65-byte secp256k1 signature
+// ([R || S || V], the shape crypto.Sign and keystore.WalletManager.Sign
+// both return) over LegacySigningHash's result with the EIP-155 tuple into
+// the same MarshalBinary-compatible wire encoding SignLegacyTransaction
+// produces, for callers who sign the hash elsewhere instead of holding the
+// key themselves.
+func AssembleLegacyTransaction(nonce uint64, gasPrice *big.Int, gasLimit uint64, to *common.Address, value *big.Int, data []byte, chainID *big.Int, sig []byte) ([]byte, error) {
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("invalid signature length %d, want 65", len(sig))
+	}
+	v := new(big.Int).Mul(big.NewInt(2), chainID)
+	v.Add(v, big.NewInt(int64(sig[64])+35))
+
+	tx := legacyTx{
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+		GasLimit: gasLimit,
+		To:       to,
+		Value:    value,
+		Data:     data,
+		V:        v,
+		R:        new(big.Int).SetBytes(sig[:32]),
+		S:        new(big.Int).SetBytes(sig[32:64]),
+	}
+	return rlp.EncodeToBytes(&tx)
+}
+
+// SignLegacyTransaction EIP-155-signs a legacy DIO transfer with key and
+// returns its MarshalBinary-compatible wire encoding, ready for
+// AccountClient.SendRawTransaction.
+func SignLegacyTransaction(nonce uint64, gasPrice *big.Int, gasLimit uint64, to *common.Address, value *big.Int, data []byte, chainID *big.Int, key *ecdsa.PrivateKey) ([]byte, error) {
+	hash, err := LegacySigningHash(nonce, gasPrice, gasLimit, to, value, data, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute signing hash: %w", err)
+	}
+	sig, err := crypto.Sign(hash.Bytes(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	return AssembleLegacyTransaction(nonce, gasPrice, gasLimit, to, value, data, chainID, sig)
+}
@@ -0,0 +1,163 @@
+package rpcclient
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TxInfo describes what the node knows about a single transaction, the
+// decoded shape of the maps eth_getTransactionByHash and
+// eth_getTransactionsByAddress return. Fields only the pool or only a
+// confirmed receipt can supply are left at their zero value when the other
+// source answered instead.
+type TxInfo struct {
+	Status           string
+	Hash             common.Hash
+	From             common.Address
+	To               *common.Address
+	Nonce            uint64
+	Value            *big.Int
+	Gas              uint64
+	BlockHash        common.Hash
+	BlockNumber      *big.Int
+	TransactionIndex uint64
+	GasUsed          uint64
+}
+
+// txInfoWire is the raw JSON shape TxInfo decodes from; every field is a
+// hex string or absent, matching how api.formatTxStatus renders it.
+type txInfoWire struct {
+	Status           string  `json:"status"`
+	Hash             string  `json:"hash"`
+	From             string  `json:"from"`
+	To               *string `json:"to"`
+	Nonce            string  `json:"nonce"`
+	Value            string  `json:"value"`
+	Gas              string  `json:"gas"`
+	BlockHash        string  `json:"blockHash"`
+	BlockNumber      string  `json:"blockNumber"`
+	TransactionIndex string  `json:"transactionIndex"`
+	GasUsed          string  `json:"gasUsed"`
+}
+
+func (w txInfoWire) decode() (*TxInfo, error) {
+	info := &TxInfo{Status: w.Status}
+
+	if w.Hash != "" {
+		info.Hash = common.HexToHash(w.Hash)
+	}
+	if w.From != "" {
+		info.From = common.HexToAddress(w.From)
+	}
+	if w.To != nil {
+		to := common.HexToAddress(*w.To)
+		info.To = &to
+	}
+	if w.Nonce != "" {
+		nonce, ok := new(big.Int).SetString(trimHexPrefix(w.Nonce), 16)
+		if !ok {
+			return nil, fmt.Errorf("invalid nonce %q", w.Nonce)
+		}
+		info.Nonce = nonce.Uint64()
+	}
+	if w.Value != "" {
+		value, err := parseHexBig(w.Value)
+		if err != nil {
+			return nil, err
+		}
+		info.Value = value
+	}
+	if w.Gas != "" {
+		gas, ok := new(big.Int).SetString(trimHexPrefix(w.Gas), 16)
+		if !ok {
+			return nil, fmt.Errorf("invalid gas %q", w.Gas)
+		}
+		info.Gas = gas.Uint64()
+	}
+	if w.BlockHash != "" {
+		info.BlockHash = common.HexToHash(w.BlockHash)
+	}
+	if w.BlockNumber != "" {
+		blockNumber, err := parseHexBig(w.BlockNumber)
+		if err != nil {
+			return nil, err
+		}
+		info.BlockNumber = blockNumber
+	}
+	if w.TransactionIndex != "" {
+		index, ok := new(big.Int).SetString(trimHexPrefix(w.TransactionIndex), 16)
+		if !ok {
+			return nil, fmt.Errorf("invalid transaction index %q", w.TransactionIndex)
+		}
+		info.TransactionIndex = index.Uint64()
+	}
+	if w.GasUsed != "" {
+		gasUsed, ok := new(big.Int).SetString(trimHexPrefix(w.GasUsed), 16)
+		if !ok {
+			return nil, fmt.Errorf("invalid gas used %q", w.GasUsed)
+		}
+		info.GasUsed = gasUsed.Uint64()
+	}
+
+	return info, nil
+}
+
+// TransactionByHash fetches what the node knows about hash: its pool status
+// if it's still pending or queued, or its confirmed block/receipt details
+// once it's been included. A nil TxInfo with no error means the node has
+// never seen this hash.
+func (c *AccountClient) TransactionByHash(hash common.Hash) (*TxInfo, error) {
+	var wire *txInfoWire
+	if err := c.Call("eth_getTransactionByHash", []interface{}{hash.Hex()}, &wire); err != nil {
+		return nil, err
+	}
+	if wire == nil {
+		return nil, nil
+	}
+	return wire.decode()
+}
+
+// TransactionsByAddress fetches at most limit transactions touching
+// address, newest first: the pool's pending/queued entries ahead of
+// confirmed history.
+func (c *AccountClient) TransactionsByAddress(address common.Address, limit int) ([]*TxInfo, error) {
+	var wire []txInfoWire
+	if err := c.Call("eth_getTransactionsByAddress", []interface{}{address.Hex(), limit}, &wire); err != nil {
+		return nil, err
+	}
+
+	infos := make([]*TxInfo, 0, len(wire))
+	for _, w := range wire {
+		info, err := w.decode()
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// RecentTransactions fetches at most limit transactions network-wide,
+// newest first, via diora_recentTransactions. Unlike TransactionsByAddress,
+// this has no server-side handler yet (see rpcMethods in api/jsonrpc.go) —
+// it's wired up client-side ahead of that work, the same way
+// GasPriceHistory/NetworkStats are, so `monitor transactions` has
+// somewhere real to call into once the node grows a namespace-wide feed.
+func (c *AccountClient) RecentTransactions(limit int) ([]*TxInfo, error) {
+	var wire []txInfoWire
+	if err := c.Call("diora_recentTransactions", []interface{}{limit}, &wire); err != nil {
+		return nil, err
+	}
+
+	infos := make([]*TxInfo, 0, len(wire))
+	for _, w := range wire {
+		info, err := w.decode()
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
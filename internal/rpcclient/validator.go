@@ -0,0 +1,237 @@
+package rpcclient
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ValidatorClient is a Client scoped to the node's validator_* JSON-RPC
+// namespace, the counterpart to the validator subcommands in
+// internal/cli/validator.go.
+type ValidatorClient struct {
+	*Client
+}
+
+// NewValidatorClient returns a ValidatorClient talking to url.
+func NewValidatorClient(url string, timeout time.Duration) *ValidatorClient {
+	return &ValidatorClient{Client: NewClient(url, timeout)}
+}
+
+// Validator is one entry of the on-chain validator set, as returned by
+// validator_list.
+type Validator struct {
+	Address    common.Address `json:"address"`
+	Stake      string         `json:"stake"`
+	Commission string         `json:"commission"`
+	Active     bool           `json:"active"`
+	Rank       int            `json:"rank"`
+}
+
+// ValidatorInfo is the detailed per-validator view validator_info returns.
+type ValidatorInfo struct {
+	Validator
+	Uptime         string   `json:"uptime"`
+	Delegators     int      `json:"delegators"`
+	TotalDelegated string   `json:"total_delegated"`
+	LastBlock      uint64   `json:"last_block"`
+	RecentBlocks   []uint64 `json:"recent_blocks,omitempty"`
+}
+
+// RewardHistory is the response shape for validator_rewards.
+type RewardHistory struct {
+	Address common.Address `json:"address"`
+	Period  string         `json:"period"`
+	Total   string         `json:"total"`
+	Entries []RewardEntry  `json:"entries"`
+}
+
+// RewardEntry is a single payout within a RewardHistory.
+type RewardEntry struct {
+	Block     uint64    `json:"block"`
+	Amount    string    `json:"amount"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// CommissionInfo is the response shape for commission_get.
+type CommissionInfo struct {
+	Address           common.Address `json:"address"`
+	Rate              string         `json:"rate"`
+	TotalEarned       string         `json:"total_earned"`
+	LastUpdated       time.Time      `json:"last_updated"`
+	CooldownRemaining string         `json:"cooldown_remaining"`
+}
+
+// Receipt is the outcome of a broadcast stake/commission transaction, as
+// returned by tx_getReceipt.
+type Receipt struct {
+	TxHash  common.Hash `json:"tx_hash"`
+	Status  bool        `json:"status"`
+	Block   uint64      `json:"block"`
+	GasUsed uint64      `json:"gas_used"`
+}
+
+// List fetches the validator set, optionally restricted to active
+// validators and sorted server-side by field (e.g. "stake", "commission").
+func (c *ValidatorClient) List(active bool, sortBy string) ([]Validator, error) {
+	var out []Validator
+	params := map[string]interface{}{"active": active, "sort": sortBy}
+	if err := c.Call("validator_list", []interface{}{params}, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Info fetches the detailed view of a single validator.
+func (c *ValidatorClient) Info(address common.Address) (*ValidatorInfo, error) {
+	var out ValidatorInfo
+	if err := c.Call("validator_info", []interface{}{address.Hex()}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Rewards fetches address's reward history over period (e.g. "24h", "7d").
+func (c *ValidatorClient) Rewards(address common.Address, period string) (*RewardHistory, error) {
+	var out RewardHistory
+	if err := c.Call("validator_rewards", []interface{}{address.Hex(), period}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CommissionOf fetches address's current commission rate and standing.
+func (c *ValidatorClient) CommissionOf(address common.Address) (*CommissionInfo, error) {
+	var out CommissionInfo
+	if err := c.Call("commission_get", []interface{}{address.Hex()}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// stakeTx and commissionTx are the unsigned payloads validator_stake and
+// commission_set broadcast. They're deliberately minimal, signed structs
+// local to this package rather than reuses of core.Transaction — the CLI
+// module doesn't import the core/accounts packages the node binary does,
+// so it defines just enough of a transaction shape to get a validator
+// action signed and onto the wire.
+type stakeTx struct {
+	From      common.Address `json:"from"`
+	Validator common.Address `json:"validator"`
+	Amount    string         `json:"amount"`
+	Nonce     uint64         `json:"nonce"`
+}
+
+type commissionTx struct {
+	From       common.Address `json:"from"`
+	Validator  common.Address `json:"validator"`
+	Commission string         `json:"commission"`
+	Nonce      uint64         `json:"nonce"`
+}
+
+type signedTx struct {
+	Payload   interface{}  `json:"payload"`
+	Signature hexSignature `json:"signature"`
+}
+
+// hexSignature is a 65-byte secp256k1 signature ([R || S || V]), hex-encoded
+// with a "0x" prefix the way every other address/hash field in this API is.
+type hexSignature []byte
+
+func (h hexSignature) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("%q", "0x"+common.Bytes2Hex(h))), nil
+}
+
+func signPayload(payload interface{}, key *ecdsa.PrivateKey) (signedTx, error) {
+	data, err := rlp.EncodeToBytes(payload)
+	if err != nil {
+		return signedTx{}, fmt.Errorf("failed to encode payload: %w", err)
+	}
+	hash := crypto.Keccak256(data)
+	sig, err := crypto.Sign(hash, key)
+	if err != nil {
+		return signedTx{}, fmt.Errorf("failed to sign payload: %w", err)
+	}
+	return signedTx{Payload: payload, Signature: hexSignature(sig)}, nil
+}
+
+// Stake builds, signs with key, and broadcasts a stake transaction
+// delegating amount to validator from the signing account, returning the
+// broadcast transaction's hash.
+func (c *ValidatorClient) Stake(from, validator common.Address, amount string, nonce uint64, key *ecdsa.PrivateKey) (common.Hash, error) {
+	tx, err := signPayload(stakeTx{From: from, Validator: validator, Amount: amount, Nonce: nonce}, key)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	var hash common.Hash
+	if err := c.Call("validator_stake", []interface{}{tx}, &hash); err != nil {
+		return common.Hash{}, err
+	}
+	return hash, nil
+}
+
+// SetCommission builds, signs with key, and broadcasts a commission-rate
+// change for validator, returning the broadcast transaction's hash.
+func (c *ValidatorClient) SetCommission(from, validator common.Address, commission string, nonce uint64, key *ecdsa.PrivateKey) (common.Hash, error) {
+	tx, err := signPayload(commissionTx{From: from, Validator: validator, Commission: commission, Nonce: nonce}, key)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	var hash common.Hash
+	if err := c.Call("commission_set", []interface{}{tx}, &hash); err != nil {
+		return common.Hash{}, err
+	}
+	return hash, nil
+}
+
+// haltVoteTx is the payload validator_haltVote takes: validator's vote that
+// the chain should halt at Height. Unlike stakeTx/commissionTx it isn't
+// wrapped in signedTx/signPayload's RLP+Keccak256 scheme, because the node
+// verifies it the same way it verifies a block signature (ecdsa.VerifyASN1
+// over a sha256 of Height, see consensus.SubmitHaltVote) rather than as an
+// ordinary account transaction.
+type haltVoteTx struct {
+	Validator common.Address `json:"validator"`
+	Height    uint64         `json:"height"`
+	Signature hexSignature   `json:"signature"`
+}
+
+// HaltVote submits validator's vote to halt the chain at height, signed with
+// sig (an ecdsa.SignASN1 signature over sha256(height), matching
+// consensus.SubmitHaltVote's verification).
+func (c *ValidatorClient) HaltVote(validator common.Address, height uint64, sig []byte) error {
+	tx := haltVoteTx{Validator: validator, Height: height, Signature: hexSignature(sig)}
+	return c.Call("validator_haltVote", []interface{}{tx}, nil)
+}
+
+// NextNonce fetches the signing account's next usable nonce.
+func (c *ValidatorClient) NextNonce(address common.Address) (uint64, error) {
+	var nonce uint64
+	if err := c.Call("account_nextNonce", []interface{}{address.Hex()}, &nonce); err != nil {
+		return 0, err
+	}
+	return nonce, nil
+}
+
+// WaitForReceipt polls tx_getReceipt for txHash until it's mined or timeout
+// elapses.
+func (c *ValidatorClient) WaitForReceipt(txHash common.Hash, timeout time.Duration) (*Receipt, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		var receipt *Receipt
+		if err := c.Call("tx_getReceipt", []interface{}{txHash.Hex()}, &receipt); err != nil {
+			return nil, err
+		}
+		if receipt != nil {
+			return receipt, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for receipt of %s", txHash.Hex())
+		}
+		time.Sleep(time.Second)
+	}
+}
@@ -0,0 +1,89 @@
+package walletrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// wildcard matches any destination or transaction kind in a Policy's
+// Allow/Deny lists, for an account that should sign freely except for a
+// short Deny list (or vice versa).
+const wildcard = "*"
+
+// Policy restricts what one account the Daemon hosts is allowed to sign:
+// which destination addresses it may send to, and which transaction kinds
+// (as rpcclient/internal/cli's --type flag names them: "legacy",
+// "accesslist", "dynamic") it may sign at all. Deny is checked before
+// Allow, so an address in both lists is rejected.
+type Policy struct {
+	Account    common.Address `json:"account"`
+	AllowTo    []string       `json:"allow_to,omitempty"`
+	DenyTo     []string       `json:"deny_to,omitempty"`
+	AllowTypes []string       `json:"allow_types,omitempty"`
+}
+
+// LoadPolicies reads a JSON array of Policy from path, keyed by account for
+// Daemon's lookup. An empty path returns an empty set, meaning every
+// account signs unrestricted.
+func LoadPolicies(path string) (map[common.Address]Policy, error) {
+	policies := make(map[common.Address]Policy)
+	if path == "" {
+		return policies, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	var list []Policy
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file: %w", err)
+	}
+	for _, p := range list {
+		policies[p.Account] = p
+	}
+	return policies, nil
+}
+
+// evaluate reports whether a signing request for account, to destination
+// (the zero address for contract creation) and kind passes account's
+// policy. An account with no configured Policy always passes.
+func evaluate(policies map[common.Address]Policy, account, to common.Address, kind string) error {
+	policy, ok := policies[account]
+	if !ok {
+		return nil
+	}
+
+	if matchesAny(policy.DenyTo, to) {
+		return fmt.Errorf("signing policy denies %s as a destination for %s", to.Hex(), account.Hex())
+	}
+	if len(policy.AllowTo) > 0 && !matchesAny(policy.AllowTo, to) {
+		return fmt.Errorf("signing policy does not allow %s as a destination for %s", to.Hex(), account.Hex())
+	}
+	if len(policy.AllowTypes) > 0 && !containsFold(policy.AllowTypes, kind) {
+		return fmt.Errorf("signing policy does not allow transaction type %q for %s", kind, account.Hex())
+	}
+	return nil
+}
+
+func matchesAny(addresses []string, to common.Address) bool {
+	for _, a := range addresses {
+		if a == wildcard || common.HexToAddress(a) == to {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if v == wildcard || strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package light
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// fakeOdrBackend answers TrieRequest/CodeRequest directly against a real
+// core.Trie/leveldb.DB, the same data LocalOdrBackend would read off a live
+// *core.Blockchain — without needing one of those, so this package's tests
+// stay as narrow as core's own trie_test.go/state tests.
+type fakeOdrBackend struct {
+	db   *leveldb.DB
+	root core.Hash
+}
+
+func (b *fakeOdrBackend) Retrieve(ctx context.Context, req OdrRequest) error {
+	switch req := req.(type) {
+	case *TrieRequest:
+		trie := core.NewTrieAt(b.db, b.root)
+		proof, err := trie.Prove(req.Key)
+		if err != nil {
+			return err
+		}
+		req.Proof = proof
+		return nil
+	case *CodeRequest:
+		code, err := b.db.Get(req.CodeHash, nil)
+		if err != nil {
+			return err
+		}
+		req.Code = code
+		return nil
+	default:
+		return fmt.Errorf("fakeOdrBackend: unsupported request type %T", req)
+	}
+}
+
+func openLightTestDB(t *testing.T) *leveldb.DB {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "diora-light-test")
+	if err != nil {
+		t.Fatalf("mkdir temp dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	db, err := leveldb.OpenFile(dir, nil)
+	if err != nil {
+		t.Fatalf("open leveldb: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestLightStateGetBalanceAndNonce(t *testing.T) {
+	db := openLightTestDB(t)
+	state := core.NewState(db)
+
+	addr := core.Address{1, 2, 3}
+	state.SetBalance(addr, big.NewInt(1000))
+	state.SetNonce(addr, 7)
+	code := []byte{0x60, 0x01}
+	state.SetCode(addr, code)
+
+	root, err := state.Commit()
+	if err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	backend := &fakeOdrBackend{db: db, root: root}
+	header := &core.BlockHeader{
+		Number:    big.NewInt(1),
+		StateRoot: common.BytesToHash(root.Bytes()),
+	}
+	ls := NewLightState(backend, header)
+
+	ethAddr := common.BytesToAddress(addr.Bytes())
+	ctx := context.Background()
+
+	bal, err := ls.GetBalance(ctx, ethAddr)
+	if err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	if bal.Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("GetBalance = %v, want 1000", bal)
+	}
+
+	nonce, err := ls.GetNonce(ctx, ethAddr)
+	if err != nil {
+		t.Fatalf("GetNonce: %v", err)
+	}
+	if nonce != 7 {
+		t.Errorf("GetNonce = %d, want 7", nonce)
+	}
+
+	gotCode, err := ls.GetCode(ctx, ethAddr)
+	if err != nil {
+		t.Fatalf("GetCode: %v", err)
+	}
+	if string(gotCode) != string(code) {
+		t.Errorf("GetCode = %x, want %x", gotCode, code)
+	}
+}
+
+func TestLightStateUnknownAddressIsZero(t *testing.T) {
+	db := openLightTestDB(t)
+	state := core.NewState(db)
+	root, err := state.Commit()
+	if err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	backend := &fakeOdrBackend{db: db, root: root}
+	header := &core.BlockHeader{Number: big.NewInt(1), StateRoot: common.BytesToHash(root.Bytes())}
+	ls := NewLightState(backend, header)
+
+	bal, err := ls.GetBalance(context.Background(), common.Address{0xaa})
+	if err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	if bal.Sign() != 0 {
+		t.Errorf("GetBalance(unknown) = %v, want 0", bal)
+	}
+}
+
+func TestLightStateRejectsProofAgainstWrongRoot(t *testing.T) {
+	db := openLightTestDB(t)
+	state := core.NewState(db)
+
+	addr := core.Address{4, 5, 6}
+	state.SetBalance(addr, big.NewInt(42))
+	root, err := state.Commit()
+	if err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	backend := &fakeOdrBackend{db: db, root: root}
+	// header names a StateRoot the backend never committed to — the proof
+	// the backend returns (rooted at `root`) shouldn't verify against it.
+	badHeader := &core.BlockHeader{Number: big.NewInt(1), StateRoot: common.Hash{}}
+	ls := NewLightState(backend, badHeader)
+
+	if _, err := ls.GetBalance(context.Background(), common.BytesToAddress(addr.Bytes())); err == nil {
+		t.Fatal("GetBalance against a mismatched header root succeeded, want an error")
+	}
+}
+
+func TestLightChainGetHeaderByHashCachesAfterSetHead(t *testing.T) {
+	backend := &fakeOdrBackend{}
+	lc := NewLightChain(backend)
+
+	header := &core.BlockHeader{Number: big.NewInt(3), StateRoot: common.Hash{0x01}}
+	lc.SetHead(header)
+
+	if got := lc.Head(); got != header {
+		t.Fatalf("Head() = %v, want %v", got, header)
+	}
+
+	got, err := lc.GetHeaderByHash(context.Background(), headerHash(header))
+	if err != nil {
+		t.Fatalf("GetHeaderByHash: %v", err)
+	}
+	if got.Number.Cmp(header.Number) != 0 {
+		t.Errorf("GetHeaderByHash returned Number %v, want %v", got.Number, header.Number)
+	}
+}
@@ -0,0 +1,117 @@
+package light
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/DioraFund/diora-go-blockchain/crypto"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ChtSectionSize is the number of consecutive block numbers one canonical-
+// hash-trie section covers, matching go-ethereum's light client convention.
+const ChtSectionSize = 32768
+
+// ChtCheckpoint pins one CHT section's root: a Merkle trie over every
+// canonical header hash in [SectionIndex*ChtSectionSize,
+// (SectionIndex+1)*ChtSectionSize), built once that range is old enough no
+// reorg is expected to touch it. A LightChain trusts these roots the same
+// way every other light-client fact is trusted — because something outside
+// this package vouched for them (a hardcoded release checkpoint, or a
+// quorum of peers), not because LightChain verified them itself.
+type ChtCheckpoint struct {
+	SectionIndex uint64
+	ChtRoot      core.Hash
+}
+
+// LightChain holds only block headers, indexed by hash, fetching ones it
+// doesn't already have via OdrBackend (a BlockRequest, keeping only the
+// header out of the answer). It trusts a header once its hash checks out
+// against whatever got it there — a parent link from an already-trusted
+// header, or eventually a CHT proof against one of checkpoints — the same
+// minimal trust model go-ethereum's LightChain uses.
+type LightChain struct {
+	odr         OdrBackend
+	checkpoints []ChtCheckpoint
+
+	mu      sync.RWMutex
+	headers map[common.Hash]*core.BlockHeader
+	head    *core.BlockHeader
+}
+
+// NewLightChain creates a LightChain backed by odr, trusting checkpoints as
+// CHT roots for bootstrapping headers with no other link to an already-
+// trusted one.
+func NewLightChain(odr OdrBackend, checkpoints ...ChtCheckpoint) *LightChain {
+	return &LightChain{
+		odr:         odr,
+		checkpoints: checkpoints,
+		headers:     make(map[common.Hash]*core.BlockHeader),
+	}
+}
+
+// AddCheckpoint registers an additional trusted CHT root, e.g. one the
+// client verified against a quorum of peers after startup.
+func (lc *LightChain) AddCheckpoint(cp ChtCheckpoint) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.checkpoints = append(lc.checkpoints, cp)
+}
+
+// SetHead records header as the chain's current trusted head — the header
+// LightState queries run against by default. A light client calls this as
+// new block announcements arrive and their headers check out.
+func (lc *LightChain) SetHead(header *core.BlockHeader) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.head = header
+	lc.headers[headerHash(header)] = header
+}
+
+// Head returns the chain's current trusted head, or nil if SetHead was
+// never called.
+func (lc *LightChain) Head() *core.BlockHeader {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	return lc.head
+}
+
+// GetHeaderByHash returns a cached header, or fetches one over odr (via a
+// BlockRequest) and caches it. Unlike a TrieRequest's proof, a bare header
+// fetch carries no proof of its own beyond the hash the caller already has
+// in hand — callers should only fetch a header this way as the parent of
+// one they already trust, the same chain-of-custody LightChain's own
+// SetHead/GetHeaderByHash pair assumes throughout this package.
+func (lc *LightChain) GetHeaderByHash(ctx context.Context, hash common.Hash) (*core.BlockHeader, error) {
+	lc.mu.RLock()
+	h, ok := lc.headers[hash]
+	lc.mu.RUnlock()
+	if ok {
+		return h, nil
+	}
+
+	req := &BlockRequest{Hash: hash}
+	if err := lc.odr.Retrieve(ctx, req); err != nil {
+		return nil, fmt.Errorf("light: fetching header %s: %w", hash.Hex(), err)
+	}
+	if req.Block == nil {
+		return nil, fmt.Errorf("light: backend returned no block for header %s", hash.Hex())
+	}
+
+	header := req.Block.Header()
+	lc.mu.Lock()
+	lc.headers[hash] = header
+	lc.mu.Unlock()
+	return header, nil
+}
+
+// headerHash computes a header's hash the same way Block.ComputeHash does —
+// Keccak256 of its RLP encoding — so a header fetched via BlockRequest hashes
+// identically whether it arrived as part of a Block or on its own.
+func headerHash(h *core.BlockHeader) common.Hash {
+	data, _ := rlp.EncodeToBytes(h)
+	return crypto.Keccak256Hash(data)
+}
@@ -0,0 +1,164 @@
+package light
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// accountRLP mirrors core's own (unexported) accountRLP exactly — field for
+// field, same order — since that's the shape core.State.commit writes into
+// the state trie and there's no exported type to decode a proof's leaf
+// value into. Any change to core's layout has to be mirrored here by hand,
+// the same way a real light client independently tracks the account RLP
+// format of the full node it talks to.
+type accountRLP struct {
+	Nonce       uint64
+	Balance     *big.Int
+	StorageRoot core.Hash
+	CodeHash    []byte
+}
+
+// lightAccount is the decoded, verified account a TrieRequest's proof
+// resolved to, cached by LightState so repeat queries against the same
+// header don't re-request and re-verify the same proof.
+type lightAccount struct {
+	nonce       uint64
+	balance     *big.Int
+	storageRoot core.Hash
+	codeHash    []byte
+	code        []byte
+}
+
+// LightState answers GetBalance/GetNonce/GetCode against a single trusted
+// header's StateRoot, by requesting a Merkle proof from odr and verifying it
+// locally via core.VerifyProof — the same proof core.Trie.Prove produces
+// server-side, and eth_getProof exposes over JSON-RPC. It never trusts odr's
+// answer until the proof checks out against header.StateRoot; a tampered or
+// stale proof is rejected the same way core.VerifyProof rejects any proof
+// that doesn't hash-chain back to the root it's checked against.
+type LightState struct {
+	odr    OdrBackend
+	header *core.BlockHeader
+
+	mu       sync.Mutex
+	accounts map[common.Address]*lightAccount
+}
+
+// NewLightState creates a LightState answering queries against header,
+// fetching proofs through odr as needed.
+func NewLightState(odr OdrBackend, header *core.BlockHeader) *LightState {
+	return &LightState{
+		odr:      odr,
+		header:   header,
+		accounts: make(map[common.Address]*lightAccount),
+	}
+}
+
+// GetBalance returns addr's balance as of ls.header, fetching and verifying
+// a Merkle proof if it hasn't been asked about yet. An address absent from
+// the trie (no account ever created there) returns a zero balance, the same
+// as core.State.GetBalance does for an account it hasn't seen.
+func (ls *LightState) GetBalance(ctx context.Context, addr common.Address) (*big.Int, error) {
+	acc, err := ls.account(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Set(acc.balance), nil
+}
+
+// GetNonce returns addr's nonce as of ls.header.
+func (ls *LightState) GetNonce(ctx context.Context, addr common.Address) (uint64, error) {
+	acc, err := ls.account(ctx, addr)
+	if err != nil {
+		return 0, err
+	}
+	return acc.nonce, nil
+}
+
+// GetCode returns addr's contract code as of ls.header, fetching it with a
+// CodeRequest (on top of the TrieRequest that already resolved CodeHash) the
+// first time it's asked for. An externally-owned account (CodeHash ==
+// Keccak256(nil)) returns nil without a request, matching core.State's own
+// emptyCodeHash shortcut.
+func (ls *LightState) GetCode(ctx context.Context, addr common.Address) ([]byte, error) {
+	acc, err := ls.account(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	emptyCodeHash := core.Keccak256(nil)
+	if len(acc.codeHash) == 0 || bytes.Equal(acc.codeHash, emptyCodeHash) {
+		return nil, nil
+	}
+
+	ls.mu.Lock()
+	if acc.code != nil {
+		code := acc.code
+		ls.mu.Unlock()
+		return code, nil
+	}
+	ls.mu.Unlock()
+
+	req := &CodeRequest{Header: ls.header, CodeHash: acc.codeHash}
+	if err := ls.odr.Retrieve(ctx, req); err != nil {
+		return nil, fmt.Errorf("light: fetching code %x: %w", acc.codeHash, err)
+	}
+	if got := core.Keccak256(req.Code); !bytes.Equal(got, acc.codeHash) {
+		return nil, fmt.Errorf("light: code for %x does not hash back to its CodeHash", acc.codeHash)
+	}
+
+	ls.mu.Lock()
+	acc.code = req.Code
+	ls.mu.Unlock()
+	return req.Code, nil
+}
+
+// account returns addr's verified, cached account, fetching and verifying a
+// TrieRequest proof against ls.header.StateRoot the first time addr is
+// asked about.
+func (ls *LightState) account(ctx context.Context, addr common.Address) (*lightAccount, error) {
+	ls.mu.Lock()
+	if acc, ok := ls.accounts[addr]; ok {
+		ls.mu.Unlock()
+		return acc, nil
+	}
+	ls.mu.Unlock()
+
+	key := core.Keccak256(addr.Bytes())
+	req := &TrieRequest{Header: ls.header, Key: key}
+	if err := ls.odr.Retrieve(ctx, req); err != nil {
+		return nil, fmt.Errorf("light: fetching proof for %s: %w", addr.Hex(), err)
+	}
+
+	root := core.BytesToHash(ls.header.StateRoot.Bytes())
+	value, err := core.VerifyProof(root, key, req.Proof)
+	if err != nil {
+		return nil, fmt.Errorf("light: invalid proof for %s: %w", addr.Hex(), err)
+	}
+
+	acc := &lightAccount{balance: big.NewInt(0)}
+	if value != nil {
+		var dec accountRLP
+		if err := rlp.DecodeBytes(value, &dec); err != nil {
+			return nil, fmt.Errorf("light: decoding account %s: %w", addr.Hex(), err)
+		}
+		acc.nonce = dec.Nonce
+		if dec.Balance != nil {
+			acc.balance = dec.Balance
+		}
+		acc.storageRoot = dec.StorageRoot
+		acc.codeHash = dec.CodeHash
+	}
+
+	ls.mu.Lock()
+	ls.accounts[addr] = acc
+	ls.mu.Unlock()
+	return acc, nil
+}
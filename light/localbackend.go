@@ -0,0 +1,72 @@
+package light
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DioraFund/diora-go-blockchain/core"
+)
+
+// LocalOdrBackend answers OdrBackend requests directly against a full
+// node's own *core.Blockchain, with no network hop — the in-process
+// counterpart to whatever eventually carries GetProofs/Proofs/
+// GetHeaderProofs messages over the wire (see protocol.go). It's what this
+// package's own tests use to drive a LightChain/LightState against a real
+// chain, and what an embedded full+light node running in the same process
+// would use instead of dialing itself over localhost.
+type LocalOdrBackend struct {
+	bc *core.Blockchain
+}
+
+// NewLocalOdrBackend wraps bc as an OdrBackend.
+func NewLocalOdrBackend(bc *core.Blockchain) *LocalOdrBackend {
+	return &LocalOdrBackend{bc: bc}
+}
+
+// Retrieve answers req directly from b.bc, the full node's own state and
+// block storage — no proof is generated beyond the one req itself asks for
+// (a TrieRequest's core.Trie.Prove); BlockRequest and ReceiptsRequest just
+// return what the full node already has, the same way a networked backend
+// would return that data today (untrusted until whatever later uses it
+// decides it trusts the full node), without yet including them in a
+// Merkle-proof flow of their own.
+func (b *LocalOdrBackend) Retrieve(ctx context.Context, req OdrRequest) error {
+	switch req := req.(type) {
+	case *TrieRequest:
+		root := core.BytesToHash(req.Header.StateRoot.Bytes())
+		trie := core.NewTrieAt(b.bc.StateDB(), root)
+		proof, err := trie.Prove(req.Key)
+		if err != nil {
+			return fmt.Errorf("light: proving key %x: %w", req.Key, err)
+		}
+		req.Proof = proof
+		return nil
+
+	case *CodeRequest:
+		code, err := b.bc.StateDB().Get(req.CodeHash, nil)
+		if err != nil {
+			return fmt.Errorf("light: code %x not found: %w", req.CodeHash, err)
+		}
+		req.Code = code
+		return nil
+
+	case *BlockRequest:
+		block, err := b.bc.GetBlockByHash(req.Hash)
+		if err != nil {
+			return fmt.Errorf("light: block %s not found: %w", req.Hash.Hex(), err)
+		}
+		req.Block = block
+		return nil
+
+	case *ReceiptsRequest:
+		block, err := b.bc.GetBlockByNumber(req.Header.Number)
+		if err != nil {
+			return fmt.Errorf("light: block %v not found: %w", req.Header.Number, err)
+		}
+		req.Receipts = b.bc.GetReceipts(block)
+		return nil
+
+	default:
+		return fmt.Errorf("light: unsupported request type %T", req)
+	}
+}
@@ -0,0 +1,95 @@
+// Package light implements an on-demand-retrieval (ODR) light client,
+// modeled on go-ethereum's les package: instead of replicating the whole
+// state trie, a light client holds only headers and fetches whatever
+// account/storage/code data a query actually touches from a full node,
+// verifying it against a trusted header's StateRoot before trusting it.
+package light
+
+import (
+	"context"
+
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// OdrBackend answers on-demand-retrieval requests for a LightChain or
+// LightState that doesn't hold full state itself. Retrieve fills in req's
+// answer fields on success; the caller is responsible for verifying
+// whatever proof comes back against the header the request named — Retrieve
+// itself doesn't have to be trusted, since LightState/LightChain check its
+// answers (see LightState.account, which runs core.VerifyProof on a
+// TrieRequest's Proof before ever touching Value).
+//
+// LocalOdrBackend implements this in-process against a real
+// *core.Blockchain, the counterpart a networked implementation (carrying
+// GetProofs/Proofs/GetHeaderProofs messages — see protocol.go) would use
+// over the wire once this repo has a p2p layer to carry them.
+type OdrBackend interface {
+	Retrieve(ctx context.Context, req OdrRequest) error
+}
+
+// OdrRequest is implemented by BlockRequest, ReceiptsRequest, TrieRequest,
+// and CodeRequest. Header names the header every request is anchored to:
+// a TrieRequest's proof is only meaningful relative to that header's
+// StateRoot, a ReceiptsRequest's receipts only relative to its ReceiptRoot,
+// and so on.
+type OdrRequest interface {
+	odrHeader() *core.BlockHeader
+}
+
+// BlockRequest asks for the full block (transactions included) at Hash —
+// Header is normally the same header this names, included because every
+// OdrRequest carries one, but BlockRequest is the one request where
+// fetching Header itself might be the point (a light client with no header
+// at all yet, bootstrapping from a CHT checkpoint).
+type BlockRequest struct {
+	Header *core.BlockHeader
+	Hash   common.Hash
+
+	// Block is filled in by a successful Retrieve.
+	Block *core.Block
+}
+
+func (r *BlockRequest) odrHeader() *core.BlockHeader { return r.Header }
+
+// ReceiptsRequest asks for every receipt belonging to Header's block,
+// verified (by the caller, once filled in) against Header.ReceiptRoot.
+type ReceiptsRequest struct {
+	Header *core.BlockHeader
+
+	// Receipts is filled in by a successful Retrieve.
+	Receipts []*core.Receipt
+}
+
+func (r *ReceiptsRequest) odrHeader() *core.BlockHeader { return r.Header }
+
+// TrieRequest asks for a Merkle proof of Key in the state trie rooted at
+// Header.StateRoot — an account leaf when Key is Keccak256(address), or a
+// storage slot when Key is Keccak256(storage key) and Header names the
+// account's own storage trie rather than the state trie. LightState is the
+// only caller today and always asks about the state trie.
+type TrieRequest struct {
+	Header *core.BlockHeader
+	Key    []byte
+
+	// Proof is filled in by a successful Retrieve; the caller still has to
+	// run it through core.VerifyProof(Header.StateRoot, Key, Proof) before
+	// trusting whatever value it resolves to.
+	Proof [][]byte
+}
+
+func (r *TrieRequest) odrHeader() *core.BlockHeader { return r.Header }
+
+// CodeRequest asks for the contract code stored under CodeHash. The caller
+// verifies it by checking Keccak256(Code) == CodeHash itself — CodeHash
+// already came out of a TrieRequest-verified account leaf, so that single
+// hash check is the whole proof; no Merkle path is needed on top of it.
+type CodeRequest struct {
+	Header   *core.BlockHeader
+	CodeHash []byte
+
+	// Code is filled in by a successful Retrieve.
+	Code []byte
+}
+
+func (r *CodeRequest) odrHeader() *core.BlockHeader { return r.Header }
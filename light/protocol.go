@@ -0,0 +1,63 @@
+package light
+
+import "github.com/ethereum/go-ethereum/common"
+
+// Wire message types an on-demand-retrieval light client's traffic would
+// carry over a p2p connection, the same three request/response pairs
+// go-ethereum's les protocol defines for its light clients. This repo has
+// no p2p networking package yet (there's no peer wire protocol anywhere
+// else in the tree to extend), so these aren't wired into an actual
+// transport — they're the message shapes LocalOdrBackend already answers
+// in-process, ready for a future p2p package to serialize and send once one
+// exists.
+const (
+	GetProofsMsg       = 0x11
+	ProofsMsg          = 0x12
+	GetHeaderProofsMsg = 0x13
+)
+
+// ProofReq names one TrieRequest by the header's hash and the key to prove,
+// the wire-sized form of TrieRequest (which carries the whole header, not
+// just its hash, since a local caller already has it in hand).
+type ProofReq struct {
+	BHash common.Hash
+	Key   []byte
+}
+
+// GetProofsPacket batches several ProofReqs under one request ID, mirroring
+// how les batches GetProofs requests to amortize a round trip across many
+// keys.
+type GetProofsPacket struct {
+	ReqID    uint64
+	Requests []ProofReq
+}
+
+// ProofsPacket answers a GetProofsPacket with one Merkle proof per request,
+// in the same order.
+type ProofsPacket struct {
+	ReqID  uint64
+	Proofs [][][]byte
+}
+
+// ChtReq names a single canonical-hash-trie lookup: the block number whose
+// canonical hash is wanted, proved against the CHT section covering it.
+type ChtReq struct {
+	ChtNum   uint64
+	BlockNum uint64
+}
+
+// GetHeaderProofsPacket asks for canonical-hash-trie proofs for a batch of
+// ChtReqs, the mechanism a LightChain bootstrapping far from its most recent
+// checkpoint uses to fetch an old header's hash without replaying every
+// block back to genesis.
+type GetHeaderProofsPacket struct {
+	ReqID    uint64
+	Requests []ChtReq
+}
+
+// HeaderProofsPacket answers a GetHeaderProofsPacket with one CHT proof per
+// request, in the same order.
+type HeaderProofsPacket struct {
+	ReqID  uint64
+	Proofs [][][]byte
+}
@@ -5,11 +5,17 @@ import (
 	"log"
 	"math/big"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/spf13/cobra"
+
+	"github.com/DioraFund/diora-go-blockchain/accounts"
+	"github.com/DioraFund/diora-go-blockchain/accounts/usbwallet"
+	"github.com/DioraFund/diora-go-blockchain/internal/keystore"
 )
 
 type Block struct {
@@ -27,6 +33,21 @@ type Blockchain struct {
 var blockchain *Blockchain
 var transactions []Transaction
 
+// ks and accountManager back every wallet endpoint: ks holds the
+// encrypted key files on disk, accountManager is the single thing the
+// Gin handlers ask to sign on an account's behalf.
+var ks *keystore.Keystore
+var accountManager *accounts.Manager
+
+// hardwareHub is the usbwallet.Hub backing the hardware-wallet endpoints;
+// nil until startNode runs.
+var hardwareHub *usbwallet.Hub
+
+// signerURL holds the --signer flag's value: the JSON-RPC endpoint of an
+// out-of-process signer (Clef/lotus-wallet style). Empty means the node
+// signs only with its local keystore.
+var signerURL string
+
 func (b *Block) CalculateHash() string {
 	return fmt.Sprintf("%d%s%s%s", b.Index, b.Timestamp.String(), b.Data, b.PreviousHash)
 }
@@ -58,6 +79,8 @@ func (bc *Blockchain) AddBlock(data string) {
 func main() {
 	blockchain = NewBlockchain()
 
+	ks = keystore.NewKeystore("./keystore")
+
 	// CLI
 	var rootCmd = &cobra.Command{
 		Use:   "diora",
@@ -70,6 +93,7 @@ func main() {
 		Short: "Start the blockchain node",
 		Run:   startNode,
 	}
+	startCmd.Flags().StringVar(&signerURL, "signer", "", "JSON-RPC URL of an external signer (Clef/lotus-wallet style); remote accounts are tried before the local keystore")
 
 	var statusCmd = &cobra.Command{
 		Use:   "status",
@@ -82,6 +106,20 @@ func main() {
 }
 
 func startNode(cmd *cobra.Command, args []string) {
+	// Remote accounts are registered before the keystore backend so a
+	// signer-held account wins if the same address somehow exists in both.
+	var backends []accounts.Backend
+	if signerURL != "" {
+		backends = append(backends, accounts.NewExternalBackend(signerURL))
+		fmt.Printf("🔏 Using external signer at %s\n", signerURL)
+	}
+	backends = append(backends, accounts.NewKeystoreBackend(ks))
+
+	hardwareHub = usbwallet.NewHub()
+	backends = append(backends, hardwareHub)
+
+	accountManager = accounts.NewManager(backends...)
+
 	// Start HTTP server
 	r := gin.Default()
 
@@ -114,6 +152,16 @@ func startNode(cmd *cobra.Command, args []string) {
 	r.POST("/api/wallets/:address/send", sendFromWallet)
 	r.GET("/api/wallets/:address/balance", getWalletBalance)
 
+	// HD wallet endpoints
+	r.POST("/api/wallets/hd", createHDWallet)
+	r.POST("/api/wallets/hd/:name/derive", deriveHDWalletAccount)
+
+	// Hardware wallet endpoints. :url is wildcard-matched (rather than a
+	// single path segment) since a device's URL embeds its USB path,
+	// which itself contains slashes.
+	r.GET("/api/wallets/hardware", getHardwareWallets)
+	r.POST("/api/wallets/hardware/*url", deriveHardwareWalletAccount)
+
 	// Web interface
 	r.StaticFile("/", "./web/dist/index.html")
 	r.Static("/static", "./web/dist")
@@ -192,19 +240,13 @@ func createTransaction(c *gin.Context) {
 		return
 	}
 
-	// Create new transaction
-	tx, err := NewTransaction(request.From, request.To, request.Amount)
+	// Create and sign the transaction through accountManager
+	tx, err := NewTransaction(accountManager, request.From, request.To, request.Amount, request.Password)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Validate transaction
-	if !tx.IsValid() {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction"})
-		return
-	}
-
 	// Add to transactions pool
 	transactions = append(transactions, *tx)
 
@@ -221,13 +263,11 @@ func createTransaction(c *gin.Context) {
 
 // Wallet functions
 func getWallets(c *gin.Context) {
-	wallets := GetAllWallets()
+	wallets := GetAllWallets(ks)
 	walletData := make([]map[string]interface{}, len(wallets))
 
 	for i, wallet := range wallets {
 		walletData[i] = wallet.ToJSON()
-		// Remove private key for security
-		delete(walletData[i], "private_key")
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -238,7 +278,8 @@ func getWallets(c *gin.Context) {
 
 func createWallet(c *gin.Context) {
 	var request struct {
-		Name string `json:"name" binding:"required"`
+		Name     string `json:"name" binding:"required"`
+		Password string `json:"password" binding:"required"`
 	}
 
 	if err := c.ShouldBindJSON(&request); err != nil {
@@ -246,7 +287,7 @@ func createWallet(c *gin.Context) {
 		return
 	}
 
-	wallet, err := NewWallet(request.Name)
+	wallet, err := NewWallet(ks, request.Name, request.Password)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -261,18 +302,14 @@ func createWallet(c *gin.Context) {
 func getWallet(c *gin.Context) {
 	address := c.Param("address")
 
-	wallet, exists := GetWallet(address)
+	wallet, exists := GetWallet(ks, address)
 	if !exists {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Wallet not found"})
 		return
 	}
 
-	walletData := wallet.ToJSON()
-	// Remove private key for security
-	delete(walletData, "private_key")
-
 	c.JSON(http.StatusOK, gin.H{
-		"wallet": walletData,
+		"wallet": wallet.ToJSON(),
 	})
 }
 
@@ -280,8 +317,9 @@ func sendFromWallet(c *gin.Context) {
 	address := c.Param("address")
 
 	var request struct {
-		To     string `json:"to" binding:"required"`
-		Amount string `json:"amount" binding:"required"`
+		To       string `json:"to" binding:"required"`
+		Amount   string `json:"amount" binding:"required"`
+		Password string `json:"password" binding:"required"`
 	}
 
 	if err := c.ShouldBindJSON(&request); err != nil {
@@ -289,7 +327,7 @@ func sendFromWallet(c *gin.Context) {
 		return
 	}
 
-	wallet, exists := GetWallet(address)
+	wallet, exists := GetWallet(ks, address)
 	if !exists {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Wallet not found"})
 		return
@@ -302,7 +340,7 @@ func sendFromWallet(c *gin.Context) {
 		return
 	}
 
-	err := wallet.Send(request.To, amount)
+	err := wallet.Send(accountManager, request.To, amount, request.Password)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -316,10 +354,143 @@ func sendFromWallet(c *gin.Context) {
 	})
 }
 
+// createHDWallet creates (mnemonic empty) or imports (mnemonic set) a
+// BIP-39/BIP-32 HD wallet and returns its first derived account.
+func createHDWallet(c *gin.Context) {
+	var request struct {
+		Name       string `json:"name" binding:"required"`
+		Password   string `json:"password" binding:"required"`
+		Mnemonic   string `json:"mnemonic"`
+		Passphrase string `json:"passphrase"`
+		Path       string `json:"path"`
+		Words      int    `json:"words"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := ks.NewHDAccount(request.Name, request.Password, request.Mnemonic, request.Passphrase, request.Path, request.Words)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	walletLedger.mu.Lock()
+	walletLedger.balance[account.Address.Hex()] = big.NewInt(0)
+	walletLedger.mu.Unlock()
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "HD wallet created successfully",
+		"wallet":  walletFromAccount(account).ToJSON(),
+	})
+}
+
+// deriveHDWalletAccount derives and returns the next unused child account
+// of the HD wallet named by the :name path param.
+func deriveHDWalletAccount(c *gin.Context) {
+	name := c.Param("name")
+
+	account, err := ks.DeriveNext(name)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "account derived successfully",
+		"wallet":  walletFromAccount(account).ToJSON(),
+	})
+}
+
+// getHardwareWallets lists every currently connected Ledger/Trezor device
+// and the accounts already derived and pinned on each.
+func getHardwareWallets(c *gin.Context) {
+	if hardwareHub == nil {
+		c.JSON(http.StatusOK, gin.H{"wallets": []interface{}{}, "count": 0})
+		return
+	}
+
+	wallets := hardwareHub.Wallets()
+	data := make([]gin.H, len(wallets))
+	for i, w := range wallets {
+		status, _ := w.Status()
+		accountList := w.Accounts()
+		addresses := make([]string, len(accountList))
+		for j, a := range accountList {
+			addresses[j] = a.Address.Hex()
+		}
+		data[i] = gin.H{
+			"url":      w.URL().String(),
+			"status":   status,
+			"accounts": addresses,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"wallets": data, "count": len(data)})
+}
+
+// deriveHardwareWalletAccount derives and pins a new account on the device
+// identified by the URL-encoded :url wildcard, along the BIP-32 path given
+// in the request body.
+func deriveHardwareWalletAccount(c *gin.Context) {
+	rawParam := strings.TrimPrefix(c.Param("url"), "/")
+	rawParam = strings.TrimSuffix(rawParam, "/derive")
+
+	deviceURL, err := url.QueryUnescape(rawParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid device URL"})
+		return
+	}
+
+	var request struct {
+		Path string `json:"path" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if hardwareHub == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "no hardware wallet hub running"})
+		return
+	}
+
+	var wallet accounts.Wallet
+	for _, w := range hardwareHub.Wallets() {
+		if w.URL().String() == deviceURL {
+			wallet = w
+			break
+		}
+	}
+	if wallet == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "device not found"})
+		return
+	}
+
+	if err := wallet.Open(""); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := wallet.Derive(request.Path, true)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "account derived successfully",
+		"address": account.Address.Hex(),
+		"path":    request.Path,
+	})
+}
+
 func getWalletBalance(c *gin.Context) {
 	address := c.Param("address")
 
-	wallet, exists := GetWallet(address)
+	wallet, exists := GetWallet(ks, address)
 	if !exists {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Wallet not found"})
 		return
@@ -0,0 +1,71 @@
+// Package p2p models this node's peer-to-peer identity and known-peer
+// bookkeeping using the same enode addressing scheme devp2p networks use
+// (enode://<128-hex-char-pubkey>@host:port), so an operator can paste an
+// enode URL between two diora nodes the same way they would between two
+// geth nodes. There is no actual devp2p transport (discovery, RLPx
+// handshake, wire protocol) behind it yet: PeerManager records peer intent
+// for the admin_* RPC namespace and a future transport to act on, rather
+// than dialing anything itself.
+package p2p
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Enode identifies a node's public key and network address, the devp2p
+// convention for addressing a peer.
+type Enode struct {
+	ID   string // 128 hex chars: the uncompressed secp256k1 public key, X||Y
+	IP   string
+	Port int
+}
+
+// NewEnode derives an Enode from pub, advertising ip/port as where that
+// node can be reached.
+func NewEnode(pub *ecdsa.PublicKey, ip string, port int) *Enode {
+	return &Enode{
+		ID:   fmt.Sprintf("%x", crypto.FromECDSAPub(pub)[1:]), // drop the 0x04 prefix byte
+		IP:   ip,
+		Port: port,
+	}
+}
+
+// String renders e as an enode:// URL.
+func (e *Enode) String() string {
+	return fmt.Sprintf("enode://%s@%s:%d", e.ID, e.IP, e.Port)
+}
+
+// ParseEnode parses an enode://<pubkey>@host:port URL.
+func ParseEnode(s string) (*Enode, error) {
+	const prefix = "enode://"
+	if !strings.HasPrefix(s, prefix) {
+		return nil, fmt.Errorf("enode URL must start with %q", prefix)
+	}
+	rest := s[len(prefix):]
+
+	at := strings.LastIndex(rest, "@")
+	if at < 0 {
+		return nil, fmt.Errorf("enode URL missing '@host:port'")
+	}
+	id, hostport := rest[:at], rest[at+1:]
+	if len(id) != 128 {
+		return nil, fmt.Errorf("enode public key must be 128 hex characters, got %d", len(id))
+	}
+
+	colon := strings.LastIndex(hostport, ":")
+	if colon < 0 {
+		return nil, fmt.Errorf("enode URL missing port")
+	}
+	host := hostport[:colon]
+	port, err := strconv.Atoi(hostport[colon+1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid enode port: %w", err)
+	}
+
+	return &Enode{ID: id, IP: host, Port: port}, nil
+}
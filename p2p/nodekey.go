@@ -0,0 +1,43 @@
+package p2p
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// LoadOrGenerateNodeKey reads the hex-encoded secp256k1 private key at
+// path, generating and persisting a new one if path doesn't exist yet.
+// This is the node's long-lived identity: its enode ID is derived from
+// this key's public half, so restarts keep advertising the same enode URL
+// instead of a fresh one every time.
+func LoadOrGenerateNodeKey(path string) (*ecdsa.PrivateKey, error) {
+	if data, err := ioutil.ReadFile(path); err == nil {
+		key, err := crypto.HexToECDSA(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse node key at %s: %w", path, err)
+		}
+		return key, nil
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read node key at %s: %w", path, err)
+	}
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate node key: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create node key directory: %w", err)
+	}
+	hexKey := fmt.Sprintf("%x", crypto.FromECDSA(key))
+	if err := ioutil.WriteFile(path, []byte(hexKey), 0600); err != nil {
+		return nil, fmt.Errorf("failed to persist node key at %s: %w", path, err)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,97 @@
+package p2p
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// PeerInfo is one entry of PeerManager's known-peer table, the shape
+// admin_peers reports over RPC.
+type PeerInfo struct {
+	ID      string    `json:"id"`
+	Enode   string    `json:"enode"`
+	Trusted bool      `json:"trusted"`
+	AddedAt time.Time `json:"addedAt"`
+}
+
+// PeerManager tracks this node's own identity and the set of peers an
+// operator has registered via admin_addPeer/admin_addTrustedPeer. It does
+// not dial or maintain any live connection to them — see the package doc
+// for why — so every PeerInfo here describes a static, admin-configured
+// entry rather than an active session.
+type PeerManager struct {
+	mu    sync.RWMutex
+	self  *Enode
+	peers map[string]*PeerInfo
+}
+
+// NewPeerManager returns a PeerManager advertising self as this node's own
+// enode.
+func NewPeerManager(self *Enode) *PeerManager {
+	return &PeerManager{
+		self:  self,
+		peers: make(map[string]*PeerInfo),
+	}
+}
+
+// Self returns this node's own enode.
+func (pm *PeerManager) Self() *Enode {
+	return pm.self
+}
+
+// Peers returns every registered peer.
+func (pm *PeerManager) Peers() []*PeerInfo {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	out := make([]*PeerInfo, 0, len(pm.peers))
+	for _, p := range pm.peers {
+		out = append(out, p)
+	}
+	return out
+}
+
+// Add registers enode as a known peer, marking it trusted if requested.
+// Adding an already-known enode just updates its trusted flag.
+func (pm *PeerManager) Add(enodeURL string, trusted bool) (*PeerInfo, error) {
+	node, err := ParseEnode(enodeURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid enode: %w", err)
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if existing, ok := pm.peers[node.ID]; ok {
+		existing.Trusted = existing.Trusted || trusted
+		return existing, nil
+	}
+
+	info := &PeerInfo{
+		ID:      node.ID,
+		Enode:   node.String(),
+		Trusted: trusted,
+		AddedAt: time.Now(),
+	}
+	pm.peers[node.ID] = info
+	return info, nil
+}
+
+// Remove drops enode from the known-peer table, reporting whether it was
+// present.
+func (pm *PeerManager) Remove(enodeURL string) (bool, error) {
+	node, err := ParseEnode(enodeURL)
+	if err != nil {
+		return false, fmt.Errorf("invalid enode: %w", err)
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if _, ok := pm.peers[node.ID]; !ok {
+		return false, nil
+	}
+	delete(pm.peers, node.ID)
+	return true, nil
+}
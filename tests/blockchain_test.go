@@ -5,8 +5,8 @@ import (
 	"testing"
 	"time"
 
-	"github.com/diora-blockchain/diora/core"
-	"github.com/diora-blockchain/diora/crypto"
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/DioraFund/diora-go-blockchain/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -1,13 +1,19 @@
 package main
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
 	"math/big"
 	"time"
+
+	"github.com/DioraFund/diora-go-blockchain/accounts"
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/ethereum/go-ethereum/common"
 )
 
+// Transaction is the HTTP-facing view of a signed core.Transaction. The
+// demo chain still records blocks as plain strings, so this flattens the
+// fields worth showing a client rather than serializing core.Transaction
+// directly.
 type Transaction struct {
 	From      string    `json:"from"`
 	To        string    `json:"to"`
@@ -18,48 +24,52 @@ type Transaction struct {
 }
 
 type TransactionRequest struct {
-	From   string `json:"from" binding:"required"`
-	To     string `json:"to" binding:"required"`
-	Amount string `json:"amount" binding:"required"`
+	From     string `json:"from" binding:"required"`
+	To       string `json:"to" binding:"required"`
+	Amount   string `json:"amount" binding:"required"`
+	Password string `json:"password" binding:"required"`
 }
 
-func NewTransaction(from, to, amount string) (*Transaction, error) {
-	// Convert amount to big.Int
+// NewTransaction signs a transfer from "from" to "to" through manager —
+// which routes the request to whichever Wallet holds "from"'s key —
+// instead of touching a private key directly, then wraps the result for
+// display/storage in the demo transaction pool.
+func NewTransaction(manager *accounts.Manager, from, to, amount, password string) (*Transaction, error) {
 	amountInt, ok := new(big.Int).SetString(amount, 10)
 	if !ok {
 		return nil, fmt.Errorf("invalid amount")
 	}
 
-	tx := &Transaction{
-		From:      from,
-		To:        to,
-		Amount:    amountInt,
-		Timestamp: time.Now(),
-	}
-
-	// Calculate hash
-	tx.Hash = tx.CalculateHash()
+	fromAddr := common.HexToAddress(from)
+	toAddr := common.HexToAddress(to)
 
-	// Sign transaction (simplified - in real blockchain this would use private key)
-	tx.Signature = "SIGNATURE_" + tx.Hash
-
-	return tx, nil
-}
+	wallet, err := manager.Find(accounts.Account{Address: fromAddr})
+	if err != nil {
+		return nil, fmt.Errorf("wallet not found: %w", err)
+	}
 
-func (tx *Transaction) CalculateHash() string {
-	data := fmt.Sprintf("%s%s%s%s",
-		tx.From,
-		tx.To,
-		tx.Amount.String(),
-		tx.Timestamp.String())
+	unsigned := &core.Transaction{
+		Type:     core.LegacyTxType,
+		Nonce:    walletLedger.nonceOf(from),
+		GasPrice: big.NewInt(1),
+		GasLimit: 21000,
+		To:       &toAddr,
+		Value:    amountInt,
+	}
 
-	hash := sha256.Sum256([]byte(data))
-	return hex.EncodeToString(hash[:])
-}
+	signed, err := wallet.SignTxWithPassphrase(accounts.Account{Address: fromAddr}, password, unsigned, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
 
-func (tx *Transaction) IsValid() bool {
-	// Check if hash matches
-	return tx.Hash == tx.CalculateHash()
+	return &Transaction{
+		From:      from,
+		To:        to,
+		Amount:    amountInt,
+		Timestamp: time.Now(),
+		Signature: fmt.Sprintf("%s:%s:%s", signed.V.String(), signed.R.String(), signed.S.String()),
+		Hash:      signed.Hash.Hex(),
+	}, nil
 }
 
 func (tx *Transaction) ToJSON() map[string]interface{} {
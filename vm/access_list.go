@@ -0,0 +1,89 @@
+package vm
+
+import "github.com/DioraFund/diora-go-blockchain/core"
+
+// gasSloadEIP2929 prices SLOAD under Berlin's EIP-2929 access list: the
+// first time this transaction touches key under contract.Address costs
+// ColdSload, every later touch costs the much cheaper WarmStorageRead. The
+// access-list bookkeeping itself lives on core.State (AddSlotToAccessList)
+// right alongside the journal that undoes it on a RevertToSnapshot, the same
+// way SetState's storageChange does.
+func gasSloadEIP2929(evm *EVM, contract *Contract, stack *Stack, memory *Memory, memorySize uint64) (uint64, error) {
+	key := core.BytesToHash(stack.back(0).Bytes())
+	if evm.state.AddSlotToAccessList(contract.Address, key) {
+		return evm.gasTable.ColdSload, nil
+	}
+	return evm.gasTable.WarmStorageRead, nil
+}
+
+// gasSstoreEIP2929 extends gasSstore's SStoreSet/SStoreReset base cost with
+// EIP-2929's cold-slot surcharge: a slot's first touch this transaction
+// additionally costs ColdSload on top of whichever base price writing to it
+// carries; later touches in the same transaction pay the base price alone.
+// Full EIP-2200 net-gas metering (refunds based on original vs. current vs.
+// new value) isn't implemented here any more than it was in gasSstore — this
+// only adds the access-list surcharge on top of that existing simplification.
+func gasSstoreEIP2929(evm *EVM, contract *Contract, stack *Stack, memory *Memory, memorySize uint64) (uint64, error) {
+	key := core.BytesToHash(stack.back(0).Bytes())
+	newValue := core.BytesToHash(stack.back(1).Bytes())
+	current := evm.state.GetState(contract.Address, key)
+
+	cost := evm.gasTable.SStoreReset
+	if current == (core.Hash{}) && newValue != (core.Hash{}) {
+		cost = evm.gasTable.SStoreSet
+	}
+	if evm.state.AddSlotToAccessList(contract.Address, key) {
+		cost += evm.gasTable.ColdSload
+	}
+	return cost, nil
+}
+
+// gasCallEIP2929 replaces CALL/CALLCODE/DELEGATECALL/STATICCALL's flat
+// Call-category cost with EIP-2929's address access-list pricing:
+// ColdAccountAccess the first time this transaction calls addr,
+// WarmStorageRead every time after. All four opcodes keep addr at stack
+// position 1 (gas, addr, ...), so one gasFunc covers them all. BALANCE and
+// EXTCODE* don't exist in this VM yet, so they have no gas function to wire
+// this into — AddAddressToAccessList is ready for them once they do.
+func gasCallEIP2929(evm *EVM, contract *Contract, stack *Stack, memory *Memory, memorySize uint64) (uint64, error) {
+	addr := core.BytesToAddress(stack.back(1).Bytes())
+	cost := evm.gasTable.WarmStorageRead
+	if evm.state.AddAddressToAccessList(addr) {
+		cost = evm.gasTable.ColdAccountAccess
+	}
+	return cost + memoryExpansionGas(memory, memorySize), nil
+}
+
+// gasTransientStorage prices TLOAD/TSTORE: EIP-1153 fixes both at
+// WarmStorageRead unconditionally — transient storage has no cold/warm
+// distinction of its own since it's never persisted, so there's nothing for
+// a first touch to be more expensive than.
+func gasTransientStorage(evm *EVM, contract *Contract, stack *Stack, memory *Memory, memorySize uint64) (uint64, error) {
+	return evm.gasTable.WarmStorageRead, nil
+}
+
+// opTload is TLOAD (0x5c): like SLOAD but against core.State's per-
+// transaction transient storage rather than its persistent one.
+func opTload(pc *uint64, contract *Contract, stack *Stack, memory *Memory, ctx *Context) *result {
+	keyOp := stack.pop()
+	hash := core.BytesToHash(keyOp.Bytes())
+	value := contract.EVM.state.GetTransientState(contract.Address, hash)
+	keyOp.SetBytes(value.Bytes())
+	stack.push(keyOp)
+	return &result{}
+}
+
+// opTstore is TSTORE (0x5d): like SSTORE but against transient storage, so
+// it's still subject to STATICCALL's write protection even though the write
+// never reaches the trie.
+func opTstore(pc *uint64, contract *Contract, stack *Stack, memory *Memory, ctx *Context) *result {
+	if contract.ReadOnly {
+		return &result{err: ErrWriteProtection}
+	}
+	key, value := stack.pop(), stack.pop()
+	hash := core.BytesToHash(key.Bytes())
+	contract.EVM.state.SetTransientState(contract.Address, hash, core.BytesToHash(value.Bytes()))
+	putInt(key)
+	putInt(value)
+	return &result{}
+}
@@ -0,0 +1,96 @@
+package vm
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/DioraFund/diora-go-blockchain/core"
+)
+
+// CallFrame is one node of a CallTracer's call tree: a single CALL/CALLCODE/
+// DELEGATECALL/STATICCALL/CREATE/CREATE2 frame, with whichever frames it in
+// turn opened nested underneath it in Calls.
+type CallFrame struct {
+	Type    string       `json:"type"`
+	From    core.Address `json:"from"`
+	To      core.Address `json:"to"`
+	Input   []byte       `json:"input"`
+	Output  []byte       `json:"output,omitempty"`
+	Gas     uint64       `json:"gas"`
+	GasUsed uint64       `json:"gasUsed"`
+	Value   *big.Int     `json:"value,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	Calls   []*CallFrame `json:"calls,omitempty"`
+}
+
+// CallTracer is a Tracer that ignores per-instruction detail and instead
+// reconstructs the nested tree of call frames a transaction opened, the
+// shape debug_traceTransaction's "callTracer" mode returns. It tracks frames
+// by a simple stack: CaptureStart pushes a new frame under whichever frame
+// is currently on top (nil if it's the root), CaptureEnd pops and fills it
+// in.
+type CallTracer struct {
+	mu    sync.Mutex
+	stack []*CallFrame
+	root  *CallFrame
+}
+
+func NewCallTracer() *CallTracer {
+	return &CallTracer{}
+}
+
+func (t *CallTracer) CaptureStart(from, to core.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	typ := "CALL"
+	if create {
+		typ = "CREATE"
+	}
+	frame := &CallFrame{Type: typ, From: from, To: to, Input: input, Gas: gas, Value: value}
+
+	if len(t.stack) > 0 {
+		parent := t.stack[len(t.stack)-1]
+		parent.Calls = append(parent.Calls, frame)
+	}
+	t.stack = append(t.stack, frame)
+}
+
+// CaptureState is a no-op: CallTracer only cares about where frames begin
+// and end, not the instructions in between.
+func (t *CallTracer) CaptureState(pc uint64, op OpCode, gas, cost uint64, memory []byte, stack []*Int, depth int, err error) {
+}
+
+// CaptureFault is a no-op too — the failure it reports is surfaced via the
+// CaptureEnd that immediately follows it instead.
+func (t *CallTracer) CaptureFault(pc uint64, op OpCode, gas, cost uint64, depth int, err error) {
+}
+
+func (t *CallTracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.stack) == 0 {
+		return
+	}
+	frame := t.stack[len(t.stack)-1]
+	t.stack = t.stack[:len(t.stack)-1]
+
+	frame.Output = output
+	frame.GasUsed = gasUsed
+	if err != nil {
+		frame.Error = err.Error()
+	}
+
+	if len(t.stack) == 0 {
+		t.root = frame
+	}
+}
+
+// CallTrace returns the root of the reconstructed call tree, or nil if no
+// frame has finished yet.
+func (t *CallTracer) CallTrace() *CallFrame {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.root
+}
@@ -0,0 +1,159 @@
+package vm
+
+import (
+	"math/big"
+
+	"github.com/DioraFund/diora-go-blockchain/core"
+)
+
+// ChainRules is a snapshot of which hard forks are active at a given block
+// number, derived once from core.Config so the rest of the VM never has to
+// compare *big.Int block numbers itself. It's the same idea as
+// go-ethereum's params.Rules: boolean flags an interpreter or precompile
+// lookup can switch on directly, computed fresh per call since the active
+// block can differ from one EVM.Call to the next.
+type ChainRules struct {
+	IsHomestead bool
+	IsByzantium bool
+	IsIstanbul  bool
+	IsBerlin    bool
+	IsCancun    bool
+}
+
+// Rules derives the ChainRules in effect at blockNumber from config's fork
+// schedule. Each flag is computed from its own fork block alone, so IsCancun
+// true means IsBerlin, IsIstanbul, IsByzantium, and IsHomestead are too only
+// when config schedules the forks in order — the "later forks are supersets"
+// assumption newJumpTable's chain of newXInstructionSet constructors relies
+// on.
+func Rules(config *core.Config, blockNumber *big.Int) ChainRules {
+	return ChainRules{
+		IsHomestead: isForkActive(config.HomesteadBlock, blockNumber),
+		IsByzantium: isForkActive(config.ByzantiumBlock, blockNumber),
+		IsIstanbul:  isForkActive(config.IstanbulBlock, blockNumber),
+		IsBerlin:    isForkActive(config.BerlinBlock, blockNumber),
+		IsCancun:    isForkActive(config.CancunBlock, blockNumber),
+	}
+}
+
+// isForkActive reports whether forkBlock has been reached by blockNumber. A
+// nil forkBlock means the fork isn't scheduled at all, so it's never active
+// regardless of blockNumber.
+func isForkActive(forkBlock, blockNumber *big.Int) bool {
+	if forkBlock == nil || blockNumber == nil {
+		return false
+	}
+	return blockNumber.Cmp(forkBlock) >= 0
+}
+
+// Rules derives the ChainRules in effect for ctx.Number under evm's config —
+// the form every call site actually wants, since they already have a
+// *Context in hand rather than a bare block number.
+func (evm *EVM) Rules(ctx *Context) ChainRules {
+	return Rules(evm.config, ctx.Number)
+}
+
+// jumpTable picks the JumpTable the interpreter should use for rules, out of
+// the set NewInterpreter precomputed. Later forks are named for the opcodes
+// and gas repricings they're expected to carry (SHL/SHR/SAR and CHAINID
+// landing with Istanbul et al., PUSH0 and warm/cold access lists with
+// Berlin, transient storage and MCOPY with Cancun) — none of those are wired
+// up yet, so today every table below newHomesteadInstructionSet is just a
+// copy of it, ready for a later chunk to override specific opcodes on top.
+func (in *Interpreter) jumpTable(rules ChainRules) *JumpTable {
+	switch {
+	case rules.IsCancun:
+		return in.tables.cancun
+	case rules.IsBerlin:
+		return in.tables.berlin
+	case rules.IsIstanbul:
+		return in.tables.istanbul
+	case rules.IsByzantium:
+		return in.tables.byzantium
+	default:
+		return in.tables.homestead
+	}
+}
+
+// instructionSets holds one precomputed JumpTable per fork this VM knows
+// about, built once in NewInterpreter rather than per-call.
+type instructionSets struct {
+	homestead *JumpTable
+	byzantium *JumpTable
+	istanbul  *JumpTable
+	berlin    *JumpTable
+	cancun    *JumpTable
+}
+
+// newInstructionSets builds all five per-fork tables up front. Each
+// newXInstructionSet below already applies the gas table itself (it's
+// newJumpTable's job), so there's nothing left to do here but collect them.
+func newInstructionSets() instructionSets {
+	return instructionSets{
+		homestead: newHomesteadInstructionSet(),
+		byzantium: newByzantiumInstructionSet(),
+		istanbul:  newIstanbulInstructionSet(),
+		berlin:    newBerlinInstructionSet(),
+		cancun:    newCancunInstructionSet(),
+	}
+}
+
+// newHomesteadInstructionSet is the VM's baseline table (everything
+// newJumpTable used to build single-handed): CALL/CREATE2/STATICCALL and the
+// arithmetic/logic/memory/storage opcodes already implemented, but none of
+// SDIV/MOD/SMOD/ADDMOD/MULMOD/SIGNEXTEND/SLT/SGT/BYTE/SHL/SHR/SAR, CHAINID,
+// SELFBALANCE, BASEFEE, PUSH0, MCOPY, or TLOAD/TSTORE.
+func newHomesteadInstructionSet() *JumpTable {
+	return newJumpTable()
+}
+
+// newByzantiumInstructionSet adds REVERT/STATICCALL/RETURNDATA* over
+// Homestead in a real fork schedule; this VM already has REVERT and
+// STATICCALL in its one base table, so for now this is a copy, left as the
+// seam a later chunk adds Byzantium-specific opcodes to.
+func newByzantiumInstructionSet() *JumpTable {
+	return newJumpTable()
+}
+
+// newIstanbulInstructionSet is where SHL/SHR/SAR, CHAINID, and SELFBALANCE
+// are expected to land; not implemented yet, so this is a copy of Byzantium.
+func newIstanbulInstructionSet() *JumpTable {
+	return newJumpTable()
+}
+
+// newBerlinInstructionSet adds EIP-2929's warm/cold access-list gas pricing
+// to SLOAD, SSTORE, and the CALL family on top of Istanbul's table — see
+// gasSloadEIP2929/gasSstoreEIP2929/gasCallEIP2929 in access_list.go. BASEFEE
+// isn't implemented yet, so this is otherwise a copy of Istanbul.
+func newBerlinInstructionSet() *JumpTable {
+	table := newJumpTable()
+
+	sload := table[SLOAD]
+	sload.gasCost = gasSloadEIP2929
+	table[SLOAD] = sload
+
+	sstore := table[SSTORE]
+	sstore.gasCost = gasSstoreEIP2929
+	table[SSTORE] = sstore
+
+	for _, op := range []OpCode{CALL, CALLCODE, DELEGATECALL, STATICCALL} {
+		entry := table[op]
+		entry.gasCost = gasCallEIP2929
+		table[op] = entry
+	}
+
+	return table
+}
+
+// newCancunInstructionSet adds EIP-1153's TLOAD/TSTORE transient storage
+// opcodes on top of Berlin's table — see opTload/opTstore in
+// access_list.go. PUSH0 and MCOPY aren't implemented yet, so this is
+// otherwise a copy of Berlin.
+func newCancunInstructionSet() *JumpTable {
+	table := newBerlinInstructionSet()
+
+	table[TLOAD] = operation{execute: opTload, gasCost: gasTransientStorage, validateStack: makeStackValidation(minMaxStack(1, 1))}
+	table[TSTORE] = operation{execute: opTstore, gasCost: gasTransientStorage, validateStack: makeStackValidation(minMaxStack(2, 0))}
+
+	return table
+}
@@ -0,0 +1,589 @@
+package vm
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"golang.org/x/crypto/ripemd160"
+
+	ethblake2b "github.com/ethereum/go-ethereum/crypto/blake2b"
+	ethbn256 "github.com/ethereum/go-ethereum/crypto/bn256"
+
+	"github.com/DioraFund/diora-go-blockchain/core"
+	"github.com/DioraFund/diora-go-blockchain/crypto"
+)
+
+// PrecompiledContract is a native contract: one whose Run is implemented in
+// Go rather than EVM bytecode, addressed at a small fixed core.Address
+// (0x01..0x0a) instead of holding deployed code. RequiredGas prices a call
+// before Run does the actual work, the same split gasCost/execute has for
+// every other opcode.
+type PrecompiledContract interface {
+	RequiredGas(input []byte) uint64
+	Run(input []byte) ([]byte, error)
+}
+
+// precompileAddress is PrecompiledContractsHomestead..Cancun's shared way of
+// spelling "the account at this small fixed address", since core.Address has
+// no literal-friendly constructor of its own.
+func precompileAddress(n byte) core.Address {
+	return core.BytesToAddress([]byte{n})
+}
+
+// PrecompiledContractsHomestead is the precompile set active pre-Byzantium:
+// just the four Frontier-era contracts.
+var PrecompiledContractsHomestead = map[core.Address]PrecompiledContract{
+	precompileAddress(1): &ecrecover{},
+	precompileAddress(2): &sha256hash{},
+	precompileAddress(3): &ripemd160hash{},
+	precompileAddress(4): &dataCopy{},
+}
+
+// PrecompiledContractsByzantium adds MODEXP (EIP-198) and the BN256 curve
+// operations (EIP-196/197), at their original (pre-EIP-1108) gas prices.
+var PrecompiledContractsByzantium = map[core.Address]PrecompiledContract{
+	precompileAddress(1): &ecrecover{},
+	precompileAddress(2): &sha256hash{},
+	precompileAddress(3): &ripemd160hash{},
+	precompileAddress(4): &dataCopy{},
+	precompileAddress(5): &bigModExp{eip2565: false},
+	precompileAddress(6): &bn256Add{gas: bn256AddGasByzantium},
+	precompileAddress(7): &bn256ScalarMul{gas: bn256ScalarMulGasByzantium},
+	precompileAddress(8): &bn256Pairing{baseGas: bn256PairingBaseGasByzantium, perPointGas: bn256PairingPerPointGasByzantium},
+}
+
+// PrecompiledContractsIstanbul adds BLAKE2F (EIP-152) and reprices the BN256
+// operations down (EIP-1108).
+var PrecompiledContractsIstanbul = map[core.Address]PrecompiledContract{
+	precompileAddress(1): &ecrecover{},
+	precompileAddress(2): &sha256hash{},
+	precompileAddress(3): &ripemd160hash{},
+	precompileAddress(4): &dataCopy{},
+	precompileAddress(5): &bigModExp{eip2565: false},
+	precompileAddress(6): &bn256Add{gas: bn256AddGasIstanbul},
+	precompileAddress(7): &bn256ScalarMul{gas: bn256ScalarMulGasIstanbul},
+	precompileAddress(8): &bn256Pairing{baseGas: bn256PairingBaseGasIstanbul, perPointGas: bn256PairingPerPointGasIstanbul},
+	precompileAddress(9): &blake2F{},
+}
+
+// PrecompiledContractsBerlin only reprices MODEXP down (EIP-2565); the rest
+// of the set is unchanged from Istanbul.
+var PrecompiledContractsBerlin = map[core.Address]PrecompiledContract{
+	precompileAddress(1): &ecrecover{},
+	precompileAddress(2): &sha256hash{},
+	precompileAddress(3): &ripemd160hash{},
+	precompileAddress(4): &dataCopy{},
+	precompileAddress(5): &bigModExp{eip2565: true},
+	precompileAddress(6): &bn256Add{gas: bn256AddGasIstanbul},
+	precompileAddress(7): &bn256ScalarMul{gas: bn256ScalarMulGasIstanbul},
+	precompileAddress(8): &bn256Pairing{baseGas: bn256PairingBaseGasIstanbul, perPointGas: bn256PairingPerPointGasIstanbul},
+	precompileAddress(9): &blake2F{},
+}
+
+// PrecompiledContractsCancun adds the EIP-4844 point evaluation precompile
+// at 0x0a on top of Berlin's set.
+var PrecompiledContractsCancun = map[core.Address]PrecompiledContract{
+	precompileAddress(1):    &ecrecover{},
+	precompileAddress(2):    &sha256hash{},
+	precompileAddress(3):    &ripemd160hash{},
+	precompileAddress(4):    &dataCopy{},
+	precompileAddress(5):    &bigModExp{eip2565: true},
+	precompileAddress(6):    &bn256Add{gas: bn256AddGasIstanbul},
+	precompileAddress(7):    &bn256ScalarMul{gas: bn256ScalarMulGasIstanbul},
+	precompileAddress(8):    &bn256Pairing{baseGas: bn256PairingBaseGasIstanbul, perPointGas: bn256PairingPerPointGasIstanbul},
+	precompileAddress(9):    &blake2F{},
+	precompileAddress(0x0a): &kzgPointEvaluation{},
+}
+
+// activePrecompiles picks the precompile set rules activates, falling back
+// to Homestead's baseline set the same way jumpTable falls back to the
+// Homestead instruction set: each later fork's map is a superset/repricing
+// of the one before it, so the first matching (highest) flag wins.
+func activePrecompiles(rules ChainRules) map[core.Address]PrecompiledContract {
+	switch {
+	case rules.IsCancun:
+		return PrecompiledContractsCancun
+	case rules.IsBerlin:
+		return PrecompiledContractsBerlin
+	case rules.IsIstanbul:
+		return PrecompiledContractsIstanbul
+	case rules.IsByzantium:
+		return PrecompiledContractsByzantium
+	default:
+		return PrecompiledContractsHomestead
+	}
+}
+
+// runPrecompiled looks addr up in rules' active precompile set and, if
+// found, runs it against gas. handled is false when addr isn't a precompile
+// under rules, telling the caller to fall through to its normal
+// code-loading path instead.
+func runPrecompiled(rules ChainRules, addr core.Address, input []byte, gas uint64) (ret []byte, remainingGas uint64, handled bool, err error) {
+	p, ok := activePrecompiles(rules)[addr]
+	if !ok {
+		return nil, gas, false, nil
+	}
+	cost := p.RequiredGas(input)
+	if gas < cost {
+		return nil, 0, true, ErrOutOfGas
+	}
+	ret, err = p.Run(input)
+	return ret, gas - cost, true, err
+}
+
+// allZero reports whether every byte of b is zero, used by ecrecover to
+// reject a v padded with anything other than zeroes.
+func allZero(b []byte) bool {
+	for _, x := range b {
+		if x != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// padInput right-pads (or truncates) data to exactly n bytes, the shape
+// ECRECOVER/MODEXP/BN256 inputs are defined to have been zero-padded to
+// regardless of how many bytes the caller actually supplied.
+func padInput(data []byte, n int) []byte {
+	out := make([]byte, n)
+	copy(out, data)
+	return out
+}
+
+// getPrecompileData is getData (evm.go) under a name that doesn't collide
+// and reads clearly from a precompile's Run, which slices a fixed-size
+// input the same right-padding way PUSH's immediate data does.
+func getPrecompileData(data []byte, start, size uint64) []byte {
+	return getData(data, start, size)
+}
+
+// ecrecover implements ECRECOVER (0x01): recover the address that produced
+// a secp256k1 signature over a hash.
+type ecrecover struct{}
+
+func (c *ecrecover) RequiredGas(input []byte) uint64 { return 3000 }
+
+func (c *ecrecover) Run(input []byte) ([]byte, error) {
+	const inputLength = 128
+	input = padInput(input, inputLength)
+
+	v := input[63] - 27
+	if !allZero(input[32:63]) || (v != 0 && v != 1) {
+		return nil, nil
+	}
+
+	sig := make([]byte, 65)
+	copy(sig, input[64:128])
+	sig[64] = v
+
+	pubKey, err := crypto.Ecrecover(input[:32], sig)
+	if err != nil {
+		return nil, nil
+	}
+
+	hash := crypto.Keccak256(pubKey[1:])
+	return padInput(hash[12:], 32), nil
+}
+
+// sha256hash implements SHA256 (0x02).
+type sha256hash struct{}
+
+func (c *sha256hash) RequiredGas(input []byte) uint64 {
+	return uint64(len(input)+31)/32*12 + 60
+}
+
+func (c *sha256hash) Run(input []byte) ([]byte, error) {
+	h := sha256.Sum256(input)
+	return h[:], nil
+}
+
+// ripemd160hash implements RIPEMD160 (0x03).
+type ripemd160hash struct{}
+
+func (c *ripemd160hash) RequiredGas(input []byte) uint64 {
+	return uint64(len(input)+31)/32*120 + 600
+}
+
+func (c *ripemd160hash) Run(input []byte) ([]byte, error) {
+	h := ripemd160.New()
+	h.Write(input)
+	return padInput(h.Sum(nil), 32), nil
+}
+
+// dataCopy implements IDENTITY (0x04): hands its input straight back.
+type dataCopy struct{}
+
+func (c *dataCopy) RequiredGas(input []byte) uint64 {
+	return uint64(len(input)+31)/32*3 + 15
+}
+
+func (c *dataCopy) Run(input []byte) ([]byte, error) {
+	return append([]byte(nil), input...), nil
+}
+
+// bigModExp implements MODEXP (0x05, EIP-198): base**exp mod mod, with
+// base/exp/mod's byte lengths given as the input's first three 32-byte
+// words. eip2565 selects Berlin's cheaper gas formula (EIP-2565) over the
+// original EIP-198 one.
+type bigModExp struct {
+	eip2565 bool
+}
+
+var (
+	modExpBig3      = big.NewInt(3)
+	modExpBig4      = big.NewInt(4)
+	modExpBig7      = big.NewInt(7)
+	modExpBig8      = big.NewInt(8)
+	modExpBig16     = big.NewInt(16)
+	modExpBig20     = big.NewInt(20)
+	modExpBig32     = big.NewInt(32)
+	modExpBig64     = big.NewInt(64)
+	modExpBig96     = big.NewInt(96)
+	modExpBig480    = big.NewInt(480)
+	modExpBig1024   = big.NewInt(1024)
+	modExpBig3072   = big.NewInt(3072)
+	modExpBig199680 = big.NewInt(199680)
+)
+
+// modexpMultComplexity is EIP-198's mult_complexity(x), x the larger of the
+// base and modulus byte lengths.
+func modexpMultComplexity(x *big.Int) *big.Int {
+	switch {
+	case x.Cmp(modExpBig64) <= 0:
+		return new(big.Int).Mul(x, x)
+	case x.Cmp(modExpBig1024) <= 0:
+		sq := new(big.Int).Mul(x, x)
+		return new(big.Int).Add(new(big.Int).Div(sq, modExpBig4), new(big.Int).Sub(new(big.Int).Mul(modExpBig96, x), modExpBig3072))
+	default:
+		sq := new(big.Int).Mul(x, x)
+		return new(big.Int).Add(new(big.Int).Div(sq, modExpBig16), new(big.Int).Sub(new(big.Int).Mul(modExpBig480, x), modExpBig199680))
+	}
+}
+
+func (c *bigModExp) RequiredGas(input []byte) uint64 {
+	baseLen := new(big.Int).SetBytes(getPrecompileData(input, 0, 32))
+	expLen := new(big.Int).SetBytes(getPrecompileData(input, 32, 32))
+	modLen := new(big.Int).SetBytes(getPrecompileData(input, 64, 32))
+
+	if len(input) > 96 {
+		input = input[96:]
+	} else {
+		input = input[:0]
+	}
+
+	var expHead *big.Int
+	if big.NewInt(int64(len(input))).Cmp(baseLen) <= 0 {
+		expHead = new(big.Int)
+	} else if expLen.Cmp(modExpBig32) > 0 {
+		expHead = new(big.Int).SetBytes(getPrecompileData(input, baseLen.Uint64(), 32))
+	} else {
+		expHead = new(big.Int).SetBytes(getPrecompileData(input, baseLen.Uint64(), expLen.Uint64()))
+	}
+
+	msb := 0
+	if bitlen := expHead.BitLen(); bitlen > 0 {
+		msb = bitlen - 1
+	}
+	adjExpLen := new(big.Int)
+	if expLen.Cmp(modExpBig32) > 0 {
+		adjExpLen.Sub(expLen, modExpBig32)
+		adjExpLen.Mul(modExpBig8, adjExpLen)
+	}
+	adjExpLen.Add(adjExpLen, big.NewInt(int64(msb)))
+
+	bigMax := baseLen
+	if modLen.Cmp(baseLen) > 0 {
+		bigMax = modLen
+	}
+	gas := new(big.Int).Set(bigMax)
+
+	adjExpLenOrOne := adjExpLen
+	if adjExpLenOrOne.Sign() < 1 {
+		adjExpLenOrOne = big.NewInt(1)
+	}
+
+	if c.eip2565 {
+		gas.Add(gas, modExpBig7)
+		gas.Div(gas, modExpBig8)
+		gas.Mul(gas, gas)
+		gas.Mul(gas, adjExpLenOrOne)
+		gas.Div(gas, modExpBig3)
+		if gas.BitLen() > 64 {
+			return ^uint64(0)
+		}
+		if gas.Uint64() < 200 {
+			return 200
+		}
+		return gas.Uint64()
+	}
+
+	gas = modexpMultComplexity(gas)
+	gas.Mul(gas, adjExpLenOrOne)
+	gas.Div(gas, modExpBig20)
+	if gas.BitLen() > 64 {
+		return ^uint64(0)
+	}
+	return gas.Uint64()
+}
+
+func (c *bigModExp) Run(input []byte) ([]byte, error) {
+	baseLen := new(big.Int).SetBytes(getPrecompileData(input, 0, 32)).Uint64()
+	expLen := new(big.Int).SetBytes(getPrecompileData(input, 32, 32)).Uint64()
+	modLen := new(big.Int).SetBytes(getPrecompileData(input, 64, 32)).Uint64()
+
+	if len(input) > 96 {
+		input = input[96:]
+	} else {
+		input = input[:0]
+	}
+
+	if baseLen == 0 && modLen == 0 {
+		return []byte{}, nil
+	}
+
+	base := new(big.Int).SetBytes(getPrecompileData(input, 0, baseLen))
+	exp := new(big.Int).SetBytes(getPrecompileData(input, baseLen, expLen))
+	mod := new(big.Int).SetBytes(getPrecompileData(input, baseLen+expLen, modLen))
+
+	var v []byte
+	switch {
+	case mod.BitLen() == 0:
+		return padInput(nil, int(modLen)), nil
+	case base.BitLen() == 1:
+		v = base.Mod(base, mod).Bytes()
+	default:
+		v = base.Exp(base, exp, mod).Bytes()
+	}
+	return padInput(v, int(modLen)), nil
+}
+
+// Gas prices for the BN256 (alt_bn128) operations: Byzantium's original
+// EIP-196/197 prices, and Istanbul's cheaper EIP-1108 ones (also used as-is
+// by Berlin and Cancun, which don't reprice these further).
+const (
+	bn256AddGasByzantium             = 500
+	bn256AddGasIstanbul              = 150
+	bn256ScalarMulGasByzantium       = 40000
+	bn256ScalarMulGasIstanbul        = 6000
+	bn256PairingBaseGasByzantium     = 100000
+	bn256PairingBaseGasIstanbul      = 45000
+	bn256PairingPerPointGasByzantium = 80000
+	bn256PairingPerPointGasIstanbul  = 34000
+)
+
+var errBadPairingInput = errors.New("bad elliptic curve pairing size")
+
+// bn256Add implements BN256_ADD (0x06, EIP-196): point addition on the
+// alt_bn128 curve.
+type bn256Add struct {
+	gas uint64
+}
+
+func (c *bn256Add) RequiredGas(input []byte) uint64 { return c.gas }
+
+func (c *bn256Add) Run(input []byte) ([]byte, error) {
+	x := new(ethbn256.G1)
+	if _, err := x.Unmarshal(getPrecompileData(input, 0, 64)); err != nil {
+		return nil, err
+	}
+	y := new(ethbn256.G1)
+	if _, err := y.Unmarshal(getPrecompileData(input, 64, 64)); err != nil {
+		return nil, err
+	}
+	res := new(ethbn256.G1).Add(x, y)
+	return res.Marshal(), nil
+}
+
+// bn256ScalarMul implements BN256_MUL (0x07, EIP-196): scalar multiplication
+// on the alt_bn128 curve.
+type bn256ScalarMul struct {
+	gas uint64
+}
+
+func (c *bn256ScalarMul) RequiredGas(input []byte) uint64 { return c.gas }
+
+func (c *bn256ScalarMul) Run(input []byte) ([]byte, error) {
+	p := new(ethbn256.G1)
+	if _, err := p.Unmarshal(getPrecompileData(input, 0, 64)); err != nil {
+		return nil, err
+	}
+	scalar := new(big.Int).SetBytes(getPrecompileData(input, 64, 32))
+	res := new(ethbn256.G1).ScalarMult(p, scalar)
+	return res.Marshal(), nil
+}
+
+var (
+	bn256PairingTrue  = padInput([]byte{1}, 32)
+	bn256PairingFalse = make([]byte, 32)
+)
+
+// bn256Pairing implements BN256_PAIRING (0x08, EIP-197): a batch pairing
+// check over (G1, G2) point pairs, 192 bytes each.
+type bn256Pairing struct {
+	baseGas     uint64
+	perPointGas uint64
+}
+
+func (c *bn256Pairing) RequiredGas(input []byte) uint64 {
+	return c.baseGas + uint64(len(input)/192)*c.perPointGas
+}
+
+func (c *bn256Pairing) Run(input []byte) ([]byte, error) {
+	if len(input)%192 != 0 {
+		return nil, errBadPairingInput
+	}
+
+	var g1s []*ethbn256.G1
+	var g2s []*ethbn256.G2
+	for i := 0; i < len(input); i += 192 {
+		g1 := new(ethbn256.G1)
+		if _, err := g1.Unmarshal(input[i : i+64]); err != nil {
+			return nil, err
+		}
+		g2 := new(ethbn256.G2)
+		if _, err := g2.Unmarshal(input[i+64 : i+192]); err != nil {
+			return nil, err
+		}
+		g1s = append(g1s, g1)
+		g2s = append(g2s, g2)
+	}
+
+	if ethbn256.PairingCheck(g1s, g2s) {
+		return bn256PairingTrue, nil
+	}
+	return bn256PairingFalse, nil
+}
+
+// blake2F implements BLAKE2F (0x09, EIP-152): one call to the BLAKE2b
+// compression function, exposed so contracts (e.g. a Zcash bridge) can
+// verify BLAKE2b-based proofs without paying EVM-bytecode prices for it.
+type blake2F struct{}
+
+const blake2FInputLength = 213
+
+func (c *blake2F) RequiredGas(input []byte) uint64 {
+	if len(input) != blake2FInputLength {
+		return 0
+	}
+	return uint64(binary.BigEndian.Uint32(input[0:4]))
+}
+
+var (
+	errBlake2FInvalidInputLength = errors.New("invalid input length")
+	errBlake2FInvalidFinalFlag   = errors.New("invalid final flag")
+)
+
+func (c *blake2F) Run(input []byte) ([]byte, error) {
+	if len(input) != blake2FInputLength {
+		return nil, errBlake2FInvalidInputLength
+	}
+	if input[212] != 0 && input[212] != 1 {
+		return nil, errBlake2FInvalidFinalFlag
+	}
+
+	var (
+		rounds = binary.BigEndian.Uint32(input[0:4])
+		final  = input[212] == 1
+
+		h [8]uint64
+		m [16]uint64
+		t [2]uint64
+	)
+	for i := 0; i < 8; i++ {
+		h[i] = binary.LittleEndian.Uint64(input[4+i*8 : 12+i*8])
+	}
+	for i := 0; i < 16; i++ {
+		m[i] = binary.LittleEndian.Uint64(input[68+i*8 : 76+i*8])
+	}
+	t[0] = binary.LittleEndian.Uint64(input[196:204])
+	t[1] = binary.LittleEndian.Uint64(input[204:212])
+
+	ethblake2b.F(&h, m, t, final, rounds)
+
+	output := make([]byte, 64)
+	for i := 0; i < 8; i++ {
+		binary.LittleEndian.PutUint64(output[i*8:i*8+8], h[i])
+	}
+	return output, nil
+}
+
+// kzgPointEvaluation implements the EIP-4844 point evaluation precompile at
+// 0x0a: it's meant to verify a KZG proof that a blob's polynomial evaluates
+// to a claimed value at a claimed point. Doing that for real needs a full
+// BLS12-381 pairing check against a trusted-setup SRS, which this codebase
+// has no other use for and doesn't vendor; what's implemented here is the
+// cheap, genuinely-checkable part of the spec — input shape and the
+// commitment-to-versioned-hash binding (both plain SHA256, no pairing
+// involved) — while the pairing-based proof check itself is left
+// unverified. Treat this precompile as shape-validated but not
+// cryptographically sound until a BLS12-381 implementation is vendored.
+type kzgPointEvaluation struct{}
+
+const (
+	blobVerifyInputLength          = 192
+	blobCommitmentVersionKZG uint8 = 0x01
+)
+
+// blobPrecompileReturnValue is the fixed (FIELD_ELEMENTS_PER_BLOB,
+// BLS_MODULUS) pair every successful call returns, per EIP-4844.
+var blobPrecompileReturnValue = mustHex("000000000000000000000000000000000000000000000000000000000000100073eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001")
+
+func mustHex(s string) []byte {
+	out := make([]byte, len(s)/2)
+	for i := range out {
+		hi := hexNibble(s[i*2])
+		lo := hexNibble(s[i*2+1])
+		out[i] = hi<<4 | lo
+	}
+	return out
+}
+
+func hexNibble(b byte) byte {
+	switch {
+	case b >= '0' && b <= '9':
+		return b - '0'
+	case b >= 'a' && b <= 'f':
+		return b - 'a' + 10
+	default:
+		return b - 'A' + 10
+	}
+}
+
+var (
+	errBlobVerifyInvalidInputLength = errors.New("invalid input length")
+	errBlobVerifyMismatchedVersion  = errors.New("mismatched versioned hash")
+)
+
+func (c *kzgPointEvaluation) RequiredGas(input []byte) uint64 { return 50000 }
+
+func (c *kzgPointEvaluation) Run(input []byte) ([]byte, error) {
+	if len(input) != blobVerifyInputLength {
+		return nil, errBlobVerifyInvalidInputLength
+	}
+
+	versionedHash := input[:32]
+	commitment := input[96:144]
+
+	got := sha256.Sum256(commitment)
+	got[0] = blobCommitmentVersionKZG
+	if !bytesEqual(got[:], versionedHash) {
+		return nil, errBlobVerifyMismatchedVersion
+	}
+
+	return blobPrecompileReturnValue, nil
+}
+
+func bytesEqual(a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
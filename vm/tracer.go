@@ -0,0 +1,126 @@
+package vm
+
+import (
+	"encoding/hex"
+	"math/big"
+	"sync"
+
+	"github.com/DioraFund/diora-go-blockchain/core"
+)
+
+// Tracer observes a call frame step by step as Interpreter.run executes it.
+// CaptureStart/CaptureEnd bracket one frame (top-level or nested, CALL or
+// CREATE alike); CaptureState fires once per instruction that actually
+// executes, CaptureFault once for whichever instruction made the frame
+// fail. The interface only ever hands a Tracer plain values — never the
+// live Stack/Memory/Contract themselves — so a future JS/WASM tracer
+// plugin can implement it without the VM exposing anything beyond this.
+type Tracer interface {
+	// CaptureStart is called once when a frame begins. create distinguishes
+	// a CREATE/CREATE2 frame from an ordinary CALL/CALLCODE/DELEGATECALL/
+	// STATICCALL one; to is the frame's own address either way (the
+	// contract being called, or the new contract address already derived
+	// for a CREATE).
+	CaptureStart(from, to core.Address, create bool, input []byte, gas uint64, value *big.Int)
+
+	// CaptureState is called once per instruction, after its gas cost has
+	// been deducted and memory grown to match but before it executes: pc,
+	// gas, and cost describe the instruction about to run; stack and memory
+	// are snapshots of the frame's state at that point.
+	CaptureState(pc uint64, op OpCode, gas, cost uint64, memory []byte, stack []*Int, depth int, err error)
+
+	// CaptureFault is called instead of CaptureState when an instruction
+	// can't run at all — invalid opcode, stack underflow/overflow, a
+	// memory-size overflow, or out of gas.
+	CaptureFault(pc uint64, op OpCode, gas, cost uint64, depth int, err error)
+
+	// CaptureEnd is called once when the frame ends, successfully or not.
+	// err is nil for STOP/RETURN, *RevertError for REVERT, and whatever
+	// CaptureFault was just given otherwise.
+	CaptureEnd(output []byte, gasUsed uint64, err error)
+}
+
+// StructLog is one CaptureState/CaptureFault step, shaped for the
+// one-JSON-object-per-opcode output debug_traceTransaction callers expect.
+// Stack/Memory are hex-encoded rather than left as raw bytes so the struct
+// marshals to JSON the same way regardless of which Tracer produced it.
+type StructLog struct {
+	Pc      uint64            `json:"pc"`
+	Op      string            `json:"op"`
+	Gas     uint64            `json:"gas"`
+	GasCost uint64            `json:"gasCost"`
+	Depth   int               `json:"depth"`
+	Stack   []string          `json:"stack"`
+	Memory  string            `json:"memory"`
+	Storage map[string]string `json:"storage,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// StructLogger is a Tracer that records one StructLog per executed
+// instruction, plus a running view of the slots SSTORE has touched so far
+// (surfaced per-step as that step's Storage diff).
+type StructLogger struct {
+	mu      sync.Mutex
+	logs    []StructLog
+	storage map[string]string
+}
+
+func NewStructLogger() *StructLogger {
+	return &StructLogger{storage: make(map[string]string)}
+}
+
+func (l *StructLogger) CaptureStart(from, to core.Address, create bool, input []byte, gas uint64, value *big.Int) {
+}
+
+func (l *StructLogger) CaptureState(pc uint64, op OpCode, gas, cost uint64, memory []byte, stack []*Int, depth int, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stackHex := make([]string, len(stack))
+	for i, v := range stack {
+		stackHex[i] = hex.EncodeToString(v.Bytes())
+	}
+
+	// SSTORE's operands sit at the top of the stack it's about to consume:
+	// top-of-stack is the key, the item below it the value (see opSstore).
+	var storage map[string]string
+	if op == SSTORE && len(stack) >= 2 {
+		key := hex.EncodeToString(stack[len(stack)-1].Bytes())
+		val := hex.EncodeToString(stack[len(stack)-2].Bytes())
+		l.storage[key] = val
+		storage = map[string]string{key: val}
+	}
+
+	l.logs = append(l.logs, StructLog{
+		Pc:      pc,
+		Op:      op.String(),
+		Gas:     gas,
+		GasCost: cost,
+		Depth:   depth,
+		Stack:   stackHex,
+		Memory:  hex.EncodeToString(memory),
+		Storage: storage,
+	})
+}
+
+func (l *StructLogger) CaptureFault(pc uint64, op OpCode, gas, cost uint64, depth int, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logs = append(l.logs, StructLog{
+		Pc:      pc,
+		Op:      op.String(),
+		Gas:     gas,
+		GasCost: cost,
+		Depth:   depth,
+		Error:   err.Error(),
+	})
+}
+
+func (l *StructLogger) CaptureEnd(output []byte, gasUsed uint64, err error) {}
+
+// StructLogs returns every step recorded so far, in execution order.
+func (l *StructLogger) StructLogs() []StructLog {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]StructLog(nil), l.logs...)
+}
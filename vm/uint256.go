@@ -0,0 +1,255 @@
+package vm
+
+import (
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+// Int is a fixed-size 256-bit unsigned integer, stored as four 64-bit words
+// least-significant-first (d[0] holds bits 0-63). Unlike math/big.Int, every
+// Int lives inline with no backing slice, and arithmetic on it never
+// allocates and wraps silently mod 2^256 — the actual semantics EVM
+// arithmetic is defined by, not a bug the old []*big.Int stack had to work
+// around with fresh allocations on every ADD/MUL.
+type Int struct {
+	d [4]uint64
+}
+
+// intPool is a sync.Pool of scratch Ints that arithmetic operations borrow
+// via getInt and return via putInt once they're done with an operand — the
+// same borrow-and-return pattern go-ethereum's EVM rework used to cut GC
+// pressure on the ADD/MUL hot path. See bench_test.go for the payoff.
+var intPool = sync.Pool{
+	New: func() interface{} { return new(Int) },
+}
+
+func getInt() *Int {
+	return intPool.Get().(*Int)
+}
+
+func putInt(x *Int) {
+	x.Clear()
+	intPool.Put(x)
+}
+
+// boolInt borrows an Int from the pool set to 1 or 0, for the comparison
+// opcodes (LT/GT/EQ/ISZERO) that push a boolean result.
+func boolInt(v bool) *Int {
+	x := getInt()
+	if v {
+		x.SetUint64(1)
+	}
+	return x
+}
+
+func (z *Int) Clear() *Int {
+	z.d[0], z.d[1], z.d[2], z.d[3] = 0, 0, 0, 0
+	return z
+}
+
+func (z *Int) Set(x *Int) *Int {
+	*z = *x
+	return z
+}
+
+func (z *Int) SetUint64(x uint64) *Int {
+	z.d[0], z.d[1], z.d[2], z.d[3] = x, 0, 0, 0
+	return z
+}
+
+func (z *Int) IsZero() bool {
+	return z.d[0] == 0 && z.d[1] == 0 && z.d[2] == 0 && z.d[3] == 0
+}
+
+// Sign reports 0 for the zero value and 1 otherwise: Int is unsigned, so
+// unlike big.Int.Sign there's no -1 case, just the zero/nonzero distinction
+// callers like DIV's zero check and ISZERO actually need.
+func (z *Int) Sign() int {
+	if z.IsZero() {
+		return 0
+	}
+	return 1
+}
+
+// SetBytes interprets b as a big-endian integer, as the EVM's PUSH/SLOAD/
+// CALLDATA* operations all do, truncating to the low 32 bytes if b is
+// longer.
+func (z *Int) SetBytes(b []byte) *Int {
+	z.Clear()
+	if len(b) > 32 {
+		b = b[len(b)-32:]
+	}
+	n := len(b)
+	for i := 0; i < n; i++ {
+		word := i / 8
+		shift := uint(i%8) * 8
+		z.d[word] |= uint64(b[n-1-i]) << shift
+	}
+	return z
+}
+
+// Bytes returns z as a 32-byte big-endian encoding, the canonical width
+// every EVM word is stored and compared at (MSTORE/SSTORE and friends).
+func (z *Int) Bytes() []byte {
+	out := make([]byte, 32)
+	for i := 0; i < 32; i++ {
+		word := i / 8
+		shift := uint(i%8) * 8
+		out[31-i] = byte(z.d[word] >> shift)
+	}
+	return out
+}
+
+// Byte returns z's low 8 bits, the single byte MSTORE8 stores — distinct
+// from Bytes()[31] only in that it skips building the other 31 bytes.
+func (z *Int) Byte() byte {
+	return byte(z.d[0])
+}
+
+func (z *Int) IsUint64() bool {
+	return z.d[1] == 0 && z.d[2] == 0 && z.d[3] == 0
+}
+
+func (z *Int) Uint64() uint64 {
+	return z.d[0]
+}
+
+// BitLen returns the number of bits required to represent z, used by EXP's
+// dynamic gas cost to price by the exponent's byte length.
+func (z *Int) BitLen() int {
+	for i := 3; i >= 0; i-- {
+		if z.d[i] != 0 {
+			return i*64 + bits.Len64(z.d[i])
+		}
+	}
+	return 0
+}
+
+// ToBig converts z to a math/big.Int, for the handful of places (CALL's
+// value argument, CREATE's endowment) where the rest of the EVM/state
+// boundary still speaks big.Int.
+func (z *Int) ToBig() *big.Int {
+	return new(big.Int).SetBytes(z.Bytes())
+}
+
+// SetFromBig is ToBig's inverse.
+func (z *Int) SetFromBig(b *big.Int) *Int {
+	return z.SetBytes(b.Bytes())
+}
+
+func cmp256(a, b *Int) int {
+	for i := 3; i >= 0; i-- {
+		if a.d[i] != b.d[i] {
+			if a.d[i] < b.d[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// Lt, Gt, and Eq compare z and x as unsigned 256-bit integers — the only
+// ordering the EVM's LT/GT/EQ opcodes need; SLT/SGT's signed comparison
+// isn't implemented here yet.
+func (z *Int) Lt(x *Int) bool { return cmp256(z, x) < 0 }
+func (z *Int) Gt(x *Int) bool { return cmp256(z, x) > 0 }
+func (z *Int) Eq(x *Int) bool { return cmp256(z, x) == 0 }
+
+// Add sets z = x+y mod 2^256, the wraparound EVM addition is defined by.
+func (z *Int) Add(x, y *Int) *Int {
+	var c uint64
+	z.d[0], c = bits.Add64(x.d[0], y.d[0], 0)
+	z.d[1], c = bits.Add64(x.d[1], y.d[1], c)
+	z.d[2], c = bits.Add64(x.d[2], y.d[2], c)
+	z.d[3], _ = bits.Add64(x.d[3], y.d[3], c)
+	return z
+}
+
+// Sub sets z = x-y mod 2^256.
+func (z *Int) Sub(x, y *Int) *Int {
+	var b uint64
+	z.d[0], b = bits.Sub64(x.d[0], y.d[0], 0)
+	z.d[1], b = bits.Sub64(x.d[1], y.d[1], b)
+	z.d[2], b = bits.Sub64(x.d[2], y.d[2], b)
+	z.d[3], _ = bits.Sub64(x.d[3], y.d[3], b)
+	return z
+}
+
+// Mul sets z = x*y mod 2^256 via schoolbook long multiplication, keeping
+// only the low four words of the full 512-bit product.
+func (z *Int) Mul(x, y *Int) *Int {
+	var r [8]uint64
+	for i := 0; i < 4; i++ {
+		if x.d[i] == 0 {
+			continue
+		}
+		var carry uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(x.d[i], y.d[j])
+			var c uint64
+			lo, c = bits.Add64(lo, carry, 0)
+			hi, _ = bits.Add64(hi, 0, c)
+			r[i+j], c = bits.Add64(r[i+j], lo, 0)
+			carry, _ = bits.Add64(hi, 0, c)
+		}
+		if i+4 < 8 {
+			r[i+4] += carry
+		}
+	}
+	z.d[0], z.d[1], z.d[2], z.d[3] = r[0], r[1], r[2], r[3]
+	return z
+}
+
+// Div sets z = x/y, 0 if y is zero (the EVM's own convention, not a panic).
+// Division is implemented via a math/big round trip rather than hand-rolled
+// long division: unlike Add/Sub/Mul it isn't the hot path intPool and
+// bench_test.go target, so correctness-by-reuse beats a bespoke
+// implementation here.
+func (z *Int) Div(x, y *Int) *Int {
+	if y.IsZero() {
+		return z.Clear()
+	}
+	return z.SetFromBig(new(big.Int).Div(x.ToBig(), y.ToBig()))
+}
+
+// Exp sets z = base**exponent mod 2^256, the same way via math/big — modular
+// exponentiation isn't worth hand-rolling correctly under this change's
+// scope either.
+func (z *Int) Exp(base, exponent *Int) *Int {
+	mod := new(big.Int).Lsh(big.NewInt(1), 256)
+	return z.SetFromBig(new(big.Int).Exp(base.ToBig(), exponent.ToBig(), mod))
+}
+
+func (z *Int) And(x, y *Int) *Int {
+	z.d[0] = x.d[0] & y.d[0]
+	z.d[1] = x.d[1] & y.d[1]
+	z.d[2] = x.d[2] & y.d[2]
+	z.d[3] = x.d[3] & y.d[3]
+	return z
+}
+
+func (z *Int) Or(x, y *Int) *Int {
+	z.d[0] = x.d[0] | y.d[0]
+	z.d[1] = x.d[1] | y.d[1]
+	z.d[2] = x.d[2] | y.d[2]
+	z.d[3] = x.d[3] | y.d[3]
+	return z
+}
+
+func (z *Int) Xor(x, y *Int) *Int {
+	z.d[0] = x.d[0] ^ y.d[0]
+	z.d[1] = x.d[1] ^ y.d[1]
+	z.d[2] = x.d[2] ^ y.d[2]
+	z.d[3] = x.d[3] ^ y.d[3]
+	return z
+}
+
+func (z *Int) Not(x *Int) *Int {
+	z.d[0] = ^x.d[0]
+	z.d[1] = ^x.d[1]
+	z.d[2] = ^x.d[2]
+	z.d[3] = ^x.d[3]
+	return z
+}
@@ -1,158 +1,176 @@
 package main
 
 import (
-	"crypto/rand"
-	"encoding/hex"
 	"fmt"
 	"math/big"
 	"sync"
 	"time"
+
+	"github.com/DioraFund/diora-go-blockchain/accounts"
+	"github.com/DioraFund/diora-go-blockchain/internal/keystore"
+	"github.com/ethereum/go-ethereum/common"
 )
 
+// Wallet is the HTTP-facing view of a keystore account. The signing key
+// itself never lives here — it stays encrypted on disk, behind
+// accountManager — Wallet only adds the in-memory balance/nonce
+// bookkeeping this demo chain keeps in place of real account state.
 type Wallet struct {
-	Name       string    `json:"name"`
-	Address    string    `json:"address"`
-	PrivateKey string    `json:"private_key"`
-	PublicKey  string    `json:"public_key"`
-	Balance    *big.Int  `json:"balance"`
-	Nonce      uint64    `json:"nonce"`
-	CreatedAt  time.Time `json:"created_at"`
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	PublicKey string    `json:"public_key"`
+	Balance   *big.Int  `json:"balance"`
+	Nonce     uint64    `json:"nonce"`
+	CreatedAt time.Time `json:"created_at"`
+	// Path is the BIP-32 derivation path this wallet's account was derived
+	// from, e.g. "m/44'/60'/0'/0/3". Empty for non-HD accounts.
+	Path string `json:"path,omitempty"`
 }
 
-type WalletManager struct {
-	wallets map[string]*Wallet
-	mutex   sync.RWMutex
+type walletLedgerT struct {
+	mu      sync.RWMutex
+	balance map[string]*big.Int
+	nonce   map[string]uint64
 }
 
-var walletManager = &WalletManager{
-	wallets: make(map[string]*Wallet),
+var walletLedger = &walletLedgerT{
+	balance: make(map[string]*big.Int),
+	nonce:   make(map[string]uint64),
 }
 
-func NewWallet(name string) (*Wallet, error) {
-	walletManager.mutex.Lock()
-	defer walletManager.mutex.Unlock()
-
-	// Generate private key
-	privateKey, err := generatePrivateKey()
-	if err != nil {
-		return nil, err
+func (l *walletLedgerT) balanceOf(address string) *big.Int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if b, ok := l.balance[address]; ok {
+		return new(big.Int).Set(b)
 	}
+	return big.NewInt(0)
+}
 
-	// Generate public key (simplified)
-	publicKey := generatePublicKey(privateKey)
-
-	// Generate address
-	address := generateAddress(publicKey)
+func (l *walletLedgerT) nonceOf(address string) uint64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.nonce[address]
+}
 
-	wallet := &Wallet{
-		Name:       name,
-		Address:    address,
-		PrivateKey: privateKey,
-		PublicKey:  publicKey,
-		Balance:    big.NewInt(0),
-		Nonce:      0,
-		CreatedAt:  time.Now(),
+func (l *walletLedgerT) credit(address string, amount *big.Int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.balance[address]
+	if !ok {
+		b = big.NewInt(0)
+		l.balance[address] = b
 	}
+	b.Add(b, amount)
+}
 
-	walletManager.wallets[address] = wallet
-	return wallet, nil
+func (l *walletLedgerT) debit(address string, amount *big.Int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.balance[address]
+	if !ok || b.Cmp(amount) < 0 {
+		return fmt.Errorf("insufficient balance")
+	}
+	b.Sub(b, amount)
+	l.nonce[address]++
+	return nil
 }
 
-func GetWallet(address string) (*Wallet, bool) {
-	walletManager.mutex.RLock()
-	defer walletManager.mutex.RUnlock()
+// NewWallet creates a new keystore account named name, encrypted with
+// password, and registers it in the ledger with a zero starting balance.
+func NewWallet(ks *keystore.Keystore, name, password string) (*Wallet, error) {
+	account, err := ks.NewAccount(name, password)
+	if err != nil {
+		return nil, err
+	}
 
-	wallet, exists := walletManager.wallets[address]
-	return wallet, exists
-}
+	address := account.Address.Hex()
+	walletLedger.mu.Lock()
+	walletLedger.balance[address] = big.NewInt(0)
+	walletLedger.mu.Unlock()
 
-func GetAllWallets() []*Wallet {
-	walletManager.mutex.RLock()
-	defer walletManager.mutex.RUnlock()
+	return walletFromAccount(account), nil
+}
 
-	wallets := make([]*Wallet, 0, len(walletManager.wallets))
-	for _, wallet := range walletManager.wallets {
-		wallets = append(wallets, wallet)
+func walletFromAccount(account *keystore.Account) *Wallet {
+	address := account.Address.Hex()
+	return &Wallet{
+		Name:      account.Name,
+		Address:   address,
+		PublicKey: account.PublicKey,
+		Balance:   walletLedger.balanceOf(address),
+		Nonce:     walletLedger.nonceOf(address),
+		CreatedAt: account.CreatedAt,
+		Path:      account.Path,
 	}
-	return wallets
 }
 
-func UpdateBalance(address string, amount *big.Int) {
-	walletManager.mutex.Lock()
-	defer walletManager.mutex.Unlock()
+// GetWallet looks up the keystore account at address.
+func GetWallet(ks *keystore.Keystore, address string) (*Wallet, bool) {
+	accountList, err := ks.ListAccounts()
+	if err != nil {
+		return nil, false
+	}
 
-	if wallet, exists := walletManager.wallets[address]; exists {
-		wallet.Balance.Add(wallet.Balance, amount)
+	target := common.HexToAddress(address)
+	for _, account := range accountList {
+		if account.Address == target {
+			return walletFromAccount(account), true
+		}
 	}
+	return nil, false
 }
 
-func generatePrivateKey() (string, error) {
-	bytes := make([]byte, 32)
-	_, err := rand.Read(bytes)
+// GetAllWallets returns every keystore account as a Wallet.
+func GetAllWallets(ks *keystore.Keystore) []*Wallet {
+	accountList, err := ks.ListAccounts()
 	if err != nil {
-		return "", err
+		return nil
 	}
-	return "0x" + hex.EncodeToString(bytes), nil
-}
-
-func generatePublicKey(privateKey string) string {
-	// Simplified public key generation
-	// In real implementation, this would use elliptic curve cryptography
-	return "0x" + privateKey[4:] + "public"
-}
 
-func generateAddress(publicKey string) string {
-	// Simplified address generation
-	// In real implementation, this would hash the public key
-	hash := publicKey[2:] // Remove 0x prefix
-	if len(hash) > 40 {
-		hash = hash[:40]
-	} else {
-		// Pad with zeros if too short
-		for len(hash) < 40 {
-			hash += "0"
-		}
+	wallets := make([]*Wallet, 0, len(accountList))
+	for _, account := range accountList {
+		wallets = append(wallets, walletFromAccount(account))
 	}
-	return "0x" + hash
+	return wallets
 }
 
 func (w *Wallet) ToJSON() map[string]interface{} {
-	return map[string]interface{}{
-		"name":        w.Name,
-		"address":     w.Address,
-		"public_key":  w.PublicKey,
-		"balance":     w.Balance.String(),
-		"nonce":       w.Nonce,
-		"created_at":  w.CreatedAt,
-		"private_key": w.PrivateKey, // Only for demo - never expose private keys in production
+	json := map[string]interface{}{
+		"name":       w.Name,
+		"address":    w.Address,
+		"public_key": w.PublicKey,
+		"balance":    w.Balance.String(),
+		"nonce":      w.Nonce,
+		"created_at": w.CreatedAt,
+	}
+	if w.Path != "" {
+		json["path"] = w.Path
 	}
+	return json
 }
 
-func (w *Wallet) Send(to string, amount *big.Int) error {
-	// Check balance
-	if w.Balance.Cmp(amount) < 0 {
+// Send signs and submits a transfer from w to to, routing the signature
+// through manager instead of touching w's private key directly.
+func (w *Wallet) Send(manager *accounts.Manager, to string, amount *big.Int, password string) error {
+	if walletLedger.balanceOf(w.Address).Cmp(amount) < 0 {
 		return fmt.Errorf("insufficient balance")
 	}
 
-	// Create transaction
-	tx, err := NewTransaction(w.Address, to, amount.String())
+	tx, err := NewTransaction(manager, w.Address, to, amount.String(), password)
 	if err != nil {
 		return err
 	}
 
-	// Add to transactions pool
 	transactions = append(transactions, *tx)
 
-	// Update balances
-	w.Balance.Sub(w.Balance, amount)
-	w.Nonce++
+	if err := walletLedger.debit(w.Address, amount); err != nil {
+		return err
+	}
+	walletLedger.credit(to, amount)
 
-	// Add to block
 	blockData := fmt.Sprintf("Transaction: %s -> %s (%s)", w.Address, to, amount.String())
 	blockchain.AddBlock(blockData)
 
-	// Update recipient balance
-	UpdateBalance(to, amount)
-
 	return nil
 }